@@ -0,0 +1,743 @@
+package accelerator
+
+import (
+	"pbrt-go/mymath"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// SplitMethod selects the algorithm used to partition primitives while building the BVH
+type SplitMethod int
+
+const (
+	SplitSAH SplitMethod = iota
+	SplitHLBVH
+	SplitMiddle
+	SplitEqualCounts
+)
+
+// BVHAccel is bounding volume hierarchy aggregate stored in flattened depth-first order
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/accelerators/bvh.h
+// see https://github.com/mmp/pbrt-v3/blob/master/src/accelerators/bvh.cpp
+type BVHAccel struct {
+	mymath.Aggregate
+	maxPrimsInNode int
+	splitMethod    SplitMethod
+	primitives     []mymath.Primitive
+	nodes          []linearBVHNode
+}
+
+type bvhPrimitiveInfo struct {
+	primitiveNumber int
+	bounds          mymath.Bounds3
+	centroid        mymath.Point3
+}
+
+type bvhBuildNode struct {
+	bounds                                  mymath.Bounds3
+	children                                [2]*bvhBuildNode
+	splitAxis, firstPrimOffset, nPrimitives int
+}
+
+type mortonPrimitive struct {
+	primitiveIndex int
+	mortonCode     uint32
+}
+
+type lbvhTreelet struct {
+	startIndex, nPrimitives int
+	root                    *bvhBuildNode
+}
+
+// linearBVHNode is node of the flattened tree, offset points to the primitives for leaf nodes
+// and to the second child for interior nodes. The first child of interior node immediately follows it.
+type linearBVHNode struct {
+	bounds      mymath.Bounds3
+	offset      int
+	nPrimitives int
+	axis        int
+}
+
+type bucketInfo struct {
+	count  int
+	bounds mymath.Bounds3
+}
+
+const nBuckets = 12
+
+func newBVHPrimitiveInfo(primitiveNumber int, bounds mymath.Bounds3) bvhPrimitiveInfo {
+	return bvhPrimitiveInfo{
+		primitiveNumber,
+		bounds,
+		bounds.PMin.Multiply(0.5).AddP(bounds.PMax.Multiply(0.5)),
+	}
+}
+
+func (n *bvhBuildNode) initLeaf(first, count int, b mymath.Bounds3) {
+	n.firstPrimOffset = first
+	n.nPrimitives = count
+	n.bounds = b
+	n.children[0] = nil
+	n.children[1] = nil
+}
+
+func (n *bvhBuildNode) initInterior(axis int, c0, c1 *bvhBuildNode) {
+	n.children[0] = c0
+	n.children[1] = c1
+	n.bounds = c0.bounds.UnionB(c1.bounds)
+	n.splitAxis = axis
+	n.nPrimitives = 0
+}
+
+// NewBVHAccel builds the hierarchy over given primitives, maxPrimsInNode is capped at 255
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/accelerators/bvh.cpp#L189
+func NewBVHAccel(p []mymath.Primitive, maxPrimsInNode int, splitMethod SplitMethod) *BVHAccel {
+	if maxPrimsInNode > 255 {
+		maxPrimsInNode = 255
+	}
+
+	bvh := &BVHAccel{
+		maxPrimsInNode: maxPrimsInNode,
+		splitMethod:    splitMethod,
+		primitives:     p,
+	}
+
+	if len(p) == 0 {
+		return bvh
+	}
+
+	// Build BVH from primitives
+
+	// Initialize primitiveInfo array for primitives
+	primitiveInfo := make([]bvhPrimitiveInfo, len(p))
+	for i, prim := range p {
+		primitiveInfo[i] = newBVHPrimitiveInfo(i, prim.WorldBound())
+	}
+
+	// Build BVH tree for primitives using primitiveInfo
+	totalNodes := 0
+	var orderedPrims []mymath.Primitive
+	var root *bvhBuildNode
+
+	if splitMethod == SplitHLBVH {
+		root, orderedPrims = bvh.hlbvhBuild(primitiveInfo, &totalNodes)
+	} else {
+		orderedPrims = make([]mymath.Primitive, 0, len(p))
+		root = bvh.recursiveBuild(primitiveInfo, 0, len(p), &totalNodes, &orderedPrims)
+	}
+
+	bvh.primitives = orderedPrims
+
+	// Compute representation of depth-first traversal of BVH tree
+	bvh.nodes = make([]linearBVHNode, totalNodes)
+	offset := 0
+	bvh.flattenBVHTree(root, &offset)
+
+	return bvh
+}
+
+// partitionPrimitiveInfo reorders items so that the ones satisfying predicate come first, returns index of the first other item
+func partitionPrimitiveInfo(info []bvhPrimitiveInfo, pred func(pi bvhPrimitiveInfo) bool) int {
+	first := 0
+	for i := range info {
+		if pred(info[i]) {
+			info[first], info[i] = info[i], info[first]
+			first++
+		}
+	}
+
+	return first
+}
+
+// bucketIndex returns SAH bucket for given centroid coordinate
+func bucketIndex(offset float64) int {
+	b := int(nBuckets * offset)
+	if b == nBuckets {
+		b = nBuckets - 1
+	}
+
+	return b
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/accelerators/bvh.cpp#L239
+func (bvh *BVHAccel) recursiveBuild(primitiveInfo []bvhPrimitiveInfo, start, end int, totalNodes *int, orderedPrims *[]mymath.Primitive) *bvhBuildNode {
+	node := &bvhBuildNode{}
+	*totalNodes++
+
+	createLeaf := func(bounds mymath.Bounds3) *bvhBuildNode {
+		firstPrimOffset := len(*orderedPrims)
+		for i := start; i < end; i++ {
+			*orderedPrims = append(*orderedPrims, bvh.primitives[primitiveInfo[i].primitiveNumber])
+		}
+
+		node.initLeaf(firstPrimOffset, end-start, bounds)
+		return node
+	}
+
+	// Compute bounds of all primitives in BVH node
+	bounds := mymath.NewBounds3Empty()
+	for i := start; i < end; i++ {
+		bounds = bounds.UnionB(primitiveInfo[i].bounds)
+	}
+
+	nPrimitives := end - start
+	if nPrimitives == 1 {
+		return createLeaf(bounds)
+	}
+
+	// Compute bound of primitive centroids, choose split dimension dim
+	centroidBounds := mymath.NewBounds3Empty()
+	for i := start; i < end; i++ {
+		centroidBounds = centroidBounds.UnionP(primitiveInfo[i].centroid)
+	}
+
+	dim := centroidBounds.MaximumExtent()
+
+	// Partition primitives into two sets and build children
+	if centroidBounds.PMax.Get(dim) == centroidBounds.PMin.Get(dim) {
+		return createLeaf(bounds)
+	}
+
+	equalCounts := func() int {
+		// Partition primitives into equally-sized subsets
+		mid := (start + end) / 2
+		info := primitiveInfo[start:end]
+		sort.Slice(info, func(a, b int) bool {
+			return info[a].centroid.Get(dim) < info[b].centroid.Get(dim)
+		})
+
+		return mid
+	}
+
+	var mid int
+
+	// Partition primitives based on splitMethod
+	switch bvh.splitMethod {
+	case SplitMiddle:
+		// Partition primitives through node's midpoint
+		pMid := (centroidBounds.PMin.Get(dim) + centroidBounds.PMax.Get(dim)) / 2
+		mid = start + partitionPrimitiveInfo(primitiveInfo[start:end], func(pi bvhPrimitiveInfo) bool {
+			return pi.centroid.Get(dim) < pMid
+		})
+
+		// For lots of prims with large overlapping bounding boxes, this
+		// may fail to partition; in that case fall through to EqualCounts.
+		if mid == start || mid == end {
+			mid = equalCounts()
+		}
+	case SplitEqualCounts:
+		mid = equalCounts()
+	default:
+		// Partition primitives using approximate SAH
+		if nPrimitives <= 2 {
+			mid = equalCounts()
+			break
+		}
+
+		// Initialize bucketInfo for SAH partition buckets
+		var buckets [nBuckets]bucketInfo
+		for i := range buckets {
+			buckets[i].bounds = mymath.NewBounds3Empty()
+		}
+
+		for i := start; i < end; i++ {
+			b := bucketIndex(centroidBounds.Offset(primitiveInfo[i].centroid).Get(dim))
+			buckets[b].count++
+			buckets[b].bounds = buckets[b].bounds.UnionB(primitiveInfo[i].bounds)
+		}
+
+		// Find bucket to split at that minimizes SAH metric
+		minCost, minCostSplitBucket := minSAHCost(buckets, bounds, 1)
+
+		// Either create leaf or split primitives at selected SAH bucket
+		leafCost := float64(nPrimitives)
+		if nPrimitives > bvh.maxPrimsInNode || minCost < leafCost {
+			mid = start + partitionPrimitiveInfo(primitiveInfo[start:end], func(pi bvhPrimitiveInfo) bool {
+				return bucketIndex(centroidBounds.Offset(pi.centroid).Get(dim)) <= minCostSplitBucket
+			})
+		} else {
+			return createLeaf(bounds)
+		}
+	}
+
+	node.initInterior(
+		dim,
+		bvh.recursiveBuild(primitiveInfo, start, mid, totalNodes, orderedPrims),
+		bvh.recursiveBuild(primitiveInfo, mid, end, totalNodes, orderedPrims))
+
+	return node
+}
+
+// minSAHCost computes costs for splitting after each bucket and returns the cheapest one
+func minSAHCost(buckets [nBuckets]bucketInfo, bounds mymath.Bounds3, traversalCost float64) (float64, int) {
+	var cost [nBuckets - 1]float64
+	for i := 0; i < nBuckets-1; i++ {
+		b0 := mymath.NewBounds3Empty()
+		b1 := mymath.NewBounds3Empty()
+		count0 := 0
+		count1 := 0
+
+		for j := 0; j <= i; j++ {
+			b0 = b0.UnionB(buckets[j].bounds)
+			count0 += buckets[j].count
+		}
+
+		for j := i + 1; j < nBuckets; j++ {
+			b1 = b1.UnionB(buckets[j].bounds)
+			count1 += buckets[j].count
+		}
+
+		cost[i] = traversalCost
+		if count0 > 0 {
+			cost[i] += float64(count0) * b0.SurfaceArea() / bounds.SurfaceArea()
+		}
+
+		if count1 > 0 {
+			cost[i] += float64(count1) * b1.SurfaceArea() / bounds.SurfaceArea()
+		}
+	}
+
+	minCost := cost[0]
+	minCostSplitBucket := 0
+	for i := 1; i < nBuckets-1; i++ {
+		if cost[i] < minCost {
+			minCost = cost[i]
+			minCostSplitBucket = i
+		}
+	}
+
+	return minCost, minCostSplitBucket
+}
+
+// leftShift3 spreads lowest 10 bits of x so that there are two zero bits between each of them
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/accelerators/bvh.cpp#L89
+func leftShift3(x uint32) uint32 {
+	if x == (1 << 10) {
+		x--
+	}
+
+	x = (x | (x << 16)) & 0b00000011000000000000000011111111
+	x = (x | (x << 8)) & 0b00000011000000001111000000001111
+	x = (x | (x << 4)) & 0b00000011000011000011000011000011
+	x = (x | (x << 2)) & 0b00001001001001001001001001001001
+
+	return x
+}
+
+// encodeMorton3 interleaves bits of the coordinates, each coordinate is expected to be in [0, 1024]
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/accelerators/bvh.cpp#L106
+func encodeMorton3(v mymath.Vector3) uint32 {
+	return (leftShift3(uint32(v.Z)) << 2) | (leftShift3(uint32(v.Y)) << 1) | leftShift3(uint32(v.X))
+}
+
+// radixSort sorts the primitives by Morton code
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/accelerators/bvh.cpp#L113
+func radixSort(v []mortonPrimitive) []mortonPrimitive {
+	tempVector := make([]mortonPrimitive, len(v))
+
+	const bitsPerPass = 6
+	const nBits = 30
+	const nPasses = nBits / bitsPerPass
+
+	for pass := 0; pass < nPasses; pass++ {
+		// Perform one pass of radix sort, sorting bitsPerPass bits
+		lowBit := pass * bitsPerPass
+
+		// Set in and out vector pointers for radix sort pass
+		in, out := v, tempVector
+		if pass&1 != 0 {
+			in, out = tempVector, v
+		}
+
+		// Count number of zero bits in array for current radix sort bit
+		const nBuckets = 1 << bitsPerPass
+		var bucketCount [nBuckets]int
+		const bitMask = (1 << bitsPerPass) - 1
+		for _, mp := range in {
+			bucket := (mp.mortonCode >> lowBit) & bitMask
+			bucketCount[bucket]++
+		}
+
+		// Compute starting index in output array for each bucket
+		var outIndex [nBuckets]int
+		for i := 1; i < nBuckets; i++ {
+			outIndex[i] = outIndex[i-1] + bucketCount[i-1]
+		}
+
+		// Store sorted values in output array
+		for _, mp := range in {
+			bucket := (mp.mortonCode >> lowBit) & bitMask
+			out[outIndex[bucket]] = mp
+			outIndex[bucket]++
+		}
+	}
+
+	// Copy final result from tempVector, if needed
+	if nPasses&1 != 0 {
+		copy(v, tempVector)
+	}
+
+	return v
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/accelerators/bvh.cpp#L397
+func (bvh *BVHAccel) hlbvhBuild(primitiveInfo []bvhPrimitiveInfo, totalNodes *int) (*bvhBuildNode, []mymath.Primitive) {
+	// Compute bounding box of all primitive centroids
+	bounds := mymath.NewBounds3Empty()
+	for _, pi := range primitiveInfo {
+		bounds = bounds.UnionP(pi.centroid)
+	}
+
+	// Compute Morton indices of primitives
+	const mortonBits = 10
+	const mortonScale = 1 << mortonBits
+
+	mortonPrims := make([]mortonPrimitive, len(primitiveInfo))
+	for i, pi := range primitiveInfo {
+		centroidOffset := bounds.Offset(pi.centroid)
+		mortonPrims[i] = mortonPrimitive{pi.primitiveNumber, encodeMorton3(centroidOffset.Multiply(mortonScale))}
+	}
+
+	// Radix sort primitive Morton indices
+	radixSort(mortonPrims)
+
+	// Create LBVH treelets at bottom of BVH
+
+	// Find intervals of primitives for each treelet
+	var treeletsToBuild []lbvhTreelet
+	for start, end := 0, 1; end <= len(mortonPrims); end++ {
+		const mask = 0b00111111111111000000000000000000
+		if end == len(mortonPrims) || (mortonPrims[start].mortonCode&mask) != (mortonPrims[end].mortonCode&mask) {
+			// Add entry to treeletsToBuild for this treelet
+			treeletsToBuild = append(treeletsToBuild, lbvhTreelet{start, end - start, nil})
+			start = end
+		}
+	}
+
+	// Create LBVHs for treelets in parallel
+	var atomicTotal, orderedPrimsOffset int64
+	orderedPrims := make([]mymath.Primitive, len(bvh.primitives))
+
+	var wg sync.WaitGroup
+	for i := range treeletsToBuild {
+		wg.Add(1)
+		go func(tr *lbvhTreelet) {
+			defer wg.Done()
+
+			// Generate ith LBVH treelet
+			nodesCreated := 0
+			const firstBitIndex = 29 - 12
+			tr.root = bvh.emitLBVH(
+				primitiveInfo,
+				mortonPrims[tr.startIndex:tr.startIndex+tr.nPrimitives],
+				&nodesCreated,
+				orderedPrims,
+				&orderedPrimsOffset,
+				firstBitIndex)
+
+			atomic.AddInt64(&atomicTotal, int64(nodesCreated))
+		}(&treeletsToBuild[i])
+	}
+	wg.Wait()
+
+	*totalNodes = int(atomicTotal)
+
+	// Create and return SAH BVH from LBVH treelets
+	finishedTreelets := make([]*bvhBuildNode, 0, len(treeletsToBuild))
+	for _, treelet := range treeletsToBuild {
+		finishedTreelets = append(finishedTreelets, treelet.root)
+	}
+
+	return bvh.buildUpperSAH(finishedTreelets, 0, len(finishedTreelets), totalNodes), orderedPrims
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/accelerators/bvh.cpp#L469
+func (bvh *BVHAccel) emitLBVH(primitiveInfo []bvhPrimitiveInfo, mortonPrims []mortonPrimitive, totalNodes *int, orderedPrims []mymath.Primitive, orderedPrimsOffset *int64, bitIndex int) *bvhBuildNode {
+	nPrimitives := len(mortonPrims)
+
+	if bitIndex == -1 || nPrimitives < bvh.maxPrimsInNode {
+		// Create and return leaf node of LBVH treelet
+		*totalNodes++
+		node := &bvhBuildNode{}
+		bounds := mymath.NewBounds3Empty()
+		firstPrimOffset := int(atomic.AddInt64(orderedPrimsOffset, int64(nPrimitives))) - nPrimitives
+
+		for i := 0; i < nPrimitives; i++ {
+			primitiveIndex := mortonPrims[i].primitiveIndex
+			orderedPrims[firstPrimOffset+i] = bvh.primitives[primitiveIndex]
+			bounds = bounds.UnionB(primitiveInfo[primitiveIndex].bounds)
+		}
+
+		node.initLeaf(firstPrimOffset, nPrimitives, bounds)
+		return node
+	}
+
+	mask := uint32(1) << bitIndex
+
+	// Advance to next subtree level if there's no LBVH split for this bit
+	if (mortonPrims[0].mortonCode & mask) == (mortonPrims[nPrimitives-1].mortonCode & mask) {
+		return bvh.emitLBVH(primitiveInfo, mortonPrims, totalNodes, orderedPrims, orderedPrimsOffset, bitIndex-1)
+	}
+
+	// Find LBVH split point for this dimension
+	searchStart := 0
+	searchEnd := nPrimitives - 1
+	for searchStart+1 != searchEnd {
+		mid := (searchStart + searchEnd) / 2
+		if (mortonPrims[searchStart].mortonCode & mask) == (mortonPrims[mid].mortonCode & mask) {
+			searchStart = mid
+		} else {
+			searchEnd = mid
+		}
+	}
+
+	splitOffset := searchEnd
+
+	// Create and return interior LBVH node
+	*totalNodes++
+	node := &bvhBuildNode{}
+	node.initInterior(
+		bitIndex%3,
+		bvh.emitLBVH(primitiveInfo, mortonPrims[:splitOffset], totalNodes, orderedPrims, orderedPrimsOffset, bitIndex-1),
+		bvh.emitLBVH(primitiveInfo, mortonPrims[splitOffset:], totalNodes, orderedPrims, orderedPrimsOffset, bitIndex-1))
+
+	return node
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/accelerators/bvh.cpp#L519
+func (bvh *BVHAccel) buildUpperSAH(treeletRoots []*bvhBuildNode, start, end int, totalNodes *int) *bvhBuildNode {
+	nNodes := end - start
+	if nNodes == 1 {
+		return treeletRoots[start]
+	}
+
+	*totalNodes++
+	node := &bvhBuildNode{}
+
+	// Compute bounds of all nodes under this HLBVH node
+	bounds := mymath.NewBounds3Empty()
+	for i := start; i < end; i++ {
+		bounds = bounds.UnionB(treeletRoots[i].bounds)
+	}
+
+	// Compute bound of HLBVH node centroids, choose split dimension dim
+	centroid := func(n *bvhBuildNode) mymath.Point3 {
+		return n.bounds.PMin.AddP(n.bounds.PMax).Multiply(0.5)
+	}
+
+	centroidBounds := mymath.NewBounds3Empty()
+	for i := start; i < end; i++ {
+		centroidBounds = centroidBounds.UnionP(centroid(treeletRoots[i]))
+	}
+
+	dim := centroidBounds.MaximumExtent()
+
+	mid := (start + end) / 2
+	if centroidBounds.PMax.Get(dim) != centroidBounds.PMin.Get(dim) {
+		// Initialize bucketInfo for HLBVH SAH partition buckets
+		var buckets [nBuckets]bucketInfo
+		for i := range buckets {
+			buckets[i].bounds = mymath.NewBounds3Empty()
+		}
+
+		for i := start; i < end; i++ {
+			b := bucketIndex(centroidBounds.Offset(centroid(treeletRoots[i])).Get(dim))
+			buckets[b].count++
+			buckets[b].bounds = buckets[b].bounds.UnionB(treeletRoots[i].bounds)
+		}
+
+		// Find bucket to split at that minimizes SAH metric
+		_, minCostSplitBucket := minSAHCost(buckets, bounds, 0.125)
+
+		// Split nodes and create interior HLBVH SAH node
+		roots := treeletRoots[start:end]
+		first := 0
+		for i := range roots {
+			if bucketIndex(centroidBounds.Offset(centroid(roots[i])).Get(dim)) <= minCostSplitBucket {
+				roots[first], roots[i] = roots[i], roots[first]
+				first++
+			}
+		}
+
+		if first != 0 && first != nNodes {
+			mid = start + first
+		}
+	}
+
+	node.initInterior(
+		dim,
+		bvh.buildUpperSAH(treeletRoots, start, mid, totalNodes),
+		bvh.buildUpperSAH(treeletRoots, mid, end, totalNodes))
+
+	return node
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/accelerators/bvh.cpp#L598
+func (bvh *BVHAccel) flattenBVHTree(node *bvhBuildNode, offset *int) int {
+	linearNode := &bvh.nodes[*offset]
+	linearNode.bounds = node.bounds
+	myOffset := *offset
+	*offset++
+
+	if node.nPrimitives > 0 {
+		linearNode.offset = node.firstPrimOffset
+		linearNode.nPrimitives = node.nPrimitives
+	} else {
+		// Create interior flattened BVH node
+		linearNode.axis = node.splitAxis
+		linearNode.nPrimitives = 0
+		bvh.flattenBVHTree(node.children[0], offset)
+		linearNode.offset = bvh.flattenBVHTree(node.children[1], offset)
+	}
+
+	return myOffset
+}
+
+func (bvh *BVHAccel) WorldBound() mymath.Bounds3 {
+	if len(bvh.nodes) == 0 {
+		return mymath.NewBounds3Empty()
+	}
+
+	return bvh.nodes[0].bounds
+}
+
+// Intersect finds the closest ray-primitive collision and shortens ray.TMax to its distance
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/accelerators/bvh.cpp#L626
+func (bvh *BVHAccel) Intersect(ray *mymath.Ray) (bool, *mymath.SurfaceInteraction) {
+	if len(bvh.nodes) == 0 {
+		return false, nil
+	}
+
+	var isect *mymath.SurfaceInteraction
+	hit := false
+
+	invDir := mymath.NewVector3(1/ray.D.X, 1/ray.D.Y, 1/ray.D.Z)
+	dirIsNeg := dirIsNegative(invDir)
+
+	// Follow ray through BVH nodes to find primitive intersections
+	toVisitOffset := 0
+	currentNodeIndex := 0
+	var nodesToVisit [64]int
+
+	for {
+		node := &bvh.nodes[currentNodeIndex]
+
+		// Check ray against BVH node
+		if node.bounds.IntersectPPrecomputed(*ray, invDir, dirIsNeg) {
+			if node.nPrimitives > 0 {
+				// Intersect ray with primitives in leaf BVH node
+				for i := 0; i < node.nPrimitives; i++ {
+					prim := bvh.primitives[node.offset+i]
+					if ok, si := prim.Intersect(ray); ok {
+						isect = si
+						hit = true
+					}
+				}
+
+				if toVisitOffset == 0 {
+					break
+				}
+
+				toVisitOffset--
+				currentNodeIndex = nodesToVisit[toVisitOffset]
+			} else {
+				// Put far BVH node on nodesToVisit stack, advance to near node
+				if dirIsNeg[node.axis] != 0 {
+					nodesToVisit[toVisitOffset] = currentNodeIndex + 1
+					currentNodeIndex = node.offset
+				} else {
+					nodesToVisit[toVisitOffset] = node.offset
+					currentNodeIndex = currentNodeIndex + 1
+				}
+
+				toVisitOffset++
+			}
+		} else {
+			if toVisitOffset == 0 {
+				break
+			}
+
+			toVisitOffset--
+			currentNodeIndex = nodesToVisit[toVisitOffset]
+		}
+	}
+
+	return hit, isect
+}
+
+// IntersectP finds if ray collides with any of the primitives
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/accelerators/bvh.cpp#L671
+func (bvh *BVHAccel) IntersectP(ray mymath.Ray) bool {
+	if len(bvh.nodes) == 0 {
+		return false
+	}
+
+	invDir := mymath.NewVector3(1/ray.D.X, 1/ray.D.Y, 1/ray.D.Z)
+	dirIsNeg := dirIsNegative(invDir)
+
+	toVisitOffset := 0
+	currentNodeIndex := 0
+	var nodesToVisit [64]int
+
+	for {
+		node := &bvh.nodes[currentNodeIndex]
+
+		if node.bounds.IntersectPPrecomputed(ray, invDir, dirIsNeg) {
+			// Process BVH node node for traversal
+			if node.nPrimitives > 0 {
+				for i := 0; i < node.nPrimitives; i++ {
+					prim := bvh.primitives[node.offset+i]
+					if prim.IntersectP(ray) {
+						return true
+					}
+				}
+
+				if toVisitOffset == 0 {
+					break
+				}
+
+				toVisitOffset--
+				currentNodeIndex = nodesToVisit[toVisitOffset]
+			} else {
+				if dirIsNeg[node.axis] != 0 {
+					// second child first
+					nodesToVisit[toVisitOffset] = currentNodeIndex + 1
+					currentNodeIndex = node.offset
+				} else {
+					nodesToVisit[toVisitOffset] = node.offset
+					currentNodeIndex = currentNodeIndex + 1
+				}
+
+				toVisitOffset++
+			}
+		} else {
+			if toVisitOffset == 0 {
+				break
+			}
+
+			toVisitOffset--
+			currentNodeIndex = nodesToVisit[toVisitOffset]
+		}
+	}
+
+	return false
+}
+
+func dirIsNegative(invDir mymath.Vector3) [3]int {
+	var dirIsNeg [3]int
+	for i := 0; i < 3; i++ {
+		if invDir.Get(i) < 0 {
+			dirIsNeg[i] = 1
+		}
+	}
+
+	return dirIsNeg
+}
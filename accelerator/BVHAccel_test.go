@@ -0,0 +1,138 @@
+package accelerator_test
+
+import (
+	"math/rand"
+	"pbrt-go/accelerator"
+	"pbrt-go/material"
+	"pbrt-go/mymath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var splitMethods = []accelerator.SplitMethod{
+	accelerator.SplitSAH,
+	accelerator.SplitHLBVH,
+	accelerator.SplitMiddle,
+	accelerator.SplitEqualCounts,
+}
+
+func TestNewBVHAccel_empty(t *testing.T) {
+	bvh := accelerator.NewBVHAccel(nil, 4, accelerator.SplitSAH)
+
+	ray := mymath.NewRay(mymath.NewPoint3(0, 0, 0), mymath.NewVector3(1, 0, 0), 100, 0, material.Medium{})
+
+	ok, si := bvh.Intersect(&ray)
+	assert.False(t, ok)
+	assert.Nil(t, si)
+	assert.False(t, bvh.IntersectP(ray))
+}
+
+func TestBVHAccel_WorldBound(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	shapes := randomScene(rng, 50)
+
+	expected := mymath.NewBounds3Empty()
+	for _, s := range shapes {
+		expected = expected.UnionB(s.WorldBound())
+	}
+
+	for _, splitMethod := range splitMethods {
+		bvh := accelerator.NewBVHAccel(shapes, 4, splitMethod)
+		assert.Equal(t, expected, bvh.WorldBound(), "split method %v", splitMethod)
+	}
+}
+
+func TestBVHAccel_Intersect(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	shapes := randomScene(rng, 200)
+
+	for _, splitMethod := range splitMethods {
+		bvh := accelerator.NewBVHAccel(shapes, 4, splitMethod)
+
+		for i := 0; i < 500; i++ {
+			ray := randomRay(rng)
+			expectedHit, expectedT := bruteForceIntersect(shapes, ray)
+
+			r := ray
+			ok, si := bvh.Intersect(&r)
+			assert.Equal(t, expectedHit, ok, "split method %v", splitMethod)
+			assert.Equal(t, expectedHit, bvh.IntersectP(ray), "split method %v", splitMethod)
+
+			if expectedHit {
+				assert.NotNil(t, si)
+				assert.InDelta(t, expectedT, r.TMax, 1e-9, "split method %v", splitMethod)
+			} else {
+				assert.Equal(t, ray.TMax, r.TMax)
+			}
+		}
+	}
+}
+
+func TestBVHAccel_Intersect_single(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+	sphere := mymath.NewSphere(1, -1, 1, 360, &identity, &identity, false)
+
+	for _, splitMethod := range splitMethods {
+		bvh := accelerator.NewBVHAccel([]mymath.Primitive{mymath.NewGeometricPrimitive(sphere, nil, nil, nil)}, 4, splitMethod)
+
+		ray := mymath.NewRay(mymath.NewPoint3(-5, 0, 0), mymath.NewVector3(1, 0, 0), 100, 0, material.Medium{})
+
+		ok, si := bvh.Intersect(&ray)
+		assert.True(t, ok)
+		// the object space ray origin is moved to the edge of its float32 error bounds
+		assert.InDelta(t, 4.0, ray.TMax, 1e-5)
+		assert.Equal(t, mymath.NewPoint3(-1, 0, 0), si.P)
+	}
+}
+
+// Single BVH instanced several times must behave as the translated copies of its geometry
+func TestBVHAccel_Intersect_instanced(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	shapes := randomScene(rng, 50)
+	bvh := accelerator.NewBVHAccel(shapes, 4, accelerator.SplitSAH)
+
+	for _, offset := range []mymath.Vector3{mymath.NewVector3(0, 0, 0), mymath.NewVector3(30, 0, 0), mymath.NewVector3(0, -25, 40)} {
+		translate := mymath.NewTransformTranslate(offset)
+		primToWorld, _ := mymath.NewAnimatedTransform(translate, 0, translate, 1)
+		instance, err := mymath.NewTransformedPrimitive(bvh, primToWorld)
+		assert.NoError(t, err)
+
+		for i := 0; i < 100; i++ {
+			ray := randomRay(rng)
+			expectedHit, expectedT := bruteForceIntersect(shapes, ray)
+
+			// move the ray together with the instance
+			r := ray
+			r.O = r.O.AddV(offset)
+			ok, si := instance.Intersect(&r)
+			assert.Equal(t, expectedHit, ok)
+
+			if expectedHit {
+				assert.NotNil(t, si.Primitive)
+				// the translated ray origin has larger error bounds
+				assert.InDelta(t, expectedT, r.TMax, 1e-4)
+			}
+		}
+	}
+}
+
+func BenchmarkBVHAccel_Intersect(b *testing.B) {
+	rng := rand.New(rand.NewSource(3))
+	shapes := randomScene(rng, 5000)
+
+	for _, splitMethod := range splitMethods {
+		bvh := accelerator.NewBVHAccel(shapes, 4, splitMethod)
+
+		b.Run(splitMethodName(splitMethod), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ray := randomRay(rng)
+				bvh.Intersect(&ray)
+			}
+		})
+	}
+}
+
+func splitMethodName(splitMethod accelerator.SplitMethod) string {
+	return [...]string{"SAH", "HLBVH", "Middle", "EqualCounts"}[splitMethod]
+}
@@ -0,0 +1,437 @@
+package accelerator
+
+import (
+	"math"
+	"pbrt-go/mymath"
+	"sort"
+)
+
+// KdTreeAccel is kd-tree aggregate built using the surface area heuristic
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/accelerators/kdtreeaccel.h
+// see https://github.com/mmp/pbrt-v3/blob/master/src/accelerators/kdtreeaccel.cpp
+type KdTreeAccel struct {
+	mymath.Aggregate
+	isectCost, traversalCost, maxPrims int
+	emptyBonus                         float64
+	primitives                         []mymath.Primitive
+	primitiveIndices                   []int
+	nodes                              []kdAccelNode
+	bounds                             mymath.Bounds3
+}
+
+// kdAccelNode is either leaf (flags == 3) holding primitives or interior node split along axis given by flags.
+// The below child of interior node immediately follows it, the above child is at index aboveChild.
+type kdAccelNode struct {
+	split                  float64
+	flags                  int
+	nPrims                 int
+	onePrimitive           int
+	primitiveIndicesOffset int
+	aboveChild             int
+}
+
+type edgeType int
+
+const (
+	edgeStart edgeType = iota
+	edgeEnd
+)
+
+type boundEdge struct {
+	t        float64
+	primNum  int
+	edgeType edgeType
+}
+
+type kdToDo struct {
+	node       int
+	tMin, tMax float64
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/accelerators/kdtreeaccel.cpp#L75
+func (n *kdAccelNode) initLeaf(primNums []int, primitiveIndices *[]int) {
+	n.flags = 3
+	n.nPrims = len(primNums)
+
+	// Store primitive ids for leaf node
+	if len(primNums) == 1 {
+		n.onePrimitive = primNums[0]
+	} else if len(primNums) > 1 {
+		n.primitiveIndicesOffset = len(*primitiveIndices)
+		*primitiveIndices = append(*primitiveIndices, primNums...)
+	}
+}
+
+func (n *kdAccelNode) initInterior(axis, aboveChild int, split float64) {
+	n.split = split
+	n.flags = axis
+	n.aboveChild = aboveChild
+}
+
+func (n *kdAccelNode) isLeaf() bool {
+	return n.flags == 3
+}
+
+// NewKdTreeAccel builds the tree over given primitives, non-positive maxDepth selects depth based on the primitive count
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/accelerators/kdtreeaccel.cpp#L46
+func NewKdTreeAccel(p []mymath.Primitive, isectCost, traversalCost int, emptyBonus float64, maxPrims, maxDepth int) *KdTreeAccel {
+	kd := &KdTreeAccel{
+		isectCost:     isectCost,
+		traversalCost: traversalCost,
+		maxPrims:      maxPrims,
+		emptyBonus:    emptyBonus,
+		primitives:    p,
+		bounds:        mymath.NewBounds3Empty(),
+	}
+
+	if len(p) == 0 {
+		return kd
+	}
+
+	// Build kd-tree for accelerator
+	if maxDepth <= 0 {
+		maxDepth = int(math.Round(8 + 1.3*float64(mymath.Log2Int(int64(len(p))))))
+	}
+
+	// Compute bounds for kd-tree construction
+	primBounds := make([]mymath.Bounds3, 0, len(p))
+	for _, prim := range p {
+		b := prim.WorldBound()
+		kd.bounds = kd.bounds.UnionB(b)
+		primBounds = append(primBounds, b)
+	}
+
+	// Allocate working memory for kd-tree construction
+	var edges [3][]boundEdge
+	for i := 0; i < 3; i++ {
+		edges[i] = make([]boundEdge, 2*len(p))
+	}
+
+	// Initialize primNums for kd-tree construction
+	primNums := make([]int, len(p))
+	for i := range primNums {
+		primNums[i] = i
+	}
+
+	// Start recursive construction of kd-tree
+	kd.buildTree(0, kd.bounds, primBounds, primNums, maxDepth, edges, 0)
+
+	return kd
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/accelerators/kdtreeaccel.cpp#L117
+func (kd *KdTreeAccel) buildTree(nodeNum int, nodeBounds mymath.Bounds3, allPrimBounds []mymath.Bounds3, primNums []int, depth int, edges [3][]boundEdge, badRefines int) {
+	// Get next free node from nodes array
+	kd.nodes = append(kd.nodes, kdAccelNode{})
+	nPrimitives := len(primNums)
+
+	// Initialize leaf node if termination criteria met
+	if nPrimitives <= kd.maxPrims || depth == 0 {
+		kd.nodes[nodeNum].initLeaf(primNums, &kd.primitiveIndices)
+		return
+	}
+
+	// Initialize interior node and continue recursion
+
+	// Choose split axis position for interior node
+	bestAxis := -1
+	bestOffset := -1
+	bestCost := math.Inf(1)
+	oldCost := float64(kd.isectCost * nPrimitives)
+	totalSA := nodeBounds.SurfaceArea()
+	invTotalSA := 1 / totalSA
+	d := nodeBounds.Diagonal()
+
+	// Choose which axis to split along
+	axis := nodeBounds.MaximumExtent()
+
+	for retries := 0; retries < 3; retries++ {
+		// Initialize edges for axis
+		for i, pn := range primNums {
+			bounds := allPrimBounds[pn]
+			edges[axis][2*i] = boundEdge{bounds.PMin.Get(axis), pn, edgeStart}
+			edges[axis][2*i+1] = boundEdge{bounds.PMax.Get(axis), pn, edgeEnd}
+		}
+
+		// Sort edges for axis
+		axisEdges := edges[axis][:2*nPrimitives]
+		sort.Slice(axisEdges, func(i, j int) bool {
+			if axisEdges[i].t == axisEdges[j].t {
+				return axisEdges[i].edgeType < axisEdges[j].edgeType
+			}
+
+			return axisEdges[i].t < axisEdges[j].t
+		})
+
+		// Compute cost of all splits for axis to find best
+		nBelow := 0
+		nAbove := nPrimitives
+		for i, edge := range axisEdges {
+			if edge.edgeType == edgeEnd {
+				nAbove--
+			}
+
+			edgeT := edge.t
+			if edgeT > nodeBounds.PMin.Get(axis) && edgeT < nodeBounds.PMax.Get(axis) {
+				// Compute cost for split at ith edge
+
+				// Compute child surface areas for split at edgeT
+				otherAxis0 := (axis + 1) % 3
+				otherAxis1 := (axis + 2) % 3
+				belowSA := 2 * (d.Get(otherAxis0)*d.Get(otherAxis1) +
+					(edgeT-nodeBounds.PMin.Get(axis))*(d.Get(otherAxis0)+d.Get(otherAxis1)))
+				aboveSA := 2 * (d.Get(otherAxis0)*d.Get(otherAxis1) +
+					(nodeBounds.PMax.Get(axis)-edgeT)*(d.Get(otherAxis0)+d.Get(otherAxis1)))
+				pBelow := belowSA * invTotalSA
+				pAbove := aboveSA * invTotalSA
+
+				eb := 0.0
+				if nAbove == 0 || nBelow == 0 {
+					eb = kd.emptyBonus
+				}
+
+				cost := float64(kd.traversalCost) +
+					float64(kd.isectCost)*(1-eb)*(pBelow*float64(nBelow)+pAbove*float64(nAbove))
+
+				// Update best split if this is lowest cost so far
+				if cost < bestCost {
+					bestCost = cost
+					bestAxis = axis
+					bestOffset = i
+				}
+			}
+
+			if edge.edgeType == edgeStart {
+				nBelow++
+			}
+		}
+
+		// Retry along other axis if no good splits were found
+		if bestAxis != -1 {
+			break
+		}
+
+		axis = (axis + 1) % 3
+	}
+
+	// Create leaf if no good splits were found
+	if bestCost > oldCost {
+		badRefines++
+	}
+
+	if (bestCost > 4*oldCost && nPrimitives < 16) || bestAxis == -1 || badRefines == 3 {
+		kd.nodes[nodeNum].initLeaf(primNums, &kd.primitiveIndices)
+		return
+	}
+
+	// Classify primitives with respect to split
+	prims0 := make([]int, 0, nPrimitives)
+	prims1 := make([]int, 0, nPrimitives)
+
+	for i := 0; i < bestOffset; i++ {
+		if edges[bestAxis][i].edgeType == edgeStart {
+			prims0 = append(prims0, edges[bestAxis][i].primNum)
+		}
+	}
+
+	for i := bestOffset + 1; i < 2*nPrimitives; i++ {
+		if edges[bestAxis][i].edgeType == edgeEnd {
+			prims1 = append(prims1, edges[bestAxis][i].primNum)
+		}
+	}
+
+	// Recursively initialize children nodes
+	tSplit := edges[bestAxis][bestOffset].t
+	bounds0 := nodeBounds
+	bounds1 := nodeBounds
+	setPointComponent(&bounds0.PMax, bestAxis, tSplit)
+	setPointComponent(&bounds1.PMin, bestAxis, tSplit)
+
+	kd.buildTree(nodeNum+1, bounds0, allPrimBounds, prims0, depth-1, edges, badRefines)
+	aboveChild := len(kd.nodes)
+	kd.nodes[nodeNum].initInterior(bestAxis, aboveChild, tSplit)
+	kd.buildTree(aboveChild, bounds1, allPrimBounds, prims1, depth-1, edges, badRefines)
+}
+
+func setPointComponent(p *mymath.Point3, component int, v float64) {
+	switch component {
+	case 0:
+		p.X = v
+	case 1:
+		p.Y = v
+	default:
+		p.Z = v
+	}
+}
+
+func (kd *KdTreeAccel) WorldBound() mymath.Bounds3 {
+	return kd.bounds
+}
+
+// leafPrimitive returns i-th primitive of the leaf node
+func (kd *KdTreeAccel) leafPrimitive(node *kdAccelNode, i int) mymath.Primitive {
+	if node.nPrims == 1 {
+		return kd.primitives[node.onePrimitive]
+	}
+
+	return kd.primitives[kd.primitiveIndices[node.primitiveIndicesOffset+i]]
+}
+
+// children returns the node children in the order they are visited by the ray
+func (kd *KdTreeAccel) children(nodeIndex int, ray mymath.Ray) (int, int) {
+	node := &kd.nodes[nodeIndex]
+	axis := node.flags
+	belowFirst := ray.O.Get(axis) < node.split || (ray.O.Get(axis) == node.split && ray.D.Get(axis) <= 0)
+
+	if belowFirst {
+		return nodeIndex + 1, node.aboveChild
+	}
+
+	return node.aboveChild, nodeIndex + 1
+}
+
+// Intersect finds the closest ray-primitive collision and shortens ray.TMax to its distance
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/accelerators/kdtreeaccel.cpp#L254
+func (kd *KdTreeAccel) Intersect(ray *mymath.Ray) (bool, *mymath.SurfaceInteraction) {
+	if len(kd.nodes) == 0 {
+		return false, nil
+	}
+
+	// Compute initial parametric range of ray inside kd-tree extent
+	ok, tMin, tMax := kd.bounds.IntersectP(*ray)
+	if !ok {
+		return false, nil
+	}
+
+	// Prepare to traverse kd-tree for ray
+	invDir := mymath.NewVector3(1/ray.D.X, 1/ray.D.Y, 1/ray.D.Z)
+	todo := make([]kdToDo, 0, 64)
+
+	// Traverse kd-tree nodes in order for ray
+	var isect *mymath.SurfaceInteraction
+	hit := false
+	nodeIndex := 0
+
+	for {
+		// Bail out if we found a hit closer than the current node
+		if ray.TMax < tMin {
+			break
+		}
+
+		node := &kd.nodes[nodeIndex]
+		if !node.isLeaf() {
+			// Process kd-tree interior node
+
+			// Compute parametric distance along ray to split plane
+			axis := node.flags
+			tPlane := (node.split - ray.O.Get(axis)) * invDir.Get(axis)
+
+			// Get node children pointers for ray
+			firstChild, secondChild := kd.children(nodeIndex, *ray)
+
+			// Advance to next child node, possibly enqueue other child
+			if tPlane > tMax || tPlane <= 0 {
+				nodeIndex = firstChild
+			} else if tPlane < tMin {
+				nodeIndex = secondChild
+			} else {
+				// Enqueue secondChild in todo list
+				todo = append(todo, kdToDo{secondChild, tPlane, tMax})
+				nodeIndex = firstChild
+				tMax = tPlane
+			}
+		} else {
+			// Check for intersections inside leaf node
+			for i := 0; i < node.nPrims; i++ {
+				p := kd.leafPrimitive(node, i)
+				if ok, si := p.Intersect(ray); ok {
+					isect = si
+					hit = true
+				}
+			}
+
+			// Grab next node to process from todo list
+			if len(todo) == 0 {
+				break
+			}
+
+			next := todo[len(todo)-1]
+			todo = todo[:len(todo)-1]
+			nodeIndex = next.node
+			tMin = next.tMin
+			tMax = next.tMax
+		}
+	}
+
+	return hit, isect
+}
+
+// IntersectP finds if ray collides with any of the primitives
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/accelerators/kdtreeaccel.cpp#L340
+func (kd *KdTreeAccel) IntersectP(ray mymath.Ray) bool {
+	if len(kd.nodes) == 0 {
+		return false
+	}
+
+	// Compute initial parametric range of ray inside kd-tree extent
+	ok, tMin, tMax := kd.bounds.IntersectP(ray)
+	if !ok {
+		return false
+	}
+
+	// Prepare to traverse kd-tree for ray
+	invDir := mymath.NewVector3(1/ray.D.X, 1/ray.D.Y, 1/ray.D.Z)
+	todo := make([]kdToDo, 0, 64)
+	nodeIndex := 0
+
+	for {
+		node := &kd.nodes[nodeIndex]
+		if node.isLeaf() {
+			// Check for shadow ray intersections inside leaf node
+			for i := 0; i < node.nPrims; i++ {
+				p := kd.leafPrimitive(node, i)
+				if p.IntersectP(ray) {
+					return true
+				}
+			}
+
+			// Grab next node to process from todo list
+			if len(todo) == 0 {
+				break
+			}
+
+			next := todo[len(todo)-1]
+			todo = todo[:len(todo)-1]
+			nodeIndex = next.node
+			tMin = next.tMin
+			tMax = next.tMax
+		} else {
+			// Process kd-tree interior node
+
+			// Compute parametric distance along ray to split plane
+			axis := node.flags
+			tPlane := (node.split - ray.O.Get(axis)) * invDir.Get(axis)
+
+			// Get node children pointers for ray
+			firstChild, secondChild := kd.children(nodeIndex, ray)
+
+			// Advance to next child node, possibly enqueue other child
+			if tPlane > tMax || tPlane <= 0 {
+				nodeIndex = firstChild
+			} else if tPlane < tMin {
+				nodeIndex = secondChild
+			} else {
+				// Enqueue secondChild in todo list
+				todo = append(todo, kdToDo{secondChild, tPlane, tMax})
+				nodeIndex = firstChild
+				tMax = tPlane
+			}
+		}
+	}
+
+	return false
+}
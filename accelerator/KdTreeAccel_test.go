@@ -0,0 +1,97 @@
+package accelerator_test
+
+import (
+	"math/rand"
+	"pbrt-go/accelerator"
+	"pbrt-go/material"
+	"pbrt-go/mymath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newDefaultKdTreeAccel(shapes []mymath.Primitive) *accelerator.KdTreeAccel {
+	return accelerator.NewKdTreeAccel(shapes, 80, 1, 0.5, 1, -1)
+}
+
+func TestNewKdTreeAccel_empty(t *testing.T) {
+	kd := newDefaultKdTreeAccel(nil)
+
+	ray := mymath.NewRay(mymath.NewPoint3(0, 0, 0), mymath.NewVector3(1, 0, 0), 100, 0, material.Medium{})
+
+	ok, si := kd.Intersect(&ray)
+	assert.False(t, ok)
+	assert.Nil(t, si)
+	assert.False(t, kd.IntersectP(ray))
+}
+
+func TestKdTreeAccel_WorldBound(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	shapes := randomScene(rng, 50)
+
+	expected := mymath.NewBounds3Empty()
+	for _, s := range shapes {
+		expected = expected.UnionB(s.WorldBound())
+	}
+
+	assert.Equal(t, expected, newDefaultKdTreeAccel(shapes).WorldBound())
+}
+
+func TestKdTreeAccel_Intersect(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	shapes := randomScene(rng, 200)
+
+	accels := []mymath.Primitive{
+		newDefaultKdTreeAccel(shapes),
+		// shallow tree with crowded leaves
+		accelerator.NewKdTreeAccel(shapes, 80, 1, 0.5, 8, 3),
+	}
+
+	for _, kd := range accels {
+		for i := 0; i < 500; i++ {
+			ray := randomRay(rng)
+			expectedHit, expectedT := bruteForceIntersect(shapes, ray)
+
+			r := ray
+			ok, si := kd.Intersect(&r)
+			assert.Equal(t, expectedHit, ok)
+			assert.Equal(t, expectedHit, kd.IntersectP(ray))
+
+			if expectedHit {
+				assert.NotNil(t, si)
+				assert.InDelta(t, expectedT, r.TMax, 1e-9)
+			} else {
+				assert.Equal(t, ray.TMax, r.TMax)
+			}
+		}
+	}
+}
+
+func TestKdTreeAccel_Intersect_single(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+	sphere := mymath.NewSphere(1, -1, 1, 360, &identity, &identity, false)
+
+	kd := newDefaultKdTreeAccel([]mymath.Primitive{mymath.NewGeometricPrimitive(sphere, nil, nil, nil)})
+
+	ray := mymath.NewRay(mymath.NewPoint3(-5, 0, 0), mymath.NewVector3(1, 0, 0), 100, 0, material.Medium{})
+
+	ok, si := kd.Intersect(&ray)
+	assert.True(t, ok)
+	// the object space ray origin is moved to the edge of its float32 error bounds
+	assert.InDelta(t, 4.0, ray.TMax, 1e-5)
+	assert.Equal(t, mymath.NewPoint3(-1, 0, 0), si.P)
+}
+
+func BenchmarkKdTreeAccel_Intersect(b *testing.B) {
+	rng := rand.New(rand.NewSource(3))
+	shapes := randomScene(rng, 5000)
+
+	kd := newDefaultKdTreeAccel(shapes)
+
+	b.Run("default", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ray := randomRay(rng)
+			kd.Intersect(&ray)
+		}
+	})
+}
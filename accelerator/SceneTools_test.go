@@ -0,0 +1,64 @@
+package accelerator_test
+
+import (
+	"math/rand"
+	"pbrt-go/material"
+	"pbrt-go/mymath"
+)
+
+// randomScene creates spheres and triangles scattered inside [-10, 10]^3 box
+func randomScene(rng *rand.Rand, n int) []mymath.Primitive {
+	shapes := make([]mymath.IShape, 0, 2*n)
+
+	for i := 0; i < n; i++ {
+		translate := mymath.NewTransformTranslate(randomVector(rng, 10))
+		translateInv := translate.Inverse()
+
+		shapes = append(shapes, mymath.NewSphere(0.2+rng.Float64(), -2, 2, 360, &translate, &translateInv, false))
+	}
+
+	identity := mymath.NewTransformEmpty()
+	for i := 0; i < n; i++ {
+		c := mymath.NewPoint3(0, 0, 0).AddV(randomVector(rng, 10))
+		p := []mymath.Point3{
+			c.AddV(randomVector(rng, 1)),
+			c.AddV(randomVector(rng, 1)),
+			c.AddV(randomVector(rng, 1)),
+		}
+
+		shapes = append(shapes, mymath.CreateTriangleMesh(&identity, &identity, false, 1, []int{0, 1, 2}, 3, p, nil, nil, nil)...)
+	}
+
+	prims := make([]mymath.Primitive, len(shapes))
+	for i, s := range shapes {
+		prims[i] = mymath.NewGeometricPrimitive(s, nil, nil, nil)
+	}
+
+	return prims
+}
+
+func randomVector(rng *rand.Rand, size float64) mymath.Vector3 {
+	return mymath.NewVector3(
+		(2*rng.Float64()-1)*size,
+		(2*rng.Float64()-1)*size,
+		(2*rng.Float64()-1)*size)
+}
+
+func randomRay(rng *rand.Rand) mymath.Ray {
+	o := mymath.NewPoint3(0, 0, 0).AddV(randomVector(rng, 15))
+	target := mymath.NewPoint3(0, 0, 0).AddV(randomVector(rng, 10))
+
+	return mymath.NewRay(o, target.SubtractP(o).Normalize(), 100, 0, material.Medium{})
+}
+
+// bruteForceIntersect finds the closest hit by testing every primitive
+func bruteForceIntersect(prims []mymath.Primitive, ray mymath.Ray) (bool, float64) {
+	hit := false
+	for _, p := range prims {
+		if ok, _ := p.Intersect(&ray); ok {
+			hit = true
+		}
+	}
+
+	return hit, ray.TMax
+}
@@ -0,0 +1,96 @@
+package camera
+
+import (
+	"pbrt-go/material"
+	"pbrt-go/mymath"
+)
+
+// CameraSample holds sample values needed to generate camera ray
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/camera.h#L76
+type CameraSample struct {
+	PFilm mymath.Point2
+	PLens mymath.Point2
+	Time  float64
+}
+
+// Camera generates rays for the film samples. The returned weight tells how much the ray contributes to the image,
+// zero weight means that no valid ray exists for the sample.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/camera.h#L48
+type Camera interface {
+	GenerateRay(sample CameraSample) (float64, mymath.Ray)
+	GenerateRayDifferential(sample CameraSample) (float64, mymath.RayDifferential)
+}
+
+// CameraBase holds data shared by all the cameras
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/camera.cpp#L46
+type CameraBase struct {
+	CameraToWorld             mymath.AnimatedTransform
+	ShutterOpen, ShutterClose float64
+	FullResolution            mymath.Point2i
+	Medium                    material.Medium
+}
+
+func NewCameraBase(cameraToWorld mymath.AnimatedTransform, shutterOpen, shutterClose float64, fullResolution mymath.Point2i, medium material.Medium) CameraBase {
+	return CameraBase{cameraToWorld, shutterOpen, shutterClose, fullResolution, medium}
+}
+
+// time maps the sample time from [0, 1] to the shutter interval
+func (c CameraBase) time(sample CameraSample) float32 {
+	return float32(mymath.Lerp(sample.Time, c.ShutterOpen, c.ShutterClose))
+}
+
+// generateRayDifferential computes differentials by generating rays for the samples shifted by a fraction of pixel,
+// it is used by the cameras that can not compute the differentials directly
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/camera.cpp#L53
+func (c CameraBase) generateRayDifferential(camera Camera, sample CameraSample) (float64, mymath.RayDifferential) {
+	wt, ray := camera.GenerateRay(sample)
+	rd := mymath.NewRayDifferentialRay(ray)
+	if wt == 0 {
+		return 0, rd
+	}
+
+	// Find camera ray after shifting a fraction of a pixel in the x direction
+	var wtx float64
+	for _, eps := range []float64{0.05, -0.05} {
+		sshift := sample
+		sshift.PFilm.X += eps
+
+		var rx mymath.Ray
+		wtx, rx = camera.GenerateRay(sshift)
+		rd.RxOrigin = rd.O.AddV(rx.O.SubtractP(rd.O).Divide(eps))
+		rd.RxDirection = rd.D.Add(rx.D.Subtract(rd.D).Divide(eps))
+		if wtx != 0 {
+			break
+		}
+	}
+
+	if wtx == 0 {
+		return 0, rd
+	}
+
+	// Find camera ray after shifting a fraction of a pixel in the y direction
+	var wty float64
+	for _, eps := range []float64{0.05, -0.05} {
+		sshift := sample
+		sshift.PFilm.Y += eps
+
+		var ry mymath.Ray
+		wty, ry = camera.GenerateRay(sshift)
+		rd.RyOrigin = rd.O.AddV(ry.O.SubtractP(rd.O).Divide(eps))
+		rd.RyDirection = rd.D.Add(ry.D.Subtract(rd.D).Divide(eps))
+		if wty != 0 {
+			break
+		}
+	}
+
+	if wty == 0 {
+		return 0, rd
+	}
+
+	rd.HasDifferentials = true
+	return wt, rd
+}
@@ -0,0 +1,97 @@
+package camera
+
+import (
+	"math"
+	"pbrt-go/material"
+	"pbrt-go/mymath"
+)
+
+// EnvironmentLayout tells how the directions of the full sphere are arranged on the film
+type EnvironmentLayout int
+
+const (
+	// LayoutLatLong is equirectangular projection, film x maps to phi and film y maps to theta
+	LayoutLatLong EnvironmentLayout = iota
+	// LayoutCubeMap arranges six cube faces into 3x2 grid, the first row holds +x, -x, +y faces and the second
+	// row -y, +z, -z faces. Face orientations follow the OpenGL cube map convention.
+	LayoutCubeMap
+)
+
+// EnvironmentCamera captures radiance arriving from all the directions around the camera
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/environment.h, https://github.com/mmp/pbrt-v3/blob/master/src/cameras/environment.cpp
+type EnvironmentCamera struct {
+	CameraBase
+	Layout EnvironmentLayout
+}
+
+func NewEnvironmentCamera(cameraToWorld mymath.AnimatedTransform, shutterOpen, shutterClose float64, fullResolution mymath.Point2i, layout EnvironmentLayout, medium material.Medium) *EnvironmentCamera {
+	return &EnvironmentCamera{
+		NewCameraBase(cameraToWorld, shutterOpen, shutterClose, fullResolution, medium),
+		layout,
+	}
+}
+
+// GenerateRay see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/environment.cpp#L41
+func (c EnvironmentCamera) GenerateRay(sample CameraSample) (float64, mymath.Ray) {
+	var dir mymath.Vector3
+	if c.Layout == LayoutCubeMap {
+		dir = c.cubeMapDirection(sample.PFilm)
+	} else {
+		dir = c.latLongDirection(sample.PFilm)
+	}
+
+	ray := mymath.NewRay(mymath.NewPoint3(0, 0, 0), dir, math.Inf(1), c.time(sample), c.Medium)
+
+	ray, err := c.CameraToWorld.ApplyR(ray)
+	if err != nil {
+		return 0, ray
+	}
+
+	return 1, ray
+}
+
+func (c EnvironmentCamera) GenerateRayDifferential(sample CameraSample) (float64, mymath.RayDifferential) {
+	return c.generateRayDifferential(c, sample)
+}
+
+// latLongDirection computes camera ray direction, the y axis points up the same as in pbrt
+func (c EnvironmentCamera) latLongDirection(pFilm mymath.Point2) mymath.Vector3 {
+	// Compute environment camera ray direction
+	theta := math.Pi * pFilm.Y / float64(c.FullResolution.Y)
+	phi := 2 * math.Pi * pFilm.X / float64(c.FullResolution.X)
+	dir := mymath.SphericalDirection(math.Sin(theta), math.Cos(theta), phi)
+
+	return dir.Permute(0, 2, 1)
+}
+
+// cubeMapDirection computes normalized direction for the film point of the cube map layout
+func (c EnvironmentCamera) cubeMapDirection(pFilm mymath.Point2) mymath.Vector3 {
+	faceWidth := float64(c.FullResolution.X) / 3
+	faceHeight := float64(c.FullResolution.Y) / 2
+
+	col := mymath.Clamp(math.Floor(pFilm.X/faceWidth), 0, 2)
+	row := mymath.Clamp(math.Floor(pFilm.Y/faceHeight), 0, 1)
+
+	// Face coordinates in [-1, 1], a goes right and b goes down
+	a := 2*(pFilm.X-col*faceWidth)/faceWidth - 1
+	b := 2*(pFilm.Y-row*faceHeight)/faceHeight - 1
+
+	var dir mymath.Vector3
+	switch int(row)*3 + int(col) {
+	case 0: // +x
+		dir = mymath.NewVector3(1, -b, -a)
+	case 1: // -x
+		dir = mymath.NewVector3(-1, -b, a)
+	case 2: // +y
+		dir = mymath.NewVector3(a, 1, b)
+	case 3: // -y
+		dir = mymath.NewVector3(a, -1, -b)
+	case 4: // +z
+		dir = mymath.NewVector3(a, -b, 1)
+	default: // -z
+		dir = mymath.NewVector3(-a, -b, -1)
+	}
+
+	return dir.Normalize()
+}
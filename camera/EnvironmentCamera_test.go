@@ -0,0 +1,79 @@
+package camera_test
+
+import (
+	"math"
+	"pbrt-go/camera"
+	"pbrt-go/material"
+	"pbrt-go/mymath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvironmentCamera_GenerateRay_latLong(t *testing.T) {
+	c := camera.NewEnvironmentCamera(staticTransform(mymath.NewTransformEmpty()), 0, 1, mymath.NewPoint2i(200, 100), camera.LayoutLatLong, material.Medium{})
+
+	wt, ray := c.GenerateRay(camera.CameraSample{PFilm: mymath.NewPoint2(0, 50)})
+	assert.Equal(t, 1.0, wt)
+	inDeltaVector3(t, mymath.NewVector3(1, 0, 0), ray.D, equalDelta)
+
+	_, ray = c.GenerateRay(camera.CameraSample{PFilm: mymath.NewPoint2(50, 50)})
+	inDeltaVector3(t, mymath.NewVector3(0, 0, 1), ray.D, equalDelta)
+
+	_, ray = c.GenerateRay(camera.CameraSample{PFilm: mymath.NewPoint2(120, 0)})
+	inDeltaVector3(t, mymath.NewVector3(0, 1, 0), ray.D, equalDelta)
+
+	// film x maps linearly to phi and film y to theta measured from the y axis
+	_, ray = c.GenerateRay(camera.CameraSample{PFilm: mymath.NewPoint2(130, 30)})
+	dir := ray.D.Permute(0, 2, 1)
+	assert.InDelta(t, 0.3*math.Pi, mymath.SphericalTheta(dir), equalDelta)
+	assert.InDelta(t, 1.3*math.Pi, mymath.SphericalPhi(dir), equalDelta)
+}
+
+func TestEnvironmentCamera_GenerateRay_cubeMap(t *testing.T) {
+	c := camera.NewEnvironmentCamera(staticTransform(mymath.NewTransformEmpty()), 0, 1, mymath.NewPoint2i(300, 200), camera.LayoutCubeMap, material.Medium{})
+
+	faceCenters := map[mymath.Point2]mymath.Vector3{
+		mymath.NewPoint2(50, 50):   mymath.NewVector3(1, 0, 0),
+		mymath.NewPoint2(150, 50):  mymath.NewVector3(-1, 0, 0),
+		mymath.NewPoint2(250, 50):  mymath.NewVector3(0, 1, 0),
+		mymath.NewPoint2(50, 150):  mymath.NewVector3(0, -1, 0),
+		mymath.NewPoint2(150, 150): mymath.NewVector3(0, 0, 1),
+		mymath.NewPoint2(250, 150): mymath.NewVector3(0, 0, -1),
+	}
+
+	for pFilm, expected := range faceCenters {
+		wt, ray := c.GenerateRay(camera.CameraSample{PFilm: pFilm})
+		assert.Equal(t, 1.0, wt)
+		inDeltaVector3(t, expected, ray.D, equalDelta)
+	}
+
+	// the +z face is seen upright, film y goes down
+	_, ray := c.GenerateRay(camera.CameraSample{PFilm: mymath.NewPoint2(150, 100)})
+	inDeltaVector3(t, mymath.NewVector3(0, 1, 1).Normalize(), ray.D, equalDelta)
+
+	// the corner of the face points to the cube corner
+	_, ray = c.GenerateRay(camera.CameraSample{PFilm: mymath.NewPoint2(199.999, 199.999)})
+	inDeltaVector3(t, mymath.NewVector3(1, -1, 1).Normalize(), ray.D, 1e-4)
+}
+
+func TestEnvironmentCamera_GenerateRayDifferential(t *testing.T) {
+	cameraToWorld, _ := mymath.NewAnimatedTransform(
+		mymath.NewTransformEmpty(), 0,
+		mymath.NewTransformTranslate(mymath.NewVector3(0, 4, 0)), 1)
+	c := camera.NewEnvironmentCamera(cameraToWorld, 0, 1, mymath.NewPoint2i(200, 100), camera.LayoutLatLong, material.Medium{})
+	sample := camera.CameraSample{PFilm: mymath.NewPoint2(70.5, 40.5), Time: 0.5}
+
+	wt, rd := c.GenerateRayDifferential(sample)
+	assert.Equal(t, 1.0, wt)
+	assert.True(t, rd.HasDifferentials)
+	inDeltaPoint3(t, mymath.NewPoint3(0, 2, 0), rd.O, equalDelta)
+	inDeltaPoint3(t, rd.O, rd.RxOrigin, equalDelta)
+
+	// differentials approximate rays of the neighbouring pixels
+	_, rx := c.GenerateRay(camera.CameraSample{PFilm: mymath.NewPoint2(71.5, 40.5), Time: 0.5})
+	inDeltaVector3(t, rx.D, rd.RxDirection, 1e-3)
+
+	_, ry := c.GenerateRay(camera.CameraSample{PFilm: mymath.NewPoint2(70.5, 41.5), Time: 0.5})
+	inDeltaVector3(t, ry.D, rd.RyDirection, 1e-3)
+}
@@ -0,0 +1,110 @@
+package camera
+
+import (
+	"math"
+	"pbrt-go/material"
+	"pbrt-go/mymath"
+)
+
+// OrthographicCamera see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/orthographic.h, https://github.com/mmp/pbrt-v3/blob/master/src/cameras/orthographic.cpp
+type OrthographicCamera struct {
+	ProjectiveCamera
+	DxCamera, DyCamera mymath.Vector3
+}
+
+// NewOrthographicCamera see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/orthographic.h#L50
+func NewOrthographicCamera(cameraToWorld mymath.AnimatedTransform, screenWindow mymath.Bounds2, shutterOpen, shutterClose, lensRadius, focalDistance float64, fullResolution mymath.Point2i, medium material.Medium) *OrthographicCamera {
+	pc := NewProjectiveCamera(cameraToWorld, mymath.NewTransformOrthographic(0, 1), screenWindow,
+		shutterOpen, shutterClose, lensRadius, focalDistance, fullResolution, medium)
+
+	// Compute differential changes in origin for orthographic camera rays
+	dxCamera := pc.RasterToCamera.ApplyV(mymath.NewVector3(1, 0, 0))
+	dyCamera := pc.RasterToCamera.ApplyV(mymath.NewVector3(0, 1, 0))
+
+	return &OrthographicCamera{pc, dxCamera, dyCamera}
+}
+
+// GenerateRay see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/orthographic.cpp#L44
+func (c OrthographicCamera) GenerateRay(sample CameraSample) (float64, mymath.Ray) {
+	// Compute raster and camera sample positions
+	pFilm := mymath.NewPoint3(sample.PFilm.X, sample.PFilm.Y, 0)
+	pCamera := c.RasterToCamera.ApplyP(pFilm)
+	ray := mymath.NewRay(pCamera, mymath.NewVector3(0, 0, 1), math.Inf(1), c.time(sample), c.Medium)
+
+	// Modify ray for depth of field
+	if c.LensRadius > 0 {
+		// Sample point on lens
+		pLens := mymath.ConcentricSampleDisk(sample.PLens)
+		pLens = mymath.NewPoint2(c.LensRadius*pLens.X, c.LensRadius*pLens.Y)
+
+		// Compute point on plane of focus
+		ft := c.FocalDistance / ray.D.Z
+		pFocus := ray.Apply(ft)
+
+		// Update ray for effect of lens
+		ray.O = mymath.NewPoint3(pLens.X, pLens.Y, 0)
+		ray.D = pFocus.SubtractP(ray.O).Normalize()
+	}
+
+	ray, err := c.CameraToWorld.ApplyR(ray)
+	if err != nil {
+		return 0, ray
+	}
+
+	return 1, ray
+}
+
+// GenerateRayDifferential see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/orthographic.cpp#L69
+func (c OrthographicCamera) GenerateRayDifferential(sample CameraSample) (float64, mymath.RayDifferential) {
+	// Compute main orthographic viewing ray
+
+	// Compute raster and camera sample positions
+	pFilm := mymath.NewPoint3(sample.PFilm.X, sample.PFilm.Y, 0)
+	pCamera := c.RasterToCamera.ApplyP(pFilm)
+	ray := mymath.NewRayDifferentialRay(mymath.NewRay(pCamera, mymath.NewVector3(0, 0, 1), math.Inf(1), c.time(sample), c.Medium))
+
+	// Modify ray for depth of field
+	if c.LensRadius > 0 {
+		// Sample point on lens
+		pLens := mymath.ConcentricSampleDisk(sample.PLens)
+		pLens = mymath.NewPoint2(c.LensRadius*pLens.X, c.LensRadius*pLens.Y)
+
+		// Compute point on plane of focus
+		ft := c.FocalDistance / ray.D.Z
+		pFocus := ray.Apply(ft)
+
+		// Update ray for effect of lens
+		ray.O = mymath.NewPoint3(pLens.X, pLens.Y, 0)
+		ray.D = pFocus.SubtractP(ray.O).Normalize()
+	}
+
+	// Compute ray differentials for OrthographicCamera
+	if c.LensRadius > 0 {
+		// Compute OrthographicCamera ray differentials accounting for lens
+		pLens := mymath.ConcentricSampleDisk(sample.PLens)
+		pLens = mymath.NewPoint2(c.LensRadius*pLens.X, c.LensRadius*pLens.Y)
+		ft := c.FocalDistance / ray.D.Z
+
+		pFocus := pCamera.AddV(c.DxCamera).AddV(mymath.NewVector3(0, 0, ft))
+		ray.RxOrigin = mymath.NewPoint3(pLens.X, pLens.Y, 0)
+		ray.RxDirection = pFocus.SubtractP(ray.RxOrigin).Normalize()
+
+		pFocus = pCamera.AddV(c.DyCamera).AddV(mymath.NewVector3(0, 0, ft))
+		ray.RyOrigin = mymath.NewPoint3(pLens.X, pLens.Y, 0)
+		ray.RyDirection = pFocus.SubtractP(ray.RyOrigin).Normalize()
+	} else {
+		ray.RxOrigin = ray.O.AddV(c.DxCamera)
+		ray.RyOrigin = ray.O.AddV(c.DyCamera)
+		ray.RxDirection = ray.D
+		ray.RyDirection = ray.D
+	}
+
+	ray.HasDifferentials = true
+
+	ray, err := c.CameraToWorld.ApplyRD(ray)
+	if err != nil {
+		return 0, ray
+	}
+
+	return 1, ray
+}
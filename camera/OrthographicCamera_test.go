@@ -0,0 +1,61 @@
+package camera_test
+
+import (
+	"pbrt-go/camera"
+	"pbrt-go/material"
+	"pbrt-go/mymath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newOrthographicCamera(lensRadius, focalDistance float64) *camera.OrthographicCamera {
+	resolution := mymath.NewPoint2i(100, 100)
+	return camera.NewOrthographicCamera(staticTransform(mymath.NewTransformEmpty()), camera.NewScreenWindow(resolution),
+		0, 1, lensRadius, focalDistance, resolution, material.Medium{})
+}
+
+func TestOrthographicCamera_GenerateRay(t *testing.T) {
+	c := newOrthographicCamera(0, 1)
+
+	wt, ray := c.GenerateRay(camera.CameraSample{PFilm: mymath.NewPoint2(0, 0)})
+	assert.Equal(t, 1.0, wt)
+	inDeltaPoint3(t, mymath.NewPoint3(-1, 1, 0), ray.O, equalDelta)
+	inDeltaVector3(t, mymath.NewVector3(0, 0, 1), ray.D, equalDelta)
+
+	_, ray = c.GenerateRay(camera.CameraSample{PFilm: mymath.NewPoint2(75, 50)})
+	inDeltaPoint3(t, mymath.NewPoint3(0.5, 0, 0), ray.O, equalDelta)
+}
+
+func TestOrthographicCamera_GenerateRayDifferential(t *testing.T) {
+	c := newOrthographicCamera(0, 1)
+
+	wt, rd := c.GenerateRayDifferential(camera.CameraSample{PFilm: mymath.NewPoint2(50, 50)})
+	assert.Equal(t, 1.0, wt)
+	assert.True(t, rd.HasDifferentials)
+	inDeltaPoint3(t, mymath.NewPoint3(0.02, 0, 0), rd.RxOrigin, equalDelta)
+	inDeltaPoint3(t, mymath.NewPoint3(0, -0.02, 0), rd.RyOrigin, equalDelta)
+	inDeltaVector3(t, rd.D, rd.RxDirection, equalDelta)
+	inDeltaVector3(t, rd.D, rd.RyDirection, equalDelta)
+}
+
+// Rays start on the lens around the camera origin and meet at the plane of focus straight ahead of the film point
+func TestOrthographicCamera_GenerateRay_depthOfField(t *testing.T) {
+	c := newOrthographicCamera(0.1, 2)
+	pFilm := mymath.NewPoint2(75, 25)
+	pFocus := mymath.NewPoint3(0.5, 0.5, 2)
+
+	for _, pLens := range []mymath.Point2{mymath.NewPoint2(0.1, 0.2), mymath.NewPoint2(0.9, 0.5), mymath.NewPoint2(0.4, 0.99)} {
+		_, ray := c.GenerateRay(camera.CameraSample{PFilm: pFilm, PLens: pLens})
+
+		assert.LessOrEqual(t, ray.O.Distance(mymath.NewPoint3(0, 0, 0)), 0.1+equalDelta)
+		inDeltaPoint3(t, pFocus, ray.Apply(2/ray.D.Z), equalDelta)
+
+		// the differentials share the lens point, pbrt takes their focus distance along the lens ray
+		_, rd := c.GenerateRayDifferential(camera.CameraSample{PFilm: pFilm, PLens: pLens})
+		inDeltaPoint3(t, ray.O, rd.O, equalDelta)
+		inDeltaPoint3(t, ray.O, rd.RxOrigin, equalDelta)
+		ft := 2 / ray.D.Z
+		inDeltaPoint3(t, mymath.NewPoint3(0.52, 0.5, ft), rd.RxOrigin.AddV(rd.RxDirection.Multiply(ft/rd.RxDirection.Z)), equalDelta)
+	}
+}
@@ -0,0 +1,113 @@
+package camera
+
+import (
+	"math"
+	"pbrt-go/material"
+	"pbrt-go/mymath"
+)
+
+// PerspectiveCamera see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/perspective.h, https://github.com/mmp/pbrt-v3/blob/master/src/cameras/perspective.cpp
+type PerspectiveCamera struct {
+	ProjectiveCamera
+	DxCamera, DyCamera mymath.Vector3
+}
+
+// NewPerspectiveCamera creates camera with the field of view fov (in degrees) along the shorter image axis
+// of the default screen window
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/perspective.cpp#L44
+func NewPerspectiveCamera(cameraToWorld mymath.AnimatedTransform, screenWindow mymath.Bounds2, shutterOpen, shutterClose, lensRadius, focalDistance, fov float64, fullResolution mymath.Point2i, medium material.Medium) *PerspectiveCamera {
+	pc := NewProjectiveCamera(cameraToWorld, mymath.NewTransformPerspective(fov, 1e-2, 1000), screenWindow,
+		shutterOpen, shutterClose, lensRadius, focalDistance, fullResolution, medium)
+
+	// Compute differential changes in origin for perspective camera rays
+	origin := pc.RasterToCamera.ApplyP(mymath.NewPoint3(0, 0, 0))
+	dxCamera := pc.RasterToCamera.ApplyP(mymath.NewPoint3(1, 0, 0)).SubtractP(origin)
+	dyCamera := pc.RasterToCamera.ApplyP(mymath.NewPoint3(0, 1, 0)).SubtractP(origin)
+
+	return &PerspectiveCamera{pc, dxCamera, dyCamera}
+}
+
+// GenerateRay see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/perspective.cpp#L72
+func (c PerspectiveCamera) GenerateRay(sample CameraSample) (float64, mymath.Ray) {
+	// Compute raster and camera sample positions
+	pFilm := mymath.NewPoint3(sample.PFilm.X, sample.PFilm.Y, 0)
+	pCamera := c.RasterToCamera.ApplyP(pFilm)
+	ray := mymath.NewRay(mymath.NewPoint3(0, 0, 0), mymath.NewVector3P(pCamera).Normalize(), math.Inf(1), c.time(sample), c.Medium)
+
+	// Modify ray for depth of field
+	if c.LensRadius > 0 {
+		// Sample point on lens
+		pLens := mymath.ConcentricSampleDisk(sample.PLens)
+		pLens = mymath.NewPoint2(c.LensRadius*pLens.X, c.LensRadius*pLens.Y)
+
+		// Compute point on plane of focus
+		ft := c.FocalDistance / ray.D.Z
+		pFocus := ray.Apply(ft)
+
+		// Update ray for effect of lens
+		ray.O = mymath.NewPoint3(pLens.X, pLens.Y, 0)
+		ray.D = pFocus.SubtractP(ray.O).Normalize()
+	}
+
+	ray, err := c.CameraToWorld.ApplyR(ray)
+	if err != nil {
+		return 0, ray
+	}
+
+	return 1, ray
+}
+
+// GenerateRayDifferential see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/perspective.cpp#L101
+func (c PerspectiveCamera) GenerateRayDifferential(sample CameraSample) (float64, mymath.RayDifferential) {
+	// Compute raster and camera sample positions
+	pFilm := mymath.NewPoint3(sample.PFilm.X, sample.PFilm.Y, 0)
+	pCamera := c.RasterToCamera.ApplyP(pFilm)
+	dir := mymath.NewVector3P(pCamera).Normalize()
+	ray := mymath.NewRayDifferentialRay(mymath.NewRay(mymath.NewPoint3(0, 0, 0), dir, math.Inf(1), c.time(sample), c.Medium))
+
+	// Modify ray for depth of field
+	if c.LensRadius > 0 {
+		// Sample point on lens
+		pLens := mymath.ConcentricSampleDisk(sample.PLens)
+		pLens = mymath.NewPoint2(c.LensRadius*pLens.X, c.LensRadius*pLens.Y)
+
+		// Compute point on plane of focus
+		ft := c.FocalDistance / ray.D.Z
+		pFocus := ray.Apply(ft)
+
+		// Update ray for effect of lens
+		ray.O = mymath.NewPoint3(pLens.X, pLens.Y, 0)
+		ray.D = pFocus.SubtractP(ray.O).Normalize()
+	}
+
+	// Compute offset rays for PerspectiveCamera ray differentials
+	if c.LensRadius > 0 {
+		// Compute PerspectiveCamera ray differentials accounting for lens, the same lens point is used
+		dx := mymath.NewVector3P(pCamera).Add(c.DxCamera).Normalize()
+		ft := c.FocalDistance / dx.Z
+		pFocus := mymath.NewPoint3(0, 0, 0).AddV(dx.Multiply(ft))
+		ray.RxOrigin = ray.O
+		ray.RxDirection = pFocus.SubtractP(ray.RxOrigin).Normalize()
+
+		dy := mymath.NewVector3P(pCamera).Add(c.DyCamera).Normalize()
+		ft = c.FocalDistance / dy.Z
+		pFocus = mymath.NewPoint3(0, 0, 0).AddV(dy.Multiply(ft))
+		ray.RyOrigin = ray.O
+		ray.RyDirection = pFocus.SubtractP(ray.RyOrigin).Normalize()
+	} else {
+		ray.RxOrigin = ray.O
+		ray.RyOrigin = ray.O
+		ray.RxDirection = mymath.NewVector3P(pCamera).Add(c.DxCamera).Normalize()
+		ray.RyDirection = mymath.NewVector3P(pCamera).Add(c.DyCamera).Normalize()
+	}
+
+	ray.HasDifferentials = true
+
+	ray, err := c.CameraToWorld.ApplyRD(ray)
+	if err != nil {
+		return 0, ray
+	}
+
+	return 1, ray
+}
@@ -0,0 +1,107 @@
+package camera_test
+
+import (
+	"math"
+	"pbrt-go/camera"
+	"pbrt-go/material"
+	"pbrt-go/mymath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const equalDelta = 0.00001
+
+func inDeltaVector3(t *testing.T, expected, actual mymath.Vector3, delta float64) {
+	assert.InDelta(t, expected.X, actual.X, delta)
+	assert.InDelta(t, expected.Y, actual.Y, delta)
+	assert.InDelta(t, expected.Z, actual.Z, delta)
+}
+
+func inDeltaPoint3(t *testing.T, expected, actual mymath.Point3, delta float64) {
+	assert.InDelta(t, expected.X, actual.X, delta)
+	assert.InDelta(t, expected.Y, actual.Y, delta)
+	assert.InDelta(t, expected.Z, actual.Z, delta)
+}
+
+func staticTransform(t mymath.Transform) mymath.AnimatedTransform {
+	at, _ := mymath.NewAnimatedTransform(t, 0, t, 1)
+	return at
+}
+
+func newPerspectiveCamera(lensRadius, focalDistance float64) *camera.PerspectiveCamera {
+	resolution := mymath.NewPoint2i(100, 50)
+	return camera.NewPerspectiveCamera(staticTransform(mymath.NewTransformEmpty()), camera.NewScreenWindow(resolution),
+		0, 1, lensRadius, focalDistance, 90, resolution, material.Medium{})
+}
+
+func TestPerspectiveCamera_GenerateRay(t *testing.T) {
+	c := newPerspectiveCamera(0, 1)
+
+	// image center looks along z axis
+	wt, ray := c.GenerateRay(camera.CameraSample{PFilm: mymath.NewPoint2(50, 25)})
+	assert.Equal(t, 1.0, wt)
+	inDeltaPoint3(t, mymath.NewPoint3(0, 0, 0), ray.O, equalDelta)
+	inDeltaVector3(t, mymath.NewVector3(0, 0, 1), ray.D, equalDelta)
+
+	// field of view spans the shorter image axis, raster y goes down
+	_, ray = c.GenerateRay(camera.CameraSample{PFilm: mymath.NewPoint2(50, 0)})
+	inDeltaVector3(t, mymath.NewVector3(0, 1, 1).Normalize(), ray.D, equalDelta)
+
+	_, ray = c.GenerateRay(camera.CameraSample{PFilm: mymath.NewPoint2(100, 25)})
+	inDeltaVector3(t, mymath.NewVector3(2, 0, 1).Normalize(), ray.D, equalDelta)
+}
+
+func TestPerspectiveCamera_GenerateRayDifferential(t *testing.T) {
+	c := newPerspectiveCamera(0, 1)
+	sample := camera.CameraSample{PFilm: mymath.NewPoint2(20.5, 10.5)}
+
+	wt, rd := c.GenerateRayDifferential(sample)
+	assert.Equal(t, 1.0, wt)
+	assert.True(t, rd.HasDifferentials)
+
+	_, ray := c.GenerateRay(sample)
+	inDeltaVector3(t, ray.D, rd.D, equalDelta)
+
+	_, rx := c.GenerateRay(camera.CameraSample{PFilm: mymath.NewPoint2(21.5, 10.5)})
+	inDeltaPoint3(t, rx.O, rd.RxOrigin, equalDelta)
+	inDeltaVector3(t, rx.D, rd.RxDirection, equalDelta)
+
+	_, ry := c.GenerateRay(camera.CameraSample{PFilm: mymath.NewPoint2(20.5, 11.5)})
+	inDeltaPoint3(t, ry.O, rd.RyOrigin, equalDelta)
+	inDeltaVector3(t, ry.D, rd.RyDirection, equalDelta)
+}
+
+// All the rays through the single film point meet at the plane of focus
+func TestPerspectiveCamera_GenerateRay_depthOfField(t *testing.T) {
+	c := newPerspectiveCamera(0.5, 3)
+	pFilm := mymath.NewPoint2(30, 40)
+
+	_, pinhole := newPerspectiveCamera(0, 3).GenerateRay(camera.CameraSample{PFilm: pFilm})
+	pFocus := pinhole.Apply(3 / pinhole.D.Z)
+
+	for _, pLens := range []mymath.Point2{mymath.NewPoint2(0.1, 0.2), mymath.NewPoint2(0.9, 0.5), mymath.NewPoint2(0.4, 0.99)} {
+		_, ray := c.GenerateRay(camera.CameraSample{PFilm: pFilm, PLens: pLens})
+
+		assert.InDelta(t, 0.0, ray.O.Z, equalDelta)
+		assert.LessOrEqual(t, math.Hypot(ray.O.X, ray.O.Y), 0.5)
+		inDeltaPoint3(t, pFocus, ray.Apply(3/ray.D.Z), equalDelta)
+
+		_, rd := c.GenerateRayDifferential(camera.CameraSample{PFilm: pFilm, PLens: pLens})
+		inDeltaPoint3(t, ray.O, rd.O, equalDelta)
+		inDeltaVector3(t, ray.D, rd.D, equalDelta)
+	}
+}
+
+func TestPerspectiveCamera_GenerateRay_motion(t *testing.T) {
+	cameraToWorld, _ := mymath.NewAnimatedTransform(
+		mymath.NewTransformEmpty(), 0,
+		mymath.NewTransformTranslate(mymath.NewVector3(10, 0, 0)), 1)
+	resolution := mymath.NewPoint2i(10, 10)
+	c := camera.NewPerspectiveCamera(cameraToWorld, camera.NewScreenWindow(resolution), 0, 0.5, 0, 1, 60, resolution, material.Medium{})
+
+	// sample time is mapped to the shutter interval
+	_, ray := c.GenerateRay(camera.CameraSample{PFilm: mymath.NewPoint2(5, 5), Time: 0.5})
+	assert.Equal(t, float32(0.25), ray.Time)
+	inDeltaPoint3(t, mymath.NewPoint3(2.5, 0, 0), ray.O, equalDelta)
+}
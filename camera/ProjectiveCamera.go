@@ -0,0 +1,52 @@
+package camera
+
+import (
+	"pbrt-go/material"
+	"pbrt-go/mymath"
+)
+
+// ProjectiveCamera holds transformations between camera, screen and raster spaces shared by the cameras
+// that can be described by 4x4 projective matrix
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/camera.h#L90
+type ProjectiveCamera struct {
+	CameraBase
+	CameraToScreen, RasterToCamera mymath.Transform
+	ScreenToRaster, RasterToScreen mymath.Transform
+	LensRadius, FocalDistance      float64
+}
+
+func NewProjectiveCamera(cameraToWorld mymath.AnimatedTransform, cameraToScreen mymath.Transform, screenWindow mymath.Bounds2, shutterOpen, shutterClose, lensRadius, focalDistance float64, fullResolution mymath.Point2i, medium material.Medium) ProjectiveCamera {
+	// Compute projective camera screen transformations
+	screenToRaster := mymath.NewTransformScale(float32(fullResolution.X), float32(fullResolution.Y), 1).
+		ApplyT(mymath.NewTransformScale(
+			float32(1/(screenWindow.PMax.X-screenWindow.PMin.X)),
+			float32(1/(screenWindow.PMin.Y-screenWindow.PMax.Y)),
+			1)).
+		ApplyT(mymath.NewTransformTranslate(mymath.NewVector3(-screenWindow.PMin.X, -screenWindow.PMax.Y, 0)))
+	rasterToScreen := screenToRaster.Inverse()
+	rasterToCamera := cameraToScreen.Inverse().ApplyT(rasterToScreen)
+
+	return ProjectiveCamera{
+		CameraBase:     NewCameraBase(cameraToWorld, shutterOpen, shutterClose, fullResolution, medium),
+		CameraToScreen: cameraToScreen,
+		RasterToCamera: rasterToCamera,
+		ScreenToRaster: screenToRaster,
+		RasterToScreen: rasterToScreen,
+		LensRadius:     lensRadius,
+		FocalDistance:  focalDistance,
+	}
+}
+
+// NewScreenWindow returns the default screen window, the shorter image axis spans [-1, 1]
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/perspective.cpp#L238
+func NewScreenWindow(fullResolution mymath.Point2i) mymath.Bounds2 {
+	frame := float64(fullResolution.X) / float64(fullResolution.Y)
+
+	if frame > 1 {
+		return mymath.NewBounds2(mymath.NewPoint2(-frame, -1), mymath.NewPoint2(frame, 1))
+	}
+
+	return mymath.NewBounds2(mymath.NewPoint2(-1, -1/frame), mymath.NewPoint2(1, 1/frame))
+}
@@ -0,0 +1,551 @@
+package camera
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"pbrt-go/material"
+	"pbrt-go/mymath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// LensElementInterface describes single spherical interface of the lens system, or the aperture stop when
+// the curvature radius is zero. Distances are in meters.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/realistic.h#L80
+type LensElementInterface struct {
+	CurvatureRadius float64
+	Thickness       float64
+	Eta             float64
+	ApertureRadius  float64
+}
+
+// RealisticCamera traces rays through the tabulated lens system, the film lies in the plane z = 0 and the lens
+// elements are placed along the negative z axis in the lens space
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/realistic.h, https://github.com/mmp/pbrt-v3/blob/master/src/cameras/realistic.cpp
+type RealisticCamera struct {
+	CameraBase
+	SimpleWeighting   bool
+	FilmDiagonal      float64
+	ElementInterfaces []LensElementInterface
+	ExitPupilBounds   []mymath.Bounds2
+}
+
+// exitPupilIntervals is number of film radius intervals with precomputed exit pupil bounds
+const exitPupilIntervals = 64
+
+// NewRealisticCamera creates camera from the lens data, four values per element interface: curvature radius,
+// thickness, index of refraction and aperture diameter, all lengths in millimeters. The aperture diameter
+// (in millimeters) limits the aperture stop, the lens is focused at focusDistance (in meters).
+// The filmDiagonal is in meters.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/realistic.cpp#L53
+func NewRealisticCamera(cameraToWorld mymath.AnimatedTransform, shutterOpen, shutterClose, apertureDiameter, focusDistance float64, simpleWeighting bool, lensData []float64, fullResolution mymath.Point2i, filmDiagonal float64, medium material.Medium) (*RealisticCamera, error) {
+	if len(lensData)%4 != 0 {
+		return nil, fmt.Errorf("excess values in lens specification, must be multiple of four values, got %v", len(lensData))
+	}
+
+	c := &RealisticCamera{
+		CameraBase:      NewCameraBase(cameraToWorld, shutterOpen, shutterClose, fullResolution, medium),
+		SimpleWeighting: simpleWeighting,
+		FilmDiagonal:    filmDiagonal,
+	}
+
+	for i := 0; i < len(lensData); i += 4 {
+		apertureDiam := lensData[i+3]
+		// Aperture stop can only be stopped down, the wider value is ignored the same as in pbrt
+		if lensData[i] == 0 && apertureDiameter <= apertureDiam {
+			apertureDiam = apertureDiameter
+		}
+
+		c.ElementInterfaces = append(c.ElementInterfaces, LensElementInterface{
+			CurvatureRadius: lensData[i] * 0.001,
+			Thickness:       lensData[i+1] * 0.001,
+			Eta:             lensData[i+2],
+			ApertureRadius:  apertureDiam * 0.001 / 2,
+		})
+	}
+
+	if len(c.ElementInterfaces) == 0 {
+		return nil, fmt.Errorf("no lens elements given")
+	}
+
+	// Compute lens-film distance for given focus distance
+	thickness, err := c.FocusThickLens(focusDistance)
+	if err != nil {
+		return nil, err
+	}
+	c.ElementInterfaces[len(c.ElementInterfaces)-1].Thickness = thickness
+
+	// Compute exit pupil bounds at sampled points on the film
+	c.ExitPupilBounds = make([]mymath.Bounds2, exitPupilIntervals)
+
+	var wg sync.WaitGroup
+	for i := range c.ExitPupilBounds {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r0 := float64(i) / exitPupilIntervals * filmDiagonal / 2
+			r1 := float64(i+1) / exitPupilIntervals * filmDiagonal / 2
+			c.ExitPupilBounds[i] = c.BoundExitPupil(r0, r1)
+		}(i)
+	}
+	wg.Wait()
+
+	return c, nil
+}
+
+// ReadLensData parses lens description in the pbrt text format, whitespace separated numbers with
+// the comments starting by #
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/floatfile.cpp#L40
+func ReadLensData(r io.Reader) ([]float64, error) {
+	var values []float64
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+
+		for _, field := range strings.Fields(line) {
+			v, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return nil, fmt.Errorf("unexpected text %q found at line %v of lens data", field, lineNumber)
+			}
+			values = append(values, v)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// LoadLensFile reads lens description file in the pbrt text format
+func LoadLensFile(filename string) ([]float64, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadLensData(f)
+}
+
+func (c *RealisticCamera) LensRearZ() float64 {
+	return c.ElementInterfaces[len(c.ElementInterfaces)-1].Thickness
+}
+
+func (c *RealisticCamera) LensFrontZ() float64 {
+	zSum := 0.0
+	for _, element := range c.ElementInterfaces {
+		zSum += element.Thickness
+	}
+
+	return zSum
+}
+
+func (c *RealisticCamera) RearElementRadius() float64 {
+	return c.ElementInterfaces[len(c.ElementInterfaces)-1].ApertureRadius
+}
+
+// flipZ converts ray between camera space and lens space, CameraToLens and LensToCamera are both Scale(1, 1, -1)
+func flipZ(r mymath.Ray) mymath.Ray {
+	r.O.Z = -r.O.Z
+	r.D.Z = -r.D.Z
+	return r
+}
+
+// TraceLensesFromFilm traces the camera space ray leaving the film through the lens elements, returns false
+// if the ray is blocked
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/realistic.cpp#L118
+func (c *RealisticCamera) TraceLensesFromFilm(rCamera mymath.Ray) (bool, mymath.Ray) {
+	elementZ := 0.0
+
+	// Transform rCamera from camera to lens system space
+	rLens := flipZ(rCamera)
+
+	for i := len(c.ElementInterfaces) - 1; i >= 0; i-- {
+		element := c.ElementInterfaces[i]
+
+		// Update ray from film accounting for interaction with element
+		elementZ -= element.Thickness
+
+		// Compute intersection of ray with lens element
+		var t float64
+		var n mymath.Normal3
+		isStop := element.CurvatureRadius == 0
+		if isStop {
+			// The refracted ray computed in the previous lens element interface may be pointed towards
+			// film plane (+z) in some extreme situations, in such cases t becomes negative
+			if rLens.D.Z >= 0 {
+				return false, mymath.Ray{}
+			}
+			t = (elementZ - rLens.O.Z) / rLens.D.Z
+		} else {
+			radius := element.CurvatureRadius
+			zCenter := elementZ + element.CurvatureRadius
+
+			var ok bool
+			if ok, t, n = intersectSphericalElement(radius, zCenter, rLens); !ok {
+				return false, mymath.Ray{}
+			}
+		}
+
+		// Test intersection point against element aperture
+		pHit := rLens.Apply(t)
+		r2 := pHit.X*pHit.X + pHit.Y*pHit.Y
+		if r2 > element.ApertureRadius*element.ApertureRadius {
+			return false, mymath.Ray{}
+		}
+		rLens.O = pHit
+
+		// Update ray path for element interface interaction
+		if !isStop {
+			etaI := element.Eta
+			etaT := 1.0
+			if i > 0 && c.ElementInterfaces[i-1].Eta != 0 {
+				etaT = c.ElementInterfaces[i-1].Eta
+			}
+
+			ok, w := mymath.Refract(rLens.D.Normalize().Negate(), n, etaI/etaT)
+			if !ok {
+				return false, mymath.Ray{}
+			}
+			rLens.D = w
+		}
+	}
+
+	// Transform rLens from lens system space back to camera space
+	return true, flipZ(rLens)
+}
+
+// intersectSphericalElement see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/realistic.cpp#L168
+func intersectSphericalElement(radius, zCenter float64, ray mymath.Ray) (bool, float64, mymath.Normal3) {
+	// Compute t0 and t1 for ray-element intersection
+	o := ray.O.SubtractV(mymath.NewVector3(0, 0, zCenter))
+	a := ray.D.X*ray.D.X + ray.D.Y*ray.D.Y + ray.D.Z*ray.D.Z
+	b := 2 * (ray.D.X*o.X + ray.D.Y*o.Y + ray.D.Z*o.Z)
+	cc := o.X*o.X + o.Y*o.Y + o.Z*o.Z - radius*radius
+
+	ok, t0, t1 := mymath.QuadraticFloat(a, b, cc)
+	if !ok {
+		return false, 0, mymath.Normal3{}
+	}
+
+	// Select intersection t based on ray direction and element curvature, the roots are ordered
+	useCloserT := (ray.D.Z > 0) != (radius < 0)
+	t := t1
+	if useCloserT {
+		t = t0
+	}
+
+	if t < 0 {
+		return false, 0, mymath.Normal3{}
+	}
+
+	// Compute surface normal of element at ray intersection point
+	n := mymath.NewNormal3V(mymath.NewVector3P(o.AddV(ray.D.Multiply(t))).Normalize())
+	n = n.FaceForward(mymath.NewNormal3V(ray.D.Negate()))
+
+	return true, t, n
+}
+
+// TraceLensesFromScene traces the camera space ray arriving from the scene through the lens elements
+// towards the film, returns false if the ray is blocked
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/realistic.cpp#L188
+func (c *RealisticCamera) TraceLensesFromScene(rCamera mymath.Ray) (bool, mymath.Ray) {
+	elementZ := -c.LensFrontZ()
+
+	// Transform rCamera from camera to lens system space
+	rLens := flipZ(rCamera)
+
+	for i, element := range c.ElementInterfaces {
+		// Compute intersection of ray with lens element
+		var t float64
+		var n mymath.Normal3
+		isStop := element.CurvatureRadius == 0
+		if isStop {
+			t = (elementZ - rLens.O.Z) / rLens.D.Z
+		} else {
+			radius := element.CurvatureRadius
+			zCenter := elementZ + element.CurvatureRadius
+
+			var ok bool
+			if ok, t, n = intersectSphericalElement(radius, zCenter, rLens); !ok {
+				return false, mymath.Ray{}
+			}
+		}
+
+		// Test intersection point against element aperture
+		pHit := rLens.Apply(t)
+		r2 := pHit.X*pHit.X + pHit.Y*pHit.Y
+		if r2 > element.ApertureRadius*element.ApertureRadius {
+			return false, mymath.Ray{}
+		}
+		rLens.O = pHit
+
+		// Update ray path for from-scene element interface interaction
+		if !isStop {
+			etaI := 1.0
+			if i > 0 && c.ElementInterfaces[i-1].Eta != 0 {
+				etaI = c.ElementInterfaces[i-1].Eta
+			}
+
+			etaT := 1.0
+			if element.Eta != 0 {
+				etaT = element.Eta
+			}
+
+			ok, w := mymath.Refract(rLens.D.Normalize().Negate(), n, etaI/etaT)
+			if !ok {
+				return false, mymath.Ray{}
+			}
+			rLens.D = w
+		}
+
+		elementZ += element.Thickness
+	}
+
+	// Transform rLens from lens system space back to camera space
+	return true, flipZ(rLens)
+}
+
+// computeCardinalPoints finds z of the principal plane and of the focal point from the ray parallel
+// to the optical axis and the ray leaving the lens system
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/realistic.cpp#L298
+func computeCardinalPoints(rIn, rOut mymath.Ray) (float64, float64) {
+	tf := -rOut.O.X / rOut.D.X
+	fz := -rOut.Apply(tf).Z
+	tp := (rIn.O.X - rOut.O.X) / rOut.D.X
+	pz := -rOut.Apply(tp).Z
+
+	return pz, fz
+}
+
+// ComputeThickLensApproximation returns z of the principal planes and of the focal points, the first values
+// are for the film side and the second for the scene side of the lens system
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/realistic.cpp#L307
+func (c *RealisticCamera) ComputeThickLensApproximation() ([2]float64, [2]float64, error) {
+	var pz, fz [2]float64
+
+	// Find height x from optical axis for parallel rays
+	x := 0.001 * c.FilmDiagonal
+
+	// Compute cardinal points for film side of lens system
+	rScene := mymath.NewRay(mymath.NewPoint3(x, 0, c.LensFrontZ()+1), mymath.NewVector3(0, 0, -1), math.Inf(1), 0, material.Medium{})
+	ok, rFilm := c.TraceLensesFromScene(rScene)
+	if !ok {
+		return pz, fz, fmt.Errorf("unable to trace ray from scene to film for thick lens approximation, is aperture stop extremely small?")
+	}
+	pz[0], fz[0] = computeCardinalPoints(rScene, rFilm)
+
+	// Compute cardinal points for scene side of lens system
+	rFilm = mymath.NewRay(mymath.NewPoint3(x, 0, c.LensRearZ()-1), mymath.NewVector3(0, 0, 1), math.Inf(1), 0, material.Medium{})
+	ok, rScene = c.TraceLensesFromFilm(rFilm)
+	if !ok {
+		return pz, fz, fmt.Errorf("unable to trace ray from film to scene for thick lens approximation, is aperture stop extremely small?")
+	}
+	pz[1], fz[1] = computeCardinalPoints(rFilm, rScene)
+
+	return pz, fz, nil
+}
+
+// FocusThickLens returns distance of the rear lens element from the film that focuses the lens system
+// at the focusDistance
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/realistic.cpp#L327
+func (c *RealisticCamera) FocusThickLens(focusDistance float64) (float64, error) {
+	pz, fz, err := c.ComputeThickLensApproximation()
+	if err != nil {
+		return 0, err
+	}
+
+	// Compute translation of lens, delta, to focus at focusDistance
+	f := fz[0] - pz[0]
+	z := -focusDistance
+	cc := (pz[1] - z - pz[0]) * (pz[1] - z - 4*f - pz[0])
+	if cc <= 0 {
+		return 0, fmt.Errorf("coefficient must be positive, it looks like focusDistance %v is too short for a given lens configuration", focusDistance)
+	}
+
+	delta := 0.5 * (pz[1] - z + pz[0] - math.Sqrt(cc))
+
+	return c.ElementInterfaces[len(c.ElementInterfaces)-1].Thickness + delta, nil
+}
+
+// FocusDistance returns distance of the plane in focus when the rear lens element is at filmDistance
+// from the film, infinity is returned when the focus ray can not be found
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/realistic.cpp#L367
+func (c *RealisticCamera) FocusDistance(filmDistance float64) float64 {
+	// Find offset ray from film center through lens
+	bounds := c.BoundExitPupil(0, 0.001*c.FilmDiagonal)
+
+	// Try some different and decreasing scaling factor to find focus ray more quickly when the aperture
+	// diameter is too small
+	var ray mymath.Ray
+	foundFocusRay := false
+	for _, scale := range []float64{0.1, 0.01, 0.001} {
+		lu := scale * bounds.PMax.X
+		rFilm := mymath.NewRay(mymath.NewPoint3(0, 0, c.LensRearZ()-filmDistance), mymath.NewVector3(lu, 0, filmDistance), math.Inf(1), 0, material.Medium{})
+
+		if foundFocusRay, ray = c.TraceLensesFromFilm(rFilm); foundFocusRay {
+			break
+		}
+	}
+
+	if !foundFocusRay {
+		return math.Inf(1)
+	}
+
+	// Compute distance zFocus where ray intersects the principal axis
+	tFocus := -ray.O.X / ray.D.X
+	zFocus := ray.Apply(tFocus).Z
+	if zFocus < 0 {
+		zFocus = math.Inf(1)
+	}
+
+	return zFocus
+}
+
+// exitPupilSamples is number of the points on the rear element tested for each film interval
+const exitPupilSamples = 1024 * 1024
+
+// BoundExitPupil bounds the points on the rear lens element plane through which the rays from the film
+// segment [pFilmX0, pFilmX1] on the x axis pass through the lens system
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/realistic.cpp#L402
+func (c *RealisticCamera) BoundExitPupil(pFilmX0, pFilmX1 float64) mymath.Bounds2 {
+	pupilBounds := mymath.NewBounds2Empty()
+
+	// Sample a collection of points on the rear lens to find exit pupil
+	nExitingRays := 0
+
+	// Compute bounding box of projection of rear element on sampling plane
+	rearRadius := c.RearElementRadius()
+	projRearBounds := mymath.NewBounds2(
+		mymath.NewPoint2(-1.5*rearRadius, -1.5*rearRadius),
+		mymath.NewPoint2(1.5*rearRadius, 1.5*rearRadius))
+
+	for i := 0; i < exitPupilSamples; i++ {
+		// Find location of sample points on x segment and rear lens element
+		pFilm := mymath.NewPoint3(mymath.Lerp((float64(i)+0.5)/exitPupilSamples, pFilmX0, pFilmX1), 0, 0)
+		u := mymath.NewPoint2(mymath.RadicalInverse(0, uint64(i)), mymath.RadicalInverse(1, uint64(i)))
+		pRear2 := projRearBounds.Lerp(u)
+		pRear := mymath.NewPoint3(pRear2.X, pRear2.Y, c.LensRearZ())
+
+		// Expand pupil bounds if ray makes it through the lens system
+		if pupilBounds.Inside(pRear2) {
+			nExitingRays++
+			continue
+		}
+
+		ray := mymath.NewRay(pFilm, pRear.SubtractP(pFilm), math.Inf(1), 0, material.Medium{})
+		if ok, _ := c.TraceLensesFromFilm(ray); ok {
+			pupilBounds = pupilBounds.UnionP(pRear2)
+			nExitingRays++
+		}
+	}
+
+	// Return entire element bounds if no rays made it through the lens system
+	if nExitingRays == 0 {
+		return projRearBounds
+	}
+
+	// Expand bounds to account for sample spacing
+	d := projRearBounds.Diagonal()
+	return pupilBounds.Expand(2 * math.Hypot(d.X, d.Y) / math.Sqrt(exitPupilSamples))
+}
+
+// SampleExitPupil returns point on the rear element plane sampled inside the exit pupil bounds of the film point
+// together with the area of the bounds
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/realistic.cpp#L634
+func (c *RealisticCamera) SampleExitPupil(pFilm, lensSample mymath.Point2) (mymath.Point3, float64) {
+	// Find exit pupil bound for sample distance from film center
+	rFilm := math.Hypot(pFilm.X, pFilm.Y)
+	rIndex := int(rFilm / (c.FilmDiagonal / 2) * float64(len(c.ExitPupilBounds)))
+	if rIndex > len(c.ExitPupilBounds)-1 {
+		rIndex = len(c.ExitPupilBounds) - 1
+	}
+
+	pupilBounds := c.ExitPupilBounds[rIndex]
+
+	// Generate sample point inside exit pupil bound
+	pLens := pupilBounds.Lerp(lensSample)
+
+	// Return sample point rotated by angle of pFilm with +x axis
+	sinTheta, cosTheta := 0.0, 1.0
+	if rFilm != 0 {
+		sinTheta = pFilm.Y / rFilm
+		cosTheta = pFilm.X / rFilm
+	}
+
+	return mymath.NewPoint3(
+		cosTheta*pLens.X-sinTheta*pLens.Y,
+		sinTheta*pLens.X+cosTheta*pLens.Y,
+		c.LensRearZ()), pupilBounds.Area()
+}
+
+// PhysicalExtent returns the film area in meters centered at the optical axis
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/film.cpp#L97
+func (c *RealisticCamera) PhysicalExtent() mymath.Bounds2 {
+	aspect := float64(c.FullResolution.Y) / float64(c.FullResolution.X)
+	x := math.Sqrt(c.FilmDiagonal * c.FilmDiagonal / (1 + aspect*aspect))
+	y := aspect * x
+
+	return mymath.NewBounds2(mymath.NewPoint2(-x/2, -y/2), mymath.NewPoint2(x/2, y/2))
+}
+
+// GenerateRay see https://github.com/mmp/pbrt-v3/blob/master/src/cameras/realistic.cpp#L659
+func (c *RealisticCamera) GenerateRay(sample CameraSample) (float64, mymath.Ray) {
+	// Find point on film, pFilm, corresponding to sample.pFilm
+	s := mymath.NewPoint2(sample.PFilm.X/float64(c.FullResolution.X), sample.PFilm.Y/float64(c.FullResolution.Y))
+	pFilm2 := c.PhysicalExtent().Lerp(s)
+	pFilm := mymath.NewPoint3(-pFilm2.X, pFilm2.Y, 0)
+
+	// Trace ray from pFilm through lens system
+	pRear, exitPupilBoundsArea := c.SampleExitPupil(mymath.NewPoint2(pFilm.X, pFilm.Y), sample.PLens)
+	rFilm := mymath.NewRay(pFilm, pRear.SubtractP(pFilm), math.Inf(1), c.time(sample), c.Medium)
+
+	ok, ray := c.TraceLensesFromFilm(rFilm)
+	if !ok {
+		return 0, ray
+	}
+
+	// Finish initialization of RealisticCamera ray
+	ray, err := c.CameraToWorld.ApplyR(ray)
+	if err != nil {
+		return 0, ray
+	}
+	ray.D = ray.D.Normalize()
+	ray.Medium = c.Medium
+
+	// Return weighting for RealisticCamera ray
+	cosTheta := rFilm.D.Normalize().Z
+	cos4Theta := (cosTheta * cosTheta) * (cosTheta * cosTheta)
+	if c.SimpleWeighting {
+		return cos4Theta * exitPupilBoundsArea / c.ExitPupilBounds[0].Area(), ray
+	}
+
+	return (c.ShutterClose - c.ShutterOpen) * (cos4Theta * exitPupilBoundsArea) / (c.LensRearZ() * c.LensRearZ()), ray
+}
+
+func (c *RealisticCamera) GenerateRayDifferential(sample CameraSample) (float64, mymath.RayDifferential) {
+	return c.generateRayDifferential(c, sample)
+}
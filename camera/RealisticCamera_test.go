@@ -0,0 +1,135 @@
+package camera_test
+
+import (
+	"math"
+	"pbrt-go/camera"
+	"pbrt-go/material"
+	"pbrt-go/mymath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	dgaussOnce   sync.Once
+	dgaussCamera *camera.RealisticCamera
+)
+
+// dgaussRealisticCamera returns double Gauss 50mm lens focused at 5 meters, the camera is shared by the tests
+// because the exit pupil computation is expensive
+func dgaussRealisticCamera(t *testing.T) *camera.RealisticCamera {
+	dgaussOnce.Do(func() {
+		data, err := camera.LoadLensFile("testdata/dgauss.50mm.dat")
+		assert.NoError(t, err)
+
+		dgaussCamera, err = camera.NewRealisticCamera(staticTransform(mymath.NewTransformEmpty()), 0, 1, 17.1, 5, false,
+			data, mymath.NewPoint2i(64, 64), 0.035, material.Medium{})
+		assert.NoError(t, err)
+	})
+
+	return dgaussCamera
+}
+
+func TestReadLensData(t *testing.T) {
+	data, err := camera.ReadLensData(strings.NewReader("# radius axpos N aperture\n35.0 5 1.5 20 # first\n\n-35 10.5\t1 20\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{35, 5, 1.5, 20, -35, 10.5, 1, 20}, data)
+
+	_, err = camera.ReadLensData(strings.NewReader("35.0 5 x 20\n"))
+	assert.Error(t, err)
+}
+
+func TestLoadLensFile(t *testing.T) {
+	data, err := camera.LoadLensFile("testdata/dgauss.50mm.dat")
+	assert.NoError(t, err)
+	assert.Len(t, data, 44)
+	assert.Equal(t, []float64{29.475, 3.76, 1.67, 25.2}, data[:4])
+
+	_, err = camera.LoadLensFile("testdata/missing.dat")
+	assert.Error(t, err)
+}
+
+func TestNewRealisticCamera_invalid(t *testing.T) {
+	_, err := camera.NewRealisticCamera(staticTransform(mymath.NewTransformEmpty()), 0, 1, 10, 5, false,
+		[]float64{35, 5, 1.5}, mymath.NewPoint2i(64, 64), 0.035, material.Medium{})
+	assert.Error(t, err)
+}
+
+func TestRealisticCamera_FocusDistance(t *testing.T) {
+	c := dgaussRealisticCamera(t)
+
+	// thick lens approximation focuses close to the requested distance
+	assert.InDelta(t, 5, c.FocusDistance(c.LensRearZ()), 0.1)
+
+	// aperture stop is set to the requested diameter
+	assert.InDelta(t, 0.00855, c.ElementInterfaces[5].ApertureRadius, 1e-9)
+}
+
+// Rays from the film center pass the lens and meet again at the plane of focus
+func TestRealisticCamera_GenerateRay_focus(t *testing.T) {
+	c := dgaussRealisticCamera(t)
+
+	for _, pLens := range []mymath.Point2{mymath.NewPoint2(0.5, 0.5), mymath.NewPoint2(0.3, 0.6), mymath.NewPoint2(0.7, 0.2), mymath.NewPoint2(0.45, 0.9)} {
+		wt, ray := c.GenerateRay(camera.CameraSample{PFilm: mymath.NewPoint2(32, 32), PLens: pLens})
+		if wt == 0 {
+			continue
+		}
+
+		assert.Greater(t, wt, 0.0)
+		assert.InDelta(t, 1, ray.D.Length(), equalDelta)
+
+		pFocus := ray.Apply((5 - ray.O.Z) / ray.D.Z)
+		assert.Less(t, math.Hypot(pFocus.X, pFocus.Y), 0.005)
+	}
+}
+
+// Image is not flipped, the top left film corner looks left and up the same as with the pinhole cameras
+func TestRealisticCamera_GenerateRay_orientation(t *testing.T) {
+	c := dgaussRealisticCamera(t)
+
+	wt, ray := c.GenerateRay(camera.CameraSample{PFilm: mymath.NewPoint2(0, 0), PLens: mymath.NewPoint2(0.5, 0.5)})
+	assert.Greater(t, wt, 0.0)
+	assert.Less(t, ray.D.X, 0.0)
+	assert.Greater(t, ray.D.Y, 0.0)
+	assert.Greater(t, ray.D.Z, 0.0)
+}
+
+// Off-axis film points receive less light through the smaller exit pupil
+func TestRealisticCamera_GenerateRay_vignetting(t *testing.T) {
+	c := dgaussRealisticCamera(t)
+
+	first := c.ExitPupilBounds[0]
+	last := c.ExitPupilBounds[len(c.ExitPupilBounds)-1]
+	assert.Less(t, last.Area(), first.Area())
+
+	meanWeight := func(pFilm mymath.Point2) float64 {
+		sum := 0.0
+		n := 16
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				wt, _ := c.GenerateRay(camera.CameraSample{PFilm: pFilm, PLens: mymath.NewPoint2((float64(i)+0.5)/float64(n), (float64(j)+0.5)/float64(n))})
+				sum += wt
+			}
+		}
+		return sum / float64(n*n)
+	}
+
+	center := meanWeight(mymath.NewPoint2(32, 32))
+	corner := meanWeight(mymath.NewPoint2(1, 1))
+	assert.Greater(t, center, 0.0)
+	assert.Less(t, corner, center)
+}
+
+func TestRealisticCamera_GenerateRayDifferential(t *testing.T) {
+	c := dgaussRealisticCamera(t)
+	sample := camera.CameraSample{PFilm: mymath.NewPoint2(20, 30), PLens: mymath.NewPoint2(0.5, 0.5)}
+
+	wt, rd := c.GenerateRayDifferential(sample)
+	_, ray := c.GenerateRay(sample)
+
+	assert.Greater(t, wt, 0.0)
+	assert.True(t, rd.HasDifferentials)
+	inDeltaVector3(t, ray.D, rd.D, equalDelta)
+}
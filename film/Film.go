@@ -0,0 +1,305 @@
+package film
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"os"
+	"pbrt-go/filter"
+	"pbrt-go/mymath"
+	"sync"
+	"sync/atomic"
+)
+
+// FilterTableWidth is the number of the precomputed filter values along each axis
+const FilterTableWidth = 16
+
+// pixel holds the weighted sum of the RGB samples and the splats added by the light transport algorithms,
+// splats are stored as float64 bits so that they can be added atomically
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/film.h#L78
+type pixel struct {
+	rgb             [3]float64
+	filterWeightSum float64
+	splatRGB        [3]uint64
+}
+
+// Film models the sensing device of the camera, it accumulates the radiance samples into the image
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/film.h#L48
+type Film struct {
+	FullResolution mymath.Point2i
+	// Diagonal is physical diagonal of the film in meters
+	Diagonal           float64
+	Filter             filter.Filter
+	Filename           string
+	CroppedPixelBounds mymath.Bounds2i
+	Scale              float64
+	MaxSampleLuminance float64
+	// FilterTable holds filter values for the positive quadrant of the filter, indexed by y*FilterTableWidth + x
+	FilterTable []float64
+
+	pixels []pixel
+	mutex  sync.Mutex
+}
+
+// NewFilm creates film with the image resolution, crop window in NDC space [0, 1]^2 and the diagonal in millimeters
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/film.cpp#L45
+func NewFilm(resolution mymath.Point2i, cropWindow mymath.Bounds2, filt filter.Filter, diagonal float64, filename string, scale, maxSampleLuminance float64) *Film {
+	// Compute film image bounds
+	croppedPixelBounds := mymath.NewBounds2i(
+		mymath.NewPoint2i(
+			int(math.Ceil(float64(resolution.X)*cropWindow.PMin.X)),
+			int(math.Ceil(float64(resolution.Y)*cropWindow.PMin.Y))),
+		mymath.NewPoint2i(
+			int(math.Ceil(float64(resolution.X)*cropWindow.PMax.X)),
+			int(math.Ceil(float64(resolution.Y)*cropWindow.PMax.Y))))
+
+	// Precompute filter weight table
+	radius := filt.GetRadius()
+	filterTable := make([]float64, 0, FilterTableWidth*FilterTableWidth)
+	for y := 0; y < FilterTableWidth; y++ {
+		for x := 0; x < FilterTableWidth; x++ {
+			p := mymath.NewPoint2(
+				(float64(x)+0.5)*radius.X/FilterTableWidth,
+				(float64(y)+0.5)*radius.Y/FilterTableWidth)
+			filterTable = append(filterTable, filt.Evaluate(p))
+		}
+	}
+
+	return &Film{
+		FullResolution:     resolution,
+		Diagonal:           diagonal * 0.001,
+		Filter:             filt,
+		Filename:           filename,
+		CroppedPixelBounds: croppedPixelBounds,
+		Scale:              scale,
+		MaxSampleLuminance: maxSampleLuminance,
+		FilterTable:        filterTable,
+		pixels:             make([]pixel, croppedPixelBounds.Area()),
+	}
+}
+
+// GetSampleBounds returns area of the samples that contribute to the cropped pixels
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/film.cpp#L81
+func (f *Film) GetSampleBounds() mymath.Bounds2i {
+	radius := f.Filter.GetRadius()
+	return mymath.Bounds2i{
+		PMin: mymath.NewPoint2i(
+			int(math.Floor(float64(f.CroppedPixelBounds.PMin.X)+0.5-radius.X)),
+			int(math.Floor(float64(f.CroppedPixelBounds.PMin.Y)+0.5-radius.Y))),
+		PMax: mymath.NewPoint2i(
+			int(math.Ceil(float64(f.CroppedPixelBounds.PMax.X)-0.5+radius.X)),
+			int(math.Ceil(float64(f.CroppedPixelBounds.PMax.Y)-0.5+radius.Y))),
+	}
+}
+
+// GetPhysicalExtent returns the film area in meters centered at the origin
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/film.cpp#L89
+func (f *Film) GetPhysicalExtent() mymath.Bounds2 {
+	aspect := float64(f.FullResolution.Y) / float64(f.FullResolution.X)
+	x := math.Sqrt(f.Diagonal * f.Diagonal / (1 + aspect*aspect))
+	y := aspect * x
+	return mymath.NewBounds2(mymath.NewPoint2(-x/2, -y/2), mymath.NewPoint2(x/2, y/2))
+}
+
+// GetFilmTile creates tile for the samples within sampleBounds, the tile covers all the pixels
+// the samples contribute to
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/film.cpp#L96
+func (f *Film) GetFilmTile(sampleBounds mymath.Bounds2i) *FilmTile {
+	// Bound image pixels that samples in sampleBounds contribute to
+	radius := f.Filter.GetRadius()
+	p0 := mymath.NewPoint2i(
+		int(math.Ceil(float64(sampleBounds.PMin.X)-0.5-radius.X)),
+		int(math.Ceil(float64(sampleBounds.PMin.Y)-0.5-radius.Y)))
+	p1 := mymath.NewPoint2i(
+		int(math.Floor(float64(sampleBounds.PMax.X)-0.5+radius.X))+1,
+		int(math.Floor(float64(sampleBounds.PMax.Y)-0.5+radius.Y))+1)
+	tilePixelBounds := mymath.Bounds2i{PMin: p0, PMax: p1}.Intersect(f.CroppedPixelBounds)
+
+	return NewFilmTile(tilePixelBounds, radius, f.FilterTable, f.MaxSampleLuminance)
+}
+
+// MergeFilmTile adds the tile pixel contributions to the film, it is safe to call it from multiple goroutines
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/film.cpp#L115
+func (f *Film) MergeFilmTile(tile *FilmTile) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for y := tile.PixelBounds.PMin.Y; y < tile.PixelBounds.PMax.Y; y++ {
+		for x := tile.PixelBounds.PMin.X; x < tile.PixelBounds.PMax.X; x++ {
+			p := mymath.NewPoint2i(x, y)
+
+			// Merge pixel into Film.pixels
+			tilePixel := tile.GetPixel(p)
+			mergePixel := f.getPixel(p)
+			for c := 0; c < 3; c++ {
+				mergePixel.rgb[c] += tilePixel.ContribSum[c]
+			}
+			mergePixel.filterWeightSum += tilePixel.FilterWeightSum
+		}
+	}
+}
+
+// SetImage replaces the film pixels by the given RGB values, one value per cropped pixel
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/film.cpp#L130
+func (f *Film) SetImage(img [][3]float64) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for i := range f.pixels {
+		f.pixels[i].rgb = img[i]
+		f.pixels[i].filterWeightSum = 1
+		f.pixels[i].splatRGB = [3]uint64{}
+	}
+}
+
+// AddSplat adds contribution to the pixel containing p without the filtering, it is safe to call it
+// from multiple goroutines
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/film.cpp#L143
+func (f *Film) AddSplat(p mymath.Point2, v [3]float64) {
+	if math.IsNaN(v[0]) || math.IsNaN(v[1]) || math.IsNaN(v[2]) ||
+		math.IsInf(v[0], 0) || math.IsInf(v[1], 0) || math.IsInf(v[2], 0) {
+		return
+	}
+
+	pi := mymath.NewPoint2i(int(math.Floor(p.X)), int(math.Floor(p.Y)))
+	if !f.CroppedPixelBounds.InsideExclusive(pi) {
+		return
+	}
+
+	if y := Luminance(v); y > f.MaxSampleLuminance {
+		v = scaleRGB(v, f.MaxSampleLuminance/y)
+	}
+
+	pixel := f.getPixel(pi)
+	for c := 0; c < 3; c++ {
+		atomicAddFloat64(&pixel.splatRGB[c], v[c])
+	}
+}
+
+// Clear resets all the pixels
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/film.cpp#L246
+func (f *Film) Clear() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for i := range f.pixels {
+		f.pixels[i] = pixel{}
+	}
+}
+
+// GetImage returns the final RGB values of the cropped pixels in the row-major order, the splats are scaled by splatScale
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/film.cpp#L166
+func (f *Film) GetImage(splatScale float64) [][3]float64 {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	img := make([][3]float64, len(f.pixels))
+	for i := range f.pixels {
+		pixel := &f.pixels[i]
+
+		// Normalize pixel with weight sum
+		var rgb [3]float64
+		if pixel.filterWeightSum != 0 {
+			invWt := 1 / pixel.filterWeightSum
+			rgb = scaleRGB(pixel.rgb, invWt)
+			for c := 0; c < 3; c++ {
+				rgb[c] = math.Max(0, rgb[c])
+			}
+		}
+
+		// Add splat value at pixel and scale the result
+		for c := 0; c < 3; c++ {
+			splat := math.Float64frombits(atomic.LoadUint64(&pixel.splatRGB[c]))
+			img[i][c] = (rgb[c] + splatScale*splat) * f.Scale
+		}
+	}
+
+	return img
+}
+
+// WriteImage writes the gamma corrected image to the file Filename in the PNG format
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/film.cpp#L166
+func (f *Film) WriteImage(splatScale float64) error {
+	file, err := os.Create(f.Filename)
+	if err != nil {
+		return err
+	}
+
+	if err := f.EncodePNG(file, splatScale); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// EncodePNG writes the gamma corrected 8-bit image to w in the PNG format
+func (f *Film) EncodePNG(w io.Writer, splatScale float64) error {
+	rgb := f.GetImage(splatScale)
+	resolution := f.CroppedPixelBounds.Diagonal()
+
+	img := image.NewNRGBA(image.Rect(0, 0, resolution.X, resolution.Y))
+	for y := 0; y < resolution.Y; y++ {
+		for x := 0; x < resolution.X; x++ {
+			v := rgb[y*resolution.X+x]
+			img.SetNRGBA(x, y, color.NRGBA{toByte(v[0]), toByte(v[1]), toByte(v[2]), 255})
+		}
+	}
+
+	return png.Encode(w, img)
+}
+
+func (f *Film) getPixel(p mymath.Point2i) *pixel {
+	width := f.CroppedPixelBounds.PMax.X - f.CroppedPixelBounds.PMin.X
+	offset := (p.X - f.CroppedPixelBounds.PMin.X) + (p.Y-f.CroppedPixelBounds.PMin.Y)*width
+	return &f.pixels[offset]
+}
+
+// Luminance returns the y coefficient of the linear sRGB color
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/spectrum.h#L462
+func Luminance(rgb [3]float64) float64 {
+	return 0.212671*rgb[0] + 0.715160*rgb[1] + 0.072169*rgb[2]
+}
+
+func scaleRGB(rgb [3]float64, s float64) [3]float64 {
+	return [3]float64{rgb[0] * s, rgb[1] * s, rgb[2] * s}
+}
+
+// gammaCorrect applies the sRGB transfer curve
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/pbrt.h#L424
+func gammaCorrect(v float64) float64 {
+	if v <= 0.0031308 {
+		return 12.92 * v
+	}
+	return 1.055*math.Pow(v, 1./2.4) - 0.055
+}
+
+func toByte(v float64) uint8 {
+	return uint8(mymath.Clamp(255*gammaCorrect(v)+0.5, 0, 255))
+}
+
+func atomicAddFloat64(addr *uint64, v float64) {
+	for {
+		oldBits := atomic.LoadUint64(addr)
+		newBits := math.Float64bits(math.Float64frombits(oldBits) + v)
+		if atomic.CompareAndSwapUint64(addr, oldBits, newBits) {
+			return
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package film
+
+import (
+	"math"
+	"pbrt-go/mymath"
+)
+
+// FilmTilePixel holds the weighted sum of the samples contributing to the pixel
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/film.h#L93
+type FilmTilePixel struct {
+	ContribSum      [3]float64
+	FilterWeightSum float64
+}
+
+// FilmTile accumulates samples of a part of the film, each goroutine works on its own tile
+// and merges it back to the film using Film.MergeFilmTile
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/film.h#L99
+type FilmTile struct {
+	PixelBounds        mymath.Bounds2i
+	FilterRadius       mymath.Vector2
+	InvFilterRadius    mymath.Vector2
+	FilterTable        []float64
+	MaxSampleLuminance float64
+
+	pixels []FilmTilePixel
+}
+
+func NewFilmTile(pixelBounds mymath.Bounds2i, filterRadius mymath.Vector2, filterTable []float64, maxSampleLuminance float64) *FilmTile {
+	return &FilmTile{
+		PixelBounds:        pixelBounds,
+		FilterRadius:       filterRadius,
+		InvFilterRadius:    mymath.NewVector2(1/filterRadius.X, 1/filterRadius.Y),
+		FilterTable:        filterTable,
+		MaxSampleLuminance: maxSampleLuminance,
+		pixels:             make([]FilmTilePixel, maxInt(0, pixelBounds.Area())),
+	}
+}
+
+// AddSample adds radiance L of the sample at pFilm to all the pixels within the filter radius
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/film.h#L113
+func (t *FilmTile) AddSample(pFilm mymath.Point2, L [3]float64, sampleWeight float64) {
+	if y := Luminance(L); y > t.MaxSampleLuminance {
+		L = scaleRGB(L, t.MaxSampleLuminance/y)
+	}
+
+	// Compute sample's raster bounds
+	pFilmDiscrete := mymath.NewPoint2(pFilm.X-0.5, pFilm.Y-0.5)
+	p0 := mymath.NewPoint2i(
+		int(math.Ceil(pFilmDiscrete.X-t.FilterRadius.X)),
+		int(math.Ceil(pFilmDiscrete.Y-t.FilterRadius.Y))).Max(t.PixelBounds.PMin)
+	p1 := mymath.NewPoint2i(
+		int(math.Floor(pFilmDiscrete.X+t.FilterRadius.X))+1,
+		int(math.Floor(pFilmDiscrete.Y+t.FilterRadius.Y))+1).Min(t.PixelBounds.PMax)
+
+	if p0.X >= p1.X || p0.Y >= p1.Y {
+		return
+	}
+
+	// Precompute x and y filter table offsets
+	ifx := make([]int, p1.X-p0.X)
+	for x := p0.X; x < p1.X; x++ {
+		fx := math.Abs((float64(x) - pFilmDiscrete.X) * t.InvFilterRadius.X * FilterTableWidth)
+		ifx[x-p0.X] = minInt(int(math.Floor(fx)), FilterTableWidth-1)
+	}
+
+	ify := make([]int, p1.Y-p0.Y)
+	for y := p0.Y; y < p1.Y; y++ {
+		fy := math.Abs((float64(y) - pFilmDiscrete.Y) * t.InvFilterRadius.Y * FilterTableWidth)
+		ify[y-p0.Y] = minInt(int(math.Floor(fy)), FilterTableWidth-1)
+	}
+
+	// Loop over filter support and add sample to pixel arrays
+	for y := p0.Y; y < p1.Y; y++ {
+		for x := p0.X; x < p1.X; x++ {
+			// Evaluate filter value at (x,y) pixel
+			offset := ify[y-p0.Y]*FilterTableWidth + ifx[x-p0.X]
+			filterWeight := t.FilterTable[offset]
+
+			// Update pixel values with filtered sample contribution
+			pixel := t.GetPixel(mymath.NewPoint2i(x, y))
+			for c := 0; c < 3; c++ {
+				pixel.ContribSum[c] += L[c] * sampleWeight * filterWeight
+			}
+			pixel.FilterWeightSum += filterWeight
+		}
+	}
+}
+
+// GetPixel returns the tile pixel at the film position p
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/film.h#L155
+func (t *FilmTile) GetPixel(p mymath.Point2i) *FilmTilePixel {
+	width := t.PixelBounds.PMax.X - t.PixelBounds.PMin.X
+	offset := (p.X - t.PixelBounds.PMin.X) + (p.Y-t.PixelBounds.PMin.Y)*width
+	return &t.pixels[offset]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
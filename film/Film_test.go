@@ -0,0 +1,203 @@
+package film_test
+
+import (
+	"bytes"
+	"image/png"
+	"math"
+	"path/filepath"
+	"pbrt-go/film"
+	"pbrt-go/filter"
+	"pbrt-go/mymath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var fullCrop = mymath.NewBounds2(mymath.NewPoint2(0, 0), mymath.NewPoint2(1, 1))
+
+func newBoxFilm(resolution mymath.Point2i, cropWindow mymath.Bounds2) *film.Film {
+	return film.NewFilm(resolution, cropWindow, filter.NewBoxFilter(mymath.NewVector2(0.5, 0.5)), 35, "", 1, math.Inf(1))
+}
+
+func TestNewFilm(t *testing.T) {
+	crop := mymath.NewBounds2(mymath.NewPoint2(0.25, 0.1), mymath.NewPoint2(0.75, 0.55))
+	f := newBoxFilm(mymath.NewPoint2i(100, 50), crop)
+
+	assert.Equal(t, mymath.NewBounds2i(mymath.NewPoint2i(25, 5), mymath.NewPoint2i(75, 28)), f.CroppedPixelBounds)
+	assert.InDelta(t, 0.035, f.Diagonal, 1e-12)
+	assert.Len(t, f.FilterTable, film.FilterTableWidth*film.FilterTableWidth)
+	assert.Len(t, f.GetImage(1), 50*23)
+}
+
+func TestNewFilm_filterTable(t *testing.T) {
+	filt := filter.NewTriangleFilter(mymath.NewVector2(2, 1))
+	f := film.NewFilm(mymath.NewPoint2i(10, 10), fullCrop, filt, 35, "", 1, math.Inf(1))
+
+	// first entry is near the filter center, last one near its corner
+	assert.InDelta(t, filt.Evaluate(mymath.NewPoint2(1./16., 0.5/16.)), f.FilterTable[0], 1e-12)
+	assert.InDelta(t, filt.Evaluate(mymath.NewPoint2(2*15.5/16., 15.5/16.)), f.FilterTable[len(f.FilterTable)-1], 1e-12)
+}
+
+func TestFilm_GetSampleBounds(t *testing.T) {
+	f := film.NewFilm(mymath.NewPoint2i(100, 50), fullCrop, filter.NewGaussianFilter(mymath.NewVector2(2, 2), 2), 35, "", 1, math.Inf(1))
+
+	expected := mymath.NewBounds2i(mymath.NewPoint2i(-2, -2), mymath.NewPoint2i(102, 52))
+	assert.Equal(t, expected, f.GetSampleBounds())
+}
+
+func TestFilm_GetPhysicalExtent(t *testing.T) {
+	f := newBoxFilm(mymath.NewPoint2i(400, 300), fullCrop)
+	extent := f.GetPhysicalExtent()
+
+	// 3-4-5 triangle
+	assert.InDelta(t, -0.014, extent.PMin.X, 1e-12)
+	assert.InDelta(t, -0.0105, extent.PMin.Y, 1e-12)
+	assert.InDelta(t, 0.014, extent.PMax.X, 1e-12)
+	assert.InDelta(t, 0.0105, extent.PMax.Y, 1e-12)
+}
+
+func TestFilm_GetFilmTile(t *testing.T) {
+	f := film.NewFilm(mymath.NewPoint2i(100, 50), fullCrop, filter.NewTriangleFilter(mymath.NewVector2(1.5, 1.5)), 35, "", 1, math.Inf(1))
+
+	// tile is enlarged by the filter radius
+	tile := f.GetFilmTile(mymath.NewBounds2i(mymath.NewPoint2i(16, 16), mymath.NewPoint2i(32, 32)))
+	assert.Equal(t, mymath.NewBounds2i(mymath.NewPoint2i(14, 14), mymath.NewPoint2i(34, 34)), tile.PixelBounds)
+
+	// and clipped by the film
+	tile = f.GetFilmTile(mymath.NewBounds2i(mymath.NewPoint2i(0, 40), mymath.NewPoint2i(16, 52)))
+	assert.Equal(t, mymath.NewBounds2i(mymath.NewPoint2i(0, 38), mymath.NewPoint2i(18, 50)), tile.PixelBounds)
+}
+
+func TestFilm_MergeFilmTile(t *testing.T) {
+	f := newBoxFilm(mymath.NewPoint2i(4, 2), fullCrop)
+
+	tile := f.GetFilmTile(f.GetSampleBounds())
+	tile.AddSample(mymath.NewPoint2(1.5, 0.5), [3]float64{1, 2, 3}, 1)
+	tile.AddSample(mymath.NewPoint2(1.3, 0.6), [3]float64{3, 2, 1}, 1)
+	tile.AddSample(mymath.NewPoint2(3.5, 1.5), [3]float64{4, 4, 4}, 0.5)
+	f.MergeFilmTile(tile)
+
+	img := f.GetImage(1)
+	assert.Equal(t, [3]float64{0, 0, 0}, img[0])
+	assert.Equal(t, [3]float64{2, 2, 2}, img[1])
+	assert.Equal(t, [3]float64{2, 2, 2}, img[7])
+}
+
+func TestFilm_MergeFilmTile_concurrent(t *testing.T) {
+	f := newBoxFilm(mymath.NewPoint2i(64, 64), fullCrop)
+
+	// every goroutine renders own 16x16 tile, each tile is rendered twice
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			tileIndex := i % 16
+			x0, y0 := 16*(tileIndex%4), 16*(tileIndex/4)
+			tile := f.GetFilmTile(mymath.NewBounds2i(mymath.NewPoint2i(x0, y0), mymath.NewPoint2i(x0+16, y0+16)))
+			for y := y0; y < y0+16; y++ {
+				for x := x0; x < x0+16; x++ {
+					tile.AddSample(mymath.NewPoint2(float64(x)+0.5, float64(y)+0.5), [3]float64{float64(x), float64(y), 1}, 1)
+				}
+			}
+			f.MergeFilmTile(tile)
+		}(i)
+	}
+	wg.Wait()
+
+	img := f.GetImage(1)
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			assert.Equal(t, [3]float64{float64(x), float64(y), 1}, img[y*64+x])
+		}
+	}
+}
+
+func TestFilmTile_AddSample_filtered(t *testing.T) {
+	filt := filter.NewTriangleFilter(mymath.NewVector2(1, 1))
+	f := film.NewFilm(mymath.NewPoint2i(8, 8), fullCrop, filt, 35, "", 1, math.Inf(1))
+	tile := f.GetFilmTile(f.GetSampleBounds())
+
+	tile.AddSample(mymath.NewPoint2(4, 4), [3]float64{1, 1, 1}, 1)
+
+	// sample at the pixel corner contributes equally to the 4 neighbours
+	expectedWeight := f.FilterTable[8*film.FilterTableWidth+8]
+	for _, p := range []mymath.Point2i{mymath.NewPoint2i(3, 3), mymath.NewPoint2i(4, 3), mymath.NewPoint2i(3, 4), mymath.NewPoint2i(4, 4)} {
+		pixel := tile.GetPixel(p)
+		assert.InDelta(t, expectedWeight, pixel.FilterWeightSum, 1e-12)
+		assert.InDelta(t, expectedWeight, pixel.ContribSum[0], 1e-12)
+	}
+	assert.Equal(t, 0.0, tile.GetPixel(mymath.NewPoint2i(5, 4)).FilterWeightSum)
+}
+
+func TestFilmTile_AddSample_maxLuminance(t *testing.T) {
+	f := film.NewFilm(mymath.NewPoint2i(2, 2), fullCrop, filter.NewBoxFilter(mymath.NewVector2(0.5, 0.5)), 35, "", 1, 2)
+	tile := f.GetFilmTile(f.GetSampleBounds())
+
+	tile.AddSample(mymath.NewPoint2(0.5, 0.5), [3]float64{10, 10, 10}, 1)
+	assert.InDelta(t, 2, film.Luminance(tile.GetPixel(mymath.NewPoint2i(0, 0)).ContribSum), 1e-9)
+}
+
+func TestFilm_AddSplat(t *testing.T) {
+	f := newBoxFilm(mymath.NewPoint2i(2, 2), fullCrop)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f.AddSplat(mymath.NewPoint2(1.5, 0.2), [3]float64{1, 2, 3})
+		}()
+	}
+	wg.Wait()
+
+	// outside of the film
+	f.AddSplat(mymath.NewPoint2(2.5, 0.2), [3]float64{1, 2, 3})
+
+	img := f.GetImage(0.5)
+	assert.Equal(t, [3]float64{50, 100, 150}, img[1])
+	assert.Equal(t, [3]float64{0, 0, 0}, img[0])
+
+	f.Clear()
+	assert.Equal(t, [3]float64{0, 0, 0}, f.GetImage(1)[1])
+}
+
+func TestFilm_SetImage(t *testing.T) {
+	f := newBoxFilm(mymath.NewPoint2i(2, 1), fullCrop)
+	f.Scale = 2
+	f.SetImage([][3]float64{{1, 2, 3}, {-1, 0.5, 0}})
+
+	assert.Equal(t, [][3]float64{{2, 4, 6}, {0, 1, 0}}, f.GetImage(1))
+}
+
+func TestFilm_EncodePNG(t *testing.T) {
+	f := newBoxFilm(mymath.NewPoint2i(3, 2), fullCrop)
+	f.SetImage([][3]float64{{0, 0, 0}, {1, 1, 1}, {0.5, 0, 2}, {0.002, 0, 0}, {0, 0, 0}, {0, 0, 0}})
+
+	var buf bytes.Buffer
+	assert.NoError(t, f.EncodePNG(&buf, 1))
+
+	img, err := png.Decode(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, img.Bounds().Dx())
+	assert.Equal(t, 2, img.Bounds().Dy())
+
+	r, g, b, _ := img.At(2, 0).RGBA()
+	assert.Equal(t, uint32(188), r>>8)
+	assert.Equal(t, uint32(0), g>>8)
+	assert.Equal(t, uint32(255), b>>8)
+
+	r, _, _, _ = img.At(0, 1).RGBA()
+	assert.Equal(t, uint32(7), r>>8)
+}
+
+func TestFilm_WriteImage(t *testing.T) {
+	f := newBoxFilm(mymath.NewPoint2i(3, 2), fullCrop)
+	f.Filename = filepath.Join(t.TempDir(), "image.png")
+	assert.NoError(t, f.WriteImage(1))
+
+	f.Filename = filepath.Join(t.TempDir(), "missing", "image.png")
+	assert.Error(t, f.WriteImage(1))
+}
@@ -0,0 +1,18 @@
+package filter
+
+import "pbrt-go/mymath"
+
+// BoxFilter weights all the samples within the radius equally
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/filters/box.h
+type BoxFilter struct {
+	FilterBase
+}
+
+func NewBoxFilter(radius mymath.Vector2) *BoxFilter {
+	return &BoxFilter{NewFilterBase(radius)}
+}
+
+func (f BoxFilter) Evaluate(_ mymath.Point2) float64 {
+	return 1
+}
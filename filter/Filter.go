@@ -0,0 +1,25 @@
+package filter
+
+import "pbrt-go/mymath"
+
+// Filter weights image samples around the pixel center, it is zero outside of its radius
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/filter.h
+type Filter interface {
+	// Evaluate returns filter weight at the point p relative to the filter center
+	Evaluate(p mymath.Point2) float64
+	GetRadius() mymath.Vector2
+}
+
+// FilterBase holds radius shared by all the filters
+type FilterBase struct {
+	Radius, InvRadius mymath.Vector2
+}
+
+func NewFilterBase(radius mymath.Vector2) FilterBase {
+	return FilterBase{radius, mymath.NewVector2(1/radius.X, 1/radius.Y)}
+}
+
+func (f FilterBase) GetRadius() mymath.Vector2 {
+	return f.Radius
+}
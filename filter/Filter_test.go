@@ -0,0 +1,97 @@
+package filter_test
+
+import (
+	"math"
+	"pbrt-go/filter"
+	"pbrt-go/mymath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func allFilters() map[string]filter.Filter {
+	radius := mymath.NewVector2(2, 1.5)
+	return map[string]filter.Filter{
+		"box":      filter.NewBoxFilter(radius),
+		"triangle": filter.NewTriangleFilter(radius),
+		"gaussian": filter.NewGaussianFilter(radius, 2),
+		"mitchell": filter.NewMitchellFilter(radius, 1./3., 1./3.),
+		"lanczos":  filter.NewLanczosSincFilter(radius, 3),
+	}
+}
+
+func TestFilter_symmetric(t *testing.T) {
+	for name, f := range allFilters() {
+		assert.Equal(t, mymath.NewVector2(2, 1.5), f.GetRadius(), name)
+
+		for _, p := range []mymath.Point2{mymath.NewPoint2(0.3, 0.7), mymath.NewPoint2(1.2, 0.1), mymath.NewPoint2(0, 1.1)} {
+			expected := f.Evaluate(p)
+			assert.InDelta(t, expected, f.Evaluate(mymath.NewPoint2(-p.X, p.Y)), 1e-12, name)
+			assert.InDelta(t, expected, f.Evaluate(mymath.NewPoint2(p.X, -p.Y)), 1e-12, name)
+			assert.InDelta(t, expected, f.Evaluate(mymath.NewPoint2(-p.X, -p.Y)), 1e-12, name)
+		}
+	}
+}
+
+func TestFilter_zeroAtRadius(t *testing.T) {
+	for name, f := range allFilters() {
+		// box has constant weight and the windowed sinc reaches zero only at integer radius
+		if name == "box" || name == "lanczos" {
+			continue
+		}
+		assert.InDelta(t, 0, f.Evaluate(mymath.NewPoint2(2, 0)), 1e-12, name)
+		assert.InDelta(t, 0, f.Evaluate(mymath.NewPoint2(0, 1.5)), 1e-12, name)
+	}
+}
+
+func TestBoxFilter_Evaluate(t *testing.T) {
+	f := filter.NewBoxFilter(mymath.NewVector2(0.5, 0.5))
+	assert.Equal(t, 1.0, f.Evaluate(mymath.NewPoint2(0, 0)))
+	assert.Equal(t, 1.0, f.Evaluate(mymath.NewPoint2(0.4, -0.2)))
+}
+
+func TestTriangleFilter_Evaluate(t *testing.T) {
+	f := filter.NewTriangleFilter(mymath.NewVector2(2, 2))
+	assert.InDelta(t, 4, f.Evaluate(mymath.NewPoint2(0, 0)), 1e-12)
+	assert.InDelta(t, 1.5*1, f.Evaluate(mymath.NewPoint2(0.5, -1)), 1e-12)
+	assert.Equal(t, 0.0, f.Evaluate(mymath.NewPoint2(2.5, 0)))
+}
+
+func TestGaussianFilter_Evaluate(t *testing.T) {
+	f := filter.NewGaussianFilter(mymath.NewVector2(1.5, 1.5), 2)
+	expv := math.Exp(-2 * 1.5 * 1.5)
+	g := func(d float64) float64 { return math.Exp(-2*d*d) - expv }
+
+	assert.InDelta(t, g(0)*g(0), f.Evaluate(mymath.NewPoint2(0, 0)), 1e-12)
+	assert.InDelta(t, g(0.5)*g(1), f.Evaluate(mymath.NewPoint2(0.5, 1)), 1e-12)
+	assert.Equal(t, 0.0, f.Evaluate(mymath.NewPoint2(0, 1.6)))
+}
+
+func TestMitchellFilter_Evaluate(t *testing.T) {
+	f := filter.NewMitchellFilter(mymath.NewVector2(2, 2), 1./3., 1./3.)
+
+	// center value is (6 - 2B) / 6 per axis
+	center := (6 - 2./3.) / 6
+	assert.InDelta(t, center*center, f.Evaluate(mymath.NewPoint2(0, 0)), 1e-12)
+
+	// negative lobes
+	assert.Less(t, f.Evaluate(mymath.NewPoint2(1.5, 0)), 0.0)
+
+	// continuous where the cubic pieces meet
+	assert.InDelta(t, f.Evaluate(mymath.NewPoint2(1-1e-9, 0)), f.Evaluate(mymath.NewPoint2(1+1e-9, 0)), 1e-6)
+}
+
+func TestLanczosSincFilter_Evaluate(t *testing.T) {
+	f := filter.NewLanczosSincFilter(mymath.NewVector2(4, 4), 3)
+	assert.InDelta(t, 1, f.Evaluate(mymath.NewPoint2(0, 0)), 1e-12)
+
+	// sinc is zero at integers
+	assert.InDelta(t, 0, f.Evaluate(mymath.NewPoint2(1, 0)), 1e-12)
+	assert.InDelta(t, 0, f.Evaluate(mymath.NewPoint2(0, 2)), 1e-12)
+
+	x := 0.5
+	expected := math.Sin(math.Pi*x) / (math.Pi * x) * math.Sin(math.Pi*x/3) / (math.Pi * x / 3)
+	assert.InDelta(t, expected, f.Evaluate(mymath.NewPoint2(x, 0)), 1e-12)
+
+	assert.Equal(t, 0.0, f.Evaluate(mymath.NewPoint2(4.5, 0)))
+}
@@ -0,0 +1,34 @@
+package filter
+
+import (
+	"math"
+	"pbrt-go/mymath"
+)
+
+// GaussianFilter applies Gaussian bump shifted down so that it reaches zero at the radius
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/filters/gaussian.h
+type GaussianFilter struct {
+	FilterBase
+	Alpha      float64
+	ExpX, ExpY float64
+}
+
+// NewGaussianFilter creates filter with the falloff rate alpha
+func NewGaussianFilter(radius mymath.Vector2, alpha float64) *GaussianFilter {
+	return &GaussianFilter{
+		NewFilterBase(radius),
+		alpha,
+		math.Exp(-alpha * radius.X * radius.X),
+		math.Exp(-alpha * radius.Y * radius.Y),
+	}
+}
+
+// Evaluate see https://github.com/mmp/pbrt-v3/blob/master/src/filters/gaussian.cpp#L41
+func (f GaussianFilter) Evaluate(p mymath.Point2) float64 {
+	return f.gaussian(p.X, f.ExpX) * f.gaussian(p.Y, f.ExpY)
+}
+
+func (f GaussianFilter) gaussian(d, expv float64) float64 {
+	return math.Max(0, math.Exp(-f.Alpha*d*d)-expv)
+}
@@ -0,0 +1,43 @@
+package filter
+
+import (
+	"math"
+	"pbrt-go/mymath"
+)
+
+// LanczosSincFilter is sinc function windowed by the Lanczos window, tau sets the number of the sinc cycles
+// before the window reaches zero
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/filters/sinc.h
+type LanczosSincFilter struct {
+	FilterBase
+	Tau float64
+}
+
+func NewLanczosSincFilter(radius mymath.Vector2, tau float64) *LanczosSincFilter {
+	return &LanczosSincFilter{NewFilterBase(radius), tau}
+}
+
+// Evaluate see https://github.com/mmp/pbrt-v3/blob/master/src/filters/sinc.cpp#L41
+func (f LanczosSincFilter) Evaluate(p mymath.Point2) float64 {
+	return f.windowedSinc(p.X, f.Radius.X) * f.windowedSinc(p.Y, f.Radius.Y)
+}
+
+func (f LanczosSincFilter) windowedSinc(x, radius float64) float64 {
+	x = math.Abs(x)
+	if x > radius {
+		return 0
+	}
+
+	lanczos := sinc(x / f.Tau)
+	return sinc(x) * lanczos
+}
+
+func sinc(x float64) float64 {
+	x = math.Abs(x)
+	if x < 1e-5 {
+		return 1
+	}
+
+	return math.Sin(math.Pi*x) / (math.Pi * x)
+}
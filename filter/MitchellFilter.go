@@ -0,0 +1,35 @@
+package filter
+
+import (
+	"math"
+	"pbrt-go/mymath"
+)
+
+// MitchellFilter is Mitchell-Netravali cubic filter parametrized by b and c, recommended values satisfy b + 2c = 1
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/filters/mitchell.h
+type MitchellFilter struct {
+	FilterBase
+	B, C float64
+}
+
+func NewMitchellFilter(radius mymath.Vector2, b, c float64) *MitchellFilter {
+	return &MitchellFilter{NewFilterBase(radius), b, c}
+}
+
+// Evaluate see https://github.com/mmp/pbrt-v3/blob/master/src/filters/mitchell.cpp#L41
+func (f MitchellFilter) Evaluate(p mymath.Point2) float64 {
+	return f.mitchell1D(p.X*f.InvRadius.X) * f.mitchell1D(p.Y*f.InvRadius.Y)
+}
+
+// mitchell1D evaluates the filter for x in [-1, 1]
+func (f MitchellFilter) mitchell1D(x float64) float64 {
+	B, C := f.B, f.C
+
+	x = math.Abs(2 * x)
+	if x > 1 {
+		return ((-B-6*C)*x*x*x + (6*B+30*C)*x*x + (-12*B-48*C)*x + (8*B + 24*C)) * (1. / 6.)
+	}
+
+	return ((12-9*B-6*C)*x*x*x + (-18+12*B+6*C)*x*x + (6 - 2*B)) * (1. / 6.)
+}
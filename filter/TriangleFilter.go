@@ -0,0 +1,22 @@
+package filter
+
+import (
+	"math"
+	"pbrt-go/mymath"
+)
+
+// TriangleFilter weight falls off linearly from the filter center to its radius
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/filters/triangle.h
+type TriangleFilter struct {
+	FilterBase
+}
+
+func NewTriangleFilter(radius mymath.Vector2) *TriangleFilter {
+	return &TriangleFilter{NewFilterBase(radius)}
+}
+
+// Evaluate see https://github.com/mmp/pbrt-v3/blob/master/src/filters/triangle.cpp#L41
+func (f TriangleFilter) Evaluate(p mymath.Point2) float64 {
+	return math.Max(0, f.Radius.X-math.Abs(p.X)) * math.Max(0, f.Radius.Y-math.Abs(p.Y))
+}
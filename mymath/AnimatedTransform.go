@@ -34,7 +34,7 @@ func NewAnimatedTransform(startTransform Transform, startTime float64, endTransf
 		R1 = R1.Negate()
 	}
 
-	return AnimatedTransform{
+	at := AnimatedTransform{
 		StartTransform:   startTransform,
 		EndTransform:     endTransform,
 		startTime:        startTime,
@@ -43,15 +43,115 @@ func NewAnimatedTransform(startTransform Transform, startTime float64, endTransf
 		T:                [2]Vector3{T0, T1},
 		R:                [2]Quaternion{R0, R1},
 		S:                [2]Matrix4x4{S0, S1},
-	}, nil
+		HasRotation:      R0.Dot(R1) < 0.9995,
+	}
 
-	// TODO
-	// hasRotation := dot < 0.9995
+	// Compute terms of motion derivative function
+	if at.HasRotation {
+		at.computeDerivativeTerms()
+	}
 
-	// // Compute terms of motion derivative function
-	// if hasRotation {
+	return at, nil
+}
 
-	// }
+// computeDerivativeTerms fills C1..C5 so that the derivative of the component c of the moving point p is
+//
+//	dp_c/dt = C1[c](p) + (C2[c](p) + C3[c](p) t) cos(2 theta t) + (C4[c](p) + C5[c](p) t) sin(2 theta t)
+//
+// where t in [0, 1] is the normalized time.
+//
+// see https://github.com/mmp/pbrt-v3/blob/aaa552a4b9cbf9dccb71450f47b268e0ed6370e2/src/core/transform.cpp#L417
+func (at *AnimatedTransform) computeDerivativeTerms() {
+	cosTheta := at.R[0].Dot(at.R[1])
+	theta := math.Acos(Clamp(cosTheta, -1, 1))
+	qperp := at.R[1].Subtract(at.R[0].Multiply(cosTheta)).Normalize()
+
+	t0x, t0y, t0z := at.T[0].X, at.T[0].Y, at.T[0].Z
+	t1x, t1y, t1z := at.T[1].X, at.T[1].Y, at.T[1].Z
+	q0x, q0y, q0z, q0w := at.R[0].V.X, at.R[0].V.Y, at.R[0].V.Z, at.R[0].W
+	qperpx, qperpy, qperpz, qperpw := qperp.V.X, qperp.V.Y, qperp.V.Z, qperp.W
+	s000, s001, s002 := float64(at.S[0].M[0][0]), float64(at.S[0].M[0][1]), float64(at.S[0].M[0][2])
+	s010, s011, s012 := float64(at.S[0].M[1][0]), float64(at.S[0].M[1][1]), float64(at.S[0].M[1][2])
+	s020, s021, s022 := float64(at.S[0].M[2][0]), float64(at.S[0].M[2][1]), float64(at.S[0].M[2][2])
+	s100, s101, s102 := float64(at.S[1].M[0][0]), float64(at.S[1].M[0][1]), float64(at.S[1].M[0][2])
+	s110, s111, s112 := float64(at.S[1].M[1][0]), float64(at.S[1].M[1][1]), float64(at.S[1].M[1][2])
+	s120, s121, s122 := float64(at.S[1].M[2][0]), float64(at.S[1].M[2][1]), float64(at.S[1].M[2][2])
+
+	at.C1[0] = NewDerivativeTerm(
+		-t0x+t1x,
+		(-1+q0y*q0y+q0z*q0z+qperpy*qperpy+qperpz*qperpz)*s000+(1-q0y*q0y-q0z*q0z-qperpy*qperpy-qperpz*qperpz)*s100+(-q0x*q0y+q0z*q0w-qperpx*qperpy+qperpz*qperpw)*s010+(q0x*q0y-q0z*q0w+qperpx*qperpy-qperpz*qperpw)*s110+(-q0x*q0z-q0y*q0w-qperpx*qperpz-qperpy*qperpw)*s020+(q0x*q0z+q0y*q0w+qperpx*qperpz+qperpy*qperpw)*s120,
+		(-1+q0y*q0y+q0z*q0z+qperpy*qperpy+qperpz*qperpz)*s001+(1-q0y*q0y-q0z*q0z-qperpy*qperpy-qperpz*qperpz)*s101+(-q0x*q0y+q0z*q0w-qperpx*qperpy+qperpz*qperpw)*s011+(q0x*q0y-q0z*q0w+qperpx*qperpy-qperpz*qperpw)*s111+(-q0x*q0z-q0y*q0w-qperpx*qperpz-qperpy*qperpw)*s021+(q0x*q0z+q0y*q0w+qperpx*qperpz+qperpy*qperpw)*s121,
+		(-1+q0y*q0y+q0z*q0z+qperpy*qperpy+qperpz*qperpz)*s002+(1-q0y*q0y-q0z*q0z-qperpy*qperpy-qperpz*qperpz)*s102+(-q0x*q0y+q0z*q0w-qperpx*qperpy+qperpz*qperpw)*s012+(q0x*q0y-q0z*q0w+qperpx*qperpy-qperpz*qperpw)*s112+(-q0x*q0z-q0y*q0w-qperpx*qperpz-qperpy*qperpw)*s022+(q0x*q0z+q0y*q0w+qperpx*qperpz+qperpy*qperpw)*s122)
+	at.C1[1] = NewDerivativeTerm(
+		-t0y+t1y,
+		(-q0x*q0y-q0z*q0w-qperpx*qperpy-qperpz*qperpw)*s000+(q0x*q0y+q0z*q0w+qperpx*qperpy+qperpz*qperpw)*s100+(-1+q0x*q0x+q0z*q0z+qperpx*qperpx+qperpz*qperpz)*s010+(1-q0x*q0x-q0z*q0z-qperpx*qperpx-qperpz*qperpz)*s110+(q0x*q0w-q0y*q0z+qperpx*qperpw-qperpy*qperpz)*s020+(-q0x*q0w+q0y*q0z-qperpx*qperpw+qperpy*qperpz)*s120,
+		(-q0x*q0y-q0z*q0w-qperpx*qperpy-qperpz*qperpw)*s001+(q0x*q0y+q0z*q0w+qperpx*qperpy+qperpz*qperpw)*s101+(-1+q0x*q0x+q0z*q0z+qperpx*qperpx+qperpz*qperpz)*s011+(1-q0x*q0x-q0z*q0z-qperpx*qperpx-qperpz*qperpz)*s111+(q0x*q0w-q0y*q0z+qperpx*qperpw-qperpy*qperpz)*s021+(-q0x*q0w+q0y*q0z-qperpx*qperpw+qperpy*qperpz)*s121,
+		(-q0x*q0y-q0z*q0w-qperpx*qperpy-qperpz*qperpw)*s002+(q0x*q0y+q0z*q0w+qperpx*qperpy+qperpz*qperpw)*s102+(-1+q0x*q0x+q0z*q0z+qperpx*qperpx+qperpz*qperpz)*s012+(1-q0x*q0x-q0z*q0z-qperpx*qperpx-qperpz*qperpz)*s112+(q0x*q0w-q0y*q0z+qperpx*qperpw-qperpy*qperpz)*s022+(-q0x*q0w+q0y*q0z-qperpx*qperpw+qperpy*qperpz)*s122)
+	at.C1[2] = NewDerivativeTerm(
+		-t0z+t1z,
+		(-q0x*q0z+q0y*q0w-qperpx*qperpz+qperpy*qperpw)*s000+(q0x*q0z-q0y*q0w+qperpx*qperpz-qperpy*qperpw)*s100+(-q0x*q0w-q0y*q0z-qperpx*qperpw-qperpy*qperpz)*s010+(q0x*q0w+q0y*q0z+qperpx*qperpw+qperpy*qperpz)*s110+(-1+q0x*q0x+q0y*q0y+qperpx*qperpx+qperpy*qperpy)*s020+(1-q0x*q0x-q0y*q0y-qperpx*qperpx-qperpy*qperpy)*s120,
+		(-q0x*q0z+q0y*q0w-qperpx*qperpz+qperpy*qperpw)*s001+(q0x*q0z-q0y*q0w+qperpx*qperpz-qperpy*qperpw)*s101+(-q0x*q0w-q0y*q0z-qperpx*qperpw-qperpy*qperpz)*s011+(q0x*q0w+q0y*q0z+qperpx*qperpw+qperpy*qperpz)*s111+(-1+q0x*q0x+q0y*q0y+qperpx*qperpx+qperpy*qperpy)*s021+(1-q0x*q0x-q0y*q0y-qperpx*qperpx-qperpy*qperpy)*s121,
+		(-q0x*q0z+q0y*q0w-qperpx*qperpz+qperpy*qperpw)*s002+(q0x*q0z-q0y*q0w+qperpx*qperpz-qperpy*qperpw)*s102+(-q0x*q0w-q0y*q0z-qperpx*qperpw-qperpy*qperpz)*s012+(q0x*q0w+q0y*q0z+qperpx*qperpw+qperpy*qperpz)*s112+(-1+q0x*q0x+q0y*q0y+qperpx*qperpx+qperpy*qperpy)*s022+(1-q0x*q0x-q0y*q0y-qperpx*qperpx-qperpy*qperpy)*s122)
+	at.C2[0] = NewDerivativeTerm(
+		0,
+		(q0y*q0y+q0z*q0z-qperpy*qperpy-qperpz*qperpz-4*theta*q0y*qperpy-4*theta*q0z*qperpz)*s000+(-q0y*q0y-q0z*q0z+qperpy*qperpy+qperpz*qperpz)*s100+(-q0x*q0y+q0z*q0w+qperpx*qperpy-qperpz*qperpw+2*theta*q0x*qperpy+2*theta*q0y*qperpx-2*theta*q0z*qperpw-2*theta*q0w*qperpz)*s010+(q0x*q0y-q0z*q0w-qperpx*qperpy+qperpz*qperpw)*s110+(-q0x*q0z-q0y*q0w+qperpx*qperpz+qperpy*qperpw+2*theta*q0x*qperpz+2*theta*q0y*qperpw+2*theta*q0z*qperpx+2*theta*q0w*qperpy)*s020+(q0x*q0z+q0y*q0w-qperpx*qperpz-qperpy*qperpw)*s120,
+		(q0y*q0y+q0z*q0z-qperpy*qperpy-qperpz*qperpz-4*theta*q0y*qperpy-4*theta*q0z*qperpz)*s001+(-q0y*q0y-q0z*q0z+qperpy*qperpy+qperpz*qperpz)*s101+(-q0x*q0y+q0z*q0w+qperpx*qperpy-qperpz*qperpw+2*theta*q0x*qperpy+2*theta*q0y*qperpx-2*theta*q0z*qperpw-2*theta*q0w*qperpz)*s011+(q0x*q0y-q0z*q0w-qperpx*qperpy+qperpz*qperpw)*s111+(-q0x*q0z-q0y*q0w+qperpx*qperpz+qperpy*qperpw+2*theta*q0x*qperpz+2*theta*q0y*qperpw+2*theta*q0z*qperpx+2*theta*q0w*qperpy)*s021+(q0x*q0z+q0y*q0w-qperpx*qperpz-qperpy*qperpw)*s121,
+		(q0y*q0y+q0z*q0z-qperpy*qperpy-qperpz*qperpz-4*theta*q0y*qperpy-4*theta*q0z*qperpz)*s002+(-q0y*q0y-q0z*q0z+qperpy*qperpy+qperpz*qperpz)*s102+(-q0x*q0y+q0z*q0w+qperpx*qperpy-qperpz*qperpw+2*theta*q0x*qperpy+2*theta*q0y*qperpx-2*theta*q0z*qperpw-2*theta*q0w*qperpz)*s012+(q0x*q0y-q0z*q0w-qperpx*qperpy+qperpz*qperpw)*s112+(-q0x*q0z-q0y*q0w+qperpx*qperpz+qperpy*qperpw+2*theta*q0x*qperpz+2*theta*q0y*qperpw+2*theta*q0z*qperpx+2*theta*q0w*qperpy)*s022+(q0x*q0z+q0y*q0w-qperpx*qperpz-qperpy*qperpw)*s122)
+	at.C2[1] = NewDerivativeTerm(
+		0,
+		(-q0x*q0y-q0z*q0w+qperpx*qperpy+qperpz*qperpw+2*theta*q0x*qperpy+2*theta*q0y*qperpx+2*theta*q0z*qperpw+2*theta*q0w*qperpz)*s000+(q0x*q0y+q0z*q0w-qperpx*qperpy-qperpz*qperpw)*s100+(q0x*q0x+q0z*q0z-qperpx*qperpx-qperpz*qperpz-4*theta*q0x*qperpx-4*theta*q0z*qperpz)*s010+(-q0x*q0x-q0z*q0z+qperpx*qperpx+qperpz*qperpz)*s110+(q0x*q0w-q0y*q0z-qperpx*qperpw+qperpy*qperpz-2*theta*q0x*qperpw+2*theta*q0y*qperpz+2*theta*q0z*qperpy-2*theta*q0w*qperpx)*s020+(-q0x*q0w+q0y*q0z+qperpx*qperpw-qperpy*qperpz)*s120,
+		(-q0x*q0y-q0z*q0w+qperpx*qperpy+qperpz*qperpw+2*theta*q0x*qperpy+2*theta*q0y*qperpx+2*theta*q0z*qperpw+2*theta*q0w*qperpz)*s001+(q0x*q0y+q0z*q0w-qperpx*qperpy-qperpz*qperpw)*s101+(q0x*q0x+q0z*q0z-qperpx*qperpx-qperpz*qperpz-4*theta*q0x*qperpx-4*theta*q0z*qperpz)*s011+(-q0x*q0x-q0z*q0z+qperpx*qperpx+qperpz*qperpz)*s111+(q0x*q0w-q0y*q0z-qperpx*qperpw+qperpy*qperpz-2*theta*q0x*qperpw+2*theta*q0y*qperpz+2*theta*q0z*qperpy-2*theta*q0w*qperpx)*s021+(-q0x*q0w+q0y*q0z+qperpx*qperpw-qperpy*qperpz)*s121,
+		(-q0x*q0y-q0z*q0w+qperpx*qperpy+qperpz*qperpw+2*theta*q0x*qperpy+2*theta*q0y*qperpx+2*theta*q0z*qperpw+2*theta*q0w*qperpz)*s002+(q0x*q0y+q0z*q0w-qperpx*qperpy-qperpz*qperpw)*s102+(q0x*q0x+q0z*q0z-qperpx*qperpx-qperpz*qperpz-4*theta*q0x*qperpx-4*theta*q0z*qperpz)*s012+(-q0x*q0x-q0z*q0z+qperpx*qperpx+qperpz*qperpz)*s112+(q0x*q0w-q0y*q0z-qperpx*qperpw+qperpy*qperpz-2*theta*q0x*qperpw+2*theta*q0y*qperpz+2*theta*q0z*qperpy-2*theta*q0w*qperpx)*s022+(-q0x*q0w+q0y*q0z+qperpx*qperpw-qperpy*qperpz)*s122)
+	at.C2[2] = NewDerivativeTerm(
+		0,
+		(-q0x*q0z+q0y*q0w+qperpx*qperpz-qperpy*qperpw+2*theta*q0x*qperpz-2*theta*q0y*qperpw+2*theta*q0z*qperpx-2*theta*q0w*qperpy)*s000+(q0x*q0z-q0y*q0w-qperpx*qperpz+qperpy*qperpw)*s100+(-q0x*q0w-q0y*q0z+qperpx*qperpw+qperpy*qperpz+2*theta*q0x*qperpw+2*theta*q0y*qperpz+2*theta*q0z*qperpy+2*theta*q0w*qperpx)*s010+(q0x*q0w+q0y*q0z-qperpx*qperpw-qperpy*qperpz)*s110+(q0x*q0x+q0y*q0y-qperpx*qperpx-qperpy*qperpy-4*theta*q0x*qperpx-4*theta*q0y*qperpy)*s020+(-q0x*q0x-q0y*q0y+qperpx*qperpx+qperpy*qperpy)*s120,
+		(-q0x*q0z+q0y*q0w+qperpx*qperpz-qperpy*qperpw+2*theta*q0x*qperpz-2*theta*q0y*qperpw+2*theta*q0z*qperpx-2*theta*q0w*qperpy)*s001+(q0x*q0z-q0y*q0w-qperpx*qperpz+qperpy*qperpw)*s101+(-q0x*q0w-q0y*q0z+qperpx*qperpw+qperpy*qperpz+2*theta*q0x*qperpw+2*theta*q0y*qperpz+2*theta*q0z*qperpy+2*theta*q0w*qperpx)*s011+(q0x*q0w+q0y*q0z-qperpx*qperpw-qperpy*qperpz)*s111+(q0x*q0x+q0y*q0y-qperpx*qperpx-qperpy*qperpy-4*theta*q0x*qperpx-4*theta*q0y*qperpy)*s021+(-q0x*q0x-q0y*q0y+qperpx*qperpx+qperpy*qperpy)*s121,
+		(-q0x*q0z+q0y*q0w+qperpx*qperpz-qperpy*qperpw+2*theta*q0x*qperpz-2*theta*q0y*qperpw+2*theta*q0z*qperpx-2*theta*q0w*qperpy)*s002+(q0x*q0z-q0y*q0w-qperpx*qperpz+qperpy*qperpw)*s102+(-q0x*q0w-q0y*q0z+qperpx*qperpw+qperpy*qperpz+2*theta*q0x*qperpw+2*theta*q0y*qperpz+2*theta*q0z*qperpy+2*theta*q0w*qperpx)*s012+(q0x*q0w+q0y*q0z-qperpx*qperpw-qperpy*qperpz)*s112+(q0x*q0x+q0y*q0y-qperpx*qperpx-qperpy*qperpy-4*theta*q0x*qperpx-4*theta*q0y*qperpy)*s022+(-q0x*q0x-q0y*q0y+qperpx*qperpx+qperpy*qperpy)*s122)
+	at.C3[0] = NewDerivativeTerm(
+		0,
+		(4*theta*q0y*qperpy+4*theta*q0z*qperpz)*s000+(-4*theta*q0y*qperpy-4*theta*q0z*qperpz)*s100+(-2*theta*q0x*qperpy-2*theta*q0y*qperpx+2*theta*q0z*qperpw+2*theta*q0w*qperpz)*s010+(2*theta*q0x*qperpy+2*theta*q0y*qperpx-2*theta*q0z*qperpw-2*theta*q0w*qperpz)*s110+(-2*theta*q0x*qperpz-2*theta*q0y*qperpw-2*theta*q0z*qperpx-2*theta*q0w*qperpy)*s020+(2*theta*q0x*qperpz+2*theta*q0y*qperpw+2*theta*q0z*qperpx+2*theta*q0w*qperpy)*s120,
+		(4*theta*q0y*qperpy+4*theta*q0z*qperpz)*s001+(-4*theta*q0y*qperpy-4*theta*q0z*qperpz)*s101+(-2*theta*q0x*qperpy-2*theta*q0y*qperpx+2*theta*q0z*qperpw+2*theta*q0w*qperpz)*s011+(2*theta*q0x*qperpy+2*theta*q0y*qperpx-2*theta*q0z*qperpw-2*theta*q0w*qperpz)*s111+(-2*theta*q0x*qperpz-2*theta*q0y*qperpw-2*theta*q0z*qperpx-2*theta*q0w*qperpy)*s021+(2*theta*q0x*qperpz+2*theta*q0y*qperpw+2*theta*q0z*qperpx+2*theta*q0w*qperpy)*s121,
+		(4*theta*q0y*qperpy+4*theta*q0z*qperpz)*s002+(-4*theta*q0y*qperpy-4*theta*q0z*qperpz)*s102+(-2*theta*q0x*qperpy-2*theta*q0y*qperpx+2*theta*q0z*qperpw+2*theta*q0w*qperpz)*s012+(2*theta*q0x*qperpy+2*theta*q0y*qperpx-2*theta*q0z*qperpw-2*theta*q0w*qperpz)*s112+(-2*theta*q0x*qperpz-2*theta*q0y*qperpw-2*theta*q0z*qperpx-2*theta*q0w*qperpy)*s022+(2*theta*q0x*qperpz+2*theta*q0y*qperpw+2*theta*q0z*qperpx+2*theta*q0w*qperpy)*s122)
+	at.C3[1] = NewDerivativeTerm(
+		0,
+		(-2*theta*q0x*qperpy-2*theta*q0y*qperpx-2*theta*q0z*qperpw-2*theta*q0w*qperpz)*s000+(2*theta*q0x*qperpy+2*theta*q0y*qperpx+2*theta*q0z*qperpw+2*theta*q0w*qperpz)*s100+(4*theta*q0x*qperpx+4*theta*q0z*qperpz)*s010+(-4*theta*q0x*qperpx-4*theta*q0z*qperpz)*s110+(2*theta*q0x*qperpw-2*theta*q0y*qperpz-2*theta*q0z*qperpy+2*theta*q0w*qperpx)*s020+(-2*theta*q0x*qperpw+2*theta*q0y*qperpz+2*theta*q0z*qperpy-2*theta*q0w*qperpx)*s120,
+		(-2*theta*q0x*qperpy-2*theta*q0y*qperpx-2*theta*q0z*qperpw-2*theta*q0w*qperpz)*s001+(2*theta*q0x*qperpy+2*theta*q0y*qperpx+2*theta*q0z*qperpw+2*theta*q0w*qperpz)*s101+(4*theta*q0x*qperpx+4*theta*q0z*qperpz)*s011+(-4*theta*q0x*qperpx-4*theta*q0z*qperpz)*s111+(2*theta*q0x*qperpw-2*theta*q0y*qperpz-2*theta*q0z*qperpy+2*theta*q0w*qperpx)*s021+(-2*theta*q0x*qperpw+2*theta*q0y*qperpz+2*theta*q0z*qperpy-2*theta*q0w*qperpx)*s121,
+		(-2*theta*q0x*qperpy-2*theta*q0y*qperpx-2*theta*q0z*qperpw-2*theta*q0w*qperpz)*s002+(2*theta*q0x*qperpy+2*theta*q0y*qperpx+2*theta*q0z*qperpw+2*theta*q0w*qperpz)*s102+(4*theta*q0x*qperpx+4*theta*q0z*qperpz)*s012+(-4*theta*q0x*qperpx-4*theta*q0z*qperpz)*s112+(2*theta*q0x*qperpw-2*theta*q0y*qperpz-2*theta*q0z*qperpy+2*theta*q0w*qperpx)*s022+(-2*theta*q0x*qperpw+2*theta*q0y*qperpz+2*theta*q0z*qperpy-2*theta*q0w*qperpx)*s122)
+	at.C3[2] = NewDerivativeTerm(
+		0,
+		(-2*theta*q0x*qperpz+2*theta*q0y*qperpw-2*theta*q0z*qperpx+2*theta*q0w*qperpy)*s000+(2*theta*q0x*qperpz-2*theta*q0y*qperpw+2*theta*q0z*qperpx-2*theta*q0w*qperpy)*s100+(-2*theta*q0x*qperpw-2*theta*q0y*qperpz-2*theta*q0z*qperpy-2*theta*q0w*qperpx)*s010+(2*theta*q0x*qperpw+2*theta*q0y*qperpz+2*theta*q0z*qperpy+2*theta*q0w*qperpx)*s110+(4*theta*q0x*qperpx+4*theta*q0y*qperpy)*s020+(-4*theta*q0x*qperpx-4*theta*q0y*qperpy)*s120,
+		(-2*theta*q0x*qperpz+2*theta*q0y*qperpw-2*theta*q0z*qperpx+2*theta*q0w*qperpy)*s001+(2*theta*q0x*qperpz-2*theta*q0y*qperpw+2*theta*q0z*qperpx-2*theta*q0w*qperpy)*s101+(-2*theta*q0x*qperpw-2*theta*q0y*qperpz-2*theta*q0z*qperpy-2*theta*q0w*qperpx)*s011+(2*theta*q0x*qperpw+2*theta*q0y*qperpz+2*theta*q0z*qperpy+2*theta*q0w*qperpx)*s111+(4*theta*q0x*qperpx+4*theta*q0y*qperpy)*s021+(-4*theta*q0x*qperpx-4*theta*q0y*qperpy)*s121,
+		(-2*theta*q0x*qperpz+2*theta*q0y*qperpw-2*theta*q0z*qperpx+2*theta*q0w*qperpy)*s002+(2*theta*q0x*qperpz-2*theta*q0y*qperpw+2*theta*q0z*qperpx-2*theta*q0w*qperpy)*s102+(-2*theta*q0x*qperpw-2*theta*q0y*qperpz-2*theta*q0z*qperpy-2*theta*q0w*qperpx)*s012+(2*theta*q0x*qperpw+2*theta*q0y*qperpz+2*theta*q0z*qperpy+2*theta*q0w*qperpx)*s112+(4*theta*q0x*qperpx+4*theta*q0y*qperpy)*s022+(-4*theta*q0x*qperpx-4*theta*q0y*qperpy)*s122)
+	at.C4[0] = NewDerivativeTerm(
+		0,
+		(2*q0y*qperpy+2*q0z*qperpz+2*theta*q0y*q0y+2*theta*q0z*q0z-2*theta*qperpy*qperpy-2*theta*qperpz*qperpz)*s000+(-2*q0y*qperpy-2*q0z*qperpz)*s100+(-q0x*qperpy-q0y*qperpx+q0z*qperpw+q0w*qperpz-2*theta*q0x*q0y+2*theta*q0z*q0w+2*theta*qperpx*qperpy-2*theta*qperpz*qperpw)*s010+(q0x*qperpy+q0y*qperpx-q0z*qperpw-q0w*qperpz)*s110+(-q0x*qperpz-q0y*qperpw-q0z*qperpx-q0w*qperpy-2*theta*q0x*q0z-2*theta*q0y*q0w+2*theta*qperpx*qperpz+2*theta*qperpy*qperpw)*s020+(q0x*qperpz+q0y*qperpw+q0z*qperpx+q0w*qperpy)*s120,
+		(2*q0y*qperpy+2*q0z*qperpz+2*theta*q0y*q0y+2*theta*q0z*q0z-2*theta*qperpy*qperpy-2*theta*qperpz*qperpz)*s001+(-2*q0y*qperpy-2*q0z*qperpz)*s101+(-q0x*qperpy-q0y*qperpx+q0z*qperpw+q0w*qperpz-2*theta*q0x*q0y+2*theta*q0z*q0w+2*theta*qperpx*qperpy-2*theta*qperpz*qperpw)*s011+(q0x*qperpy+q0y*qperpx-q0z*qperpw-q0w*qperpz)*s111+(-q0x*qperpz-q0y*qperpw-q0z*qperpx-q0w*qperpy-2*theta*q0x*q0z-2*theta*q0y*q0w+2*theta*qperpx*qperpz+2*theta*qperpy*qperpw)*s021+(q0x*qperpz+q0y*qperpw+q0z*qperpx+q0w*qperpy)*s121,
+		(2*q0y*qperpy+2*q0z*qperpz+2*theta*q0y*q0y+2*theta*q0z*q0z-2*theta*qperpy*qperpy-2*theta*qperpz*qperpz)*s002+(-2*q0y*qperpy-2*q0z*qperpz)*s102+(-q0x*qperpy-q0y*qperpx+q0z*qperpw+q0w*qperpz-2*theta*q0x*q0y+2*theta*q0z*q0w+2*theta*qperpx*qperpy-2*theta*qperpz*qperpw)*s012+(q0x*qperpy+q0y*qperpx-q0z*qperpw-q0w*qperpz)*s112+(-q0x*qperpz-q0y*qperpw-q0z*qperpx-q0w*qperpy-2*theta*q0x*q0z-2*theta*q0y*q0w+2*theta*qperpx*qperpz+2*theta*qperpy*qperpw)*s022+(q0x*qperpz+q0y*qperpw+q0z*qperpx+q0w*qperpy)*s122)
+	at.C4[1] = NewDerivativeTerm(
+		0,
+		(-q0x*qperpy-q0y*qperpx-q0z*qperpw-q0w*qperpz-2*theta*q0x*q0y-2*theta*q0z*q0w+2*theta*qperpx*qperpy+2*theta*qperpz*qperpw)*s000+(q0x*qperpy+q0y*qperpx+q0z*qperpw+q0w*qperpz)*s100+(2*q0x*qperpx+2*q0z*qperpz+2*theta*q0x*q0x+2*theta*q0z*q0z-2*theta*qperpx*qperpx-2*theta*qperpz*qperpz)*s010+(-2*q0x*qperpx-2*q0z*qperpz)*s110+(q0x*qperpw-q0y*qperpz-q0z*qperpy+q0w*qperpx+2*theta*q0x*q0w-2*theta*q0y*q0z-2*theta*qperpx*qperpw+2*theta*qperpy*qperpz)*s020+(-q0x*qperpw+q0y*qperpz+q0z*qperpy-q0w*qperpx)*s120,
+		(-q0x*qperpy-q0y*qperpx-q0z*qperpw-q0w*qperpz-2*theta*q0x*q0y-2*theta*q0z*q0w+2*theta*qperpx*qperpy+2*theta*qperpz*qperpw)*s001+(q0x*qperpy+q0y*qperpx+q0z*qperpw+q0w*qperpz)*s101+(2*q0x*qperpx+2*q0z*qperpz+2*theta*q0x*q0x+2*theta*q0z*q0z-2*theta*qperpx*qperpx-2*theta*qperpz*qperpz)*s011+(-2*q0x*qperpx-2*q0z*qperpz)*s111+(q0x*qperpw-q0y*qperpz-q0z*qperpy+q0w*qperpx+2*theta*q0x*q0w-2*theta*q0y*q0z-2*theta*qperpx*qperpw+2*theta*qperpy*qperpz)*s021+(-q0x*qperpw+q0y*qperpz+q0z*qperpy-q0w*qperpx)*s121,
+		(-q0x*qperpy-q0y*qperpx-q0z*qperpw-q0w*qperpz-2*theta*q0x*q0y-2*theta*q0z*q0w+2*theta*qperpx*qperpy+2*theta*qperpz*qperpw)*s002+(q0x*qperpy+q0y*qperpx+q0z*qperpw+q0w*qperpz)*s102+(2*q0x*qperpx+2*q0z*qperpz+2*theta*q0x*q0x+2*theta*q0z*q0z-2*theta*qperpx*qperpx-2*theta*qperpz*qperpz)*s012+(-2*q0x*qperpx-2*q0z*qperpz)*s112+(q0x*qperpw-q0y*qperpz-q0z*qperpy+q0w*qperpx+2*theta*q0x*q0w-2*theta*q0y*q0z-2*theta*qperpx*qperpw+2*theta*qperpy*qperpz)*s022+(-q0x*qperpw+q0y*qperpz+q0z*qperpy-q0w*qperpx)*s122)
+	at.C4[2] = NewDerivativeTerm(
+		0,
+		(-q0x*qperpz+q0y*qperpw-q0z*qperpx+q0w*qperpy-2*theta*q0x*q0z+2*theta*q0y*q0w+2*theta*qperpx*qperpz-2*theta*qperpy*qperpw)*s000+(q0x*qperpz-q0y*qperpw+q0z*qperpx-q0w*qperpy)*s100+(-q0x*qperpw-q0y*qperpz-q0z*qperpy-q0w*qperpx-2*theta*q0x*q0w-2*theta*q0y*q0z+2*theta*qperpx*qperpw+2*theta*qperpy*qperpz)*s010+(q0x*qperpw+q0y*qperpz+q0z*qperpy+q0w*qperpx)*s110+(2*q0x*qperpx+2*q0y*qperpy+2*theta*q0x*q0x+2*theta*q0y*q0y-2*theta*qperpx*qperpx-2*theta*qperpy*qperpy)*s020+(-2*q0x*qperpx-2*q0y*qperpy)*s120,
+		(-q0x*qperpz+q0y*qperpw-q0z*qperpx+q0w*qperpy-2*theta*q0x*q0z+2*theta*q0y*q0w+2*theta*qperpx*qperpz-2*theta*qperpy*qperpw)*s001+(q0x*qperpz-q0y*qperpw+q0z*qperpx-q0w*qperpy)*s101+(-q0x*qperpw-q0y*qperpz-q0z*qperpy-q0w*qperpx-2*theta*q0x*q0w-2*theta*q0y*q0z+2*theta*qperpx*qperpw+2*theta*qperpy*qperpz)*s011+(q0x*qperpw+q0y*qperpz+q0z*qperpy+q0w*qperpx)*s111+(2*q0x*qperpx+2*q0y*qperpy+2*theta*q0x*q0x+2*theta*q0y*q0y-2*theta*qperpx*qperpx-2*theta*qperpy*qperpy)*s021+(-2*q0x*qperpx-2*q0y*qperpy)*s121,
+		(-q0x*qperpz+q0y*qperpw-q0z*qperpx+q0w*qperpy-2*theta*q0x*q0z+2*theta*q0y*q0w+2*theta*qperpx*qperpz-2*theta*qperpy*qperpw)*s002+(q0x*qperpz-q0y*qperpw+q0z*qperpx-q0w*qperpy)*s102+(-q0x*qperpw-q0y*qperpz-q0z*qperpy-q0w*qperpx-2*theta*q0x*q0w-2*theta*q0y*q0z+2*theta*qperpx*qperpw+2*theta*qperpy*qperpz)*s012+(q0x*qperpw+q0y*qperpz+q0z*qperpy+q0w*qperpx)*s112+(2*q0x*qperpx+2*q0y*qperpy+2*theta*q0x*q0x+2*theta*q0y*q0y-2*theta*qperpx*qperpx-2*theta*qperpy*qperpy)*s022+(-2*q0x*qperpx-2*q0y*qperpy)*s122)
+	at.C5[0] = NewDerivativeTerm(
+		0,
+		(-2*theta*q0y*q0y-2*theta*q0z*q0z+2*theta*qperpy*qperpy+2*theta*qperpz*qperpz)*s000+(2*theta*q0y*q0y+2*theta*q0z*q0z-2*theta*qperpy*qperpy-2*theta*qperpz*qperpz)*s100+(2*theta*q0x*q0y-2*theta*q0z*q0w-2*theta*qperpx*qperpy+2*theta*qperpz*qperpw)*s010+(-2*theta*q0x*q0y+2*theta*q0z*q0w+2*theta*qperpx*qperpy-2*theta*qperpz*qperpw)*s110+(2*theta*q0x*q0z+2*theta*q0y*q0w-2*theta*qperpx*qperpz-2*theta*qperpy*qperpw)*s020+(-2*theta*q0x*q0z-2*theta*q0y*q0w+2*theta*qperpx*qperpz+2*theta*qperpy*qperpw)*s120,
+		(-2*theta*q0y*q0y-2*theta*q0z*q0z+2*theta*qperpy*qperpy+2*theta*qperpz*qperpz)*s001+(2*theta*q0y*q0y+2*theta*q0z*q0z-2*theta*qperpy*qperpy-2*theta*qperpz*qperpz)*s101+(2*theta*q0x*q0y-2*theta*q0z*q0w-2*theta*qperpx*qperpy+2*theta*qperpz*qperpw)*s011+(-2*theta*q0x*q0y+2*theta*q0z*q0w+2*theta*qperpx*qperpy-2*theta*qperpz*qperpw)*s111+(2*theta*q0x*q0z+2*theta*q0y*q0w-2*theta*qperpx*qperpz-2*theta*qperpy*qperpw)*s021+(-2*theta*q0x*q0z-2*theta*q0y*q0w+2*theta*qperpx*qperpz+2*theta*qperpy*qperpw)*s121,
+		(-2*theta*q0y*q0y-2*theta*q0z*q0z+2*theta*qperpy*qperpy+2*theta*qperpz*qperpz)*s002+(2*theta*q0y*q0y+2*theta*q0z*q0z-2*theta*qperpy*qperpy-2*theta*qperpz*qperpz)*s102+(2*theta*q0x*q0y-2*theta*q0z*q0w-2*theta*qperpx*qperpy+2*theta*qperpz*qperpw)*s012+(-2*theta*q0x*q0y+2*theta*q0z*q0w+2*theta*qperpx*qperpy-2*theta*qperpz*qperpw)*s112+(2*theta*q0x*q0z+2*theta*q0y*q0w-2*theta*qperpx*qperpz-2*theta*qperpy*qperpw)*s022+(-2*theta*q0x*q0z-2*theta*q0y*q0w+2*theta*qperpx*qperpz+2*theta*qperpy*qperpw)*s122)
+	at.C5[1] = NewDerivativeTerm(
+		0,
+		(2*theta*q0x*q0y+2*theta*q0z*q0w-2*theta*qperpx*qperpy-2*theta*qperpz*qperpw)*s000+(-2*theta*q0x*q0y-2*theta*q0z*q0w+2*theta*qperpx*qperpy+2*theta*qperpz*qperpw)*s100+(-2*theta*q0x*q0x-2*theta*q0z*q0z+2*theta*qperpx*qperpx+2*theta*qperpz*qperpz)*s010+(2*theta*q0x*q0x+2*theta*q0z*q0z-2*theta*qperpx*qperpx-2*theta*qperpz*qperpz)*s110+(-2*theta*q0x*q0w+2*theta*q0y*q0z+2*theta*qperpx*qperpw-2*theta*qperpy*qperpz)*s020+(2*theta*q0x*q0w-2*theta*q0y*q0z-2*theta*qperpx*qperpw+2*theta*qperpy*qperpz)*s120,
+		(2*theta*q0x*q0y+2*theta*q0z*q0w-2*theta*qperpx*qperpy-2*theta*qperpz*qperpw)*s001+(-2*theta*q0x*q0y-2*theta*q0z*q0w+2*theta*qperpx*qperpy+2*theta*qperpz*qperpw)*s101+(-2*theta*q0x*q0x-2*theta*q0z*q0z+2*theta*qperpx*qperpx+2*theta*qperpz*qperpz)*s011+(2*theta*q0x*q0x+2*theta*q0z*q0z-2*theta*qperpx*qperpx-2*theta*qperpz*qperpz)*s111+(-2*theta*q0x*q0w+2*theta*q0y*q0z+2*theta*qperpx*qperpw-2*theta*qperpy*qperpz)*s021+(2*theta*q0x*q0w-2*theta*q0y*q0z-2*theta*qperpx*qperpw+2*theta*qperpy*qperpz)*s121,
+		(2*theta*q0x*q0y+2*theta*q0z*q0w-2*theta*qperpx*qperpy-2*theta*qperpz*qperpw)*s002+(-2*theta*q0x*q0y-2*theta*q0z*q0w+2*theta*qperpx*qperpy+2*theta*qperpz*qperpw)*s102+(-2*theta*q0x*q0x-2*theta*q0z*q0z+2*theta*qperpx*qperpx+2*theta*qperpz*qperpz)*s012+(2*theta*q0x*q0x+2*theta*q0z*q0z-2*theta*qperpx*qperpx-2*theta*qperpz*qperpz)*s112+(-2*theta*q0x*q0w+2*theta*q0y*q0z+2*theta*qperpx*qperpw-2*theta*qperpy*qperpz)*s022+(2*theta*q0x*q0w-2*theta*q0y*q0z-2*theta*qperpx*qperpw+2*theta*qperpy*qperpz)*s122)
+	at.C5[2] = NewDerivativeTerm(
+		0,
+		(2*theta*q0x*q0z-2*theta*q0y*q0w-2*theta*qperpx*qperpz+2*theta*qperpy*qperpw)*s000+(-2*theta*q0x*q0z+2*theta*q0y*q0w+2*theta*qperpx*qperpz-2*theta*qperpy*qperpw)*s100+(2*theta*q0x*q0w+2*theta*q0y*q0z-2*theta*qperpx*qperpw-2*theta*qperpy*qperpz)*s010+(-2*theta*q0x*q0w-2*theta*q0y*q0z+2*theta*qperpx*qperpw+2*theta*qperpy*qperpz)*s110+(-2*theta*q0x*q0x-2*theta*q0y*q0y+2*theta*qperpx*qperpx+2*theta*qperpy*qperpy)*s020+(2*theta*q0x*q0x+2*theta*q0y*q0y-2*theta*qperpx*qperpx-2*theta*qperpy*qperpy)*s120,
+		(2*theta*q0x*q0z-2*theta*q0y*q0w-2*theta*qperpx*qperpz+2*theta*qperpy*qperpw)*s001+(-2*theta*q0x*q0z+2*theta*q0y*q0w+2*theta*qperpx*qperpz-2*theta*qperpy*qperpw)*s101+(2*theta*q0x*q0w+2*theta*q0y*q0z-2*theta*qperpx*qperpw-2*theta*qperpy*qperpz)*s011+(-2*theta*q0x*q0w-2*theta*q0y*q0z+2*theta*qperpx*qperpw+2*theta*qperpy*qperpz)*s111+(-2*theta*q0x*q0x-2*theta*q0y*q0y+2*theta*qperpx*qperpx+2*theta*qperpy*qperpy)*s021+(2*theta*q0x*q0x+2*theta*q0y*q0y-2*theta*qperpx*qperpx-2*theta*qperpy*qperpy)*s121,
+		(2*theta*q0x*q0z-2*theta*q0y*q0w-2*theta*qperpx*qperpz+2*theta*qperpy*qperpw)*s002+(-2*theta*q0x*q0z+2*theta*q0y*q0w+2*theta*qperpx*qperpz-2*theta*qperpy*qperpw)*s102+(2*theta*q0x*q0w+2*theta*q0y*q0z-2*theta*qperpx*qperpw-2*theta*qperpy*qperpz)*s012+(-2*theta*q0x*q0w-2*theta*q0y*q0z+2*theta*qperpx*qperpw+2*theta*qperpy*qperpz)*s112+(-2*theta*q0x*q0x-2*theta*q0y*q0y+2*theta*qperpx*qperpx+2*theta*qperpy*qperpy)*s022+(2*theta*q0x*q0x+2*theta*q0y*q0y-2*theta*qperpx*qperpx-2*theta*qperpy*qperpy)*s122)
 }
 
 // MotionBounds see https://github.com/mmp/pbrt-v3/blob/aaa552a4b9cbf9dccb71450f47b268e0ed6370e2/src/core/transform.cpp#L1215
@@ -60,12 +160,12 @@ func (at AnimatedTransform) MotionBounds(b Bounds3) (Bounds3, error) {
 		return at.StartTransform.ApplyB(b), nil
 	}
 
-	if at.HasRotation {
+	if !at.HasRotation {
 		return at.StartTransform.ApplyB(b).UnionB(at.EndTransform.ApplyB(b)), nil
 	}
 
 	// Return motion bounds accounting for animated rotation
-	bounds := Bounds3{}
+	bounds := NewBounds3Empty()
 	for corner := 0; corner < 8; corner++ {
 		motionBound, err := at.boundPointMotion(b.Corner(corner))
 		if err != nil {
@@ -86,7 +186,7 @@ func (at AnimatedTransform) boundPointMotion(p Point3) (Bounds3, error) {
 
 	for c := 0; c < 3; c++ {
 		// Find any motion derivative zeros for the component c
-		zeros := [4]float64{}
+		zeros := [8]float64{}
 		nZeros := 0
 		intervalFindZeros(at.C1[c].Eval(p), at.C2[c].Eval(p), at.C3[c].Eval(p), at.C4[c].Eval(p), at.C5[c].Eval(p), theta, NewInterval(0.0, 1.0), &zeros, &nZeros, 8)
 
@@ -105,11 +205,11 @@ func (at AnimatedTransform) boundPointMotion(p Point3) (Bounds3, error) {
 }
 
 // intervalFindZeros see https://github.com/mmp/pbrt-v3/blob/aaa552a4b9cbf9dccb71450f47b268e0ed6370e2/src/core/transform.cpp#L354
-func intervalFindZeros(c1, c2, c3, c4, c5, theta float64, tInterval Interval, zeros *[4]float64, zeroCount *int, depth int) {
+func intervalFindZeros(c1, c2, c3, c4, c5, theta float64, tInterval Interval, zeros *[8]float64, zeroCount *int, depth int) {
 	// Evaluate motion derivative in interval form, return if no zeros
 	span := NewIntervalSingle(c1).Add(
 		NewIntervalSingle(c2).Add(NewIntervalSingle(c3).Multiply(tInterval)).Multiply(Cos(NewIntervalSingle(2 * theta).Multiply(tInterval)))).Add(
-		NewIntervalSingle(c4).Add(NewIntervalSingle(c5).Multiply(tInterval).Multiply(Sin(NewIntervalSingle(2 * theta).Multiply(tInterval)))))
+		NewIntervalSingle(c4).Add(NewIntervalSingle(c5).Multiply(tInterval)).Multiply(Sin(NewIntervalSingle(2 * theta).Multiply(tInterval))))
 
 	if span.Low > 0 || span.High < 0 || span.Low == span.High {
 		return
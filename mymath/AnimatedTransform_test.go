@@ -434,3 +434,154 @@ func BenchmarkAnimatedTransform_ApplyV_static(b *testing.B) {
 
 	assert.NotNil(b, res)
 }
+
+func TestNewAnimatedTransform_HasRotation(t *testing.T) {
+	t0 := mymath.NewTransformEmpty()
+
+	at, err := mymath.NewAnimatedTransform(t0, 0, mymath.NewTransformTranslate(mymath.NewVector3(1, 2, 3)), 1)
+	assert.Nil(t, err)
+	assert.False(t, at.HasRotation)
+
+	at, err = mymath.NewAnimatedTransform(t0, 0, mymath.NewTransformScale(1, 2, 3), 1)
+	assert.Nil(t, err)
+	assert.False(t, at.HasRotation)
+
+	at, err = mymath.NewAnimatedTransform(t0, 0, mymath.NewTransformRotateY(1), 1)
+	assert.Nil(t, err)
+	assert.True(t, at.HasRotation)
+}
+
+func newRotatingAnimatedTransforms(t *testing.T) []mymath.AnimatedTransform {
+	transforms := [][2]mymath.Transform{
+		{
+			mymath.NewTransformEmpty(),
+			mymath.NewTransformRotateZ(math.Pi / 2),
+		},
+		{
+			mymath.NewTransformTranslate(mymath.NewVector3(-1, 2, 0)).ApplyT(mymath.NewTransformRotateX(0.3)),
+			mymath.NewTransformTranslate(mymath.NewVector3(3, 0, 1)).ApplyT(mymath.NewTransformRotateY(2.5)),
+		},
+		{
+			mymath.NewTransformRotateX(-0.4).ApplyT(mymath.NewTransformScale(1, 2, 0.5)),
+			mymath.NewTransformTranslate(mymath.NewVector3(0, 1, -2)).ApplyT(mymath.NewTransformRotateZ(2)).ApplyT(mymath.NewTransformScale(3, 1, 1)),
+		},
+		{
+			mymath.NewTransformRotateY(0.2).ApplyT(mymath.NewTransformRotateZ(1.1)),
+			mymath.NewTransformRotateX(2.9).ApplyT(mymath.NewTransformScale(0.5, 0.5, 0.5)),
+		},
+	}
+
+	ats := make([]mymath.AnimatedTransform, len(transforms))
+	for i, ts := range transforms {
+		at, err := mymath.NewAnimatedTransform(ts[0], 2, ts[1], 4)
+		assert.Nil(t, err)
+		assert.True(t, at.HasRotation)
+		ats[i] = at
+	}
+
+	return ats
+}
+
+// animatedPosition evaluates T(u) + R(u) S(u) p in double precision, u in [0, 1] is the normalized time
+func animatedPosition(at mymath.AnimatedTransform, u float64, p mymath.Point3) [3]float64 {
+	q := at.R[0].Slerp(u, at.R[1])
+	x, y, z, w := q.V.X, q.V.Y, q.V.Z, q.W
+	r := [3][3]float64{
+		{1 - 2*(y*y+z*z), 2 * (x*y - z*w), 2 * (x*z + y*w)},
+		{2 * (x*y + z*w), 1 - 2*(x*x+z*z), 2 * (y*z - x*w)},
+		{2 * (x*z - y*w), 2 * (y*z + x*w), 1 - 2*(x*x+y*y)},
+	}
+
+	var sp [3]float64
+	for j := 0; j < 3; j++ {
+		for k := 0; k < 3; k++ {
+			s := mymath.Lerp(u, float64(at.S[0].M[j][k]), float64(at.S[1].M[j][k]))
+			sp[j] += s * [3]float64{p.X, p.Y, p.Z}[k]
+		}
+	}
+
+	t := at.T[0].Multiply(1 - u).Add(at.T[1].Multiply(u))
+	res := [3]float64{t.X, t.Y, t.Z}
+	for c := 0; c < 3; c++ {
+		res[c] += r[c][0]*sp[0] + r[c][1]*sp[1] + r[c][2]*sp[2]
+	}
+
+	return res
+}
+
+// Motion derivative terms must match the numerical derivative of the interpolated position
+func TestNewAnimatedTransform_derivativeTerms(t *testing.T) {
+	p := mymath.NewPoint3(0.7, -1.3, 2.1)
+
+	for i, at := range newRotatingAnimatedTransforms(t) {
+		cosTheta := at.R[0].Dot(at.R[1])
+		theta := math.Acos(mymath.Clamp(cosTheta, -1, 1))
+
+		for _, u := range []float64{0.1, 0.35, 0.5, 0.8, 0.95} {
+			// central difference over normalized time u
+			h := 1e-4
+			p0, p1 := animatedPosition(at, u-h, p), animatedPosition(at, u+h, p)
+
+			for c := 0; c < 3; c++ {
+				numeric := (p1[c] - p0[c]) / (2 * h)
+				derivative := at.C1[c].Eval(p) +
+					(at.C2[c].Eval(p)+at.C3[c].Eval(p)*u)*math.Cos(2*theta*u) +
+					(at.C4[c].Eval(p)+at.C5[c].Eval(p)*u)*math.Sin(2*theta*u)
+
+				assert.InDelta(t, numeric, derivative, 1e-6, "transform %v, u %v, component %v", i, u, c)
+			}
+		}
+	}
+}
+
+// bruteForceMotionBounds samples the animated box corners densely in time
+func bruteForceMotionBounds(at mymath.AnimatedTransform, b mymath.Bounds3) mymath.Bounds3 {
+	bounds := mymath.NewBounds3Empty()
+	const steps = 20000
+	for i := 0; i <= steps; i++ {
+		for corner := 0; corner < 8; corner++ {
+			p := animatedPosition(at, float64(i)/steps, b.Corner(corner))
+			bounds = bounds.UnionP(mymath.NewPoint3(p[0], p[1], p[2]))
+		}
+	}
+
+	return bounds
+}
+
+func TestAnimatedTransform_MotionBounds(t *testing.T) {
+	b := mymath.NewBounds3(mymath.NewPoint3(-1, -0.5, 0.2), mymath.NewPoint3(1.5, 1, 2))
+
+	for i, at := range newRotatingAnimatedTransforms(t) {
+		motionBounds, err := at.MotionBounds(b)
+		assert.Nil(t, err)
+
+		expected := bruteForceMotionBounds(at, b)
+
+		// bounds are conservative
+		assert.True(t, motionBounds.Expand(1e-6).Inside(expected.PMin), "transform %v", i)
+		assert.True(t, motionBounds.Expand(1e-6).Inside(expected.PMax), "transform %v", i)
+
+		// and tight
+		assert.InDelta(t, expected.PMin.X, motionBounds.PMin.X, 1e-6, "transform %v", i)
+		assert.InDelta(t, expected.PMin.Y, motionBounds.PMin.Y, 1e-6, "transform %v", i)
+		assert.InDelta(t, expected.PMin.Z, motionBounds.PMin.Z, 1e-6, "transform %v", i)
+		assert.InDelta(t, expected.PMax.X, motionBounds.PMax.X, 1e-6, "transform %v", i)
+		assert.InDelta(t, expected.PMax.Y, motionBounds.PMax.Y, 1e-6, "transform %v", i)
+		assert.InDelta(t, expected.PMax.Z, motionBounds.PMax.Z, 1e-6, "transform %v", i)
+	}
+}
+
+func TestAnimatedTransform_MotionBounds_noRotation(t *testing.T) {
+	b := mymath.NewBounds3(mymath.NewPoint3(0, 0, 0), mymath.NewPoint3(1, 1, 1))
+
+	at, err := mymath.NewAnimatedTransform(
+		mymath.NewTransformEmpty(), 2,
+		mymath.NewTransformTranslate(mymath.NewVector3(2, 0, 0)).ApplyT(mymath.NewTransformScale(1, 3, 1)), 4)
+	assert.Nil(t, err)
+
+	motionBounds, err := at.MotionBounds(b)
+	assert.Nil(t, err)
+
+	InDeltaPoint3(t, mymath.NewPoint3(0, 0, 0), motionBounds.PMin)
+	InDeltaPoint3(t, mymath.NewPoint3(3, 3, 1), motionBounds.PMax)
+}
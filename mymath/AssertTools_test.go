@@ -2,7 +2,9 @@ package mymath_test
 
 import (
 	"github.com/stretchr/testify/assert"
+	"math"
 	"pbrt-go/mymath"
+	"pbrt-go/spectrum"
 	"testing"
 )
 
@@ -76,3 +78,8 @@ func InDeltaRayDifferential(t *testing.T, expected, actual mymath.RayDifferentia
 	InDeltaVector3(t, expected.RxDirection, actual.RxDirection, msgAndArgs...)
 	InDeltaVector3(t, expected.RyDirection, actual.RyDirection, msgAndArgs...)
 }
+
+func InDeltaSpectrum(t *testing.T, expected, actual spectrum.Spectrum, delta float64, msgAndArgs ...interface{}) {
+	d := expected.Subtract(actual)
+	assert.LessOrEqual(t, math.Max(d.MaxComponentValue(), d.Negate().MaxComponentValue()), delta, msgAndArgs...)
+}
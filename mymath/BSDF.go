@@ -0,0 +1,217 @@
+package mymath
+
+import (
+	"math"
+	"pbrt-go/spectrum"
+)
+
+// BSDF is collection of the BxDF lobes at the surface point, it transforms the directions between the world space
+// and the local shading coordinate system of the lobes
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L421
+type BSDF struct {
+	// Eta is relative index of refraction over the boundary, 1 for opaque surfaces
+	Eta float64
+	// Ns and Ng are the shading and the geometric normal
+	Ns, Ng Normal3
+	// Ss and Ts are the shading tangents, Ss is along the shading dp/du
+	Ss, Ts Vector3
+	BxDFs  []BxDF
+}
+
+// NewBSDF creates empty BSDF with the shading coordinate system of the interaction
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L424
+func NewBSDF(si *SurfaceInteraction, eta float64) *BSDF {
+	ns := si.Shading.N
+	ss := si.Shading.Dpdu.Normalize()
+	return &BSDF{
+		Eta: eta,
+		Ns:  ns,
+		Ng:  si.N,
+		Ss:  ss,
+		Ts:  NewVector3N(ns).Cross(ss),
+	}
+}
+
+// Add appends the lobe
+func (b *BSDF) Add(bxdf BxDF) {
+	b.BxDFs = append(b.BxDFs, bxdf)
+}
+
+// NumComponents returns number of the lobes matching the flags
+func (b *BSDF) NumComponents(flags BxDFType) int {
+	num := 0
+	for _, bxdf := range b.BxDFs {
+		if bxdf.Type().Matches(flags) {
+			num++
+		}
+	}
+	return num
+}
+
+// WorldToLocal transforms the world space direction to the local shading coordinate system
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L437
+func (b *BSDF) WorldToLocal(v Vector3) Vector3 {
+	return NewVector3(v.Dot(b.Ss), v.Dot(b.Ts), v.Dot(NewVector3N(b.Ns)))
+}
+
+// LocalToWorld transforms the direction in the local shading coordinate system to the world space
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L440
+func (b *BSDF) LocalToWorld(v Vector3) Vector3 {
+	return b.Ss.Multiply(v.X).Add(b.Ts.Multiply(v.Y)).Add(NewVector3N(b.Ns).Multiply(v.Z))
+}
+
+// F returns sum of the matching lobes for the world space directions, the reflection or the transmission lobes
+// are chosen by the geometric normal so that the light does not leak through the shading normal discontinuities
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L550
+func (b *BSDF) F(woW, wiW Vector3, flags BxDFType) spectrum.Spectrum {
+	wi, wo := b.WorldToLocal(wiW), b.WorldToLocal(woW)
+	if wo.Z == 0 {
+		return spectrum.NewSpectrum(0)
+	}
+
+	reflect := b.isReflection(woW, wiW)
+	f := spectrum.NewSpectrum(0)
+	for _, bxdf := range b.BxDFs {
+		if b.contributes(bxdf, flags, reflect) {
+			f = f.Add(bxdf.F(wo, wi))
+		}
+	}
+	return f
+}
+
+// SampleF chooses one of the matching lobes by u.X and samples the incident direction by it, the returned
+// value and pdf account for all the matching lobes unless the chosen lobe is specular
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L596
+func (b *BSDF) SampleF(woWorld Vector3, u Point2, flags BxDFType) (wiWorld Vector3, f spectrum.Spectrum, pdf float64, sampledType BxDFType) {
+	// Choose which BxDF to sample
+	matchingComps := b.NumComponents(flags)
+	if matchingComps == 0 {
+		return Vector3{}, spectrum.NewSpectrum(0), 0, 0
+	}
+	comp := minInt(int(math.Floor(u.X*float64(matchingComps))), matchingComps-1)
+
+	// Get BxDF for chosen component
+	var bxdf BxDF
+	count := comp
+	for _, bx := range b.BxDFs {
+		if bx.Type().Matches(flags) {
+			if count == 0 {
+				bxdf = bx
+				break
+			}
+			count--
+		}
+	}
+
+	// Remap BxDF sample u to [0,1)^2
+	uRemapped := NewPoint2(math.Min(u.X*float64(matchingComps)-float64(comp), OneMinusEpsilon), u.Y)
+
+	// Sample chosen BxDF
+	wo := b.WorldToLocal(woWorld)
+	if wo.Z == 0 {
+		return Vector3{}, spectrum.NewSpectrum(0), 0, 0
+	}
+	wi, f, pdf, sampledType := bxdf.SampleF(wo, uRemapped)
+	if pdf == 0 {
+		return Vector3{}, spectrum.NewSpectrum(0), 0, 0
+	}
+	wiWorld = b.LocalToWorld(wi)
+
+	// Compute overall PDF with all matching BxDFs
+	specular := bxdf.Type()&BSDFSpecular != 0
+	if !specular && matchingComps > 1 {
+		for _, bx := range b.BxDFs {
+			if bx != bxdf && bx.Type().Matches(flags) {
+				pdf += bx.Pdf(wo, wi)
+			}
+		}
+	}
+	if matchingComps > 1 {
+		pdf /= float64(matchingComps)
+	}
+
+	// Compute value of BSDF for sampled direction
+	if !specular {
+		reflect := b.isReflection(woWorld, wiWorld)
+		f = spectrum.NewSpectrum(0)
+		for _, bx := range b.BxDFs {
+			if b.contributes(bx, flags, reflect) {
+				f = f.Add(bx.F(wo, wi))
+			}
+		}
+	}
+
+	return wiWorld, f, pdf, sampledType
+}
+
+// Pdf returns average density of the matching lobes
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L662
+func (b *BSDF) Pdf(woWorld, wiWorld Vector3, flags BxDFType) float64 {
+	if len(b.BxDFs) == 0 {
+		return 0
+	}
+
+	wo, wi := b.WorldToLocal(woWorld), b.WorldToLocal(wiWorld)
+	if wo.Z == 0 {
+		return 0
+	}
+
+	pdf := 0.0
+	matchingComps := 0
+	for _, bxdf := range b.BxDFs {
+		if bxdf.Type().Matches(flags) {
+			matchingComps++
+			pdf += bxdf.Pdf(wo, wi)
+		}
+	}
+
+	if matchingComps == 0 {
+		return 0
+	}
+	return pdf / float64(matchingComps)
+}
+
+// Rho returns sum of the hemispherical-directional reflectances of the matching lobes
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L532
+func (b *BSDF) Rho(woWorld Vector3, samples []Point2, flags BxDFType) spectrum.Spectrum {
+	wo := b.WorldToLocal(woWorld)
+	ret := spectrum.NewSpectrum(0)
+	for _, bxdf := range b.BxDFs {
+		if bxdf.Type().Matches(flags) {
+			ret = ret.Add(bxdf.Rho(wo, samples))
+		}
+	}
+	return ret
+}
+
+// RhoHH returns sum of the hemispherical-hemispherical reflectances of the matching lobes
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L522
+func (b *BSDF) RhoHH(samples1, samples2 []Point2, flags BxDFType) spectrum.Spectrum {
+	ret := spectrum.NewSpectrum(0)
+	for _, bxdf := range b.BxDFs {
+		if bxdf.Type().Matches(flags) {
+			ret = ret.Add(bxdf.RhoHH(samples1, samples2))
+		}
+	}
+	return ret
+}
+
+func (b *BSDF) isReflection(woW, wiW Vector3) bool {
+	ng := NewVector3N(b.Ng)
+	return wiW.Dot(ng)*woW.Dot(ng) > 0
+}
+
+// contributes tells whether the lobe matches the flags and the kind of scattering
+func (b *BSDF) contributes(bxdf BxDF, flags BxDFType, reflect bool) bool {
+	t := bxdf.Type()
+	return t.Matches(flags) && ((reflect && t&BSDFReflection != 0) || (!reflect && t&BSDFTransmission != 0))
+}
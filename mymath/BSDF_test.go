@@ -0,0 +1,93 @@
+package mymath_test
+
+import (
+	"math"
+	"math/rand"
+	"pbrt-go/mymath"
+	"pbrt-go/spectrum"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTiltedBSDF creates BSDF at the point of the plane tilted around the x axis
+func newTiltedBSDF() *mymath.BSDF {
+	dpdu := mymath.NewVector3(2, 0, 0)
+	dpdv := mymath.NewVector3(0, math.Cos(0.4), math.Sin(0.4))
+	si := mymath.NewSurfaceInteraction(mymath.NewPoint3(0, 0, 0), mymath.Vector3{}, mymath.NewPoint2(0, 0), mymath.NewVector3(0, 0, 1),
+		dpdu, dpdv, mymath.Normal3{}, mymath.Normal3{}, 0, nil)
+	return mymath.NewBSDF(&si, 1)
+}
+
+func TestBSDF_frame(t *testing.T) {
+	bsdf := newTiltedBSDF()
+
+	InDeltaVector3(t, mymath.NewVector3(1, 0, 0), bsdf.Ss)
+	InDeltaVector3(t, mymath.NewVector3(0, 0, 1), bsdf.WorldToLocal(mymath.NewVector3N(bsdf.Ns)))
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		v := randomDirection(rng)
+		InDeltaVector3(t, v, bsdf.LocalToWorld(bsdf.WorldToLocal(v)))
+		assert.InDelta(t, 1, bsdf.WorldToLocal(v).Length(), equalDelta)
+	}
+}
+
+func TestBSDF_F(t *testing.T) {
+	bsdf := newTiltedBSDF()
+	r := spectrum.NewSpectrum(0.5)
+	bsdf.Add(mymath.NewLambertianReflection(r))
+
+	wo := bsdf.LocalToWorld(mymath.NewVector3(0.6, 0, 0.8))
+	wi := bsdf.LocalToWorld(mymath.NewVector3(0, 0.6, 0.8))
+
+	InDeltaSpectrum(t, r.Divide(math.Pi), bsdf.F(wo, wi, mymath.BSDFAll), equalDelta)
+	assert.True(t, bsdf.F(wo, wi, mymath.BSDFAll&^mymath.BSDFDiffuse).IsBlack())
+
+	// reflection lobe does not transmit
+	assert.True(t, bsdf.F(wo, wi.Negate(), mymath.BSDFAll).IsBlack())
+	assert.Equal(t, 1, bsdf.NumComponents(mymath.BSDFAll))
+	assert.Equal(t, 0, bsdf.NumComponents(mymath.BSDFTransmission))
+}
+
+func TestBSDF_SampleF(t *testing.T) {
+	bsdf := newTiltedBSDF()
+	bsdf.Add(mymath.NewLambertianReflection(spectrum.NewSpectrum(0.3)))
+	bsdf.Add(mymath.NewOrenNayar(spectrum.NewSpectrum(0.4), 30))
+	bsdf.Add(mymath.NewLambertianTransmission(spectrum.NewSpectrum(0.2)))
+
+	wo := bsdf.LocalToWorld(mymath.NewVector3(0.3, 0.4, 0.5).Normalize())
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 100; i++ {
+		wi, f, pdf, _ := bsdf.SampleF(wo, mymath.NewPoint2(rng.Float64(), rng.Float64()), mymath.BSDFAll)
+		assert.Greater(t, pdf, 0.0)
+		assert.InDelta(t, bsdf.Pdf(wo, wi, mymath.BSDFAll), pdf, equalDelta)
+		InDeltaSpectrum(t, bsdf.F(wo, wi, mymath.BSDFAll), f, equalDelta)
+	}
+
+	// specular lobe alone
+	bsdf.Add(mymath.NewSpecularReflection(spectrum.NewSpectrum(1), mymath.FresnelNoOp{}))
+	wi, f, pdf, sampledType := bsdf.SampleF(wo, mymath.NewPoint2(0.5, 0.5), mymath.BSDFSpecular|mymath.BSDFReflection)
+	assert.Equal(t, mymath.BSDFSpecular|mymath.BSDFReflection, sampledType)
+	assert.Equal(t, 1.0, pdf)
+	InDeltaVector3(t, mymath.Reflect(wo, mymath.NewVector3N(bsdf.Ns)), wi)
+	InDeltaSpectrum(t, spectrum.NewSpectrum(1), f.Multiply(mymath.AbsCosTheta(bsdf.WorldToLocal(wi))), equalDelta)
+
+	// nothing matches
+	_, f, pdf, _ = newTiltedBSDF().SampleF(wo, mymath.NewPoint2(0.5, 0.5), mymath.BSDFAll)
+	assert.Equal(t, 0.0, pdf)
+	assert.True(t, f.IsBlack())
+}
+
+func TestBSDF_Rho(t *testing.T) {
+	bsdf := newTiltedBSDF()
+	bsdf.Add(mymath.NewLambertianReflection(spectrum.NewSpectrum(0.3)))
+	bsdf.Add(mymath.NewLambertianTransmission(spectrum.NewSpectrum(0.2)))
+
+	rng := rand.New(rand.NewSource(3))
+	samples1, samples2 := randomSamples(rng, 10), randomSamples(rng, 10)
+	wo := mymath.NewVector3N(bsdf.Ns)
+
+	InDeltaSpectrum(t, spectrum.NewSpectrum(0.5), bsdf.Rho(wo, samples1, mymath.BSDFAll), equalDelta)
+	InDeltaSpectrum(t, spectrum.NewSpectrum(0.3), bsdf.RhoHH(samples1, samples2, mymath.BSDFReflection|mymath.BSDFDiffuse), equalDelta)
+}
@@ -0,0 +1,162 @@
+package mymath
+
+import "math"
+
+// BeckmannDistribution is the Gaussian distribution of the microfacet slopes, AlphaX and AlphaY are the anisotropic
+// roughnesses along the tangent and the bitangent
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/microfacet.h#L80
+type BeckmannDistribution struct {
+	AlphaX, AlphaY    float64
+	SampleVisibleArea bool
+}
+
+func NewBeckmannDistribution(alphaX, alphaY float64, sampleVisibleArea bool) *BeckmannDistribution {
+	return &BeckmannDistribution{
+		AlphaX:            math.Max(1e-3, alphaX),
+		AlphaY:            math.Max(1e-3, alphaY),
+		SampleVisibleArea: sampleVisibleArea,
+	}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/microfacet.cpp#L45
+func (d *BeckmannDistribution) D(wh Vector3) float64 {
+	tan2Theta := Tan2Theta(wh)
+	if math.IsInf(tan2Theta, 0) || math.IsNaN(tan2Theta) {
+		return 0
+	}
+	cos4Theta := Cos2Theta(wh) * Cos2Theta(wh)
+	return math.Exp(-tan2Theta*(Cos2Phi(wh)/(d.AlphaX*d.AlphaX)+Sin2Phi(wh)/(d.AlphaY*d.AlphaY))) /
+		(math.Pi * d.AlphaX * d.AlphaY * cos4Theta)
+}
+
+// Lambda uses the rational approximation of the Beckmann Lambda
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/microfacet.cpp#L63
+func (d *BeckmannDistribution) Lambda(w Vector3) float64 {
+	absTanTheta := math.Abs(TanTheta(w))
+	if math.IsInf(absTanTheta, 0) || math.IsNaN(absTanTheta) {
+		return 0
+	}
+
+	// Compute alpha for direction w
+	alpha := math.Sqrt(Cos2Phi(w)*d.AlphaX*d.AlphaX + Sin2Phi(w)*d.AlphaY*d.AlphaY)
+	a := 1 / (alpha * absTanTheta)
+	if a >= 1.6 {
+		return 0
+	}
+	return (1 - 1.259*a + 0.396*a*a) / (3.535*a + 2.181*a*a)
+}
+
+func (d *BeckmannDistribution) G1(w Vector3) float64 {
+	return MicrofacetG1(d, w)
+}
+
+func (d *BeckmannDistribution) G(wo, wi Vector3) float64 {
+	return MicrofacetG(d, wo, wi)
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/microfacet.cpp#L284
+func (d *BeckmannDistribution) SampleWh(wo Vector3, u Point2) Vector3 {
+	if d.SampleVisibleArea {
+		// Sample visible area of normals for Beckmann distribution
+		return sampleVisible(wo, d.AlphaX, d.AlphaY, u, beckmannSample11)
+	}
+
+	// Sample full distribution of normals for Beckmann distribution
+
+	// Compute tan^2(theta) and phi for Beckmann distribution sample
+	var tan2Theta, phi float64
+	logSample := math.Log(1 - u.X)
+	if math.IsInf(logSample, 0) {
+		logSample = 0
+	}
+	if d.AlphaX == d.AlphaY {
+		tan2Theta = -d.AlphaX * d.AlphaX * logSample
+		phi = u.Y * 2 * math.Pi
+	} else {
+		// Compute tan2Theta and phi for anisotropic Beckmann distribution
+		phi = anisotropicPhi(d.AlphaX, d.AlphaY, u.Y)
+		sinPhi, cosPhi := math.Sin(phi), math.Cos(phi)
+		tan2Theta = -logSample / (cosPhi*cosPhi/(d.AlphaX*d.AlphaX) + sinPhi*sinPhi/(d.AlphaY*d.AlphaY))
+	}
+
+	// Map sampled Beckmann angles to normal direction wh
+	cosTheta := 1 / math.Sqrt(1+tan2Theta)
+	sinTheta := math.Sqrt(math.Max(0, 1-cosTheta*cosTheta))
+	wh := SphericalDirection(sinTheta, cosTheta, phi)
+	if !SameHemisphere(wo, wh) {
+		wh = wh.Negate()
+	}
+	return wh
+}
+
+func (d *BeckmannDistribution) Pdf(wo, wh Vector3) float64 {
+	return MicrofacetPdf(d, d.SampleVisibleArea, wo, wh)
+}
+
+// beckmannSample11 samples the slopes of the visible normals of the isotropic Beckmann distribution
+// with unit roughness
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/microfacet.cpp#L60
+func beckmannSample11(cosThetaI, u1, u2 float64) (slopeX, slopeY float64) {
+	// Special case (normal incidence)
+	if cosThetaI > .9999 {
+		r := math.Sqrt(-math.Log(1 - u1))
+		sinPhi, cosPhi := math.Sincos(2 * math.Pi * u2)
+		return r * cosPhi, r * sinPhi
+	}
+
+	// The original inversion routine from the paper contained discontinuities, which causes issues for QMC
+	// integration and techniques like Kelemen-style MLT. The following code performs a numerical inversion
+	// with better behavior
+	sinThetaI := math.Sqrt(math.Max(0, 1-cosThetaI*cosThetaI))
+	tanThetaI := sinThetaI / cosThetaI
+	cotThetaI := 1 / tanThetaI
+
+	// Search interval -- everything is parameterized in the Erf() domain
+	a, c := -1.0, math.Erf(cotThetaI)
+	sampleX := math.Max(u1, 1e-6)
+
+	// Start with a good initial guess
+	thetaI := math.Acos(cosThetaI)
+	fit := 1 + thetaI*(-0.876+thetaI*(0.4265-0.0594*thetaI))
+	b := c - (1+c)*math.Pow(1-sampleX, fit)
+
+	// Normalization factor for the CDF
+	sqrtPiInv := 1 / math.Sqrt(math.Pi)
+	normalization := 1 / (1 + c + sqrtPiInv*tanThetaI*math.Exp(-cotThetaI*cotThetaI))
+
+	for it := 1; it < 10; it++ {
+		// Bisection criterion -- the oddly-looking Boolean expression are intentional to check for NaNs
+		// at little additional cost
+		if !(b >= a && b <= c) {
+			b = 0.5 * (a + c)
+		}
+
+		// Evaluate the CDF and its derivative (i.e. the density function)
+		invErf := math.Erfinv(b)
+		value := normalization*(1+b+sqrtPiInv*tanThetaI*math.Exp(-invErf*invErf)) - sampleX
+		derivative := normalization * (1 - invErf*tanThetaI)
+
+		if math.Abs(value) < 1e-5 {
+			break
+		}
+
+		// Update bisection intervals
+		if value > 0 {
+			c = b
+		} else {
+			a = b
+		}
+
+		b -= value / derivative
+	}
+
+	// Now convert back into a slope value
+	slopeX = math.Erfinv(b)
+
+	// Simulate Y component
+	slopeY = math.Erfinv(2*math.Max(u2, 1e-6) - 1)
+	return slopeX, slopeY
+}
@@ -0,0 +1,54 @@
+package mymath
+
+import "math"
+
+// Bounds2 is axis aligned rectangle
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/geometry.h#L617
+type Bounds2 struct {
+	PMin Point2
+	PMax Point2
+}
+
+// NewBounds2Empty returns degenerate bounds with inverted limits so that any union with it yields the other operand
+func NewBounds2Empty() Bounds2 {
+	return Bounds2{
+		NewPoint2(math.MaxFloat64, math.MaxFloat64),
+		NewPoint2(-math.MaxFloat64, -math.MaxFloat64)}
+}
+
+func NewBounds2(p1 Point2, p2 Point2) Bounds2 {
+	return Bounds2{
+		NewPoint2(math.Min(p1.X, p2.X), math.Min(p1.Y, p2.Y)),
+		NewPoint2(math.Max(p1.X, p2.X), math.Max(p1.Y, p2.Y))}
+}
+
+func (b Bounds2) Diagonal() Point2 {
+	return NewPoint2(b.PMax.X-b.PMin.X, b.PMax.Y-b.PMin.Y)
+}
+
+func (b Bounds2) Area() float64 {
+	d := b.Diagonal()
+	return d.X * d.Y
+}
+
+func (b Bounds2) Inside(p Point2) bool {
+	return p.X >= b.PMin.X && p.X <= b.PMax.X && p.Y >= b.PMin.Y && p.Y <= b.PMax.Y
+}
+
+func (b Bounds2) UnionP(p Point2) Bounds2 {
+	return Bounds2{
+		NewPoint2(math.Min(b.PMin.X, p.X), math.Min(b.PMin.Y, p.Y)),
+		NewPoint2(math.Max(b.PMax.X, p.X), math.Max(b.PMax.Y, p.Y))}
+}
+
+func (b Bounds2) Expand(delta float64) Bounds2 {
+	return Bounds2{
+		NewPoint2(b.PMin.X-delta, b.PMin.Y-delta),
+		NewPoint2(b.PMax.X+delta, b.PMax.Y+delta)}
+}
+
+// Lerp interpolates between the corners of the bounds in each dimension
+func (b Bounds2) Lerp(t Point2) Point2 {
+	return NewPoint2(Lerp(t.X, b.PMin.X, b.PMax.X), Lerp(t.Y, b.PMin.Y, b.PMax.Y))
+}
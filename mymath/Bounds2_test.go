@@ -0,0 +1,29 @@
+package mymath_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pbrt-go/mymath"
+	"testing"
+)
+
+func TestBounds2(t *testing.T) {
+	b := mymath.NewBounds2Empty().UnionP(mymath.NewPoint2(1, 4)).UnionP(mymath.NewPoint2(3, 2))
+
+	assert.Equal(t, mymath.NewBounds2(mymath.NewPoint2(3, 4), mymath.NewPoint2(1, 2)), b)
+	assert.Equal(t, 4.0, b.Area())
+	assert.True(t, b.Inside(mymath.NewPoint2(2, 3)))
+	assert.False(t, b.Inside(mymath.NewPoint2(0, 3)))
+	assert.Equal(t, mymath.NewPoint2(2.5, 2.5), b.Lerp(mymath.NewPoint2(0.75, 0.25)))
+	assert.Equal(t, mymath.NewBounds2(mymath.NewPoint2(0.5, 1.5), mymath.NewPoint2(3.5, 4.5)), b.Expand(0.5))
+}
+
+func TestBounds2i_Intersect(t *testing.T) {
+	b1 := mymath.NewBounds2i(mymath.NewPoint2i(0, 0), mymath.NewPoint2i(10, 5))
+	b2 := mymath.NewBounds2i(mymath.NewPoint2i(4, -2), mymath.NewPoint2i(12, 3))
+
+	b := b1.Intersect(b2)
+	assert.Equal(t, mymath.NewBounds2i(mymath.NewPoint2i(4, 0), mymath.NewPoint2i(10, 3)), b)
+	assert.Equal(t, 18, b.Area())
+	assert.True(t, b.InsideExclusive(mymath.NewPoint2i(4, 0)))
+	assert.False(t, b.InsideExclusive(mymath.NewPoint2i(10, 2)))
+}
@@ -0,0 +1,32 @@
+package mymath
+
+// Bounds2i is rectangle of integer points, PMax is exclusive when iterating over the pixels
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/geometry.h#L617
+type Bounds2i struct {
+	PMin Point2i
+	PMax Point2i
+}
+
+func NewBounds2i(p1 Point2i, p2 Point2i) Bounds2i {
+	return Bounds2i{p1.Min(p2), p1.Max(p2)}
+}
+
+func (b Bounds2i) Diagonal() Point2i {
+	return NewPoint2i(b.PMax.X-b.PMin.X, b.PMax.Y-b.PMin.Y)
+}
+
+func (b Bounds2i) Area() int {
+	d := b.Diagonal()
+	return d.X * d.Y
+}
+
+// Intersect returns the overlap of the bounds, the result may be degenerate with zero area
+func (b1 Bounds2i) Intersect(b2 Bounds2i) Bounds2i {
+	return Bounds2i{b1.PMin.Max(b2.PMin), b1.PMax.Min(b2.PMax)}
+}
+
+// InsideExclusive tells if the point lies in the bounds, the upper bounds are excluded
+func (b Bounds2i) InsideExclusive(p Point2i) bool {
+	return p.X >= b.PMin.X && p.X < b.PMax.X && p.Y >= b.PMin.Y && p.Y < b.PMax.Y
+}
@@ -1,10 +1,21 @@
 package mymath
 
+import "math"
+
 type Bounds3 struct {
 	PMin Point3
 	PMax Point3
 }
 
+// NewBounds3Empty returns degenerate bounds with inverted limits so that any union with it yields the other operand
+//
+// see https://github.com/mmp/pbrt-v3/blob/aaa552a4b9cbf9dccb71450f47b268e0ed6370e2/src/core/geometry.h#L753
+func NewBounds3Empty() Bounds3 {
+	return Bounds3{
+		NewPoint3(math.MaxFloat64, math.MaxFloat64, math.MaxFloat64),
+		NewPoint3(-math.MaxFloat64, -math.MaxFloat64, -math.MaxFloat64)}
+}
+
 func NewBounds3P(p Point3) Bounds3 {
 	return Bounds3{p, p}
 }
@@ -44,15 +55,15 @@ func (b Bounds3) Corner(corner int) Point3 {
 }
 
 func (b Bounds3) UnionP(p Point3) Bounds3 {
-	return NewBounds3(
+	return Bounds3{
 		b.PMin.Min(p),
-		b.PMax.Max(p))
+		b.PMax.Max(p)}
 }
 
 func (b1 Bounds3) UnionB(b2 Bounds3) Bounds3 {
-	return NewBounds3(
+	return Bounds3{
 		b1.PMin.Min(b2.PMin),
-		b1.PMax.Max(b2.PMax))
+		b1.PMax.Max(b2.PMax)}
 }
 
 func (b1 Bounds3) Intersect(b2 Bounds3) Bounds3 {
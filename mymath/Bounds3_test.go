@@ -26,6 +26,14 @@ func TestBounds3_NewBounds3(t *testing.T) {
 	assert.Equal(t, p2, b.PMax)
 }
 
+func TestBounds3_NewBounds3Empty(t *testing.T) {
+	b := mymath.NewBounds3Empty()
+
+	assert.Equal(t, mymath.NewBounds3Empty(), b.UnionB(mymath.NewBounds3Empty()))
+	assert.Equal(t, mymath.NewBounds3P(mymath.NewPoint3(1, 2, 3)), b.UnionP(mymath.NewPoint3(1, 2, 3)))
+	assert.False(t, b.Inside(mymath.NewPoint3(0, 0, 0)))
+}
+
 func TestBounds3_NewBounds3MinMax(t *testing.T) {
 	p1 := mymath.NewPoint3(0, 0, 0)
 	p2 := mymath.NewPoint3(1, 2, 3)
@@ -0,0 +1,102 @@
+package mymath
+
+import (
+	"math"
+	"pbrt-go/spectrum"
+)
+
+// BxDFType classifies the scattering functions by the kind of scattering
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L110
+type BxDFType int
+
+const (
+	BSDFReflection BxDFType = 1 << iota
+	BSDFTransmission
+	BSDFDiffuse
+	BSDFGlossy
+	BSDFSpecular
+	BSDFAll = BSDFDiffuse | BSDFGlossy | BSDFSpecular | BSDFReflection | BSDFTransmission
+)
+
+// Matches tells whether all the flags of the type t are set in flags
+func (t BxDFType) Matches(flags BxDFType) bool {
+	return t&flags == t
+}
+
+// BxDF is the scattering function of a single lobe, the directions are in the local shading coordinate system
+// where the normal is the z axis
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L179
+type BxDF interface {
+	// F returns value of the distribution function for the pair of directions
+	F(wo, wi Vector3) spectrum.Spectrum
+	// SampleF samples the incident direction wi for the outgoing direction wo using the uniform sample u,
+	// returns the value of the distribution function, the pdf and the type of the sampled lobe
+	SampleF(wo Vector3, u Point2) (wi Vector3, f spectrum.Spectrum, pdf float64, sampledType BxDFType)
+	// Pdf returns the solid angle density of sampling wi for wo by SampleF
+	Pdf(wo, wi Vector3) float64
+	// Rho returns the hemispherical-directional reflectance estimated using the samples
+	Rho(wo Vector3, samples []Point2) spectrum.Spectrum
+	// RhoHH returns the hemispherical-hemispherical reflectance estimated using the samples
+	RhoHH(samples1, samples2 []Point2) spectrum.Spectrum
+	// Type returns the flags of the lobe
+	Type() BxDFType
+}
+
+// CosineSampleF samples wi by the cosine-weighted hemisphere on the side of wo, it is the default
+// sampling of the lobes
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L410
+func CosineSampleF(b BxDF, wo Vector3, u Point2) (Vector3, spectrum.Spectrum, float64, BxDFType) {
+	// Cosine-sample the hemisphere, flipping the direction if necessary
+	wi := CosineSampleHemisphere(u)
+	if wo.Z < 0 {
+		wi.Z *= -1
+	}
+	return wi, b.F(wo, wi), b.Pdf(wo, wi), b.Type()
+}
+
+// CosinePdf is density of CosineSampleF
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L419
+func CosinePdf(wo, wi Vector3) float64 {
+	if SameHemisphere(wo, wi) {
+		return AbsCosTheta(wi) / math.Pi
+	}
+	return 0
+}
+
+// EstimateRho estimates the hemispherical-directional reflectance of the lobe by Monte Carlo integration
+// of its SampleF
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L675
+func EstimateRho(b BxDF, wo Vector3, samples []Point2) spectrum.Spectrum {
+	r := spectrum.NewSpectrum(0)
+	for _, u := range samples {
+		// Estimate one term of rho_hd
+		wi, f, pdf, _ := b.SampleF(wo, u)
+		if pdf > 0 {
+			r = r.Add(f.Multiply(AbsCosTheta(wi) / pdf))
+		}
+	}
+	return r.Divide(float64(len(samples)))
+}
+
+// EstimateRhoHH estimates the hemispherical-hemispherical reflectance of the lobe by Monte Carlo integration,
+// wo are sampled uniformly by samples1 and wi by the SampleF using samples2
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L687
+func EstimateRhoHH(b BxDF, samples1, samples2 []Point2) spectrum.Spectrum {
+	r := spectrum.NewSpectrum(0)
+	for i := range samples1 {
+		// Estimate one term of rho_hh
+		wo := UniformSampleHemisphere(samples1[i])
+		pdfo := UniformHemispherePdf()
+		wi, f, pdfi, _ := b.SampleF(wo, samples2[i])
+		if pdfi > 0 {
+			r = r.Add(f.Multiply(AbsCosTheta(wi) * AbsCosTheta(wo) / (pdfo * pdfi)))
+		}
+	}
+	return r.Divide(math.Pi * float64(len(samples1)))
+}
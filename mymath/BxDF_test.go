@@ -0,0 +1,274 @@
+package mymath_test
+
+import (
+	"math"
+	"math/rand"
+	"pbrt-go/mymath"
+	"pbrt-go/spectrum"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func randomSamples(rng *rand.Rand, n int) []mymath.Point2 {
+	samples := make([]mymath.Point2, n)
+	for i := range samples {
+		samples[i] = mymath.NewPoint2(rng.Float64(), rng.Float64())
+	}
+	return samples
+}
+
+func randomDirection(rng *rand.Rand) mymath.Vector3 {
+	return mymath.UniformSampleSphere(mymath.NewPoint2(rng.Float64(), rng.Float64()))
+}
+
+// integratePdf estimates the integral of the lobe density over all directions
+func integratePdf(bxdf mymath.BxDF, wo mymath.Vector3, n int) float64 {
+	rng := rand.New(rand.NewSource(5))
+
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		wi := randomDirection(rng)
+		sum += bxdf.Pdf(wo, wi) / mymath.UniformSpherePdf()
+	}
+	return sum / float64(n)
+}
+
+// assertSampleF checks that the sampled values agree with F and Pdf of the lobe
+func assertSampleF(t *testing.T, bxdf mymath.BxDF, wo mymath.Vector3) {
+	rng := rand.New(rand.NewSource(6))
+
+	for i := 0; i < 100; i++ {
+		wi, f, pdf, sampledType := bxdf.SampleF(wo, mymath.NewPoint2(rng.Float64(), rng.Float64()))
+		assert.Equal(t, bxdf.Type(), sampledType)
+		if pdf == 0 {
+			// failed sample
+			assert.True(t, f.IsBlack())
+			continue
+		}
+		assert.InDelta(t, 1, wi.Length(), equalDelta)
+		assert.InDelta(t, bxdf.Pdf(wo, wi), pdf, equalDelta*math.Max(1, pdf))
+		InDeltaSpectrum(t, bxdf.F(wo, wi), f, equalDelta*math.Max(1, f.MaxComponentValue()))
+	}
+}
+
+func TestLambertianReflection(t *testing.T) {
+	r := spectrum.NewSpectrum(0.5)
+	bxdf := mymath.NewLambertianReflection(r)
+	wo := mymath.NewVector3(0.3, 0.4, 0.5).Normalize()
+
+	InDeltaSpectrum(t, r.Divide(math.Pi), bxdf.F(wo, mymath.NewVector3(0, 0, 1)), equalDelta)
+	assertSampleF(t, bxdf, wo)
+	assertSampleF(t, bxdf, wo.Negate())
+	assert.InDelta(t, 1, integratePdf(bxdf, wo, 100000), 0.02)
+
+	// the analytic reflectance agrees with the estimate
+	rng := rand.New(rand.NewSource(7))
+	samples1, samples2 := randomSamples(rng, 1000), randomSamples(rng, 1000)
+	InDeltaSpectrum(t, bxdf.Rho(wo, samples1), mymath.EstimateRho(bxdf, wo, samples1), equalDelta)
+	InDeltaSpectrum(t, bxdf.RhoHH(samples1, samples2), mymath.EstimateRhoHH(bxdf, samples1, samples2), 0.02)
+}
+
+func TestLambertianTransmission(t *testing.T) {
+	bxdf := mymath.NewLambertianTransmission(spectrum.NewSpectrum(0.5))
+	wo := mymath.NewVector3(0.3, 0.4, 0.5).Normalize()
+
+	wi, _, pdf, _ := bxdf.SampleF(wo, mymath.NewPoint2(0.3, 0.6))
+	assert.Less(t, wi.Z, 0.0)
+	assert.Greater(t, pdf, 0.0)
+	assert.Equal(t, 0.0, bxdf.Pdf(wo, wo))
+
+	assertSampleF(t, bxdf, wo)
+	assertSampleF(t, bxdf, wo.Negate())
+	assert.InDelta(t, 1, integratePdf(bxdf, wo, 100000), 0.02)
+}
+
+func TestOrenNayar(t *testing.T) {
+	r := spectrum.NewSpectrum(0.8)
+	rng := rand.New(rand.NewSource(8))
+
+	// zero roughness is Lambertian
+	smooth := mymath.NewOrenNayar(r, 0)
+	lambertian := mymath.NewLambertianReflection(r)
+	for i := 0; i < 100; i++ {
+		wo, wi := randomDirection(rng), randomDirection(rng)
+		InDeltaSpectrum(t, lambertian.F(wo, wi), smooth.F(wo, wi), equalDelta)
+	}
+
+	// reciprocity
+	rough := mymath.NewOrenNayar(r, 20)
+	for i := 0; i < 100; i++ {
+		wo, wi := randomDirection(rng), randomDirection(rng)
+		InDeltaSpectrum(t, rough.F(wo, wi), rough.F(wi, wo), equalDelta)
+	}
+
+	// energy conservation
+	wo := mymath.NewVector3(0.6, 0, 0.8)
+	rho := rough.Rho(wo, randomSamples(rng, 10000))
+	assert.LessOrEqual(t, rho.MaxComponentValue(), 1.0)
+	assert.Greater(t, rho.MaxComponentValue(), 0.5)
+
+	assertSampleF(t, rough, wo)
+}
+
+func TestSpecularReflection(t *testing.T) {
+	bxdf := mymath.NewSpecularReflection(spectrum.NewSpectrum(1), mymath.NewFresnelDielectric(1, 1.5))
+	wo := mymath.NewVector3(0.6, 0, 0.8)
+
+	wi, f, pdf, sampledType := bxdf.SampleF(wo, mymath.NewPoint2(0.5, 0.5))
+	InDeltaVector3(t, mymath.NewVector3(-0.6, 0, 0.8), wi)
+	assert.Equal(t, 1.0, pdf)
+	assert.Equal(t, mymath.BSDFReflection|mymath.BSDFSpecular, sampledType)
+	InDeltaSpectrum(t, spectrum.NewSpectrum(mymath.FrDielectric(0.8, 1, 1.5)), f.Multiply(mymath.AbsCosTheta(wi)), equalDelta)
+
+	// the delta distribution is never hit by chance
+	assert.True(t, bxdf.F(wo, wi).IsBlack())
+	assert.Equal(t, 0.0, bxdf.Pdf(wo, wi))
+
+	// perfect mirror
+	mirror := mymath.NewSpecularReflection(spectrum.NewSpectrum(1), mymath.FresnelNoOp{})
+	InDeltaSpectrum(t, spectrum.NewSpectrum(1), mirror.Rho(wo, randomSamples(rand.New(rand.NewSource(9)), 10)), equalDelta)
+}
+
+func TestSpecularTransmission(t *testing.T) {
+	bxdf := mymath.NewSpecularTransmission(spectrum.NewSpectrum(1), 1, 1.5, mymath.Importance)
+	wo := mymath.NewVector3(0.6, 0, 0.8)
+
+	wi, f, pdf, sampledType := bxdf.SampleF(wo, mymath.NewPoint2(0.5, 0.5))
+	assert.Equal(t, 1.0, pdf)
+	assert.Equal(t, mymath.BSDFTransmission|mymath.BSDFSpecular, sampledType)
+
+	// Snell's law
+	assert.InDelta(t, -0.6/1.5, wi.X, equalDelta)
+	assert.Less(t, wi.Z, 0.0)
+
+	// reflected and transmitted energy sum to one
+	fr := mymath.FrDielectric(mymath.CosTheta(wi), 1, 1.5)
+	assert.InDelta(t, fr, mymath.FrDielectric(mymath.CosTheta(wo), 1, 1.5), equalDelta)
+	InDeltaSpectrum(t, spectrum.NewSpectrum(1-fr), f.Multiply(mymath.AbsCosTheta(wi)), equalDelta)
+
+	// radiance is scaled by the squared ratio of the indices of refraction
+	radiance := mymath.NewSpecularTransmission(spectrum.NewSpectrum(1), 1, 1.5, mymath.Radiance)
+	_, fRadiance, _, _ := radiance.SampleF(wo, mymath.NewPoint2(0.5, 0.5))
+	InDeltaSpectrum(t, f.Divide(1.5*1.5), fRadiance, equalDelta)
+
+	// total internal reflection when leaving the denser medium at grazing angle
+	_, _, pdf, _ = bxdf.SampleF(mymath.NewVector3(0.9, 0, -math.Sqrt(1-0.81)), mymath.NewPoint2(0.5, 0.5))
+	assert.Equal(t, 0.0, pdf)
+}
+
+func TestFresnelSpecular(t *testing.T) {
+	bxdf := mymath.NewFresnelSpecular(spectrum.NewSpectrum(1), spectrum.NewSpectrum(1), 1, 1.5, mymath.Importance)
+	wo := mymath.NewVector3(0.6, 0, 0.8)
+	fr := mymath.FrDielectric(0.8, 1, 1.5)
+
+	// reflection
+	wi, f, pdf, sampledType := bxdf.SampleF(wo, mymath.NewPoint2(fr/2, 0.5))
+	InDeltaVector3(t, mymath.NewVector3(-0.6, 0, 0.8), wi)
+	assert.InDelta(t, fr, pdf, equalDelta)
+	assert.Equal(t, mymath.BSDFReflection|mymath.BSDFSpecular, sampledType)
+	InDeltaSpectrum(t, spectrum.NewSpectrum(1), f.Multiply(mymath.AbsCosTheta(wi)/pdf), equalDelta)
+
+	// transmission
+	wi, f, pdf, sampledType = bxdf.SampleF(wo, mymath.NewPoint2((fr+1)/2, 0.5))
+	assert.Less(t, wi.Z, 0.0)
+	assert.InDelta(t, 1-fr, pdf, equalDelta)
+	assert.Equal(t, mymath.BSDFTransmission|mymath.BSDFSpecular, sampledType)
+	InDeltaSpectrum(t, spectrum.NewSpectrum(1), f.Multiply(mymath.AbsCosTheta(wi)/pdf), equalDelta)
+
+	// all the energy is either reflected or transmitted
+	rho := bxdf.Rho(wo, randomSamples(rand.New(rand.NewSource(10)), 1000))
+	InDeltaSpectrum(t, spectrum.NewSpectrum(1), rho, equalDelta)
+}
+
+func TestBxDFType_Matches(t *testing.T) {
+	diffuse := mymath.BSDFReflection | mymath.BSDFDiffuse
+	assert.True(t, diffuse.Matches(mymath.BSDFAll))
+	assert.True(t, diffuse.Matches(diffuse))
+	assert.False(t, diffuse.Matches(mymath.BSDFReflection))
+	assert.False(t, diffuse.Matches(mymath.BSDFAll&^mymath.BSDFReflection))
+}
+
+func TestMicrofacetReflection(t *testing.T) {
+	rng := rand.New(rand.NewSource(14))
+
+	for _, sampleVisibleArea := range []bool{false, true} {
+		for name, d := range microfacetDistributions(sampleVisibleArea) {
+			bxdf := mymath.NewMicrofacetReflection(spectrum.NewSpectrum(1), d, mymath.FresnelNoOp{})
+
+			// reciprocity
+			for i := 0; i < 100; i++ {
+				wo, wi := randomDirection(rng), randomDirection(rng)
+				InDeltaSpectrum(t, bxdf.F(wo, wi), bxdf.F(wi, wo), equalDelta, name)
+			}
+
+			for _, wo := range []mymath.Vector3{mymath.NewVector3(0, 0, 1), mymath.NewVector3(0.6, 0, 0.8), mymath.NewVector3(0, -0.6, -0.8)} {
+				assertSampleF(t, bxdf, wo)
+				assert.LessOrEqual(t, integratePdf(bxdf, wo, 100000), 1.02, name)
+
+				// energy conservation of the perfect reflector, only the masking loses energy
+				rho := bxdf.Rho(wo, randomSamples(rng, 10000))
+				assert.LessOrEqual(t, rho.MaxComponentValue(), 1.01, name)
+				assert.Greater(t, rho.MaxComponentValue(), 0.6, name)
+			}
+		}
+	}
+}
+
+func TestMicrofacetTransmission(t *testing.T) {
+	rng := rand.New(rand.NewSource(15))
+
+	for _, sampleVisibleArea := range []bool{false, true} {
+		for name, d := range microfacetDistributions(sampleVisibleArea) {
+			reflection := mymath.NewMicrofacetReflection(spectrum.NewSpectrum(1), d, mymath.NewFresnelDielectric(1, 1.5))
+			transmission := mymath.NewMicrofacetTransmission(spectrum.NewSpectrum(1), d, 1, 1.5, mymath.Importance)
+
+			for _, wo := range []mymath.Vector3{mymath.NewVector3(0.6, 0, 0.8), mymath.NewVector3(0, -0.3, -math.Sqrt(0.91))} {
+				assertSampleF(t, transmission, wo)
+				assert.LessOrEqual(t, integratePdf(transmission, wo, 100000), 1.02, name)
+
+				wi, _, pdf, _ := transmission.SampleF(wo, mymath.NewPoint2(0.5, 0.5))
+				assert.Greater(t, pdf, 0.0)
+				assert.False(t, mymath.SameHemisphere(wo, wi))
+
+				// reflected and transmitted energy of the rough dielectric
+				samples := randomSamples(rng, 10000)
+				rho := reflection.Rho(wo, samples).Add(transmission.Rho(wo, samples))
+				assert.LessOrEqual(t, rho.MaxComponentValue(), 1.01, name)
+				assert.Greater(t, rho.MaxComponentValue(), 0.6, name)
+			}
+
+			// radiance is scaled by the squared ratio of the indices of refraction
+			radiance := mymath.NewMicrofacetTransmission(spectrum.NewSpectrum(1), d, 1, 1.5, mymath.Radiance)
+			wo, wi := mymath.NewVector3(0.6, 0, 0.8), mymath.NewVector3(-0.3, 0.1, -0.9).Normalize()
+			InDeltaSpectrum(t, transmission.F(wo, wi).Divide(1.5*1.5), radiance.F(wo, wi), equalDelta, name)
+		}
+	}
+}
+
+func TestFresnelBlend(t *testing.T) {
+	rng := rand.New(rand.NewSource(16))
+
+	for name, d := range microfacetDistributions(true) {
+		bxdf := mymath.NewFresnelBlend(spectrum.NewSpectrum(0.5), spectrum.NewSpectrum(0.04), d)
+
+		// reciprocity
+		for i := 0; i < 100; i++ {
+			wo, wi := randomDirection(rng), randomDirection(rng)
+			InDeltaSpectrum(t, bxdf.F(wo, wi), bxdf.F(wi, wo), equalDelta, name)
+		}
+
+		for _, wo := range []mymath.Vector3{mymath.NewVector3(0, 0, 1), mymath.NewVector3(0.6, 0, 0.8), mymath.NewVector3(0, -0.6, -0.8)} {
+			assertSampleF(t, bxdf, wo)
+			assert.LessOrEqual(t, integratePdf(bxdf, wo, 100000), 1.02, name)
+
+			rho := bxdf.Rho(wo, randomSamples(rng, 10000))
+			assert.LessOrEqual(t, rho.MaxComponentValue(), 1.0, name)
+		}
+	}
+
+	// Schlick's approximation
+	bxdf := mymath.NewFresnelBlend(spectrum.NewSpectrum(0.5), spectrum.NewSpectrum(0.04), microfacetDistributions(true)["Beckmann isotropic"])
+	InDeltaSpectrum(t, spectrum.NewSpectrum(0.04), bxdf.SchlickFresnel(1), equalDelta)
+	InDeltaSpectrum(t, spectrum.NewSpectrum(1), bxdf.SchlickFresnel(0), equalDelta)
+}
@@ -0,0 +1,263 @@
+package mymath
+
+import (
+	"math"
+)
+
+// Cone
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/cone.h
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/cone.cpp
+type Cone struct {
+	Shape
+	Radius,
+	Height,
+	PhiMax float64
+}
+
+func NewCone(height, radius, phiMax float64, objectToWorld, worldToObject *Transform, reverseOrientation bool) *Cone {
+	return &Cone{
+		NewShape(objectToWorld, worldToObject, reverseOrientation),
+		radius,
+		height,
+		Radians(Clamp(phiMax, 0, 360)),
+	}
+}
+
+func (cone Cone) ObjectBound() Bounds3 {
+	return NewBounds3(
+		NewPoint3(-cone.Radius, -cone.Radius, 0),
+		NewPoint3(cone.Radius, cone.Radius, cone.Height))
+}
+
+// Intersect finds ray-shape collision point and its metadata
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/cone.cpp#L57
+func (cone Cone) Intersect(r Ray, _ bool) (bool, float64, *SurfaceInteraction) {
+	// Transform Ray to object space
+	ray, oErr, dErr := cone.WorldToObject.ApplyRError(r)
+
+	// Compute quadratic cone coefficients
+
+	// Initialize EFloat ray coordinate values
+	ox := NewEFloatErr(ray.O.X, oErr.X)
+	oy := NewEFloatErr(ray.O.Y, oErr.Y)
+	oz := NewEFloatErr(ray.O.Z, oErr.Z)
+
+	dx := NewEFloatErr(ray.D.X, dErr.X)
+	dy := NewEFloatErr(ray.D.Y, dErr.Y)
+	dz := NewEFloatErr(ray.D.Z, dErr.Z)
+
+	k := NewEFloat(cone.Radius).Divide(NewEFloat(cone.Height))
+	k = k.Multiply(k)
+	ozh := oz.Subtract(NewEFloat(cone.Height))
+
+	a := dx.Multiply(dx).Add(dy.Multiply(dy)).Subtract(k.Multiply(dz).Multiply(dz))
+	b := NewEFloat(2).Multiply(dx.Multiply(ox).Add(dy.Multiply(oy)).Subtract(k.Multiply(dz).Multiply(ozh)))
+	c := ox.Multiply(ox).Add(oy.Multiply(oy)).Subtract(k.Multiply(ozh).Multiply(ozh))
+
+	// Solve quadratic equation for t values
+	ok, t0, t1 := Quadratic(a, b, c)
+
+	if !ok {
+		return false, 0, nil
+	}
+
+	// Check quadratic shape t0 and t1 for nearest intersection
+	if t0.High > ray.TMax || t1.Low <= 0 {
+		return false, 0, nil
+	}
+
+	tShapeHit := t0
+	if tShapeHit.Low <= 0 {
+		tShapeHit = t1
+		if tShapeHit.High > ray.TMax {
+			return false, 0, nil
+		}
+	}
+
+	// Compute cone inverse mapping
+	pHit := ray.Apply(tShapeHit.V)
+
+	phi := math.Atan2(pHit.Y, pHit.X)
+	if phi < 0 {
+		phi += 2 * math.Pi
+	}
+
+	// Test cone intersection against clipping parameters
+	if pHit.Z < 0 || pHit.Z > cone.Height || phi > cone.PhiMax {
+		if tShapeHit == t1 {
+			return false, 0, nil
+		}
+
+		tShapeHit = t1
+		if t1.High > ray.TMax {
+			return false, 0, nil
+		}
+
+		// Compute cone inverse mapping
+		pHit = ray.Apply(tShapeHit.V)
+
+		phi = math.Atan2(pHit.Y, pHit.X)
+		if phi < 0 {
+			phi += 2 * math.Pi
+		}
+
+		if pHit.Z < 0 || pHit.Z > cone.Height || phi > cone.PhiMax {
+			return false, 0, nil
+		}
+	}
+
+	// Find parametric representation of cone hit
+	u := phi / cone.PhiMax
+	v := pHit.Z / cone.Height
+
+	// Compute cone dpdu and dpdv
+	dpdu := NewVector3(-cone.PhiMax*pHit.Y, cone.PhiMax*pHit.X, 0)
+	dpdv := NewVector3(-pHit.X/(1-v), -pHit.Y/(1-v), cone.Height)
+
+	// Compute cone dndu and dndv
+	d2Pduu := NewVector3(pHit.X, pHit.Y, 0).Multiply(-cone.PhiMax * cone.PhiMax)
+	d2Pduv := NewVector3(pHit.Y, -pHit.X, 0).Multiply(cone.PhiMax / (1 - v))
+	d2Pdvv := NewVector3(0, 0, 0)
+
+	// Compute coefficients for fundamental forms
+	E := dpdu.Dot(dpdu)
+	F := dpdu.Dot(dpdv)
+	G := dpdv.Dot(dpdv)
+	N := dpdu.Cross(dpdv).Normalize()
+	e := N.Dot(d2Pduu)
+	f := N.Dot(d2Pduv)
+	g := N.Dot(d2Pdvv)
+
+	// Compute dndu and dndv from fundamental form coefficients
+	invEGF2 := 1 / (E*G - F*F)
+	dndu := NewNormal3V(dpdu.Multiply((f*F - e*G) * invEGF2).Add(dpdv.Multiply((e*F - f*E) * invEGF2)))
+	dndv := NewNormal3V(dpdu.Multiply((g*F - f*G) * invEGF2).Add(dpdv.Multiply((f*F - g*E) * invEGF2)))
+
+	// Compute error bounds for cone intersection
+
+	// Compute error bounds for intersection computed with ray equation
+	px := ox.Add(tShapeHit.Multiply(dx))
+	py := oy.Add(tShapeHit.Multiply(dy))
+	pz := oz.Add(tShapeHit.Multiply(dz))
+	pError := NewVector3(px.GetAbsoluteError(), py.GetAbsoluteError(), pz.GetAbsoluteError())
+
+	// Initialize _SurfaceInteraction_ from parametric information
+	si := NewSurfaceInteraction(
+		pHit,
+		pError,
+		NewPoint2(u, v),
+		ray.D.Negate(),
+		dpdu,
+		dpdv,
+		dndu,
+		dndv,
+		float64(ray.Time),
+		&cone.Shape)
+
+	isect := cone.ObjectToWorld.ApplySI(&si)
+
+	// Update _tHit_ for quadric intersection
+	return true, tShapeHit.V, isect
+}
+
+// IntersectP finds if ray collides with this shape
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/cone.cpp#L161
+func (cone Cone) IntersectP(_ Intersecter, r Ray, _ bool) bool {
+	// Transform Ray to object space
+	ray, oErr, dErr := cone.WorldToObject.ApplyRError(r)
+
+	// Compute quadratic cone coefficients
+
+	// Initialize EFloat ray coordinate values
+	ox := NewEFloatErr(ray.O.X, oErr.X)
+	oy := NewEFloatErr(ray.O.Y, oErr.Y)
+	oz := NewEFloatErr(ray.O.Z, oErr.Z)
+
+	dx := NewEFloatErr(ray.D.X, dErr.X)
+	dy := NewEFloatErr(ray.D.Y, dErr.Y)
+	dz := NewEFloatErr(ray.D.Z, dErr.Z)
+
+	k := NewEFloat(cone.Radius).Divide(NewEFloat(cone.Height))
+	k = k.Multiply(k)
+	ozh := oz.Subtract(NewEFloat(cone.Height))
+
+	a := dx.Multiply(dx).Add(dy.Multiply(dy)).Subtract(k.Multiply(dz).Multiply(dz))
+	b := NewEFloat(2).Multiply(dx.Multiply(ox).Add(dy.Multiply(oy)).Subtract(k.Multiply(dz).Multiply(ozh)))
+	c := ox.Multiply(ox).Add(oy.Multiply(oy)).Subtract(k.Multiply(ozh).Multiply(ozh))
+
+	// Solve quadratic equation for t values
+	ok, t0, t1 := Quadratic(a, b, c)
+
+	if !ok {
+		return false
+	}
+
+	// Check quadratic shape t0 and t1 for nearest intersection
+	if t0.High > ray.TMax || t1.Low <= 0 {
+		return false
+	}
+
+	tShapeHit := t0
+	if tShapeHit.Low <= 0 {
+		tShapeHit = t1
+		if tShapeHit.High > ray.TMax {
+			return false
+		}
+	}
+
+	// Compute cone inverse mapping
+	pHit := ray.Apply(tShapeHit.V)
+
+	phi := math.Atan2(pHit.Y, pHit.X)
+	if phi < 0 {
+		phi += 2 * math.Pi
+	}
+
+	// Test cone intersection against clipping parameters
+	if pHit.Z < 0 || pHit.Z > cone.Height || phi > cone.PhiMax {
+		if tShapeHit == t1 {
+			return false
+		}
+
+		tShapeHit = t1
+		if t1.High > ray.TMax {
+			return false
+		}
+
+		// Compute cone inverse mapping
+		pHit = ray.Apply(tShapeHit.V)
+
+		phi = math.Atan2(pHit.Y, pHit.X)
+		if phi < 0 {
+			phi += 2 * math.Pi
+		}
+
+		if pHit.Z < 0 || pHit.Z > cone.Height || phi > cone.PhiMax {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Area see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/cone.cpp#L238
+func (cone Cone) Area() float64 {
+	return cone.Radius * math.Sqrt(cone.Height*cone.Height+cone.Radius*cone.Radius) * cone.PhiMax / 2
+}
+
+// Sample is not implemented, the same as in pbrt
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/cone.cpp
+func (cone Cone) Sample(_ Point2) Interaction {
+	panic("Cone.Sample not implemented")
+}
+
+func (cone Cone) SampleRef(_ Interaction, u Point2) Interaction {
+	return cone.Sample(u)
+}
+
+func (cone Cone) Pdf(ref Interaction, wi Vector3) float64 {
+	return cone.Shape.pdf(cone, ref, wi)
+}
@@ -0,0 +1,136 @@
+package mymath_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"pbrt-go/material"
+	"pbrt-go/mymath"
+	"testing"
+)
+
+func TestNewCone(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	c := mymath.NewCone(2, 1, 180, &identity, &identity, false)
+
+	assert.Equal(t, 1.0, c.Radius)
+	assert.Equal(t, 2.0, c.Height)
+	assert.InDelta(t, math.Pi, c.PhiMax, equalDelta)
+}
+
+func TestCone_ObjectBound(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	c := mymath.NewCone(2, 1, 360, &identity, &identity, false)
+
+	assert.Equal(
+		t,
+		mymath.NewBounds3(mymath.NewPoint3(-1, -1, 0), mymath.NewPoint3(1, 1, 2)),
+		c.ObjectBound())
+}
+
+func TestCone_Intersect(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	c := mymath.NewCone(2, 1, 360, &identity, &identity, false)
+
+	ray := mymath.NewRay(
+		mymath.NewPoint3(-5, 0, 1),
+		mymath.NewVector3(1, 0, 0),
+		50,
+		0,
+		material.Medium{})
+
+	ok, tHit, si := c.Intersect(ray, false)
+	assert.Equal(t, true, ok)
+	assert.InDelta(t, 4.5, tHit, equalDelta)
+
+	InDeltaPoint3(t, mymath.NewPoint3(-0.5, 0, 1), si.Interaction.P)
+	assert.InDelta(t, 0.5, si.Uv.X, equalDelta)
+	assert.InDelta(t, 0.5, si.Uv.Y, equalDelta)
+	assert.Equal(t, mymath.NewVector3(-1, 0, 0), si.Interaction.Wo)
+	InDeltaNormal3(t, mymath.NewNormal3(-2/math.Sqrt(5), 0, 1/math.Sqrt(5)), si.Interaction.N)
+
+	InDeltaVector3(t, mymath.NewVector3(0, -math.Pi, 0), si.Dpdu)
+	InDeltaVector3(t, mymath.NewVector3(1, 0, 2), si.Dpdv)
+
+	// normal turns around the axis together with u and does not change along the line v
+	InDeltaNormal3(t, mymath.NewNormal3(0, -4*math.Pi/math.Sqrt(5), 0), si.Dndu)
+	InDeltaNormal3(t, mymath.NewNormal3(0, 0, 0), si.Dndv)
+}
+
+func TestCone_Intersect_phiMax(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	// quarter of the cone, the first hit at phi = pi is clipped away
+	c := mymath.NewCone(2, 1, 90, &identity, &identity, false)
+
+	ray := mymath.NewRay(
+		mymath.NewPoint3(-5, 0, 1),
+		mymath.NewVector3(1, 0, 0),
+		50,
+		0,
+		material.Medium{})
+
+	ok, tHit, si := c.Intersect(ray, false)
+	assert.Equal(t, true, ok)
+	assert.InDelta(t, 5.5, tHit, equalDelta)
+	InDeltaPoint3(t, mymath.NewPoint3(0.5, 0, 1), si.Interaction.P)
+	assert.InDelta(t, 0.0, si.Uv.X, equalDelta)
+
+	// the ray going through the clipped part only
+	ray = mymath.NewRay(
+		mymath.NewPoint3(-0.3, -5, 1),
+		mymath.NewVector3(0, 1, 0),
+		50,
+		0,
+		material.Medium{})
+
+	ok, _, _ = c.Intersect(ray, false)
+	assert.Equal(t, false, ok)
+	assert.Equal(t, false, c.IntersectP(c, ray, false))
+}
+
+func TestCone_Intersect_transformed(t *testing.T) {
+	transform := mymath.NewTransformTranslate(mymath.NewVector3(3, -2, 1)).ApplyT(mymath.NewTransformRotateY(0.7))
+	transformInv := transform.Inverse()
+
+	c := mymath.NewCone(2, 1, 360, &transform, &transformInv, false)
+
+	o := transform.ApplyP(mymath.NewPoint3(-5, 0.1, 1))
+	target := transform.ApplyP(mymath.NewPoint3(-math.Sqrt(0.25-0.01), 0.1, 1))
+
+	ray := mymath.NewRay(o, target.SubtractP(o), 50, 0, material.Medium{})
+
+	ok, tHit, si := c.Intersect(ray, false)
+	assert.Equal(t, true, ok)
+	assert.InDelta(t, 1.0, tHit, equalDelta)
+
+	// error bounds contain the exact hit point
+	diff := si.Interaction.P.SubtractP(target).Abs()
+	assert.LessOrEqual(t, diff.X, si.Interaction.PError.X+1e-6)
+	assert.LessOrEqual(t, diff.Y, si.Interaction.PError.Y+1e-6)
+	assert.LessOrEqual(t, diff.Z, si.Interaction.PError.Z+1e-6)
+}
+
+func TestCone_IntersectP(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	c := mymath.NewCone(2, 1, 360, &identity, &identity, false)
+
+	hit := mymath.NewRay(mymath.NewPoint3(-5, 0, 1), mymath.NewVector3(1, 0, 0), 50, 0, material.Medium{})
+	miss := mymath.NewRay(mymath.NewPoint3(-5, 0, 2.5), mymath.NewVector3(1, 0, 0), 50, 0, material.Medium{})
+
+	assert.Equal(t, true, c.IntersectP(c, hit, false))
+	assert.Equal(t, false, c.IntersectP(c, miss, false))
+}
+
+func TestCone_Area(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	c := mymath.NewCone(2, 1, 360, &identity, &identity, false)
+	assert.InDelta(t, math.Pi*math.Sqrt(5), c.Area(), equalDelta)
+
+	c = mymath.NewCone(2, 1, 90, &identity, &identity, false)
+	assert.InDelta(t, math.Pi*math.Sqrt(5)/4, c.Area(), equalDelta)
+}
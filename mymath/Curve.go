@@ -0,0 +1,462 @@
+package mymath
+
+import (
+	"math"
+)
+
+// CurveType see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/curve.h#L48
+type CurveType int
+
+const (
+	// CurveFlat is always facing the ray
+	CurveFlat CurveType = iota
+	// CurveCylinder is flat curve shaded as if it was cylinder
+	CurveCylinder
+	// CurveRibbon is oriented by the normals interpolated between the curve endpoints
+	CurveRibbon
+)
+
+// CurveCommon holds the control points data shared by all segments of the curve
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/curve.h#L51
+type CurveCommon struct {
+	Type                           CurveType
+	CpObj                          [4]Point3
+	Width                          [2]float64
+	N                              [2]Normal3
+	NormalAngle, InvSinNormalAngle float64
+}
+
+// NewCurveCommon see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/curve.cpp#L45
+func NewCurveCommon(c [4]Point3, width0, width1 float64, curveType CurveType, norm []Normal3) *CurveCommon {
+	common := &CurveCommon{
+		Type:  curveType,
+		CpObj: c,
+		Width: [2]float64{width0, width1},
+	}
+
+	if norm != nil {
+		common.N[0] = norm[0].Normalize()
+		common.N[1] = norm[1].Normalize()
+		common.NormalAngle = math.Acos(Clamp(common.N[0].Dot(common.N[1]), 0, 1))
+		common.InvSinNormalAngle = 1 / math.Sin(common.NormalAngle)
+	}
+
+	return common
+}
+
+// Curve is a segment [UMin, UMax] of the cubic Bezier curve
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/curve.h
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/curve.cpp
+type Curve struct {
+	Shape
+	Common     *CurveCommon
+	UMin, UMax float64
+}
+
+func NewCurve(objectToWorld, worldToObject *Transform, reverseOrientation bool, common *CurveCommon, uMin, uMax float64) *Curve {
+	return &Curve{
+		NewShape(objectToWorld, worldToObject, reverseOrientation),
+		common,
+		uMin,
+		uMax,
+	}
+}
+
+// CreateCurve splits the curve into 2^splitDepth segments sharing the same control points
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/curve.cpp#L58
+func CreateCurve(objectToWorld, worldToObject *Transform, reverseOrientation bool, c [4]Point3, w0, w1 float64, curveType CurveType, norm []Normal3, splitDepth int) []IShape {
+	common := NewCurveCommon(c, w0, w1, curveType, norm)
+
+	nSegments := 1 << splitDepth
+	segments := make([]IShape, nSegments)
+
+	for i := 0; i < nSegments; i++ {
+		uMin := float64(i) / float64(nSegments)
+		uMax := float64(i+1) / float64(nSegments)
+		segments[i] = NewCurve(objectToWorld, worldToObject, reverseOrientation, common, uMin, uMax)
+	}
+
+	return segments
+}
+
+// BlossomBezier see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/curve.cpp#L74
+func BlossomBezier(p [4]Point3, u0, u1, u2 float64) Point3 {
+	a := [3]Point3{p[0].Lerp(u0, p[1]), p[1].Lerp(u0, p[2]), p[2].Lerp(u0, p[3])}
+	b := [2]Point3{a[0].Lerp(u1, a[1]), a[1].Lerp(u1, a[2])}
+
+	return b[0].Lerp(u2, b[1])
+}
+
+// SubdivideBezier splits the curve in half, the halves share the middle control point
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/curve.cpp#L84
+func SubdivideBezier(cp [4]Point3) [7]Point3 {
+	return [7]Point3{
+		cp[0],
+		cp[0].AddP(cp[1]).Multiply(1.0 / 2),
+		cp[0].AddP(cp[1].Multiply(2)).AddP(cp[2]).Multiply(1.0 / 4),
+		cp[0].AddP(cp[1].Multiply(3)).AddP(cp[2].Multiply(3)).AddP(cp[3]).Multiply(1.0 / 8),
+		cp[1].AddP(cp[2].Multiply(2)).AddP(cp[3]).Multiply(1.0 / 4),
+		cp[2].AddP(cp[3]).Multiply(1.0 / 2),
+		cp[3],
+	}
+}
+
+// EvalBezier returns the point on curve and its derivative
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/curve.cpp#L94
+func EvalBezier(cp [4]Point3, u float64) (Point3, Vector3) {
+	cp1 := [3]Point3{cp[0].Lerp(u, cp[1]), cp[1].Lerp(u, cp[2]), cp[2].Lerp(u, cp[3])}
+	cp2 := [2]Point3{cp1[0].Lerp(u, cp1[1]), cp1[1].Lerp(u, cp1[2])}
+
+	var deriv Vector3
+	if cp2[1].SubtractP(cp2[0]).LengthSq() > 0 {
+		deriv = cp2[1].SubtractP(cp2[0]).Multiply(3)
+	} else {
+		// For a cubic Bezier, if the first three control points (say) are
+		// coincident, then the derivative of the curve is legitimately (0,0,0)
+		// at u=0.  This is problematic for us, though, since we'd like to be
+		// able to compute a surface normal there.  In that case, just punt and
+		// take the difference between the first and last control points, which
+		// ain't great, but will hopefully do.
+		deriv = cp[3].SubtractP(cp[0])
+	}
+
+	return cp2[0].Lerp(u, cp2[1]), deriv
+}
+
+// segmentControlPoints computes object-space control points for curve segment
+func (curve Curve) segmentControlPoints() [4]Point3 {
+	return [4]Point3{
+		BlossomBezier(curve.Common.CpObj, curve.UMin, curve.UMin, curve.UMin),
+		BlossomBezier(curve.Common.CpObj, curve.UMin, curve.UMin, curve.UMax),
+		BlossomBezier(curve.Common.CpObj, curve.UMin, curve.UMax, curve.UMax),
+		BlossomBezier(curve.Common.CpObj, curve.UMax, curve.UMax, curve.UMax),
+	}
+}
+
+// width returns interpolated curve width at u
+func (curve Curve) width(u float64) float64 {
+	return Lerp(u, curve.Common.Width[0], curve.Common.Width[1])
+}
+
+// ObjectBound see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/curve.cpp#L116
+func (curve Curve) ObjectBound() Bounds3 {
+	// Compute object-space control points for curve segment, _cpObj_
+	cpObj := curve.segmentControlPoints()
+
+	b := NewBounds3(cpObj[0], cpObj[1]).UnionB(NewBounds3(cpObj[2], cpObj[3]))
+	return b.Expand(math.Max(curve.width(curve.UMin), curve.width(curve.UMax)) * 0.5)
+}
+
+// Intersect finds ray-shape collision point and its metadata
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/curve.cpp#L130
+func (curve Curve) Intersect(r Ray, _ bool) (bool, float64, *SurfaceInteraction) {
+	return curve.intersect(r, true)
+}
+
+// IntersectP finds if ray collides with this shape
+func (curve Curve) IntersectP(_ Intersecter, r Ray, _ bool) bool {
+	ok, _, _ := curve.intersect(r, false)
+	return ok
+}
+
+// intersect computes the surface interaction only when requested by computeInteraction, otherwise
+// it returns at the first hit found
+func (curve Curve) intersect(r Ray, computeInteraction bool) (bool, float64, *SurfaceInteraction) {
+	// Transform Ray to object space
+	ray, _, _ := curve.WorldToObject.ApplyRError(r)
+
+	// Compute object-space control points for curve segment, _cpObj_
+	cpObj := curve.segmentControlPoints()
+
+	// Project curve control points to plane perpendicular to ray
+
+	// Be careful to set the "up" direction passed to LookAt() to equal the
+	// vector from the first to the last control points.  In turn, this
+	// helps orient the curve to be roughly parallel to the x axis in the
+	// ray coordinate system.
+	//
+	// In turn (especially for curves that are approaching stright lines),
+	// we get curve bounds with minimal extent in y, which in turn lets us
+	// early out more quickly in recursiveIntersect().
+	dx := ray.D.Cross(cpObj[3].SubtractP(cpObj[0]))
+	if dx.LengthSq() == 0 {
+		// If the ray and the vector between the first and last control
+		// points are parallel, dx will be zero.  Generate an arbitrary xy
+		// orientation for the ray coordinate system so that intersection
+		// tests can proceeed in this unusual case.
+		dx, _ = ray.D.CoordinateSystem()
+	}
+
+	objectToRay, err := NewTransformLookAt(ray.O, ray.O.AddV(ray.D), dx)
+	if err != nil {
+		return false, 0, nil
+	}
+
+	cp := [4]Point3{
+		objectToRay.ApplyP(cpObj[0]),
+		objectToRay.ApplyP(cpObj[1]),
+		objectToRay.ApplyP(cpObj[2]),
+		objectToRay.ApplyP(cpObj[3]),
+	}
+
+	// Before going any further, see if the ray's bounding box intersects
+	// the curve's bounding box. We start with the y dimension, since the y
+	// extent is generally the smallest (and is often tiny) due to our
+	// careful orientation of the ray coordinate ysstem above.
+	maxWidth := math.Max(curve.width(curve.UMin), curve.width(curve.UMax))
+	rayLength := ray.D.Length()
+	zMax := rayLength * ray.TMax
+	if !overlapsRayBounds(cp[:], maxWidth, zMax) {
+		return false, 0, nil
+	}
+
+	// Compute refinement depth for curve, _maxDepth_
+	L0 := 0.0
+	for i := 0; i < 2; i++ {
+		L0 = math.Max(L0, math.Max(
+			math.Max(
+				math.Abs(cp[i].X-2*cp[i+1].X+cp[i+2].X),
+				math.Abs(cp[i].Y-2*cp[i+1].Y+cp[i+2].Y)),
+			math.Abs(cp[i].Z-2*cp[i+1].Z+cp[i+2].Z)))
+	}
+
+	// width / 20
+	eps := math.Max(curve.Common.Width[0], curve.Common.Width[1]) * .05
+
+	// Compute log base 4 by dividing log2 in half.
+	r0 := log2Nearest(1.41421356237*6*L0/(8*eps)) / 2
+	maxDepth := int(Clamp(float64(r0), 0, 10))
+
+	rayToObject := objectToRay.Inverse()
+	return curve.recursiveIntersect(ray, computeInteraction, cp, rayToObject, curve.UMin, curve.UMax, maxDepth)
+}
+
+// log2Nearest returns log2 of v rounded to the nearest integer, zero for v < 1
+//
+// see https://graphics.stanford.edu/~seander/bithacks.html#IntegerLog
+func log2Nearest(v float64) int {
+	if v < 1 {
+		return 0
+	}
+
+	bits := math.Float32bits(float32(v))
+
+	// With an additional add so get round-to-nearest rather than round down.
+	res := int(bits>>23) - 127
+	if bits&(1<<22) != 0 {
+		res++
+	}
+
+	return res
+}
+
+// overlapsRayBounds checks if the bounds of the curve control points expanded by half of the width
+// overlap the ray segment going from origin along z axis up to zMax
+func overlapsRayBounds(cp []Point3, maxWidth, zMax float64) bool {
+	// check y first, since it most commonly lets us exit out early.
+	if math.Max(math.Max(cp[0].Y, cp[1].Y), math.Max(cp[2].Y, cp[3].Y))+0.5*maxWidth < 0 ||
+		math.Min(math.Min(cp[0].Y, cp[1].Y), math.Min(cp[2].Y, cp[3].Y))-0.5*maxWidth > 0 {
+		return false
+	}
+
+	// Check for non-overlap in x.
+	if math.Max(math.Max(cp[0].X, cp[1].X), math.Max(cp[2].X, cp[3].X))+0.5*maxWidth < 0 ||
+		math.Min(math.Min(cp[0].X, cp[1].X), math.Min(cp[2].X, cp[3].X))-0.5*maxWidth > 0 {
+		return false
+	}
+
+	// Check for non-overlap in z.
+	if math.Max(math.Max(cp[0].Z, cp[1].Z), math.Max(cp[2].Z, cp[3].Z))+0.5*maxWidth < 0 ||
+		math.Min(math.Min(cp[0].Z, cp[1].Z), math.Min(cp[2].Z, cp[3].Z))-0.5*maxWidth > zMax {
+		return false
+	}
+
+	return true
+}
+
+// recursiveIntersect see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/curve.cpp#L212
+func (curve Curve) recursiveIntersect(ray Ray, computeInteraction bool, cp [4]Point3, rayToObject Transform, u0, u1 float64, depth int) (bool, float64, *SurfaceInteraction) {
+	rayLength := ray.D.Length()
+
+	if depth > 0 {
+		// Split curve segment into sub-segments and test for intersection
+		cpSplit := SubdivideBezier(cp)
+
+		// For each of the two segments, see if the ray's bounding box
+		// overlaps the segment before recursively checking for
+		// intersection with it.
+		hit := false
+		tHit := 0.0
+		var isect *SurfaceInteraction
+
+		u := [3]float64{u0, (u0 + u1) / 2, u1}
+		for seg := 0; seg < 2; seg++ {
+			// the 4 control points for the current segment.
+			cps := cpSplit[3*seg : 3*seg+4]
+
+			maxWidth := math.Max(curve.width(u[seg]), curve.width(u[seg+1]))
+			zMax := rayLength * ray.TMax
+			if !overlapsRayBounds(cps, maxWidth, zMax) {
+				continue
+			}
+
+			if ok, t, si := curve.recursiveIntersect(ray, computeInteraction, [4]Point3{cps[0], cps[1], cps[2], cps[3]}, rayToObject, u[seg], u[seg+1], depth-1); ok {
+				// If we found an intersection and this is a shadow ray,
+				// we can exit out immediately.
+				if !computeInteraction {
+					return true, 0, nil
+				}
+
+				// the second segment may only report closer hit
+				hit, tHit, isect = true, t, si
+				ray.TMax = t
+			}
+		}
+
+		return hit, tHit, isect
+	}
+
+	// Intersect ray with curve segment
+
+	// Test ray against segment endpoint boundaries
+
+	// Test sample point against tangent perpendicular at curve start
+	edge := (cp[1].Y-cp[0].Y)*-cp[0].Y + cp[0].X*(cp[0].X-cp[1].X)
+	if edge < 0 {
+		return false, 0, nil
+	}
+
+	// Test sample point against tangent perpendicular at curve end
+	edge = (cp[2].Y-cp[3].Y)*-cp[3].Y + cp[3].X*(cp[3].X-cp[2].X)
+	if edge < 0 {
+		return false, 0, nil
+	}
+
+	// Compute line w that gives minimum distance to sample point
+	segmentDirection := NewPoint2(cp[3].X-cp[0].X, cp[3].Y-cp[0].Y)
+	denom := segmentDirection.X*segmentDirection.X + segmentDirection.Y*segmentDirection.Y
+	if denom == 0 {
+		return false, 0, nil
+	}
+	w := (-cp[0].X*segmentDirection.X - cp[0].Y*segmentDirection.Y) / denom
+
+	// Compute u coordinate of curve intersection point and hitWidth
+	u := Clamp(Lerp(w, u0, u1), u0, u1)
+	hitWidth := curve.width(u)
+	var nHit Normal3
+	if curve.Common.Type == CurveRibbon {
+		// Scale hitWidth based on ribbon orientation
+		if curve.Common.NormalAngle == 0 {
+			// parallel normals, the spherical interpolation would divide by zero
+			nHit = curve.Common.N[0]
+		} else {
+			sin0 := math.Sin((1-u)*curve.Common.NormalAngle) * curve.Common.InvSinNormalAngle
+			sin1 := math.Sin(u*curve.Common.NormalAngle) * curve.Common.InvSinNormalAngle
+			nHit = curve.Common.N[0].Multiply(sin0).Add(curve.Common.N[1].Multiply(sin1))
+		}
+		hitWidth *= math.Abs(NewVector3(nHit.X, nHit.Y, nHit.Z).Dot(ray.D)) / rayLength
+	}
+
+	// Test intersection point against curve width
+	pc, dpcdw := EvalBezier(cp, Clamp(w, 0, 1))
+	ptCurveDist2 := pc.X*pc.X + pc.Y*pc.Y
+	if ptCurveDist2 > hitWidth*hitWidth*.25 {
+		return false, 0, nil
+	}
+
+	zMax := rayLength * ray.TMax
+	if pc.Z < 0 || pc.Z > zMax {
+		return false, 0, nil
+	}
+
+	// Compute v coordinate of curve intersection point
+	ptCurveDist := math.Sqrt(ptCurveDist2)
+	edgeFunc := dpcdw.X*-pc.Y + pc.X*dpcdw.Y
+	v := 0.5 - ptCurveDist/hitWidth
+	if edgeFunc > 0 {
+		v = 0.5 + ptCurveDist/hitWidth
+	}
+
+	// FIXME: this tHit isn't quite right for ribbons...
+	tHit := pc.Z / rayLength
+
+	if !computeInteraction {
+		return true, tHit, nil
+	}
+
+	// Compute hit t and partial derivatives for curve intersection
+
+	// Compute error bounds for curve intersection
+	pError := NewVector3(2*hitWidth, 2*hitWidth, 2*hitWidth)
+
+	// Compute dpdu and dpdv for curve intersection
+	_, dpdu := EvalBezier(curve.Common.CpObj, u)
+
+	var dpdv Vector3
+	if curve.Common.Type == CurveRibbon {
+		dpdv = NewVector3(nHit.X, nHit.Y, nHit.Z).Cross(dpdu).Normalize().Multiply(hitWidth)
+	} else {
+		// Compute curve dpdv for flat and cylinder curves
+		dpduPlane := rayToObject.Inverse().ApplyV(dpdu)
+		dpdvPlane := NewVector3(-dpduPlane.Y, dpduPlane.X, 0).Normalize().Multiply(hitWidth)
+		if curve.Common.Type == CurveCylinder {
+			// Rotate dpdvPlane to give cylindrical appearance
+			theta := Lerp(v, -90, 90)
+			rot := NewTransformRotate(Radians(-theta), dpduPlane)
+			dpdvPlane = rot.ApplyV(dpdvPlane)
+		}
+		dpdv = rayToObject.ApplyV(dpdvPlane)
+	}
+
+	si := NewSurfaceInteraction(
+		ray.Apply(tHit),
+		pError,
+		NewPoint2(u, v),
+		ray.D.Negate(),
+		dpdu,
+		dpdv,
+		NewNormal3(0, 0, 0),
+		NewNormal3(0, 0, 0),
+		float64(ray.Time),
+		&curve.Shape)
+
+	isect := curve.ObjectToWorld.ApplySI(&si)
+
+	return true, tHit, isect
+}
+
+// Area see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/curve.cpp#L361
+func (curve Curve) Area() float64 {
+	// Compute object-space control points for curve segment, _cpObj_
+	cpObj := curve.segmentControlPoints()
+
+	avgWidth := (curve.width(curve.UMin) + curve.width(curve.UMax)) * 0.5
+
+	approxLength := 0.0
+	for i := 0; i < 3; i++ {
+		approxLength += cpObj[i].Distance(cpObj[i+1])
+	}
+
+	return approxLength * avgWidth
+}
+
+// Sample is not implemented, the same as in pbrt
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/curve.cpp
+func (curve Curve) Sample(_ Point2) Interaction {
+	panic("Curve.Sample not implemented")
+}
+
+func (curve Curve) SampleRef(_ Interaction, u Point2) Interaction {
+	return curve.Sample(u)
+}
+
+func (curve Curve) Pdf(ref Interaction, wi Vector3) float64 {
+	return curve.Shape.pdf(curve, ref, wi)
+}
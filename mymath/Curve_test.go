@@ -0,0 +1,244 @@
+package mymath_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"pbrt-go/material"
+	"pbrt-go/mymath"
+	"testing"
+)
+
+// straight curve along x axis with uniform parametrization u = (x + 1) / 2
+var straightCurve = [4]mymath.Point3{
+	mymath.NewPoint3(-1, 0, 0),
+	mymath.NewPoint3(-1.0/3, 0, 0),
+	mymath.NewPoint3(1.0/3, 0, 0),
+	mymath.NewPoint3(1, 0, 0),
+}
+
+var bentCurve = [4]mymath.Point3{
+	mymath.NewPoint3(-1, 0, 0),
+	mymath.NewPoint3(-0.5, 1, 0.2),
+	mymath.NewPoint3(0.5, -1, -0.2),
+	mymath.NewPoint3(1, 0.5, 0),
+}
+
+func newDownRay(x, y float64) mymath.Ray {
+	return mymath.NewRay(mymath.NewPoint3(x, y, 5), mymath.NewVector3(0, 0, -1), 50, 0, material.Medium{})
+}
+
+func TestCreateCurve(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	segments := mymath.CreateCurve(&identity, &identity, false, straightCurve, 0.1, 0.2, mymath.CurveFlat, nil, 2)
+	assert.Len(t, segments, 4)
+
+	first := segments[0].(*mymath.Curve)
+	last := segments[3].(*mymath.Curve)
+
+	assert.Same(t, first.Common, last.Common)
+	assert.Equal(t, 0.0, first.UMin)
+	assert.Equal(t, 0.25, first.UMax)
+	assert.Equal(t, 0.75, last.UMin)
+	assert.Equal(t, 1.0, last.UMax)
+}
+
+func TestNewCurveCommon_normals(t *testing.T) {
+	common := mymath.NewCurveCommon(straightCurve, 0.1, 0.1, mymath.CurveRibbon,
+		[]mymath.Normal3{mymath.NewNormal3(0, 0, 2), mymath.NewNormal3(0, 1, 1)})
+
+	InDeltaNormal3(t, mymath.NewNormal3(0, 0, 1), common.N[0])
+	InDeltaNormal3(t, mymath.NewNormal3(0, 1/math.Sqrt2, 1/math.Sqrt2), common.N[1])
+	assert.InDelta(t, math.Pi/4, common.NormalAngle, equalDelta)
+	assert.InDelta(t, math.Sqrt2, common.InvSinNormalAngle, equalDelta)
+}
+
+func TestBezier(t *testing.T) {
+	split := mymath.SubdivideBezier(bentCurve)
+	firstHalf := [4]mymath.Point3{split[0], split[1], split[2], split[3]}
+	secondHalf := [4]mymath.Point3{split[3], split[4], split[5], split[6]}
+
+	for _, u := range []float64{0, 0.2, 0.5, 0.7, 1} {
+		p, _ := mymath.EvalBezier(bentCurve, u)
+
+		InDeltaPoint3(t, p, mymath.BlossomBezier(bentCurve, u, u, u))
+
+		if u <= 0.5 {
+			pHalf, _ := mymath.EvalBezier(firstHalf, 2*u)
+			InDeltaPoint3(t, p, pHalf)
+		} else {
+			pHalf, _ := mymath.EvalBezier(secondHalf, 2*u-1)
+			InDeltaPoint3(t, p, pHalf)
+		}
+	}
+
+	// derivative compared to central difference
+	p0, _ := mymath.EvalBezier(bentCurve, 0.3-1e-6)
+	p1, _ := mymath.EvalBezier(bentCurve, 0.3+1e-6)
+	_, deriv := mymath.EvalBezier(bentCurve, 0.3)
+	InDeltaVector3(t, p1.SubtractP(p0).Divide(2e-6), deriv)
+}
+
+func TestCurve_ObjectBound(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	segments := mymath.CreateCurve(&identity, &identity, false, bentCurve, 0.1, 0.3, mymath.CurveFlat, nil, 1)
+
+	for _, segment := range segments {
+		curve := segment.(*mymath.Curve)
+		b := curve.ObjectBound()
+
+		for i := 0; i <= 10; i++ {
+			u := mymath.Lerp(float64(i)/10, curve.UMin, curve.UMax)
+			p, _ := mymath.EvalBezier(bentCurve, u)
+			assert.True(t, b.Inside(p), "point %v at u=%v not in %v", p, u, b)
+		}
+	}
+
+	straight := mymath.NewCurve(&identity, &identity, false, mymath.NewCurveCommon(straightCurve, 0.2, 0.2, mymath.CurveFlat, nil), 0, 1)
+	assert.Equal(t, mymath.NewBounds3(mymath.NewPoint3(-1.1, -0.1, -0.1), mymath.NewPoint3(1.1, 0.1, 0.1)), straight.ObjectBound())
+}
+
+func TestCurve_Intersect(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	curve := mymath.NewCurve(&identity, &identity, false, mymath.NewCurveCommon(straightCurve, 0.2, 0.2, mymath.CurveFlat, nil), 0, 1)
+
+	ok, tHit, si := curve.Intersect(newDownRay(0, 0), false)
+	assert.Equal(t, true, ok)
+	assert.InDelta(t, 5.0, tHit, equalDelta)
+	InDeltaPoint3(t, mymath.NewPoint3(0, 0, 0), si.Interaction.P)
+	assert.InDelta(t, 0.5, si.Uv.X, equalDelta)
+	assert.InDelta(t, 0.5, si.Uv.Y, equalDelta)
+
+	// flat curve faces the ray
+	assert.InDelta(t, 1.0, math.Abs(si.Interaction.N.Z), equalDelta)
+	InDeltaVector3(t, mymath.NewVector3(2, 0, 0), si.Dpdu)
+
+	// v goes across the width
+	ok, _, si = curve.Intersect(newDownRay(0.5, 0.05), false)
+	assert.Equal(t, true, ok)
+	assert.InDelta(t, 0.75, si.Uv.X, equalDelta)
+	assert.InDelta(t, 0.25, math.Abs(si.Uv.Y-0.5), equalDelta)
+
+	// outside of the width
+	ok, _, _ = curve.Intersect(newDownRay(0.5, 0.15), false)
+	assert.Equal(t, false, ok)
+	assert.Equal(t, false, curve.IntersectP(curve, newDownRay(0.5, 0.15), false))
+
+	// behind the end point
+	ok, _, _ = curve.Intersect(newDownRay(1.05, 0), false)
+	assert.Equal(t, false, ok)
+}
+
+func TestCurve_Intersect_width(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	// width grows from 0.1 to 0.5
+	curve := mymath.NewCurve(&identity, &identity, false, mymath.NewCurveCommon(straightCurve, 0.1, 0.5, mymath.CurveFlat, nil), 0, 1)
+
+	assert.Equal(t, false, curve.IntersectP(curve, newDownRay(-0.9, 0.1), false))
+	assert.Equal(t, true, curve.IntersectP(curve, newDownRay(0.9, 0.1), false))
+}
+
+// Rays aimed at the points on the bent curve must hit it, rays shifted further than the width must not
+func TestCurve_Intersect_bent(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	const width = 0.05
+	segments := mymath.CreateCurve(&identity, &identity, false, bentCurve, width, width, mymath.CurveFlat, nil, 2)
+
+	intersect := func(ray mymath.Ray) (bool, float64) {
+		hit := false
+		for _, s := range segments {
+			if ok, tHit, _ := s.Intersect(ray, false); ok {
+				ray.TMax = tHit
+				hit = true
+			}
+		}
+
+		return hit, ray.TMax
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		u := 0.02 + 0.96*rng.Float64()
+		p, _ := mymath.EvalBezier(bentCurve, u)
+
+		o := p.AddV(mymath.NewVector3(rng.Float64()-0.5, rng.Float64()-0.5, 3))
+		ray := mymath.NewRay(o, p.SubtractP(o), 50, 0, material.Medium{})
+
+		ok, tHit := intersect(ray)
+		assert.Equal(t, true, ok, "u=%v", u)
+		assert.InDelta(t, 1.0, tHit, 0.05, "u=%v", u)
+	}
+
+	// far away from the curve
+	ok, _ := intersect(newDownRay(0, 2))
+	assert.Equal(t, false, ok)
+}
+
+func TestCurve_Intersect_ribbon(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	// ribbon facing the ray has the full width
+	facing := mymath.NewCurve(&identity, &identity, false, mymath.NewCurveCommon(straightCurve, 0.2, 0.2, mymath.CurveRibbon,
+		[]mymath.Normal3{mymath.NewNormal3(0, 0, 1), mymath.NewNormal3(0, 0, 1)}), 0, 1)
+
+	ok, _, si := facing.Intersect(newDownRay(0, 0.08), false)
+	assert.Equal(t, true, ok)
+	assert.InDelta(t, 1.0, math.Abs(si.Interaction.N.Z), equalDelta)
+
+	// ribbon seen edge-on is invisible
+	edgeOn := mymath.NewCurve(&identity, &identity, false, mymath.NewCurveCommon(straightCurve, 0.2, 0.2, mymath.CurveRibbon,
+		[]mymath.Normal3{mymath.NewNormal3(0, 1, 0), mymath.NewNormal3(0, 1, 0)}), 0, 1)
+
+	assert.Equal(t, false, edgeOn.IntersectP(edgeOn, newDownRay(0, 0.01), false))
+}
+
+func TestCurve_Intersect_cylinder(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	curve := mymath.NewCurve(&identity, &identity, false, mymath.NewCurveCommon(straightCurve, 0.2, 0.2, mymath.CurveCylinder, nil), 0, 1)
+
+	// in the middle the normal faces the ray
+	ok, _, si := curve.Intersect(newDownRay(0, 0), false)
+	assert.Equal(t, true, ok)
+	assert.InDelta(t, 1.0, math.Abs(si.Interaction.N.Z), 1e-3)
+
+	// near the edge the normal bends away like on the cylinder
+	ok, _, si = curve.Intersect(newDownRay(0, 0.09), false)
+	assert.Equal(t, true, ok)
+	assert.InDelta(t, 0.9, math.Abs(si.Interaction.N.Y), 0.1)
+}
+
+func TestCurve_Intersect_transformed(t *testing.T) {
+	transform := mymath.NewTransformTranslate(mymath.NewVector3(1, 2, 3)).ApplyT(mymath.NewTransformRotateX(0.5))
+	transformInv := transform.Inverse()
+
+	curve := mymath.NewCurve(&transform, &transformInv, false, mymath.NewCurveCommon(straightCurve, 0.2, 0.2, mymath.CurveFlat, nil), 0, 1)
+
+	target := transform.ApplyP(mymath.NewPoint3(0.5, 0, 0))
+	o := target.AddV(mymath.NewVector3(0.3, 1, 4))
+	ray := mymath.NewRay(o, target.SubtractP(o), 50, 0, material.Medium{})
+
+	ok, tHit, si := curve.Intersect(ray, false)
+	assert.Equal(t, true, ok)
+	assert.InDelta(t, 1.0, tHit, 1e-3)
+	assert.InDelta(t, 0.75, si.Uv.X, 1e-3)
+	assert.InDelta(t, 0.0, target.Distance(si.Interaction.P), 1e-3)
+}
+
+func TestCurve_Area(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	segments := mymath.CreateCurve(&identity, &identity, false, straightCurve, 0.2, 0.2, mymath.CurveFlat, nil, 1)
+
+	area := 0.0
+	for _, s := range segments {
+		area += s.Area()
+	}
+
+	assert.InDelta(t, 0.4, area, equalDelta)
+}
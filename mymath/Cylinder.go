@@ -249,3 +249,35 @@ func (cyl Cylinder) IntersectP(_ Intersecter, r Ray, _ bool) bool {
 func (cyl Cylinder) Area() float64 {
 	return cyl.PhiMax * cyl.Radius * (cyl.ZMax - cyl.ZMin)
 }
+
+// Sample samples point uniformly on the cylinder
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/cylinder.cpp#L167
+func (cyl Cylinder) Sample(u Point2) Interaction {
+	z := Lerp(u.X, cyl.ZMin, cyl.ZMax)
+	phi := u.Y * cyl.PhiMax
+	pObj := NewPoint3(cyl.Radius*math.Cos(phi), cyl.Radius*math.Sin(phi), z)
+
+	var it Interaction
+	it.N = cyl.ObjectToWorld.ApplyN(NewNormal3(pObj.X, pObj.Y, 0)).Normalize()
+	if cyl.ReverseOrientation {
+		it.N = it.N.Negate()
+	}
+
+	// Reproject pObj to cylinder surface and compute pObjError
+	hitRad := math.Sqrt(pObj.X*pObj.X + pObj.Y*pObj.Y)
+	pObj.X *= cyl.Radius / hitRad
+	pObj.Y *= cyl.Radius / hitRad
+	pObjError := NewVector3(pObj.X, pObj.Y, 0).Abs().Multiply(Gamma3)
+	it.P, it.PError = cyl.ObjectToWorld.ApplyPPError(pObj, pObjError)
+
+	return it
+}
+
+func (cyl Cylinder) SampleRef(_ Interaction, u Point2) Interaction {
+	return cyl.Sample(u)
+}
+
+func (cyl Cylinder) Pdf(ref Interaction, wi Vector3) float64 {
+	return cyl.Shape.pdf(cyl, ref, wi)
+}
@@ -118,3 +118,29 @@ func TestCylinder_Area(t *testing.T) {
 
 	assert.InDelta(t, 4*math.Pi*16.1*16.1, c.Area(), equalDelta)
 }
+
+func TestCylinder_Sample(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+	cyl := mymath.NewCylinder(2, -1, 3, 180, &identity, &identity, false)
+
+	for i := 0; i < 10; i++ {
+		it := cyl.Sample(mymath.NewPoint2(float64(i)/10, 0.45))
+
+		assert.InDelta(t, 2.0, math.Hypot(it.P.X, it.P.Y), equalDelta)
+		assert.True(t, it.P.Z >= -1 && it.P.Z <= 3)
+		assert.GreaterOrEqual(t, it.P.Y, 0.0)
+		InDeltaNormal3(t, mymath.NewNormal3(it.P.X/2, it.P.Y/2, 0), it.N)
+	}
+}
+
+func TestCylinder_Pdf(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+	cyl := mymath.NewCylinder(2, -1, 3, 360, &identity, &identity, false)
+	ref := mymath.NewInteraction(mymath.NewPoint3(5, 0, 1), mymath.NewNormal3(0, 0, 0), mymath.NewVector3(0, 0, 0), mymath.NewVector3(0, 0, 0), 0, nil)
+
+	assert.InDelta(t, 9/cyl.Area(), cyl.Pdf(ref, mymath.NewVector3(-1, 0, 0)), equalDelta)
+	assert.Equal(t, 0.0, cyl.Pdf(ref, mymath.NewVector3(1, 0, 0)))
+
+	// nearest hit only, the density integrates to the visible fraction of the area
+	assert.Less(t, integrateSolidAnglePdf(cyl, ref, 100000), 1.0)
+}
@@ -142,3 +142,29 @@ func (disk Disk) IntersectP(_ Intersecter, r Ray, _ bool) bool {
 func (disk Disk) Area() float64 {
 	return disk.PhiMax * 0.5 * (disk.Radius*disk.Radius - disk.InnerRadius*disk.InnerRadius)
 }
+
+// Sample samples point uniformly on the disk using concentric mapping
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/disk.cpp#L122
+func (disk Disk) Sample(u Point2) Interaction {
+	pd := ConcentricSampleDisk(u)
+	pObj := NewPoint3(pd.X*disk.Radius, pd.Y*disk.Radius, disk.Height)
+
+	var it Interaction
+	it.N = disk.ObjectToWorld.ApplyN(NewNormal3(0, 0, 1)).Normalize()
+	if disk.ReverseOrientation {
+		it.N = it.N.Negate()
+	}
+
+	it.P, it.PError = disk.ObjectToWorld.ApplyPPError(pObj, NewVector3(0, 0, 0))
+
+	return it
+}
+
+func (disk Disk) SampleRef(_ Interaction, u Point2) Interaction {
+	return disk.Sample(u)
+}
+
+func (disk Disk) Pdf(ref Interaction, wi Vector3) float64 {
+	return disk.Shape.pdf(disk, ref, wi)
+}
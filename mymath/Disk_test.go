@@ -117,3 +117,30 @@ func TestDisk_Area(t *testing.T) {
 
 	assert.InDelta(t, 2*math.Pi*0.5*(30*30-20*20), d.Area(), equalDelta)
 }
+
+func TestDisk_Sample(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+	disk := mymath.NewDisk(2, 3, 0, 360, &identity, &identity, false)
+
+	for i := 0; i < 10; i++ {
+		it := disk.Sample(mymath.NewPoint2(float64(i)/10, 0.83))
+
+		assert.Equal(t, 2.0, it.P.Z)
+		assert.LessOrEqual(t, math.Hypot(it.P.X, it.P.Y), 3+equalDelta)
+		assert.Equal(t, mymath.NewNormal3(0, 0, 1), it.N)
+	}
+
+	reversed := mymath.NewDisk(2, 3, 0, 360, &identity, &identity, true)
+	assert.Equal(t, mymath.NewNormal3(0, 0, -1), reversed.Sample(mymath.NewPoint2(0.2, 0.2)).N)
+}
+
+func TestDisk_Pdf(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+	disk := mymath.NewDisk(2, 3, 0, 360, &identity, &identity, false)
+	ref := mymath.NewInteraction(mymath.NewPoint3(0, 0, 0), mymath.NewNormal3(0, 0, 0), mymath.NewVector3(0, 0, 0), mymath.NewVector3(0, 0, 0), 0, nil)
+
+	// the density is distance^2 / (cos * area)
+	assert.InDelta(t, 2.5*2.5/(0.8*disk.Area()), disk.Pdf(ref, mymath.NewVector3(0.6, 0, 0.8)), equalDelta)
+	assert.Equal(t, 0.0, disk.Pdf(ref, mymath.NewVector3(0, 0, -1)))
+	assert.InDelta(t, 1.0, integrateSolidAnglePdf(disk, ref, 200000), 0.05)
+}
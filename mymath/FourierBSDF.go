@@ -0,0 +1,188 @@
+package mymath
+
+import (
+	"math"
+	"pbrt-go/spectrum"
+)
+
+// FourierBSDF evaluates and samples the tabulated FourierBSDFTable, it is usually loaded from the measured data
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L546
+type FourierBSDF struct {
+	Table *FourierBSDFTable
+	Mode  TransportMode
+}
+
+func NewFourierBSDF(table *FourierBSDFTable, mode TransportMode) *FourierBSDF {
+	return &FourierBSDF{table, mode}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L248
+func (b *FourierBSDF) F(wo, wi Vector3) spectrum.Spectrum {
+	// Find the zenith angle cosines and azimuth difference angle
+	muI, muO := CosTheta(wi.Negate()), CosTheta(wo)
+	cosPhi := CosDPhi(wi.Negate(), wo)
+
+	// Compute Fourier coefficients a_k for (muI, muO)
+	ak, mMax := b.coefficients(muI, muO, b.Table.NChannels)
+	if ak == nil {
+		return spectrum.NewSpectrum(0)
+	}
+
+	// Evaluate Fourier expansion for angle phi
+	y := math.Max(0, Fourier(ak[:mMax], cosPhi))
+	return b.toSpectrum(ak, mMax, cosPhi, y, muI, muO)
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L580
+func (b *FourierBSDF) SampleF(wo Vector3, u Point2) (Vector3, spectrum.Spectrum, float64, BxDFType) {
+	table := b.Table
+
+	// Sample zenith angle component for FourierBSDF
+	muO := CosTheta(wo)
+	muI, _, pdfMu := SampleCatmullRom2D(table.Mu, table.Mu, table.A0, table.Cdf, muO, u.Y)
+
+	// Compute Fourier coefficients a_k for (muI, muO)
+	ak, mMax := b.coefficients(muI, muO, table.NChannels)
+	if ak == nil || mMax == 0 || ak[0] <= 0 {
+		return Vector3{}, spectrum.NewSpectrum(0), 0, b.Type()
+	}
+
+	// Importance sample the luminance Fourier expansion
+	y, pdfPhi, phi := SampleFourier(ak[:mMax], table.Recip, u.X)
+	pdf := math.Max(0, pdfPhi*pdfMu)
+
+	// Compute the scattered direction for FourierBSDF
+	sin2ThetaI := math.Max(0, 1-muI*muI)
+	norm := math.Sqrt(sin2ThetaI / Sin2Theta(wo))
+	if math.IsInf(norm, 0) {
+		norm = 0
+	}
+	sinPhi, cosPhi := math.Sincos(phi)
+	wi := NewVector3(
+		norm*(cosPhi*wo.X-sinPhi*wo.Y),
+		norm*(sinPhi*wo.X+cosPhi*wo.Y),
+		muI).Negate()
+
+	// Mathematically, wi will be normalized (if wo was). However, in practice, floating-point rounding error can
+	// accumulate in the computed value of wi here, so we normalize again.
+	wi = wi.Normalize()
+
+	// Evaluate remaining Fourier expansions for angle phi
+	return wi, b.toSpectrum(ak, mMax, cosPhi, y, muI, muO), pdf, b.Type()
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L640
+func (b *FourierBSDF) Pdf(wo, wi Vector3) float64 {
+	table := b.Table
+
+	// Find the zenith angle cosines and azimuth difference angle
+	muI, muO := CosTheta(wi.Negate()), CosTheta(wo)
+	cosPhi := CosDPhi(wi.Negate(), wo)
+
+	// Compute luminance Fourier coefficients a_k for (muI, muO)
+	ak, mMax := b.coefficients(muI, muO, 1)
+	if ak == nil {
+		return 0
+	}
+
+	// Evaluate probability of sampling wi
+	_, offsetO, weightsO := table.GetWeightsAndOffset(muO)
+	nMu := len(table.Mu)
+	rho := 0.0
+	for o := 0; o < 4; o++ {
+		if weightsO[o] == 0 {
+			continue
+		}
+		rho += weightsO[o] * table.Cdf[(offsetO+o)*nMu+nMu-1] * (2 * math.Pi)
+	}
+
+	y := Fourier(ak[:mMax], cosPhi)
+	if rho > 0 && y > 0 {
+		return y / rho
+	}
+	return 0
+}
+
+func (b *FourierBSDF) Rho(wo Vector3, samples []Point2) spectrum.Spectrum {
+	return EstimateRho(b, wo, samples)
+}
+
+func (b *FourierBSDF) RhoHH(samples1, samples2 []Point2) spectrum.Spectrum {
+	return EstimateRhoHH(b, samples1, samples2)
+}
+
+func (b *FourierBSDF) Type() BxDFType {
+	return BSDFReflection | BSDFTransmission | BSDFGlossy
+}
+
+// coefficients accumulates the spline weighted coefficients of the first nChannels channels of the nearby table
+// entries, each channel occupies MMax values. Returns nil when muI or muO lies outside the table.
+func (b *FourierBSDF) coefficients(muI, muO float64, nChannels int) ([]float64, int) {
+	table := b.Table
+
+	// Determine offsets and weights for muI and muO
+	okI, offsetI, weightsI := table.GetWeightsAndOffset(muI)
+	okO, offsetO, weightsO := table.GetWeightsAndOffset(muO)
+	if !okI || !okO {
+		return nil, 0
+	}
+
+	// Allocate storage to accumulate a_k coefficients
+	ak := make([]float64, table.MMax*nChannels)
+
+	// Accumulate weighted sums of nearby a_k coefficients
+	mMax := 0
+	for o := 0; o < 4; o++ {
+		for i := 0; i < 4; i++ {
+			// Add contribution of (i, o) to a_k values
+			weight := weightsI[i] * weightsO[o]
+			if weight == 0 {
+				continue
+			}
+			ap, m := table.GetAk(offsetI+i, offsetO+o)
+			mMax = maxInt(mMax, m)
+			for c := 0; c < nChannels; c++ {
+				for k := 0; k < m; k++ {
+					ak[c*table.MMax+k] += weight * ap[c*m+k]
+				}
+			}
+		}
+	}
+
+	return ak, mMax
+}
+
+// toSpectrum returns the BSDF value from the luminance y and the remaining channels of ak evaluated at cosPhi
+func (b *FourierBSDF) toSpectrum(ak []float64, mMax int, cosPhi, y, muI, muO float64) spectrum.Spectrum {
+	table := b.Table
+
+	scale := 0.0
+	if muI != 0 {
+		scale = 1 / math.Abs(muI)
+	}
+
+	// Update scale to account for adjoint light transport
+	if b.Mode == Radiance && muI*muO > 0 {
+		eta := table.Eta
+		if muI > 0 {
+			eta = 1 / table.Eta
+		}
+		scale *= eta * eta
+	}
+
+	if table.NChannels == 1 {
+		return spectrum.NewSpectrum(y * scale)
+	}
+
+	// Compute and return RGB colors for tabulated BSDF
+	red := Fourier(ak[1*table.MMax:1*table.MMax+mMax], cosPhi)
+	blue := Fourier(ak[2*table.MMax:2*table.MMax+mMax], cosPhi)
+	green := 1.39829*y - 0.100913*blue - 0.297375*red
+	rgb := [3]float64{
+		math.Max(0, red*scale),
+		math.Max(0, green*scale),
+		math.Max(0, blue*scale),
+	}
+	return spectrum.SpectrumFromRGB(rgb, spectrum.Reflectance)
+}
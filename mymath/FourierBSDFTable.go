@@ -0,0 +1,165 @@
+package mymath
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+// FourierBSDFTable holds the measured or simulated BSDF as the Fourier series in the azimuth difference angle phi,
+// tabulated for the pairs of the zenith angle cosines (muI, muO)
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L274
+type FourierBSDFTable struct {
+	// Eta is the relative index of refraction of the interface
+	Eta float64
+	// MMax is the maximal number of the Fourier coefficients of the whole table
+	MMax int
+	// NChannels is 1 for the monochromatic table or 3 for the luminance, red and blue channels
+	NChannels int
+	// Mu are the sorted zenith angle cosines
+	Mu []float64
+	// M, AOffset are the number of coefficients and their offset into A for each (muO, muI) pair
+	M, AOffset []int
+	// A are the coefficients of all pairs, for each pair the M coefficients of each channel follow each other
+	A []float64
+	// A0 are the first luminance coefficients of each pair
+	A0 []float64
+	// Cdf holds for each muO the running integral of A0 over muI
+	Cdf []float64
+	// Recip are the reciprocals 1/k of the coefficient orders
+	Recip []float64
+}
+
+// fourierBSDFHeader is the header of the binary .bsdf file, all values are little endian
+type fourierBSDFHeader struct {
+	Identifier [8]byte
+	Flags      int32
+	NMu        int32
+	NCoeffs    int32
+	MMax       int32
+	NChannels  int32
+	NBases     int32
+	_          [3]int32
+	Eta        float32
+	_          [4]int32
+}
+
+var fourierBSDFIdentifier = [8]byte{'S', 'C', 'A', 'T', 'F', 'U', 'N', 1}
+
+// The limits of the header counts, they bound the memory allocated before the data are read
+const (
+	maxFourierBSDFMu     = 1 << 10
+	maxFourierBSDFCoeffs = 1 << 26
+	maxFourierBSDFMMax   = 1 << 16
+)
+
+// ReadFourierBSDFTable reads the table from the binary .bsdf file
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L566
+func ReadFourierBSDFTable(filename string) (*FourierBSDFTable, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	table, err := DecodeFourierBSDFTable(file)
+	if err != nil {
+		return nil, fmt.Errorf("tabulated BSDF file %q: %w", filename, err)
+	}
+
+	return table, nil
+}
+
+// DecodeFourierBSDFTable decodes the table in the binary .bsdf format
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L566
+func DecodeFourierBSDFTable(r io.Reader) (*FourierBSDFTable, error) {
+	var header fourierBSDFHeader
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return nil, err
+	}
+	if header.Identifier != fourierBSDFIdentifier {
+		return nil, fmt.Errorf("incompatible file format or version")
+	}
+
+	// Only a subset of BSDF files are supported for simplicity, in particular: monochromatic and RGB files with
+	// uniform (i.e. non-textured) material properties
+	if header.Flags != 1 || (header.NChannels != 1 && header.NChannels != 3) || header.NBases != 1 {
+		return nil, fmt.Errorf("unsupported flags %v, %v channels or %v bases", header.Flags, header.NChannels, header.NBases)
+	}
+
+	if header.NMu <= 0 || header.NMu > maxFourierBSDFMu || header.NCoeffs <= 0 || header.NCoeffs > maxFourierBSDFCoeffs ||
+		header.MMax <= 0 || header.MMax > maxFourierBSDFMMax {
+		return nil, fmt.Errorf("invalid %v zenith angles, %v coefficients or %v maximal order", header.NMu, header.NCoeffs, header.MMax)
+	}
+
+	nMu := int(header.NMu)
+	mu := make([]float32, nMu)
+	cdf := make([]float32, nMu*nMu)
+	offsetAndLength := make([]int32, nMu*nMu*2)
+	a := make([]float32, header.NCoeffs)
+	for _, data := range []any{mu, cdf, offsetAndLength, a} {
+		if err := binary.Read(r, binary.LittleEndian, data); err != nil {
+			return nil, err
+		}
+	}
+
+	table := &FourierBSDFTable{
+		Eta:       float64(header.Eta),
+		MMax:      int(header.MMax),
+		NChannels: int(header.NChannels),
+		Mu:        toFloat64s(mu),
+		M:         make([]int, nMu*nMu),
+		AOffset:   make([]int, nMu*nMu),
+		A:         toFloat64s(a),
+		A0:        make([]float64, nMu*nMu),
+		Cdf:       toFloat64s(cdf),
+	}
+
+	for i := 0; i < nMu*nMu; i++ {
+		offset, length := int(offsetAndLength[2*i]), int(offsetAndLength[2*i+1])
+		if offset < 0 || length < 0 || offset+length*table.NChannels > len(table.A) || length > table.MMax {
+			return nil, fmt.Errorf("coefficients %v of length %v out of bounds", offset, length)
+		}
+		table.AOffset[i] = offset
+		table.M[i] = length
+		if length > 0 {
+			table.A0[i] = table.A[offset]
+		}
+	}
+
+	table.Recip = make([]float64, table.MMax)
+	for i := range table.Recip {
+		table.Recip[i] = 1 / float64(i)
+	}
+
+	return table, nil
+}
+
+// GetAk returns the coefficients of the (muI, muO) pair with the given indices and their number per channel
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L290
+func (t *FourierBSDFTable) GetAk(offsetI, offsetO int) ([]float64, int) {
+	offset := offsetO*len(t.Mu) + offsetI
+	m := t.M[offset]
+	return t.A[t.AOffset[offset] : t.AOffset[offset]+m*t.NChannels], m
+}
+
+// GetWeightsAndOffset returns the spline weights of the four zenith angle cosines around cosTheta and the offset
+// of the first of them
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L295
+func (t *FourierBSDFTable) GetWeightsAndOffset(cosTheta float64) (bool, int, [4]float64) {
+	return CatmullRomWeights(t.Mu, cosTheta)
+}
+
+func toFloat64s(values []float32) []float64 {
+	result := make([]float64, len(values))
+	for i, v := range values {
+		result[i] = float64(v)
+	}
+	return result
+}
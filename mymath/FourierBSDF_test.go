@@ -0,0 +1,229 @@
+package mymath_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"pbrt-go/mymath"
+	"pbrt-go/spectrum"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// encodeFourierBSDFTable builds the binary .bsdf file from the coefficients of each channel of the (muI, muO) pairs
+func encodeFourierBSDFTable(eta float64, mu []float64, nChannels int, coefficients func(muI, muO float64) [][]float64) []byte {
+	nMu := len(mu)
+	var a, a0, cdf []float32
+	offsetAndLength := make([]int32, 0, 2*nMu*nMu)
+	mMax := 0
+
+	for _, muO := range mu {
+		row := make([]float64, nMu)
+		for i, muI := range mu {
+			channels := coefficients(muI, muO)
+			m := len(channels[0])
+			offsetAndLength = append(offsetAndLength, int32(len(a)), int32(m))
+			for _, channel := range channels {
+				for _, ak := range channel {
+					a = append(a, float32(ak))
+				}
+			}
+			if m > 0 {
+				row[i] = float64(float32(channels[0][0]))
+			}
+			if m > mMax {
+				mMax = m
+			}
+		}
+
+		rowCdf := make([]float64, nMu)
+		mymath.IntegrateCatmullRom(mu, row, rowCdf)
+		for i := range row {
+			a0 = append(a0, float32(row[i]))
+			cdf = append(cdf, float32(rowCdf[i]))
+		}
+	}
+
+	mu32 := make([]float32, nMu)
+	for i, m := range mu {
+		mu32[i] = float32(m)
+	}
+
+	var buffer bytes.Buffer
+	for _, data := range []any{
+		[]byte("SCATFUN\x01"),
+		[]int32{1, int32(nMu), int32(len(a)), int32(mMax), int32(nChannels), 1, 0, 0, 0},
+		float32(eta),
+		[]int32{0, 0, 0, 0},
+		mu32, cdf, offsetAndLength, a,
+	} {
+		_ = binary.Write(&buffer, binary.LittleEndian, data)
+	}
+	return buffer.Bytes()
+}
+
+func fourierMu(n int) []float64 {
+	mu := make([]float64, n)
+	for i := range mu {
+		mu[i] = -1 + 2*float64(i)/float64(n-1)
+	}
+	return mu
+}
+
+// lambertianFourierTable is the diffuse reflector with the given albedo, the table stores f * |muI|
+func lambertianFourierTable(t *testing.T, albedo float64) *mymath.FourierBSDFTable {
+	data := encodeFourierBSDFTable(1, fourierMu(41), 1, func(muI, muO float64) [][]float64 {
+		if muI*muO >= 0 {
+			return [][]float64{nil}
+		}
+		return [][]float64{{albedo / math.Pi * math.Abs(muI)}}
+	})
+	table, err := mymath.DecodeFourierBSDFTable(bytes.NewReader(data))
+	assert.NoError(t, err)
+	return table
+}
+
+func TestDecodeFourierBSDFTable(t *testing.T) {
+	table := lambertianFourierTable(t, 0.5)
+	assert.Equal(t, 1.0, table.Eta)
+	assert.Equal(t, 1, table.MMax)
+	assert.Equal(t, 1, table.NChannels)
+	assert.Len(t, table.Mu, 41)
+	assert.Len(t, table.A0, 41*41)
+	assert.Len(t, table.Cdf, 41*41)
+
+	// muI = -0.5, muO = 0.5
+	ak, m := table.GetAk(10, 30)
+	assert.Equal(t, 1, m)
+	assert.InDelta(t, 0.25/math.Pi, ak[0], 1e-7)
+	assert.Equal(t, ak[0], table.A0[30*41+10])
+
+	// muI = 0.5, muO = 0.5
+	ak, m = table.GetAk(30, 30)
+	assert.Equal(t, 0, m)
+	assert.Empty(t, ak)
+	assert.Equal(t, 0.0, table.A0[30*41+30])
+}
+
+func TestDecodeFourierBSDFTable_Invalid(t *testing.T) {
+	data := encodeFourierBSDFTable(1.5, fourierMu(5), 1, func(muI, muO float64) [][]float64 {
+		return [][]float64{{1}}
+	})
+
+	_, err := mymath.DecodeFourierBSDFTable(bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	// truncated
+	_, err = mymath.DecodeFourierBSDFTable(bytes.NewReader(data[:len(data)-1]))
+	assert.Error(t, err)
+
+	// wrong identifier
+	wrong := append([]byte{}, data...)
+	wrong[7] = 2
+	_, err = mymath.DecodeFourierBSDFTable(bytes.NewReader(wrong))
+	assert.Error(t, err)
+
+	// unsupported number of channels
+	wrong = append([]byte{}, data...)
+	wrong[24] = 2
+	_, err = mymath.DecodeFourierBSDFTable(bytes.NewReader(wrong))
+	assert.Error(t, err)
+}
+
+func TestDecodeFourierBSDFTable_corruptHeader(t *testing.T) {
+	data := encodeFourierBSDFTable(1.5, fourierMu(5), 1, func(muI, muO float64) [][]float64 {
+		return [][]float64{{1}}
+	})
+
+	// NMu, NCoeffs and MMax follow the identifier and the flags
+	for _, c := range []struct {
+		offset int
+		value  int32
+	}{
+		{12, 0}, {12, -5}, {12, math.MaxInt32},
+		{16, 0}, {16, -1}, {16, math.MaxInt32},
+		{20, 0}, {20, -1}, {20, math.MaxInt32},
+	} {
+		corrupt := append([]byte{}, data...)
+		binary.LittleEndian.PutUint32(corrupt[c.offset:], uint32(c.value))
+
+		table, err := mymath.DecodeFourierBSDFTable(bytes.NewReader(corrupt))
+		assert.Error(t, err, "offset %v, value %v", c.offset, c.value)
+		assert.Nil(t, table)
+	}
+}
+
+func TestReadFourierBSDFTable(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "table.bsdf")
+	data := encodeFourierBSDFTable(1.5, fourierMu(5), 1, func(muI, muO float64) [][]float64 {
+		return [][]float64{{1, 0.5}}
+	})
+	assert.NoError(t, os.WriteFile(filename, data, 0o644))
+
+	table, err := mymath.ReadFourierBSDFTable(filename)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, table.Eta)
+	assert.Equal(t, 2, table.MMax)
+	assert.Equal(t, []float64{math.Inf(1), 1}, table.Recip)
+
+	_, err = mymath.ReadFourierBSDFTable(filepath.Join(t.TempDir(), "missing.bsdf"))
+	assert.Error(t, err)
+}
+
+func TestFourierBSDF_Lambertian(t *testing.T) {
+	bxdf := mymath.NewFourierBSDF(lambertianFourierTable(t, 0.5), mymath.Radiance)
+	wo := mymath.NewVector3(0.6, 0, 0.8)
+
+	// the cosines on the table nodes are exact
+	wi := mymath.NewVector3(0, 0.6, 0.8)
+	InDeltaSpectrum(t, spectrum.NewSpectrum(0.5/math.Pi), bxdf.F(wo, wi), 1e-6)
+	assert.True(t, bxdf.F(wo, wi.Negate()).IsBlack())
+	assert.Equal(t, 0.0, bxdf.Pdf(wo, wi.Negate()))
+
+	assertSampleF(t, bxdf, wo)
+	assertSampleF(t, bxdf, wo.Negate())
+	assert.InDelta(t, 1, integratePdf(bxdf, wo, 100000), 0.02)
+
+	rng := rand.New(rand.NewSource(11))
+	InDeltaSpectrum(t, spectrum.NewSpectrum(0.5), bxdf.Rho(wo, randomSamples(rng, 10000)), 0.01)
+	InDeltaSpectrum(t, spectrum.NewSpectrum(0.5), bxdf.Rho(wo.Negate(), randomSamples(rng, 10000)), 0.01)
+}
+
+func TestFourierBSDF_RGB(t *testing.T) {
+	red, green, blue := 0.2, 0.5, 0.8
+	y := 0.212671*red + 0.715160*green + 0.072169*blue
+
+	// glossy transmission with the azimuthal variation 1 + 0.5 cos(phi)
+	data := encodeFourierBSDFTable(1.5, fourierMu(41), 3, func(muI, muO float64) [][]float64 {
+		if muI*muO <= 0 {
+			return [][]float64{nil, nil, nil}
+		}
+		ak := []float64{0.5 / math.Pi * math.Abs(muI), 0.25 / math.Pi * math.Abs(muI)}
+		scaled := func(s float64) []float64 { return []float64{s * ak[0], s * ak[1]} }
+		return [][]float64{scaled(y), scaled(red), scaled(blue)}
+	})
+	table, err := mymath.DecodeFourierBSDFTable(bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	wo := mymath.NewVector3(0.6, 0, 0.8)
+	wi := mymath.NewVector3(-0.6, 0, -0.8)
+
+	// the light arriving from below is scaled by 1 / eta^2 in the radiance mode
+	importance := mymath.NewFourierBSDF(table, mymath.Importance)
+	radiance := mymath.NewFourierBSDF(table, mymath.Radiance)
+	expected := spectrum.SpectrumFromRGB([3]float64{red, green, blue}, spectrum.Reflectance).Multiply(0.75 / math.Pi)
+	InDeltaSpectrum(t, expected, importance.F(wo, wi), 1e-4)
+	InDeltaSpectrum(t, expected.Divide(1.5*1.5), radiance.F(wo, wi), 1e-4)
+	InDeltaSpectrum(t, expected.Multiply(1.5*1.5), radiance.F(wo.Negate(), wi.Negate()), 1e-4)
+
+	// no reflection
+	assert.True(t, importance.F(wo, mymath.NewVector3(-0.6, 0, 0.8)).IsBlack())
+
+	assertSampleF(t, importance, wo)
+	assertSampleF(t, radiance, wo.Negate())
+	assert.InDelta(t, 1, integratePdf(importance, wo, 100000), 0.02)
+}
@@ -0,0 +1,53 @@
+package mymath
+
+import "pbrt-go/spectrum"
+
+// Fresnel returns fraction of the light reflected by the surface
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L142
+type Fresnel interface {
+	Evaluate(cosThetaI float64) spectrum.Spectrum
+}
+
+// FresnelConductor is Fresnel reflectance of the conductor
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L150
+type FresnelConductor struct {
+	EtaI, EtaT, K spectrum.Spectrum
+}
+
+func NewFresnelConductor(etaI, etaT, k spectrum.Spectrum) *FresnelConductor {
+	return &FresnelConductor{etaI, etaT, k}
+}
+
+func (f *FresnelConductor) Evaluate(cosThetaI float64) spectrum.Spectrum {
+	if cosThetaI < 0 {
+		cosThetaI = -cosThetaI
+	}
+	return FrConductor(cosThetaI, f.EtaI, f.EtaT, f.K)
+}
+
+// FresnelDielectric is Fresnel reflectance of the dielectric
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L165
+type FresnelDielectric struct {
+	EtaI, EtaT float64
+}
+
+func NewFresnelDielectric(etaI, etaT float64) *FresnelDielectric {
+	return &FresnelDielectric{etaI, etaT}
+}
+
+func (f *FresnelDielectric) Evaluate(cosThetaI float64) spectrum.Spectrum {
+	return spectrum.NewSpectrum(FrDielectric(cosThetaI, f.EtaI, f.EtaT))
+}
+
+// FresnelNoOp reflects all the light
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L178
+type FresnelNoOp struct {
+}
+
+func (FresnelNoOp) Evaluate(_ float64) spectrum.Spectrum {
+	return spectrum.NewSpectrum(1)
+}
@@ -0,0 +1,90 @@
+package mymath
+
+import (
+	"math"
+	"pbrt-go/spectrum"
+)
+
+// FresnelBlend is the Ashikhmin-Shirley model of the diffuse substrate under the glossy specular coating
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L506
+type FresnelBlend struct {
+	Rd, Rs       spectrum.Spectrum
+	Distribution MicrofacetDistribution
+}
+
+func NewFresnelBlend(rd, rs spectrum.Spectrum, distribution MicrofacetDistribution) *FresnelBlend {
+	return &FresnelBlend{rd, rs, distribution}
+}
+
+// SchlickFresnel is Schlick's approximation of the Fresnel reflectance of the coating
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L514
+func (b *FresnelBlend) SchlickFresnel(cosTheta float64) spectrum.Spectrum {
+	return b.Rs.Add(spectrum.NewSpectrum(1).Subtract(b.Rs).Multiply(pow5(1 - cosTheta)))
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L264
+func (b *FresnelBlend) F(wo, wi Vector3) spectrum.Spectrum {
+	diffuse := b.Rd.MultiplyS(spectrum.NewSpectrum(1).Subtract(b.Rs)).Multiply(
+		(28.0 / (23.0 * math.Pi)) * (1 - pow5(1-.5*AbsCosTheta(wi))) * (1 - pow5(1-.5*AbsCosTheta(wo))))
+	wh := wi.Add(wo)
+	if wh.X == 0 && wh.Y == 0 && wh.Z == 0 {
+		return spectrum.NewSpectrum(0)
+	}
+	wh = wh.Normalize()
+	specular := b.SchlickFresnel(wi.Dot(wh)).Multiply(
+		b.Distribution.D(wh) / (4 * math.Abs(wi.Dot(wh)) * math.Max(AbsCosTheta(wi), AbsCosTheta(wo))))
+	return diffuse.Add(specular)
+}
+
+// SampleF samples the diffuse and the glossy part with equal probability
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L517
+func (b *FresnelBlend) SampleF(wo Vector3, uOrig Point2) (Vector3, spectrum.Spectrum, float64, BxDFType) {
+	u := uOrig
+	var wi Vector3
+	if u.X < .5 {
+		u.X = math.Min(2*u.X, OneMinusEpsilon)
+		// Cosine-sample the hemisphere, flipping the direction if necessary
+		wi = CosineSampleHemisphere(u)
+		if wo.Z < 0 {
+			wi.Z *= -1
+		}
+	} else {
+		u.X = math.Min(2*(u.X-.5), OneMinusEpsilon)
+		// Sample microfacet orientation wh and reflected direction wi
+		wh := b.Distribution.SampleWh(wo, u)
+		wi = Reflect(wo, wh)
+		if !SameHemisphere(wo, wi) {
+			return wi, spectrum.NewSpectrum(0), 0, b.Type()
+		}
+	}
+	return wi, b.F(wo, wi), b.Pdf(wo, wi), b.Type()
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L540
+func (b *FresnelBlend) Pdf(wo, wi Vector3) float64 {
+	if !SameHemisphere(wo, wi) {
+		return 0
+	}
+	wh := wo.Add(wi).Normalize()
+	pdfWh := b.Distribution.Pdf(wo, wh)
+	return .5 * (AbsCosTheta(wi)/math.Pi + pdfWh/(4*wo.Dot(wh)))
+}
+
+func (b *FresnelBlend) Rho(wo Vector3, samples []Point2) spectrum.Spectrum {
+	return EstimateRho(b, wo, samples)
+}
+
+func (b *FresnelBlend) RhoHH(samples1, samples2 []Point2) spectrum.Spectrum {
+	return EstimateRhoHH(b, samples1, samples2)
+}
+
+func (b *FresnelBlend) Type() BxDFType {
+	return BSDFReflection | BSDFGlossy
+}
+
+func pow5(v float64) float64 {
+	return (v * v) * (v * v) * v
+}
@@ -0,0 +1,76 @@
+package mymath
+
+import "pbrt-go/spectrum"
+
+// FresnelSpecular combines the specular reflection and transmission of the dielectric, the lobe is chosen
+// by the Fresnel reflectance
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L326
+type FresnelSpecular struct {
+	R, T spectrum.Spectrum
+	// EtaA and EtaB are indices of refraction above and below the surface
+	EtaA, EtaB float64
+	Mode       TransportMode
+}
+
+func NewFresnelSpecular(r, t spectrum.Spectrum, etaA, etaB float64, mode TransportMode) *FresnelSpecular {
+	return &FresnelSpecular{r, t, etaA, etaB, mode}
+}
+
+// F is zero, the delta distribution is handled only by SampleF
+func (b *FresnelSpecular) F(_, _ Vector3) spectrum.Spectrum {
+	return spectrum.NewSpectrum(0)
+}
+
+// SampleF chooses the reflection with the probability equal to the Fresnel reflectance and the transmission
+// otherwise
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L627
+func (b *FresnelSpecular) SampleF(wo Vector3, u Point2) (Vector3, spectrum.Spectrum, float64, BxDFType) {
+	f := FrDielectric(CosTheta(wo), b.EtaA, b.EtaB)
+	if u.X < f {
+		// Compute specular reflection for FresnelSpecular
+
+		// Compute perfect specular reflection direction
+		wi := NewVector3(-wo.X, -wo.Y, wo.Z)
+		return wi, b.R.Multiply(f / AbsCosTheta(wi)), f, BSDFSpecular | BSDFReflection
+	}
+
+	// Compute specular transmission for FresnelSpecular
+
+	// Figure out which eta is incident and which is transmitted
+	entering := CosTheta(wo) > 0
+	etaI, etaT := b.EtaA, b.EtaB
+	if !entering {
+		etaI, etaT = etaT, etaI
+	}
+
+	// Compute ray direction for specular transmission
+	ok, wi := Refract(wo, NewNormal3(0, 0, 1).FaceForward(NewNormal3V(wo)), etaI/etaT)
+	if !ok {
+		return Vector3{}, spectrum.NewSpectrum(0), 0, 0
+	}
+	ft := b.T.Multiply(1 - f)
+
+	// Account for non-symmetry with transmission to different medium
+	if b.Mode == Radiance {
+		ft = ft.Multiply((etaI * etaI) / (etaT * etaT))
+	}
+	return wi, ft.Divide(AbsCosTheta(wi)), 1 - f, BSDFSpecular | BSDFTransmission
+}
+
+func (b *FresnelSpecular) Pdf(_, _ Vector3) float64 {
+	return 0
+}
+
+func (b *FresnelSpecular) Rho(wo Vector3, samples []Point2) spectrum.Spectrum {
+	return EstimateRho(b, wo, samples)
+}
+
+func (b *FresnelSpecular) RhoHH(samples1, samples2 []Point2) spectrum.Spectrum {
+	return EstimateRhoHH(b, samples1, samples2)
+}
+
+func (b *FresnelSpecular) Type() BxDFType {
+	return BSDFReflection | BSDFTransmission | BSDFSpecular
+}
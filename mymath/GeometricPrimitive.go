@@ -0,0 +1,50 @@
+package mymath
+
+import "pbrt-go/material"
+
+// GeometricPrimitive ties single shape to its material, area light and participating media
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/primitive.h#L75
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/primitive.cpp#L87
+type GeometricPrimitive struct {
+	Shape           IShape
+	Material        Material
+	AreaLight       AreaLight
+	MediumInterface *material.MediumInterface
+}
+
+func NewGeometricPrimitive(shape IShape, mat Material, areaLight AreaLight, mediumInterface *material.MediumInterface) *GeometricPrimitive {
+	return &GeometricPrimitive{shape, mat, areaLight, mediumInterface}
+}
+
+func (p *GeometricPrimitive) WorldBound() Bounds3 {
+	return p.Shape.WorldBound(p.Shape)
+}
+
+// Intersect see https://github.com/mmp/pbrt-v3/blob/master/src/core/primitive.cpp#L97
+func (p *GeometricPrimitive) Intersect(ray *Ray) (bool, *SurfaceInteraction) {
+	ok, tHit, isect := p.Shape.Intersect(*ray, true)
+	if !ok {
+		return false, nil
+	}
+
+	ray.TMax = tHit
+	isect.Primitive = p
+
+	// Initialize SurfaceInteraction.MediumInterface after Shape intersection
+	isect.MediumInterface = p.MediumInterface
+
+	return true, isect
+}
+
+func (p *GeometricPrimitive) IntersectP(ray Ray) bool {
+	return p.Shape.IntersectP(p.Shape, ray, true)
+}
+
+func (p *GeometricPrimitive) GetAreaLight() AreaLight {
+	return p.AreaLight
+}
+
+func (p *GeometricPrimitive) GetMaterial() Material {
+	return p.Material
+}
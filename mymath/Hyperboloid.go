@@ -0,0 +1,328 @@
+package mymath
+
+import (
+	"math"
+)
+
+// Hyperboloid of one sheet swept by rotating the line segment P1-P2 around z axis
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/hyperboloid.h
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/hyperboloid.cpp
+type Hyperboloid struct {
+	Shape
+	P1, P2 Point3
+	ZMin, ZMax,
+	PhiMax,
+	RMax,
+	Ah, Ch float64
+}
+
+// NewHyperboloid see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/hyperboloid.cpp#L41
+func NewHyperboloid(point1, point2 Point3, phiMax float64, objectToWorld, worldToObject *Transform, reverseOrientation bool) *Hyperboloid {
+	p1, p2 := point1, point2
+
+	radius1 := math.Sqrt(p1.X*p1.X + p1.Y*p1.Y)
+	radius2 := math.Sqrt(p2.X*p2.X + p2.Y*p2.Y)
+
+	// Compute implicit function coefficients for hyperboloid
+	if p2.Z == 0 {
+		p1, p2 = p2, p1
+	}
+
+	pp := p1
+	var ah, ch float64
+	for ok := true; ok; ok = math.IsInf(ah, 0) || math.IsNaN(ah) {
+		pp = pp.AddV(p2.SubtractP(p1).Multiply(2))
+		xy1 := pp.X*pp.X + pp.Y*pp.Y
+		xy2 := p2.X*p2.X + p2.Y*p2.Y
+		ah = (1/xy1 - (pp.Z*pp.Z)/(xy1*p2.Z*p2.Z)) /
+			(1 - (xy2*pp.Z*pp.Z)/(xy1*p2.Z*p2.Z))
+		ch = (ah*xy2 - 1) / (p2.Z * p2.Z)
+	}
+
+	return &Hyperboloid{
+		NewShape(objectToWorld, worldToObject, reverseOrientation),
+		p1,
+		p2,
+		math.Min(point1.Z, point2.Z),
+		math.Max(point1.Z, point2.Z),
+		Radians(Clamp(phiMax, 0, 360)),
+		math.Max(radius1, radius2),
+		ah,
+		ch,
+	}
+}
+
+func (hyp Hyperboloid) ObjectBound() Bounds3 {
+	return NewBounds3(
+		NewPoint3(-hyp.RMax, -hyp.RMax, hyp.ZMin),
+		NewPoint3(hyp.RMax, hyp.RMax, hyp.ZMax))
+}
+
+// Intersect finds ray-shape collision point and its metadata
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/hyperboloid.cpp#L80
+func (hyp Hyperboloid) Intersect(r Ray, _ bool) (bool, float64, *SurfaceInteraction) {
+	// Transform Ray to object space
+	ray, oErr, dErr := hyp.WorldToObject.ApplyRError(r)
+
+	// Compute quadratic hyperboloid coefficients
+
+	// Initialize EFloat ray coordinate values
+	ox := NewEFloatErr(ray.O.X, oErr.X)
+	oy := NewEFloatErr(ray.O.Y, oErr.Y)
+	oz := NewEFloatErr(ray.O.Z, oErr.Z)
+
+	dx := NewEFloatErr(ray.D.X, dErr.X)
+	dy := NewEFloatErr(ray.D.Y, dErr.Y)
+	dz := NewEFloatErr(ray.D.Z, dErr.Z)
+
+	ah := NewEFloat(hyp.Ah)
+	ch := NewEFloat(hyp.Ch)
+
+	a := ah.Multiply(dx).Multiply(dx).Add(ah.Multiply(dy).Multiply(dy)).Subtract(ch.Multiply(dz).Multiply(dz))
+	b := NewEFloat(2).Multiply(ah.Multiply(dx).Multiply(ox).Add(ah.Multiply(dy).Multiply(oy)).Subtract(ch.Multiply(dz).Multiply(oz)))
+	c := ah.Multiply(ox).Multiply(ox).Add(ah.Multiply(oy).Multiply(oy)).Subtract(ch.Multiply(oz).Multiply(oz)).Subtract(NewEFloat(1))
+
+	// Solve quadratic equation for t values
+	ok, t0, t1 := Quadratic(a, b, c)
+
+	if !ok {
+		return false, 0, nil
+	}
+
+	// Check quadratic shape t0 and t1 for nearest intersection
+	if t0.High > ray.TMax || t1.Low <= 0 {
+		return false, 0, nil
+	}
+
+	tShapeHit := t0
+	if tShapeHit.Low <= 0 {
+		tShapeHit = t1
+		if tShapeHit.High > ray.TMax {
+			return false, 0, nil
+		}
+	}
+
+	// Compute hyperboloid inverse mapping
+	pHit := ray.Apply(tShapeHit.V)
+	v, phi := hyp.inverseMapping(pHit)
+
+	// Test hyperboloid intersection against clipping parameters
+	if pHit.Z < hyp.ZMin || pHit.Z > hyp.ZMax || phi > hyp.PhiMax {
+		if tShapeHit == t1 {
+			return false, 0, nil
+		}
+
+		tShapeHit = t1
+		if t1.High > ray.TMax {
+			return false, 0, nil
+		}
+
+		// Compute hyperboloid inverse mapping
+		pHit = ray.Apply(tShapeHit.V)
+		v, phi = hyp.inverseMapping(pHit)
+
+		if pHit.Z < hyp.ZMin || pHit.Z > hyp.ZMax || phi > hyp.PhiMax {
+			return false, 0, nil
+		}
+	}
+
+	// Compute parametric representation of hyperboloid hit
+	u := phi / hyp.PhiMax
+
+	// Compute hyperboloid dpdu and dpdv
+	cosPhi := math.Cos(phi)
+	sinPhi := math.Sin(phi)
+	dpdu := NewVector3(-hyp.PhiMax*pHit.Y, hyp.PhiMax*pHit.X, 0)
+	dpdv := NewVector3(
+		(hyp.P2.X-hyp.P1.X)*cosPhi-(hyp.P2.Y-hyp.P1.Y)*sinPhi,
+		(hyp.P2.X-hyp.P1.X)*sinPhi+(hyp.P2.Y-hyp.P1.Y)*cosPhi,
+		hyp.P2.Z-hyp.P1.Z)
+
+	// Compute hyperboloid dndu and dndv
+	d2Pduu := NewVector3(pHit.X, pHit.Y, 0).Multiply(-hyp.PhiMax * hyp.PhiMax)
+	d2Pduv := NewVector3(-dpdv.Y, dpdv.X, 0).Multiply(hyp.PhiMax)
+	d2Pdvv := NewVector3(0, 0, 0)
+
+	// Compute coefficients for fundamental forms
+	E := dpdu.Dot(dpdu)
+	F := dpdu.Dot(dpdv)
+	G := dpdv.Dot(dpdv)
+	N := dpdu.Cross(dpdv).Normalize()
+	e := N.Dot(d2Pduu)
+	f := N.Dot(d2Pduv)
+	g := N.Dot(d2Pdvv)
+
+	// Compute dndu and dndv from fundamental form coefficients
+	invEGF2 := 1 / (E*G - F*F)
+	dndu := NewNormal3V(dpdu.Multiply((f*F - e*G) * invEGF2).Add(dpdv.Multiply((e*F - f*E) * invEGF2)))
+	dndv := NewNormal3V(dpdu.Multiply((g*F - f*G) * invEGF2).Add(dpdv.Multiply((f*F - g*E) * invEGF2)))
+
+	// Compute error bounds for hyperboloid intersection
+
+	// Compute error bounds for intersection computed with ray equation
+	px := ox.Add(tShapeHit.Multiply(dx))
+	py := oy.Add(tShapeHit.Multiply(dy))
+	pz := oz.Add(tShapeHit.Multiply(dz))
+	pError := NewVector3(px.GetAbsoluteError(), py.GetAbsoluteError(), pz.GetAbsoluteError())
+
+	// Initialize _SurfaceInteraction_ from parametric information
+	si := NewSurfaceInteraction(
+		pHit,
+		pError,
+		NewPoint2(u, v),
+		ray.D.Negate(),
+		dpdu,
+		dpdv,
+		dndu,
+		dndv,
+		float64(ray.Time),
+		&hyp.Shape)
+
+	isect := hyp.ObjectToWorld.ApplySI(&si)
+
+	// Update _tHit_ for quadric intersection
+	return true, tShapeHit.V, isect
+}
+
+// inverseMapping finds v and phi of the point on the hyperboloid, phi is measured from the swept line
+func (hyp Hyperboloid) inverseMapping(pHit Point3) (float64, float64) {
+	v := (pHit.Z - hyp.P1.Z) / (hyp.P2.Z - hyp.P1.Z)
+	pr := hyp.P1.Multiply(1 - v).AddP(hyp.P2.Multiply(v))
+
+	phi := math.Atan2(pr.X*pHit.Y-pHit.X*pr.Y, pHit.X*pr.X+pHit.Y*pr.Y)
+	if phi < 0 {
+		phi += 2 * math.Pi
+	}
+
+	return v, phi
+}
+
+// IntersectP finds if ray collides with this shape
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/hyperboloid.cpp#L200
+func (hyp Hyperboloid) IntersectP(_ Intersecter, r Ray, _ bool) bool {
+	// Transform Ray to object space
+	ray, oErr, dErr := hyp.WorldToObject.ApplyRError(r)
+
+	// Compute quadratic hyperboloid coefficients
+
+	// Initialize EFloat ray coordinate values
+	ox := NewEFloatErr(ray.O.X, oErr.X)
+	oy := NewEFloatErr(ray.O.Y, oErr.Y)
+	oz := NewEFloatErr(ray.O.Z, oErr.Z)
+
+	dx := NewEFloatErr(ray.D.X, dErr.X)
+	dy := NewEFloatErr(ray.D.Y, dErr.Y)
+	dz := NewEFloatErr(ray.D.Z, dErr.Z)
+
+	ah := NewEFloat(hyp.Ah)
+	ch := NewEFloat(hyp.Ch)
+
+	a := ah.Multiply(dx).Multiply(dx).Add(ah.Multiply(dy).Multiply(dy)).Subtract(ch.Multiply(dz).Multiply(dz))
+	b := NewEFloat(2).Multiply(ah.Multiply(dx).Multiply(ox).Add(ah.Multiply(dy).Multiply(oy)).Subtract(ch.Multiply(dz).Multiply(oz)))
+	c := ah.Multiply(ox).Multiply(ox).Add(ah.Multiply(oy).Multiply(oy)).Subtract(ch.Multiply(oz).Multiply(oz)).Subtract(NewEFloat(1))
+
+	// Solve quadratic equation for t values
+	ok, t0, t1 := Quadratic(a, b, c)
+
+	if !ok {
+		return false
+	}
+
+	// Check quadratic shape t0 and t1 for nearest intersection
+	if t0.High > ray.TMax || t1.Low <= 0 {
+		return false
+	}
+
+	tShapeHit := t0
+	if tShapeHit.Low <= 0 {
+		tShapeHit = t1
+		if tShapeHit.High > ray.TMax {
+			return false
+		}
+	}
+
+	// Compute hyperboloid inverse mapping
+	pHit := ray.Apply(tShapeHit.V)
+	_, phi := hyp.inverseMapping(pHit)
+
+	// Test hyperboloid intersection against clipping parameters
+	if pHit.Z < hyp.ZMin || pHit.Z > hyp.ZMax || phi > hyp.PhiMax {
+		if tShapeHit == t1 {
+			return false
+		}
+
+		tShapeHit = t1
+		if t1.High > ray.TMax {
+			return false
+		}
+
+		// Compute hyperboloid inverse mapping
+		pHit = ray.Apply(tShapeHit.V)
+		_, phi = hyp.inverseMapping(pHit)
+
+		if pHit.Z < hyp.ZMin || pHit.Z > hyp.ZMax || phi > hyp.PhiMax {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Area integrates |dpdu x dpdv| over the surface in closed form. The squared length is quadratic in v, so
+// the integral of its square root is elementary. This differs from pbrt-v3, whose formula is exact only
+// for the cylinder-like case.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/hyperboloid.cpp#L274
+func (hyp Hyperboloid) Area() float64 {
+	// Point on the swept line p(v) = p1 + v*d, at phi = 0
+	d := hyp.P2.SubtractP(hyp.P1)
+	dxy2 := d.X*d.X + d.Y*d.Y
+	pd := hyp.P1.X*d.X + hyp.P1.Y*d.Y
+	r2 := hyp.P1.X*hyp.P1.X + hyp.P1.Y*hyp.P1.Y
+
+	// |dpdu x dpdv|^2 / phiMax^2 = (px^2 + py^2) dz^2 + (px dx + py dy)^2 = a v^2 + b v + c
+	a := dxy2*d.Z*d.Z + dxy2*dxy2
+	b := 2*pd*d.Z*d.Z + 2*pd*dxy2
+	c := r2*d.Z*d.Z + pd*pd
+
+	return hyp.PhiMax * integrateSqrtQuadratic(a, b, c)
+}
+
+// integrateSqrtQuadratic computes integral of sqrt(a v^2 + b v + c) over v in [0, 1], the quadratic must be non-negative there
+func integrateSqrtQuadratic(a, b, c float64) float64 {
+	if a == 0 {
+		return math.Sqrt(c)
+	}
+
+	sqrtA := math.Sqrt(a)
+	disc := 4*a*c - b*b
+
+	antiderivative := func(v float64) float64 {
+		q := math.Sqrt(math.Max(0, a*v*v+b*v+c))
+		res := (2*a*v + b) / (4 * a) * q
+		if disc > 0 {
+			res += disc / (8 * a * sqrtA) * math.Log(2*a*v+b+2*sqrtA*q)
+		}
+
+		return res
+	}
+
+	return antiderivative(1) - antiderivative(0)
+}
+
+// Sample is not implemented, the same as in pbrt
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/hyperboloid.cpp
+func (hyp Hyperboloid) Sample(_ Point2) Interaction {
+	panic("Hyperboloid.Sample not implemented")
+}
+
+func (hyp Hyperboloid) SampleRef(_ Interaction, u Point2) Interaction {
+	return hyp.Sample(u)
+}
+
+func (hyp Hyperboloid) Pdf(ref Interaction, wi Vector3) float64 {
+	return hyp.Shape.pdf(hyp, ref, wi)
+}
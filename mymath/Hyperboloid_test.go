@@ -0,0 +1,113 @@
+package mymath_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"pbrt-go/material"
+	"pbrt-go/mymath"
+	"testing"
+)
+
+func TestNewHyperboloid(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	// x^2 + y^2 = 1/2 + z^2 / 2
+	h := mymath.NewHyperboloid(mymath.NewPoint3(1, 0, -1), mymath.NewPoint3(0, 1, 1), 360, &identity, &identity, false)
+
+	assert.InDelta(t, 2.0, h.Ah, equalDelta)
+	assert.InDelta(t, 1.0, h.Ch, equalDelta)
+	assert.Equal(t, -1.0, h.ZMin)
+	assert.Equal(t, 1.0, h.ZMax)
+	assert.Equal(t, 1.0, h.RMax)
+}
+
+func TestHyperboloid_ObjectBound(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	h := mymath.NewHyperboloid(mymath.NewPoint3(1, 0, -1), mymath.NewPoint3(0, 2, 1), 360, &identity, &identity, false)
+
+	assert.Equal(
+		t,
+		mymath.NewBounds3(mymath.NewPoint3(-2, -2, -1), mymath.NewPoint3(2, 2, 1)),
+		h.ObjectBound())
+}
+
+func TestHyperboloid_Intersect(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	h := mymath.NewHyperboloid(mymath.NewPoint3(1, 0, -1), mymath.NewPoint3(0, 1, 1), 360, &identity, &identity, false)
+
+	ray := mymath.NewRay(
+		mymath.NewPoint3(-5, 0, 0),
+		mymath.NewVector3(1, 0, 0),
+		50,
+		0,
+		material.Medium{})
+
+	ok, tHit, si := h.Intersect(ray, false)
+	assert.Equal(t, true, ok)
+	assert.InDelta(t, 5-1/math.Sqrt2, tHit, equalDelta)
+
+	InDeltaPoint3(t, mymath.NewPoint3(-1/math.Sqrt2, 0, 0), si.Interaction.P)
+	assert.InDelta(t, 3.0/8, si.Uv.X, equalDelta)
+	assert.InDelta(t, 0.5, si.Uv.Y, equalDelta)
+	InDeltaNormal3(t, mymath.NewNormal3(-1, 0, 0), si.Interaction.N)
+
+	InDeltaVector3(t, mymath.NewVector3(0, -math.Sqrt2*math.Pi, 0), si.Dpdu)
+	InDeltaVector3(t, mymath.NewVector3(0, -math.Sqrt2, 2), si.Dpdv)
+}
+
+// Hit points must lie on the implicit surface and match the swept line parametrization
+func TestHyperboloid_Intersect_random(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	p1 := mymath.NewPoint3(1, 0, -1)
+	p2 := mymath.NewPoint3(0, 1, 1)
+	h := mymath.NewHyperboloid(p1, p2, 360, &identity, &identity, false)
+
+	rng := rand.New(rand.NewSource(1))
+	hits := 0
+	for i := 0; i < 200; i++ {
+		o := mymath.NewPoint3(rng.Float64()*10-5, rng.Float64()*10-5, rng.Float64()*10-5)
+		target := mymath.NewPoint3(rng.Float64()-0.5, rng.Float64()-0.5, rng.Float64()*2-1)
+		ray := mymath.NewRay(o, target.SubtractP(o), 50, 0, material.Medium{})
+
+		ok, _, si := h.Intersect(ray, false)
+		assert.Equal(t, ok, h.IntersectP(h, ray, false))
+		if !ok {
+			continue
+		}
+		hits++
+
+		p := si.Interaction.P
+		assert.InDelta(t, 1.0, h.Ah*(p.X*p.X+p.Y*p.Y)-h.Ch*p.Z*p.Z, 1e-6)
+
+		// rotating the swept line point at v by phi = u * phiMax gives the hit point
+		u, v := si.Uv.X, si.Uv.Y
+		pr := p1.Multiply(1 - v).AddP(p2.Multiply(v))
+		phi := u * h.PhiMax
+		InDeltaPoint3(t, mymath.NewPoint3(pr.X*math.Cos(phi)-pr.Y*math.Sin(phi), pr.X*math.Sin(phi)+pr.Y*math.Cos(phi), pr.Z), p)
+	}
+
+	assert.Greater(t, hits, 50)
+}
+
+func TestHyperboloid_Area(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	// degenerates to cylinder
+	h := mymath.NewHyperboloid(mymath.NewPoint3(1, 0, 0), mymath.NewPoint3(1, 0, 1), 360, &identity, &identity, false)
+	assert.InDelta(t, 2*math.Pi, h.Area(), equalDelta)
+
+	// degenerates to cone
+	h = mymath.NewHyperboloid(mymath.NewPoint3(1, 0, 0), mymath.NewPoint3(0, 0, 2), 360, &identity, &identity, false)
+	assert.InDelta(t, math.Pi*math.Sqrt(5), h.Area(), equalDelta)
+
+	// reference value by numerical integration of |dpdu x dpdv|
+	h = mymath.NewHyperboloid(mymath.NewPoint3(1, 0, -1), mymath.NewPoint3(0, 1, 1), 360, &identity, &identity, false)
+	assert.InDelta(t, 10.767475571228394, h.Area(), equalDelta)
+
+	h = mymath.NewHyperboloid(mymath.NewPoint3(1, 0, -1), mymath.NewPoint3(0, 1, 1), 90, &identity, &identity, false)
+	assert.InDelta(t, 10.767475571228394/4, h.Area(), equalDelta)
+}
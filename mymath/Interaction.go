@@ -1,6 +1,9 @@
 package mymath
 
-import "pbrt-go/material"
+import (
+	"math"
+	"pbrt-go/material"
+)
 
 type Interaction struct {
 	P               Point3
@@ -17,3 +20,74 @@ func NewInteraction(p Point3, n Normal3, pError, wo Vector3, time float64, mediu
 func (i Interaction) IsSurfaceInteraction() bool {
 	return i.N != NewNormal3(0, 0, 0)
 }
+
+// SpawnRay creates ray leaving the interaction point in the direction d, the origin is offset
+// so that the ray does not re-intersect the surface
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/interaction.h#L80
+func (i Interaction) SpawnRay(d Vector3) Ray {
+	o := OffsetRayOrigin(i.P, i.PError, i.N, d)
+	return NewRay(o, d, math.Inf(1), float32(i.Time), i.GetMedium(d))
+}
+
+// SpawnRayTo creates ray leaving the interaction point towards the point p2, the ray stops just before reaching p2
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/interaction.h#L84
+func (i Interaction) SpawnRayTo(p2 Point3) Ray {
+	origin := OffsetRayOrigin(i.P, i.PError, i.N, p2.SubtractP(i.P))
+	d := p2.SubtractP(origin)
+	return NewRay(origin, d, 1-ShadowEpsilon, float32(i.Time), i.GetMedium(d))
+}
+
+// SpawnRayToInteraction creates ray between the two interactions, both of its end points are offset
+// from their surfaces
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/interaction.h#L89
+func (i Interaction) SpawnRayToInteraction(it Interaction) Ray {
+	pOrigin := OffsetRayOrigin(i.P, i.PError, i.N, it.P.SubtractP(i.P))
+	pTarget := OffsetRayOrigin(it.P, it.PError, it.N, pOrigin.SubtractP(it.P))
+	d := pTarget.SubtractP(pOrigin)
+	return NewRay(pOrigin, d, 1-ShadowEpsilon, float32(i.Time), i.GetMedium(d))
+}
+
+// GetMedium returns medium on the side of the surface given by direction w, participating media
+// are not supported yet so the empty medium is returned
+func (i Interaction) GetMedium(_ Vector3) material.Medium {
+	return material.Medium{}
+}
+
+// OffsetRayOrigin moves the point p along the normal n by the distance given by the error bounds pError,
+// the point is offset to the side of the surface where the direction w points
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/geometry.h#L1444
+func OffsetRayOrigin(p Point3, pError Vector3, n Normal3, w Vector3) Point3 {
+	nv := NewVector3N(n)
+	d := nv.Abs().Dot(pError)
+	offset := nv.Multiply(d)
+	if w.Dot(nv) < 0 {
+		offset = offset.Negate()
+	}
+
+	po := p.AddV(offset)
+
+	// Round offset point po away from p
+	if offset.X > 0 {
+		po.X = NextFloatUp(po.X)
+	} else if offset.X < 0 {
+		po.X = NextFloatDown(po.X)
+	}
+
+	if offset.Y > 0 {
+		po.Y = NextFloatUp(po.Y)
+	} else if offset.Y < 0 {
+		po.Y = NextFloatDown(po.Y)
+	}
+
+	if offset.Z > 0 {
+		po.Z = NextFloatUp(po.Z)
+	} else if offset.Z < 0 {
+		po.Z = NextFloatDown(po.Z)
+	}
+
+	return po
+}
@@ -0,0 +1,104 @@
+package mymath_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"pbrt-go/material"
+	"pbrt-go/mymath"
+	"testing"
+)
+
+func TestOffsetRayOrigin(t *testing.T) {
+	p := mymath.NewPoint3(1, 2, 3)
+	pError := mymath.NewVector3(0.1, 0.2, 0.3)
+	n := mymath.NewNormal3(0, 0, 1)
+
+	above := mymath.OffsetRayOrigin(p, pError, n, mymath.NewVector3(1, 0, 1))
+	assert.Equal(t, 1.0, above.X)
+	assert.Equal(t, 2.0, above.Y)
+	assert.Greater(t, above.Z, 3.3)
+
+	below := mymath.OffsetRayOrigin(p, pError, n, mymath.NewVector3(1, 0, -1))
+	assert.Less(t, below.Z, 2.7)
+}
+
+func TestInteraction_SpawnRayTo(t *testing.T) {
+	it := mymath.NewInteraction(mymath.NewPoint3(0, 0, 0), mymath.NewNormal3(0, 0, 1), mymath.NewVector3(0, 0, 0), mymath.NewVector3(0, 0, 0), 0.5, nil)
+
+	ray := it.SpawnRayTo(mymath.NewPoint3(0, 0, 2))
+	assert.Equal(t, 1-mymath.ShadowEpsilon, ray.TMax)
+	assert.Equal(t, float32(0.5), ray.Time)
+	InDeltaPoint3(t, mymath.NewPoint3(0, 0, 2), ray.Apply(1))
+
+	ray = it.SpawnRay(mymath.NewVector3(0, 1, 0))
+	assert.True(t, math.IsInf(ray.TMax, 1))
+}
+
+// randomTransform returns random composition of scale, rotation and translation together with its inverse
+func randomTransform(rng *rand.Rand) (mymath.Transform, mymath.Transform) {
+	r := func() float64 { return -10 + 20*rng.Float64() }
+
+	scale := mymath.NewTransformScale(float32(0.1+5*rng.Float64()), float32(0.1+5*rng.Float64()), float32(0.1+5*rng.Float64()))
+	rotate := mymath.NewTransformRotate(2*math.Pi*rng.Float64(), mymath.NewVector3(r(), r(), r()).Normalize())
+	translate := mymath.NewTransformTranslate(mymath.NewVector3(r(), r(), r()))
+
+	objectToWorld := translate.ApplyT(rotate).ApplyT(scale)
+	return objectToWorld, objectToWorld.Inverse()
+}
+
+// Rays spawned from the hit point must not hit the same surface again when shooting back towards the ray origin
+func TestInteraction_SpawnRay_noSelfIntersection(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	newShapes := []func(o2w, w2o *mymath.Transform) mymath.IShape{
+		func(o2w, w2o *mymath.Transform) mymath.IShape {
+			return mymath.NewSphere(1, -1, 1, 360, o2w, w2o, false)
+		},
+		func(o2w, w2o *mymath.Transform) mymath.IShape {
+			return mymath.NewCylinder(1, -1, 1, 360, o2w, w2o, false)
+		},
+		func(o2w, w2o *mymath.Transform) mymath.IShape { return mymath.NewDisk(0, 1, 0, 360, o2w, w2o, false) },
+	}
+
+	for _, newShape := range newShapes {
+		for i := 0; i < 1000; i++ {
+			objectToWorld, worldToObject := randomTransform(rng)
+			shape := newShape(&objectToWorld, &worldToObject)
+
+			// shoot the ray from random point towards random point of the shape
+			pObj := mymath.NewPoint3(-1+2*rng.Float64(), -1+2*rng.Float64(), -1+2*rng.Float64())
+			o := objectToWorld.ApplyP(pObj.Multiply(20))
+			target := shape.Sample(mymath.NewPoint2(rng.Float64(), rng.Float64()))
+			ray := mymath.NewRay(o, target.P.SubtractP(o), math.Inf(1), 0, material.Medium{})
+
+			ok, _, si := shape.Intersect(ray, false)
+			if !ok {
+				continue
+			}
+
+			// back to the origin
+			assert.False(t, shape.IntersectP(shape, si.SpawnRayTo(o), false))
+
+			// to the point between the hit and the origin
+			other := mymath.NewInteraction(si.P.Lerp(rng.Float64(), o), mymath.NewNormal3(0, 0, 0), mymath.NewVector3(0, 0, 0), mymath.NewVector3(0, 0, 0), 0, nil)
+			assert.False(t, shape.IntersectP(shape, si.SpawnRayToInteraction(other), false))
+		}
+	}
+}
+
+// Chord between two points on the sphere must not intersect the sphere, both end points are offset
+func TestInteraction_SpawnRayToInteraction_sphereChord(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+
+	for i := 0; i < 1000; i++ {
+		objectToWorld, worldToObject := randomTransform(rng)
+		sphere := mymath.NewSphere(1, -1, 1, 360, &objectToWorld, &worldToObject, false)
+
+		it1 := sphere.Sample(mymath.NewPoint2(rng.Float64(), rng.Float64()))
+		it2 := sphere.Sample(mymath.NewPoint2(rng.Float64(), rng.Float64()))
+
+		assert.False(t, sphere.IntersectP(sphere, it1.SpawnRayToInteraction(it2), false))
+		assert.False(t, sphere.IntersectP(sphere, it2.SpawnRayToInteraction(it1), false))
+	}
+}
@@ -0,0 +1,271 @@
+package mymath
+
+import "math"
+
+// CatmullRomWeights returns the offset of the first node and the weights of the four nodes of the Catmull-Rom spline
+// passing through x, returns false when x lies outside the nodes
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/interpolation.cpp#L41
+func CatmullRomWeights(nodes []float64, x float64) (bool, int, [4]float64) {
+	var weights [4]float64
+	size := len(nodes)
+
+	// Return false if x is out of bounds
+	if !(x >= nodes[0] && x <= nodes[size-1]) {
+		return false, 0, weights
+	}
+
+	// Search for the interval idx containing x
+	idx := FindInterval(size, func(i int) bool { return nodes[i] <= x })
+	offset := idx - 1
+	x0, x1 := nodes[idx], nodes[idx+1]
+
+	// Compute the t parameter and powers
+	t := (x - x0) / (x1 - x0)
+	t2 := t * t
+	t3 := t2 * t
+
+	// Compute initial node weights w1 and w2
+	weights[1] = 2*t3 - 3*t2 + 1
+	weights[2] = -2*t3 + 3*t2
+
+	// Compute first node weight w0
+	if idx > 0 {
+		w0 := (t3 - 2*t2 + t) * (x1 - x0) / (x1 - nodes[idx-1])
+		weights[0] = -w0
+		weights[2] += w0
+	} else {
+		w0 := t3 - 2*t2 + t
+		weights[0] = 0
+		weights[1] -= w0
+		weights[2] += w0
+	}
+
+	// Compute last node weight w3
+	if idx+2 < size {
+		w3 := (t3 - t2) * (x1 - x0) / (nodes[idx+2] - x0)
+		weights[1] -= w3
+		weights[3] = w3
+	} else {
+		w3 := t3 - t2
+		weights[1] -= w3
+		weights[2] += w3
+		weights[3] = 0
+	}
+
+	return true, offset, weights
+}
+
+// SampleCatmullRom2D samples the second dimension of the 2D spline function given by values on the grid
+// nodes1 x nodes2, the first dimension is fixed to alpha. The cdf holds the running integrals of the rows computed
+// by IntegrateCatmullRom. Returns the sample, the function value and the pdf at the sample.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/interpolation.cpp#L146
+func SampleCatmullRom2D(nodes1, nodes2, values, cdf []float64, alpha, u float64) (float64, float64, float64) {
+	size2 := len(nodes2)
+
+	// Determine offset and coefficients for the alpha parameter
+	ok, offset, weights := CatmullRomWeights(nodes1, alpha)
+	if !ok {
+		return 0, 0, 0
+	}
+
+	// Define a function to interpolate table entries
+	interpolate := func(array []float64, idx int) float64 {
+		value := 0.0
+		for i := 0; i < 4; i++ {
+			if weights[i] != 0 {
+				value += array[(offset+i)*size2+idx] * weights[i]
+			}
+		}
+		return value
+	}
+
+	// Map u to a spline interval by inverting the interpolated cdf
+	maximum := interpolate(cdf, size2-1)
+	u *= maximum
+	idx := FindInterval(size2, func(i int) bool { return interpolate(cdf, i) <= u })
+
+	// Look up node positions and interpolated function values
+	f0, f1 := interpolate(values, idx), interpolate(values, idx+1)
+	x0, x1 := nodes2[idx], nodes2[idx+1]
+	width := x1 - x0
+
+	// Re-scale u using the interpolated cdf
+	u = (u - interpolate(cdf, idx)) / width
+
+	// Approximate derivatives using finite differences of the interpolant
+	var d0, d1 float64
+	if idx > 0 {
+		d0 = width * (f1 - interpolate(values, idx-1)) / (x1 - nodes2[idx-1])
+	} else {
+		d0 = f1 - f0
+	}
+	if idx+2 < size2 {
+		d1 = width * (interpolate(values, idx+2) - f0) / (nodes2[idx+2] - x0)
+	} else {
+		d1 = f1 - f0
+	}
+
+	// Invert definite integral over spline segment and return solution
+
+	// Set initial guess for t by importance sampling a linear interpolant
+	var t float64
+	if f0 != f1 {
+		t = (f0 - math.Sqrt(math.Max(0, f0*f0+2*u*(f1-f0)))) / (f0 - f1)
+	} else {
+		t = u / f0
+	}
+
+	a, b := 0.0, 1.0
+	var FHat, fHat float64
+	for {
+		// Fall back to a bisection step when t is out of bounds
+		if !(t >= a && t <= b) {
+			t = 0.5 * (a + b)
+		}
+
+		// Evaluate target function and its derivative in Horner form
+		FHat = t * (f0 + t*(.5*d0+t*((1.0/3.0)*(-2*d0-d1)+f1-f0+t*(.25*(d0+d1)+.5*(f0-f1)))))
+		fHat = f0 + t*(d0+t*(-2*d0-d1+3*(f1-f0)+t*(d0+d1+2*(f0-f1))))
+
+		// Stop the iteration if converged
+		if math.Abs(FHat-u) < 1e-6 || b-a < 1e-6 {
+			break
+		}
+
+		// Update bisection bounds using updated t
+		if FHat-u < 0 {
+			a = t
+		} else {
+			b = t
+		}
+
+		// Perform a Newton step
+		t -= (FHat - u) / fHat
+	}
+
+	return x0 + width*t, fHat, fHat / maximum
+}
+
+// IntegrateCatmullRom fills the cdf with the running integral of the spline function given by values in nodes x,
+// returns the integral over the whole domain
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/interpolation.cpp#L230
+func IntegrateCatmullRom(x, values, cdf []float64) float64 {
+	n := len(x)
+	sum := 0.0
+	cdf[0] = 0
+	for i := 0; i < n-1; i++ {
+		// Look up x_i and function values of spline segment i
+		x0, x1 := x[i], x[i+1]
+		f0, f1 := values[i], values[i+1]
+		width := x1 - x0
+
+		// Approximate derivatives using finite differences
+		var d0, d1 float64
+		if i > 0 {
+			d0 = width * (f1 - values[i-1]) / (x1 - x[i-1])
+		} else {
+			d0 = f1 - f0
+		}
+		if i+2 < n {
+			d1 = width * (values[i+2] - f0) / (x[i+2] - x0)
+		} else {
+			d1 = f1 - f0
+		}
+
+		// Keep a running sum and build a cumulative distribution function
+		sum += ((d0-d1)*(1.0/12.0) + (f0+f1)*.5) * width
+		cdf[i+1] = sum
+	}
+
+	return sum
+}
+
+// Fourier evaluates the even Fourier series with coefficients a at the angle with the cosine cosPhi
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/interpolation.cpp#L307
+func Fourier(a []float64, cosPhi float64) float64 {
+	value := 0.0
+
+	// Initialize cosine iterates
+	cosKMinusOnePhi := cosPhi
+	cosKPhi := 1.0
+	for k := range a {
+		// Add the current summand and update the cosine iterates
+		value += a[k] * cosKPhi
+		cosKPlusOnePhi := 2*cosPhi*cosKPhi - cosKMinusOnePhi
+		cosKMinusOnePhi = cosKPhi
+		cosKPhi = cosKPlusOnePhi
+	}
+
+	return value
+}
+
+// SampleFourier samples the angle phi proportionally to the even Fourier series with coefficients ak, recip holds
+// the reciprocals 1/k. Returns the series value, the pdf and the angle phi.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/interpolation.cpp#L323
+func SampleFourier(ak, recip []float64, u float64) (float64, float64, float64) {
+	// Pick a side and declare bisection variables
+	flip := u >= 0.5
+	if flip {
+		u = 1 - 2*(u-.5)
+	} else {
+		u *= 2
+	}
+
+	a, b, phi := 0.0, math.Pi, 0.5*math.Pi
+	var F, f float64
+	for {
+		// Evaluate F(phi) and its derivative f(phi)
+
+		// Initialize sine and cosine iterates
+		cosPhi := math.Cos(phi)
+		sinPhi := math.Sqrt(math.Max(0, 1-cosPhi*cosPhi))
+		cosPhiPrev, cosPhiCur := cosPhi, 1.0
+		sinPhiPrev, sinPhiCur := -sinPhi, 0.0
+
+		// Initialize F and f with the first series term
+		F = ak[0] * phi
+		f = ak[0]
+		for k := 1; k < len(ak); k++ {
+			// Compute next sine and cosine iterates
+			sinPhiNext := 2*cosPhi*sinPhiCur - sinPhiPrev
+			cosPhiNext := 2*cosPhi*cosPhiCur - cosPhiPrev
+			sinPhiPrev, sinPhiCur = sinPhiCur, sinPhiNext
+			cosPhiPrev, cosPhiCur = cosPhiCur, cosPhiNext
+
+			// Add the next series term to F and f
+			F += ak[k] * recip[k] * sinPhiNext
+			f += ak[k] * cosPhiNext
+		}
+		F -= u * ak[0] * math.Pi
+
+		// Update bisection bounds using updated phi
+		if F > 0 {
+			b = phi
+		} else {
+			a = phi
+		}
+
+		// Stop the Fourier bisection iteration if converged
+		if math.Abs(F) < 1e-6 || b-a < 1e-6 {
+			break
+		}
+
+		// Perform a Newton step given f(phi) and F(phi)
+		phi -= F / f
+		if !(phi > a && phi < b) {
+			phi = 0.5 * (a + b)
+		}
+	}
+
+	// Potentially flip phi and return the result
+	if flip {
+		phi = 2*math.Pi - phi
+	}
+
+	return f, f / (2 * math.Pi * ak[0]), phi
+}
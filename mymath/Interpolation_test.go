@@ -0,0 +1,119 @@
+package mymath_test
+
+import (
+	"math"
+	"pbrt-go/mymath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatmullRomWeights(t *testing.T) {
+	nodes := []float64{-1, -0.5, 0, 0.2, 0.7, 1}
+
+	ok, _, _ := mymath.CatmullRomWeights(nodes, -1.1)
+	assert.False(t, ok)
+	ok, _, _ = mymath.CatmullRomWeights(nodes, 1.1)
+	assert.False(t, ok)
+
+	// the node value is picked exactly
+	ok, offset, weights := mymath.CatmullRomWeights(nodes, 0.2)
+	assert.True(t, ok)
+	assert.Equal(t, 2, offset)
+	assert.Equal(t, [4]float64{0, 1, 0, 0}, weights)
+
+	// linear functions are reproduced exactly, including the boundary intervals
+	linear := func(x float64) float64 { return 2*x - 0.3 }
+	for _, x := range []float64{-1, -0.8, -0.1, 0.1, 0.5, 0.95, 1} {
+		ok, offset, weights := mymath.CatmullRomWeights(nodes, x)
+		assert.True(t, ok)
+
+		sum, value := 0.0, 0.0
+		for i, w := range weights {
+			if w != 0 {
+				sum += w
+				value += w * linear(nodes[offset+i])
+			}
+		}
+		assert.InDelta(t, 1, sum, equalDelta)
+		assert.InDelta(t, linear(x), value, equalDelta, "x = %v", x)
+	}
+}
+
+func TestIntegrateCatmullRom(t *testing.T) {
+	x := []float64{0, 0.1, 0.3, 0.6, 1}
+	values := make([]float64, len(x))
+	for i, xi := range x {
+		values[i] = 3*xi + 1
+	}
+
+	cdf := make([]float64, len(x))
+	assert.InDelta(t, 2.5, mymath.IntegrateCatmullRom(x, values, cdf), equalDelta)
+	assert.Equal(t, 0.0, cdf[0])
+	assert.InDelta(t, 0.6*0.6*1.5+0.6, cdf[3], equalDelta)
+	assert.InDelta(t, 2.5, cdf[4], equalDelta)
+}
+
+func TestSampleCatmullRom2D(t *testing.T) {
+	nodes1 := []float64{0, 1}
+	nodes2 := []float64{0, 0.25, 0.5, 0.75, 1}
+
+	// constant function for alpha 0, linear function for alpha 1
+	values := make([]float64, 2*len(nodes2))
+	cdf := make([]float64, 2*len(nodes2))
+	for i, x := range nodes2 {
+		values[i] = 1
+		values[len(nodes2)+i] = x
+	}
+	mymath.IntegrateCatmullRom(nodes2, values[:len(nodes2)], cdf[:len(nodes2)])
+	mymath.IntegrateCatmullRom(nodes2, values[len(nodes2):], cdf[len(nodes2):])
+
+	for _, u := range []float64{0, 0.1, 0.5, 0.9, 0.99} {
+		x, f, pdf := mymath.SampleCatmullRom2D(nodes1, nodes2, values, cdf, 0, u)
+		assert.InDelta(t, u, x, equalDelta)
+		assert.InDelta(t, 1, f, equalDelta)
+		assert.InDelta(t, 1, pdf, equalDelta)
+
+		x, f, pdf = mymath.SampleCatmullRom2D(nodes1, nodes2, values, cdf, 1, u)
+		assert.InDelta(t, math.Sqrt(u), x, 1e-5)
+		assert.InDelta(t, x, f, 1e-5)
+		assert.InDelta(t, 2*x, pdf, 1e-5)
+	}
+
+	x, f, pdf := mymath.SampleCatmullRom2D(nodes1, nodes2, values, cdf, 2, 0.5)
+	assert.Equal(t, [3]float64{0, 0, 0}, [3]float64{x, f, pdf})
+}
+
+func TestFourier(t *testing.T) {
+	a := []float64{0.5, 0.3, -0.2, 0.1}
+	for _, phi := range []float64{0, 0.3, 1.5, 3, 5} {
+		expected := 0.0
+		for k, ak := range a {
+			expected += ak * math.Cos(float64(k)*phi)
+		}
+		assert.InDelta(t, expected, mymath.Fourier(a, math.Cos(phi)), equalDelta)
+	}
+	assert.Equal(t, 0.0, mymath.Fourier(nil, 0.5))
+}
+
+func TestSampleFourier(t *testing.T) {
+	a := []float64{0.5, 0.3, -0.2, 0.1}
+	recip := []float64{math.Inf(1), 1, 0.5, 1.0 / 3}
+
+	// integral of the series from 0 to phi
+	integral := func(phi float64) float64 {
+		sum := a[0] * phi
+		for k := 1; k < len(a); k++ {
+			sum += a[k] * math.Sin(float64(k)*phi) / float64(k)
+		}
+		return sum
+	}
+
+	for _, u := range []float64{0.01, 0.2, 0.5, 0.7, 0.99} {
+		f, pdf, phi := mymath.SampleFourier(a, recip, u)
+		assert.True(t, phi >= 0 && phi <= 2*math.Pi)
+		assert.InDelta(t, mymath.Fourier(a, math.Cos(phi)), f, equalDelta)
+		assert.InDelta(t, f/(2*math.Pi*a[0]), pdf, equalDelta)
+		assert.InDelta(t, u, integral(phi)/(2*math.Pi*a[0]), 1e-5, "u = %v", u)
+	}
+}
@@ -0,0 +1,41 @@
+package mymath
+
+import (
+	"math"
+	"pbrt-go/spectrum"
+)
+
+// LambertianReflection scatters the light equally in all directions of the hemisphere
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L359
+type LambertianReflection struct {
+	R spectrum.Spectrum
+}
+
+func NewLambertianReflection(r spectrum.Spectrum) *LambertianReflection {
+	return &LambertianReflection{r}
+}
+
+func (b *LambertianReflection) F(_, _ Vector3) spectrum.Spectrum {
+	return b.R.Divide(math.Pi)
+}
+
+func (b *LambertianReflection) SampleF(wo Vector3, u Point2) (Vector3, spectrum.Spectrum, float64, BxDFType) {
+	return CosineSampleF(b, wo, u)
+}
+
+func (b *LambertianReflection) Pdf(wo, wi Vector3) float64 {
+	return CosinePdf(wo, wi)
+}
+
+func (b *LambertianReflection) Rho(_ Vector3, _ []Point2) spectrum.Spectrum {
+	return b.R
+}
+
+func (b *LambertianReflection) RhoHH(_, _ []Point2) spectrum.Spectrum {
+	return b.R
+}
+
+func (b *LambertianReflection) Type() BxDFType {
+	return BSDFReflection | BSDFDiffuse
+}
@@ -0,0 +1,51 @@
+package mymath
+
+import (
+	"math"
+	"pbrt-go/spectrum"
+)
+
+// LambertianTransmission scatters the light equally in all directions of the opposite hemisphere
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L380
+type LambertianTransmission struct {
+	T spectrum.Spectrum
+}
+
+func NewLambertianTransmission(t spectrum.Spectrum) *LambertianTransmission {
+	return &LambertianTransmission{t}
+}
+
+func (b *LambertianTransmission) F(_, _ Vector3) spectrum.Spectrum {
+	return b.T.Divide(math.Pi)
+}
+
+// SampleF samples wi by the cosine-weighted hemisphere opposite to wo
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L250
+func (b *LambertianTransmission) SampleF(wo Vector3, u Point2) (Vector3, spectrum.Spectrum, float64, BxDFType) {
+	wi := CosineSampleHemisphere(u)
+	if wo.Z > 0 {
+		wi.Z *= -1
+	}
+	return wi, b.F(wo, wi), b.Pdf(wo, wi), b.Type()
+}
+
+func (b *LambertianTransmission) Pdf(wo, wi Vector3) float64 {
+	if !SameHemisphere(wo, wi) {
+		return AbsCosTheta(wi) / math.Pi
+	}
+	return 0
+}
+
+func (b *LambertianTransmission) Rho(_ Vector3, _ []Point2) spectrum.Spectrum {
+	return b.T
+}
+
+func (b *LambertianTransmission) RhoHH(_, _ []Point2) spectrum.Spectrum {
+	return b.T
+}
+
+func (b *LambertianTransmission) Type() BxDFType {
+	return BSDFTransmission | BSDFDiffuse
+}
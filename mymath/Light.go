@@ -0,0 +1,11 @@
+package mymath
+
+import "pbrt-go/spectrum"
+
+// AreaLight is light source emitting from the surface of the primitive
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/light.h#L111
+type AreaLight interface {
+	// L returns the radiance emitted from the point on the surface in the outgoing direction w
+	L(intr Interaction, w Vector3) spectrum.Spectrum
+}
@@ -0,0 +1,554 @@
+package mymath
+
+import (
+	"math"
+)
+
+func nextIndex(i int) int {
+	return (i + 1) % 3
+}
+
+func prevIndex(i int) int {
+	return (i + 2) % 3
+}
+
+// sdVertex see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/loopsubdiv.cpp#L46
+type sdVertex struct {
+	id        int
+	p         Point3
+	startFace *sdFace
+	child     *sdVertex
+	boundary  bool
+}
+
+// sdFace see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/loopsubdiv.cpp#L60
+type sdFace struct {
+	v        [3]*sdVertex
+	f        [3]*sdFace
+	children [4]*sdFace
+	// sharp marks crease edges, edge i goes from v[i] to v[nextIndex(i)]
+	sharp [3]bool
+}
+
+// sdEdge identifies edge regardless of its orientation
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/loopsubdiv.cpp#L100
+type sdEdge struct {
+	v0, v1 int
+}
+
+func newSDEdge(v0, v1 *sdVertex) sdEdge {
+	if v0.id < v1.id {
+		return sdEdge{v0.id, v1.id}
+	}
+
+	return sdEdge{v1.id, v0.id}
+}
+
+// sdEdgeFace remembers the first face seen for the edge while building the neighbor pointers
+type sdEdgeFace struct {
+	f0        *sdFace
+	f0edgeNum int
+}
+
+func (f *sdFace) vnum(vert *sdVertex) int {
+	for i := 0; i < 3; i++ {
+		if f.v[i] == vert {
+			return i
+		}
+	}
+
+	panic("Basic logic error in sdFace.vnum()")
+}
+
+func (f *sdFace) nextFace(vert *sdVertex) *sdFace {
+	return f.f[f.vnum(vert)]
+}
+
+func (f *sdFace) prevFace(vert *sdVertex) *sdFace {
+	return f.f[prevIndex(f.vnum(vert))]
+}
+
+func (f *sdFace) nextVert(vert *sdVertex) *sdVertex {
+	return f.v[nextIndex(f.vnum(vert))]
+}
+
+func (f *sdFace) prevVert(vert *sdVertex) *sdVertex {
+	return f.v[prevIndex(f.vnum(vert))]
+}
+
+func (f *sdFace) otherVert(v0, v1 *sdVertex) *sdVertex {
+	for i := 0; i < 3; i++ {
+		if f.v[i] != v0 && f.v[i] != v1 {
+			return f.v[i]
+		}
+	}
+
+	panic("Basic logic error in sdFace.otherVert()")
+}
+
+// isSharp reports if the edge is on the boundary or it is a crease
+func (f *sdFace) isSharp(edgeNum int) bool {
+	return f.f[edgeNum] == nil || f.sharp[edgeNum]
+}
+
+// valence see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/loopsubdiv.cpp#L132
+func (v *sdVertex) valence() int {
+	f := v.startFace
+	if !v.boundary {
+		// Compute valence of interior vertex
+		nf := 1
+		for f = f.nextFace(v); f != v.startFace; f = f.nextFace(v) {
+			nf++
+		}
+
+		return nf
+	}
+
+	// Compute valence of boundary vertex
+	nf := 1
+	for f = f.nextFace(v); f != nil; f = f.nextFace(v) {
+		nf++
+	}
+
+	f = v.startFace
+	for f = f.prevFace(v); f != nil; f = f.prevFace(v) {
+		nf++
+	}
+
+	return nf + 1
+}
+
+// oneRing see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/loopsubdiv.cpp#L444
+func (v *sdVertex) oneRing() []Point3 {
+	p := make([]Point3, 0, v.valence())
+
+	if !v.boundary {
+		// Get one-ring vertices for interior vertex
+		face := v.startFace
+		for ok := true; ok; ok = face != v.startFace {
+			p = append(p, face.nextVert(v).p)
+			face = face.nextFace(v)
+		}
+
+		return p
+	}
+
+	// Get one-ring vertices for boundary vertex
+	face := v.startFace
+	for f2 := face.nextFace(v); f2 != nil; f2 = face.nextFace(v) {
+		face = f2
+	}
+
+	p = append(p, face.nextVert(v).p)
+	for face != nil {
+		p = append(p, face.prevVert(v).p)
+		face = face.prevFace(v)
+	}
+
+	return p
+}
+
+// faces returns all faces sharing the vertex
+func (v *sdVertex) faces() []*sdFace {
+	faces := []*sdFace{v.startFace}
+
+	for f := v.startFace.nextFace(v); f != nil && f != v.startFace; f = f.nextFace(v) {
+		faces = append(faces, f)
+	}
+
+	if v.boundary {
+		for f := v.startFace.prevFace(v); f != nil; f = f.prevFace(v) {
+			faces = append(faces, f)
+		}
+	}
+
+	return faces
+}
+
+// sharpNeighbors returns vertices connected to this vertex by boundary or crease edges
+func (v *sdVertex) sharpNeighbors() []*sdVertex {
+	var neighbors []*sdVertex
+
+	for _, f := range v.faces() {
+		i := f.vnum(v)
+		if f.isSharp(i) {
+			neighbors = append(neighbors, f.v[nextIndex(i)])
+		}
+
+		// boundary edges are seen from single face only, interior creases are found as the next edge of the neighbor
+		if f.f[prevIndex(i)] == nil {
+			neighbors = append(neighbors, f.v[prevIndex(i)])
+		}
+	}
+
+	return neighbors
+}
+
+// sector returns the faces and one-ring vertices of the fan around the vertex bounded by sharp edges.
+// The ring is ordered the same way as the ring of boundary vertex, so the boundary tangent rules apply.
+func (v *sdVertex) sector(start *sdFace) ([]*sdFace, []Point3) {
+	// Go to the face whose next edge is sharp
+	face := start
+	for !face.isSharp(face.vnum(v)) {
+		face = face.nextFace(v)
+	}
+
+	faces := []*sdFace{}
+	ring := []Point3{face.nextVert(v).p}
+	for {
+		faces = append(faces, face)
+		ring = append(ring, face.prevVert(v).p)
+
+		i := face.vnum(v)
+		if face.isSharp(prevIndex(i)) {
+			break
+		}
+		face = face.f[prevIndex(i)]
+	}
+
+	return faces, ring
+}
+
+// loopBeta see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/loopsubdiv.cpp#L154
+func loopBeta(valence int) float64 {
+	if valence == 3 {
+		return 3.0 / 16.0
+	}
+
+	return 3.0 / (8.0 * float64(valence))
+}
+
+// loopGamma see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/loopsubdiv.cpp#L161
+func loopGamma(valence int) float64 {
+	return 1.0 / (float64(valence) + 3.0/(8.0*loopBeta(valence)))
+}
+
+// weightOneRing see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/loopsubdiv.cpp#L432
+func weightOneRing(vert *sdVertex, beta float64) Point3 {
+	// Put vert one-ring in pRing
+	pRing := vert.oneRing()
+	valence := len(pRing)
+
+	p := vert.p.Multiply(1 - float64(valence)*beta)
+	for i := 0; i < valence; i++ {
+		p = p.AddP(pRing[i].Multiply(beta))
+	}
+
+	return p
+}
+
+// weightCrease applies boundary rule along the two sharp edges
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/loopsubdiv.cpp#L467
+func weightCrease(vert *sdVertex, neighbors []*sdVertex, beta float64) Point3 {
+	return vert.p.Multiply(1 - 2*beta).
+		AddP(neighbors[0].p.Multiply(beta)).
+		AddP(neighbors[1].p.Multiply(beta))
+}
+
+// LoopSubdivide refines the control mesh nLevels times by Loop subdivision scheme and projects the
+// vertices to the limit surface. Boundary edges and crease edges given as vertex index pairs stay sharp.
+// Vertex with exactly two sharp edges follows the crease curve, vertex with more of them is a corner
+// and does not move. Limit normals are smooth across the surface but discontinuous along the creases.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/loopsubdiv.cpp#L169
+func LoopSubdivide(objectToWorld, worldToObject *Transform, reverseOrientation bool, nLevels, nIndices int, vertexIndices []int, nVertices int, p []Point3, creases []int) []IShape {
+	// Allocate LoopSubdiv vertices and faces
+	vertices := make([]*sdVertex, nVertices)
+	for i := 0; i < nVertices; i++ {
+		vertices[i] = &sdVertex{id: i, p: p[i]}
+	}
+
+	nFaces := nIndices / 3
+	faces := make([]*sdFace, nFaces)
+	for i := 0; i < nFaces; i++ {
+		faces[i] = &sdFace{}
+	}
+
+	// Set face to vertex pointers
+	for i := 0; i < nFaces; i++ {
+		f := faces[i]
+		for j := 0; j < 3; j++ {
+			v := vertices[vertexIndices[3*i+j]]
+			f.v[j] = v
+			v.startFace = f
+		}
+	}
+
+	creaseEdges := map[sdEdge]bool{}
+	for i := 0; i+1 < len(creases); i += 2 {
+		creaseEdges[newSDEdge(vertices[creases[i]], vertices[creases[i+1]])] = true
+	}
+
+	// Set neighbor pointers in faces
+	edges := map[sdEdge]sdEdgeFace{}
+	for i := 0; i < nFaces; i++ {
+		f := faces[i]
+		for edgeNum := 0; edgeNum < 3; edgeNum++ {
+			// Update neighbor pointer for edgeNum
+			v0, v1 := edgeNum, nextIndex(edgeNum)
+			e := newSDEdge(f.v[v0], f.v[v1])
+			f.sharp[edgeNum] = creaseEdges[e]
+
+			if ef, ok := edges[e]; !ok {
+				// Handle new edge
+				edges[e] = sdEdgeFace{f, edgeNum}
+			} else {
+				// Handle previously seen edge
+				ef.f0.f[ef.f0edgeNum] = f
+				f.f[edgeNum] = ef.f0
+				delete(edges, e)
+			}
+		}
+	}
+
+	// Finish vertex initialization
+	for i := 0; i < nVertices; i++ {
+		v := vertices[i]
+		f := v.startFace
+		for ok := true; ok; ok = f != nil && f != v.startFace {
+			f = f.nextFace(v)
+		}
+		v.boundary = f == nil
+	}
+
+	// Refine LoopSubdiv into triangles
+	f := faces
+	v := vertices
+	for i := 0; i < nLevels; i++ {
+		// Update f and v for next level of subdivision
+		newFaces := make([]*sdFace, 0, 4*len(f))
+		newVertices := make([]*sdVertex, 0, 2*len(v))
+
+		// Allocate next level of children in mesh tree
+		for _, vertex := range v {
+			vertex.child = &sdVertex{id: len(newVertices), boundary: vertex.boundary}
+			newVertices = append(newVertices, vertex.child)
+		}
+
+		for _, face := range f {
+			for k := 0; k < 4; k++ {
+				face.children[k] = &sdFace{}
+				newFaces = append(newFaces, face.children[k])
+			}
+		}
+
+		// Update vertex positions and create new edge vertices
+
+		// Update vertex positions for even vertices
+		for _, vertex := range v {
+			neighbors := vertex.sharpNeighbors()
+			switch {
+			case len(neighbors) < 2:
+				// Apply one-ring rule for even vertex
+				vertex.child.p = weightOneRing(vertex, loopBeta(vertex.valence()))
+			case len(neighbors) == 2:
+				// Apply boundary rule for even vertex
+				vertex.child.p = weightCrease(vertex, neighbors, 1.0/8.0)
+			default:
+				// Corner vertex stays in place
+				vertex.child.p = vertex.p
+			}
+		}
+
+		// Compute new odd edge vertices
+		edgeVerts := map[sdEdge]*sdVertex{}
+		for _, face := range f {
+			for k := 0; k < 3; k++ {
+				// Compute odd vertex on kth edge
+				v0, v1 := face.v[k], face.v[nextIndex(k)]
+				edge := newSDEdge(v0, v1)
+				if _, ok := edgeVerts[edge]; ok {
+					continue
+				}
+
+				// Create and initialize new odd vertex
+				vert := &sdVertex{id: len(newVertices)}
+				newVertices = append(newVertices, vert)
+				vert.boundary = face.f[k] == nil
+				vert.startFace = face.children[3]
+
+				// Apply edge rules to compute new vertex position
+				if face.isSharp(k) {
+					vert.p = v0.p.Multiply(0.5).AddP(v1.p.Multiply(0.5))
+				} else {
+					vert.p = v0.p.Multiply(3.0 / 8.0).
+						AddP(v1.p.Multiply(3.0 / 8.0)).
+						AddP(face.otherVert(v0, v1).p.Multiply(1.0 / 8.0)).
+						AddP(face.f[k].otherVert(v0, v1).p.Multiply(1.0 / 8.0))
+				}
+				edgeVerts[edge] = vert
+			}
+		}
+
+		// Update new mesh topology
+
+		// Update even vertex face pointers
+		for _, vertex := range v {
+			vertNum := vertex.startFace.vnum(vertex)
+			vertex.child.startFace = vertex.startFace.children[vertNum]
+		}
+
+		// Update face neighbor pointers
+		for _, face := range f {
+			for j := 0; j < 3; j++ {
+				// Update children f pointers for siblings
+				face.children[3].f[j] = face.children[nextIndex(j)]
+				face.children[j].f[nextIndex(j)] = face.children[3]
+
+				// Update children f pointers for neighbor children
+				f2 := face.f[j]
+				if f2 != nil {
+					face.children[j].f[j] = f2.children[f2.vnum(face.v[j])]
+				}
+
+				f2 = face.f[prevIndex(j)]
+				if f2 != nil {
+					face.children[j].f[prevIndex(j)] = f2.children[f2.vnum(face.v[j])]
+				}
+
+				// Halves of the crease edge are creases too
+				face.children[j].sharp[j] = face.sharp[j]
+				face.children[j].sharp[prevIndex(j)] = face.sharp[prevIndex(j)]
+			}
+		}
+
+		// Update face vertex pointers
+		for _, face := range f {
+			for j := 0; j < 3; j++ {
+				// Update child vertex pointer to new even vertex
+				face.children[j].v[j] = face.v[j].child
+
+				// Update child vertex pointer to new odd vertex
+				vert := edgeVerts[newSDEdge(face.v[j], face.v[nextIndex(j)])]
+				face.children[j].v[nextIndex(j)] = vert
+				face.children[nextIndex(j)].v[j] = vert
+				face.children[3].v[j] = vert
+			}
+		}
+
+		// Prepare for next level of subdivision
+		f = newFaces
+		v = newVertices
+	}
+
+	// Push vertices to limit surface
+	pLimit := make([]Point3, len(v))
+	for i, vertex := range v {
+		neighbors := vertex.sharpNeighbors()
+		switch {
+		case len(neighbors) < 2:
+			pLimit[i] = weightOneRing(vertex, loopGamma(vertex.valence()))
+		case len(neighbors) == 2:
+			pLimit[i] = weightCrease(vertex, neighbors, 1.0/5.0)
+		default:
+			pLimit[i] = vertex.p
+		}
+	}
+
+	for i, vertex := range v {
+		vertex.p = pLimit[i]
+	}
+
+	// Compute vertex tangents on limit surface, vertices on creases are split to one copy per sector
+	// so that the normals can differ on both sides of the crease. The one-ring goes clockwise around
+	// the counter-clockwise faces, so the normal is T x S to agree with the winding of the faces.
+	pOut := make([]Point3, 0, len(v))
+	nOut := make([]Normal3, 0, len(v))
+	faceVertex := map[*sdFace][3]int{}
+	for _, face := range f {
+		faceVertex[face] = [3]int{-1, -1, -1}
+	}
+
+	setFaceVertex := func(face *sdFace, vertex *sdVertex, index int) {
+		fv := faceVertex[face]
+		fv[face.vnum(vertex)] = index
+		faceVertex[face] = fv
+	}
+
+	for _, vertex := range v {
+		neighbors := vertex.sharpNeighbors()
+
+		if len(neighbors) < 2 {
+			// Compute tangents of interior face
+			pRing := vertex.oneRing()
+			valence := len(pRing)
+
+			S := NewVector3(0, 0, 0)
+			T := NewVector3(0, 0, 0)
+			for j := 0; j < valence; j++ {
+				S = S.Add(NewVector3P(pRing[j]).Multiply(math.Cos(2 * math.Pi * float64(j) / float64(valence))))
+				T = T.Add(NewVector3P(pRing[j]).Multiply(math.Sin(2 * math.Pi * float64(j) / float64(valence))))
+			}
+
+			for _, face := range vertex.faces() {
+				setFaceVertex(face, vertex, len(pOut))
+			}
+			pOut = append(pOut, vertex.p)
+			nOut = append(nOut, NewNormal3V(T.Cross(S)))
+
+			continue
+		}
+
+		for _, start := range vertex.faces() {
+			if faceVertex[start][start.vnum(vertex)] >= 0 {
+				// sector already processed
+				continue
+			}
+
+			sectorFaces, pRing := vertex.sector(start)
+			S, T := boundaryTangents(vertex.p, pRing)
+
+			for _, face := range sectorFaces {
+				setFaceVertex(face, vertex, len(pOut))
+			}
+			pOut = append(pOut, vertex.p)
+			nOut = append(nOut, NewNormal3V(T.Cross(S)))
+		}
+	}
+
+	// Create triangle mesh from subdivision mesh
+	nTris := len(f)
+	verts := make([]int, 0, 3*nTris)
+	for _, face := range f {
+		fv := faceVertex[face]
+		verts = append(verts, fv[0], fv[1], fv[2])
+	}
+
+	return CreateTriangleMesh(objectToWorld, worldToObject, reverseOrientation, nTris, verts, len(pOut), pOut, nil, nOut, nil)
+}
+
+// boundaryTangents computes tangents of boundary face
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/loopsubdiv.cpp#L384
+func boundaryTangents(p Point3, pRing []Point3) (Vector3, Vector3) {
+	valence := len(pRing)
+
+	S := pRing[valence-1].SubtractP(pRing[0])
+
+	var T Vector3
+	switch {
+	case valence == 2:
+		T = pRing[0].AddP(pRing[1]).SubtractP(p.Multiply(2))
+	case valence == 3:
+		T = pRing[1].SubtractP(p)
+	case valence == 4:
+		// regular
+		T = NewVector3P(pRing[0].Multiply(-1).
+			AddP(pRing[1].Multiply(2)).
+			AddP(pRing[2].Multiply(2)).
+			AddP(pRing[3].Multiply(-1)).
+			AddP(p.Multiply(-2)))
+	default:
+		theta := math.Pi / float64(valence-1)
+		T = NewVector3P(pRing[0].AddP(pRing[valence-1]).Multiply(math.Sin(theta)))
+		for k := 1; k < valence-1; k++ {
+			wt := (2*math.Cos(theta) - 2) * math.Sin(float64(k)*theta)
+			T = T.Add(NewVector3P(pRing[k].Multiply(wt)))
+		}
+		T = T.Negate()
+	}
+
+	return S, T
+}
@@ -0,0 +1,196 @@
+package mymath_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"pbrt-go/material"
+	"pbrt-go/mymath"
+	"testing"
+)
+
+// tetrahedron with counter-clockwise faces seen from outside, centered at origin
+var tetrahedronP = []mymath.Point3{
+	mymath.NewPoint3(1, 1, 1),
+	mymath.NewPoint3(-1, -1, 1),
+	mymath.NewPoint3(-1, 1, -1),
+	mymath.NewPoint3(1, -1, -1),
+}
+
+var tetrahedronIndices = []int{
+	0, 1, 3,
+	0, 2, 1,
+	0, 3, 2,
+	1, 2, 3,
+}
+
+func loopSubdivideTetrahedron(nLevels int, creases []int) *mymath.TriangleMesh {
+	identity := mymath.NewTransformEmpty()
+
+	tris := mymath.LoopSubdivide(&identity, &identity, false, nLevels, len(tetrahedronIndices), tetrahedronIndices, len(tetrahedronP), tetrahedronP, creases)
+
+	return tris[0].(*mymath.Triangle).Mesh
+}
+
+func TestLoopSubdivide_closed(t *testing.T) {
+	mesh := loopSubdivideTetrahedron(3, nil)
+
+	assert.Equal(t, 4*64, mesh.NTriangles)
+	// every vertex is shared by the whole one-ring, V - E + F = 2
+	assert.Equal(t, 2+mesh.NTriangles*3/2-mesh.NTriangles, mesh.NVertices)
+
+	for i, p := range mesh.P {
+		// limit surface is strictly inside the control hull and smooth normals point outwards
+		assert.Less(t, p.Distance(mymath.NewPoint3(0, 0, 0)), math.Sqrt(3))
+
+		n := mesh.N[i].Normalize()
+		assert.Greater(t, n.Dot(mymath.NewNormal3(p.X, p.Y, p.Z).Normalize()), 0.5, "vertex %v", p)
+	}
+}
+
+// Limit normal must agree with the normals of the triangles around the vertex
+func TestLoopSubdivide_limitNormals(t *testing.T) {
+	mesh := loopSubdivideTetrahedron(4, nil)
+
+	for tri := 0; tri < mesh.NTriangles; tri++ {
+		p0 := mesh.P[mesh.VertexIndices[3*tri]]
+		p1 := mesh.P[mesh.VertexIndices[3*tri+1]]
+		p2 := mesh.P[mesh.VertexIndices[3*tri+2]]
+		faceN := p1.SubtractP(p0).Cross(p2.SubtractP(p0)).Normalize()
+
+		for j := 0; j < 3; j++ {
+			n := mesh.N[mesh.VertexIndices[3*tri+j]].Normalize()
+			assert.Greater(t, n.Dot(mymath.NewNormal3(faceN.X, faceN.Y, faceN.Z)), 0.9)
+		}
+	}
+}
+
+func TestLoopSubdivide_boundary(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	// flat square made of two triangles
+	p := []mymath.Point3{
+		mymath.NewPoint3(0, 0, 0),
+		mymath.NewPoint3(1, 0, 0),
+		mymath.NewPoint3(1, 1, 0),
+		mymath.NewPoint3(0, 1, 0),
+	}
+
+	tris := mymath.LoopSubdivide(&identity, &identity, false, 2, 6, []int{0, 1, 2, 0, 2, 3}, 4, p, nil)
+	assert.Len(t, tris, 32)
+
+	mesh := tris[0].(*mymath.Triangle).Mesh
+	for i, p := range mesh.P {
+		// the surface stays flat and inside the square
+		assert.InDelta(t, 0.0, p.Z, equalDelta)
+		assert.True(t, p.X >= 0 && p.X <= 1 && p.Y >= 0 && p.Y <= 1, "vertex %v", p)
+		InDeltaNormal3(t, mymath.NewNormal3(0, 0, 1), mesh.N[i].Normalize())
+	}
+}
+
+func TestLoopSubdivide_creases(t *testing.T) {
+	// all the edges are creases, corners stay in place and edges stay straight
+	creases := []int{0, 1, 0, 2, 0, 3, 1, 2, 1, 3, 2, 3}
+	const nLevels = 3
+
+	mesh := loopSubdivideTetrahedron(nLevels, creases)
+
+	onEdge := func(p mymath.Point3) bool {
+		for i := 0; i < len(creases); i += 2 {
+			a, b := tetrahedronP[creases[i]], tetrahedronP[creases[i+1]]
+			ab := b.SubtractP(a)
+			s := mymath.Clamp(p.SubtractP(a).Dot(ab)/ab.LengthSq(), 0, 1)
+			if p.Distance(a.AddV(ab.Multiply(s))) < 1e-9 {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for _, corner := range tetrahedronP {
+		found := 0
+		for _, p := range mesh.P {
+			if p.Distance(corner) < 1e-12 {
+				found++
+			}
+		}
+
+		// corner vertex is split to one copy for each of the 3 faces
+		assert.Equal(t, 3, found, "corner %v", corner)
+	}
+
+	// crease vertices are split to one copy for each side
+	verticesOnEdges := 0
+	for _, p := range mesh.P {
+		if onEdge(p) {
+			verticesOnEdges++
+		}
+	}
+
+	perEdge := 1<<nLevels - 1
+	assert.Equal(t, 4*3+6*perEdge*2, verticesOnEdges)
+
+	// normals on both sides of the crease differ
+	for i, p := range mesh.P {
+		if !onEdge(p) {
+			continue
+		}
+
+		for j := i + 1; j < len(mesh.P); j++ {
+			if p.Distance(mesh.P[j]) < 1e-12 {
+				assert.Less(t, mesh.N[i].Normalize().Dot(mesh.N[j].Normalize()), 0.9)
+			}
+		}
+	}
+}
+
+// Crease curve depends on the crease vertices only, so it stays in the plane of the creased face
+func TestLoopSubdivide_creaseLoop(t *testing.T) {
+	const nLevels = 3
+	mesh := loopSubdivideTetrahedron(nLevels, []int{0, 1, 1, 2, 2, 0})
+
+	planeN := tetrahedronP[1].SubtractP(tetrahedronP[0]).Cross(tetrahedronP[2].SubtractP(tetrahedronP[0])).Normalize()
+	distanceToPlane := func(p mymath.Point3) float64 {
+		return math.Abs(p.SubtractP(tetrahedronP[0]).Dot(planeN))
+	}
+
+	// vertices on the crease are split, each position is there twice
+	creaseVertices := 0
+	for i, p := range mesh.P {
+		for j := i + 1; j < len(mesh.P); j++ {
+			if p.Distance(mesh.P[j]) < 1e-12 {
+				creaseVertices++
+				assert.InDelta(t, 0.0, distanceToPlane(p), 1e-9)
+			}
+		}
+	}
+
+	assert.Equal(t, 3<<nLevels, creaseVertices)
+
+	// without creases the surface shrinks away from the plane
+	smooth := loopSubdivideTetrahedron(nLevels, nil)
+	for _, p := range smooth.P {
+		assert.Greater(t, distanceToPlane(p), 1e-3)
+	}
+}
+
+func TestLoopSubdivide_Intersect(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	tris := mymath.LoopSubdivide(&identity, &identity, false, 3, len(tetrahedronIndices), tetrahedronIndices, len(tetrahedronP), tetrahedronP, nil)
+
+	ray := mymath.NewRay(mymath.NewPoint3(0, 0, 10), mymath.NewVector3(0, 0, -1), 50, 0, material.Medium{})
+
+	var isect *mymath.SurfaceInteraction
+	for _, tri := range tris {
+		if ok, tHit, si := tri.Intersect(ray, false); ok {
+			ray.TMax = tHit
+			isect = si
+		}
+	}
+
+	// the closest hit is on the top of the surface
+	assert.NotNil(t, isect)
+	assert.Greater(t, isect.Interaction.P.Z, 0.0)
+	assert.Greater(t, isect.Interaction.N.Z, 0.0)
+}
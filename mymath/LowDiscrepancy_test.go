@@ -0,0 +1,89 @@
+package mymath_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pbrt-go/mymath"
+	"testing"
+)
+
+func TestPrimes(t *testing.T) {
+	assert.Len(t, mymath.Primes, mymath.PrimeTableSize)
+	assert.Equal(t, []uint64{2, 3, 5, 7, 11, 13, 17, 19}, mymath.Primes[:8])
+	assert.Equal(t, uint64(7919), mymath.Primes[999])
+}
+
+func TestRadicalInverse(t *testing.T) {
+	assert.Equal(t, 0.0, mymath.RadicalInverse(0, 0))
+	assert.Equal(t, 0.5, mymath.RadicalInverse(0, 1))
+	assert.Equal(t, 0.25, mymath.RadicalInverse(0, 2))
+	assert.Equal(t, 0.75, mymath.RadicalInverse(0, 3))
+
+	// 5 = 12 in base 3 -> 0.21
+	assert.InDelta(t, 2.0/3+1.0/9, mymath.RadicalInverse(1, 5), 1e-15)
+	// 7 = 12 in base 5 -> 0.21
+	assert.InDelta(t, 2.0/5+1.0/25, mymath.RadicalInverse(2, 7), 1e-15)
+	// 7919 is 1000th prime, 7920 = 11 -> 0.11
+	assert.InDelta(t, 1.0/7919+1.0/7919/7919, mymath.RadicalInverse(999, 7920), 1e-15)
+}
+
+func TestInverseRadicalInverse(t *testing.T) {
+	// 6 = 110 in base 2, mirrored 3 digits 011 = 3
+	assert.Equal(t, uint64(6), mymath.InverseRadicalInverse(2, 3, 3))
+	// 5 = 12 in base 3, mirrored 21 = 7
+	assert.Equal(t, uint64(5), mymath.InverseRadicalInverse(3, 7, 2))
+}
+
+func TestFaurePermutation(t *testing.T) {
+	assert.Equal(t, []uint16{0, 1}, mymath.FaurePermutation(2))
+	assert.Equal(t, []uint16{0, 1, 2}, mymath.FaurePermutation(3))
+	assert.Equal(t, []uint16{0, 2, 1, 3}, mymath.FaurePermutation(4))
+	assert.Equal(t, []uint16{0, 3, 2, 1, 4}, mymath.FaurePermutation(5))
+	assert.Equal(t, []uint16{0, 2, 5, 3, 1, 4, 6}, mymath.FaurePermutation(7))
+
+	// large permutation contains each digit once
+	perm := mymath.FaurePermutation(7919)
+	seen := make([]bool, 7919)
+	for _, d := range perm {
+		assert.False(t, seen[d])
+		seen[d] = true
+	}
+}
+
+func TestScrambledRadicalInverse(t *testing.T) {
+	identity := []uint16{0, 1, 2, 3, 4}
+	for a := uint64(0); a < 100; a++ {
+		assert.InDelta(t, mymath.RadicalInverse(2, a), mymath.ScrambledRadicalInverse(2, a, identity), 1e-15)
+	}
+
+	// 7 = 12 in base 5 -> digits 0.21 permuted to 0.23 and the zero tail to 0.00111...
+	perm := []uint16{1, 3, 2, 0, 4}
+	expected := 2.0/5 + 3.0/25 + 1.0/25/4
+	assert.InDelta(t, expected, mymath.ScrambledRadicalInverse(2, 7, perm), 1e-15)
+}
+
+func TestGrayCodeSample(t *testing.T) {
+	// van der Corput and the first columns of Pascal matrix
+	vanDerCorput := make([]uint32, 32)
+	for i := range vanDerCorput {
+		vanDerCorput[i] = 1 << (31 - i)
+	}
+	pascal := []uint32{0x80000000, 0xc0000000, 0xa0000000, 0xf0000000}
+
+	samples := make([]float64, 16)
+	mymath.GrayCodeSample(vanDerCorput, 0, samples)
+
+	// Gray code order visits the same points as the sequence
+	expected := map[float64]bool{}
+	for i := uint32(0); i < 16; i++ {
+		expected[mymath.SampleGeneratorMatrix(vanDerCorput, i, 0)] = true
+	}
+	for _, s := range samples {
+		assert.True(t, expected[s])
+		delete(expected, s)
+	}
+
+	points := make([]mymath.Point2, 4)
+	mymath.GrayCodeSample2D(vanDerCorput, pascal, 0, 0x80000000, points)
+	assert.Equal(t, mymath.NewPoint2(0, 0.5), points[0])
+	assert.Equal(t, mymath.NewPoint2(0.5, 0), points[1])
+}
@@ -0,0 +1,10 @@
+package mymath
+
+// Material describes surface appearance of the primitive
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/material.h
+type Material interface {
+	// ComputeScatteringFunctions sets the BSDF of the interaction, allowMultipleLobes tells whether the material
+	// may use the lobes that aggregate several types of scattering, e.g. FresnelSpecular
+	ComputeScatteringFunctions(si *SurfaceInteraction, mode TransportMode, allowMultipleLobes bool)
+}
@@ -0,0 +1,99 @@
+package mymath
+
+import "math"
+
+// MicrofacetDistribution describes the distribution of the microfacet normals of the rough surface, the directions
+// are in the local shading coordinate system
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/microfacet.h#L48
+type MicrofacetDistribution interface {
+	// D returns the differential area of the microfacets with the normal wh
+	D(wh Vector3) float64
+	// Lambda returns the ratio of the masked to the visible microfacet area for the direction w
+	Lambda(w Vector3) float64
+	// G1 returns the fraction of the microfacets visible from the direction w
+	G1(w Vector3) float64
+	// G returns the fraction of the microfacets visible from both the directions wo and wi
+	G(wo, wi Vector3) float64
+	// SampleWh samples the microfacet normal for the outgoing direction wo
+	SampleWh(wo Vector3, u Point2) Vector3
+	// Pdf returns the density of sampling wh by SampleWh
+	Pdf(wo, wh Vector3) float64
+}
+
+// MicrofacetG1 is the Smith masking function computed from Lambda of the distribution
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/microfacet.h#L55
+func MicrofacetG1(d MicrofacetDistribution, w Vector3) float64 {
+	return 1 / (1 + d.Lambda(w))
+}
+
+// MicrofacetG is the Smith masking-shadowing function computed from Lambda of the distribution
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/microfacet.h#L61
+func MicrofacetG(d MicrofacetDistribution, wo, wi Vector3) float64 {
+	return 1 / (1 + d.Lambda(wo) + d.Lambda(wi))
+}
+
+// MicrofacetPdf returns the density of the microfacet normal wh sampled either from the visible normals or from
+// the whole distribution
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/microfacet.cpp#L325
+func MicrofacetPdf(d MicrofacetDistribution, sampleVisibleArea bool, wo, wh Vector3) float64 {
+	if sampleVisibleArea {
+		return d.D(wh) * d.G1(wo) * math.Abs(wo.Dot(wh)) / AbsCosTheta(wo)
+	}
+	return d.D(wh) * AbsCosTheta(wh)
+}
+
+// RoughnessToAlpha maps the user-friendly roughness in [0, 1] to the alpha parameter of the distributions
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/microfacet.h#L82
+func RoughnessToAlpha(roughness float64) float64 {
+	roughness = math.Max(roughness, 1e-3)
+	x := math.Log(roughness)
+	return 1.62142 + 0.819955*x + 0.1734*x*x + 0.0171201*x*x*x + 0.000640711*x*x*x*x
+}
+
+// stretchedSample samples the visible normal for the direction wi using the slope sampling of the distribution
+// for the isotropic unit roughness
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/microfacet.cpp#L114
+func stretchedSample(wi Vector3, alphaX, alphaY float64, u Point2, sample11 func(cosTheta, u1, u2 float64) (float64, float64)) Vector3 {
+	// 1. stretch wi
+	wiStretched := NewVector3(alphaX*wi.X, alphaY*wi.Y, wi.Z).Normalize()
+
+	// 2. simulate P22_{wi}(x_slope, y_slope, 1, 1)
+	slopeX, slopeY := sample11(CosTheta(wiStretched), u.X, u.Y)
+
+	// 3. rotate
+	tmp := CosPhi(wiStretched)*slopeX - SinPhi(wiStretched)*slopeY
+	slopeY = SinPhi(wiStretched)*slopeX + CosPhi(wiStretched)*slopeY
+	slopeX = tmp
+
+	// 4. unstretch
+	slopeX = alphaX * slopeX
+	slopeY = alphaY * slopeY
+
+	// 5. compute normal
+	return NewVector3(-slopeX, -slopeY, 1).Normalize()
+}
+
+// sampleVisible samples the visible normal on the side of wo
+func sampleVisible(wo Vector3, alphaX, alphaY float64, u Point2, sample11 func(cosTheta, u1, u2 float64) (float64, float64)) Vector3 {
+	if wo.Z < 0 {
+		return stretchedSample(wo.Negate(), alphaX, alphaY, u, sample11).Negate()
+	}
+	return stretchedSample(wo, alphaX, alphaY, u, sample11)
+}
+
+// anisotropicPhi samples the azimuth of the anisotropic distribution
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/microfacet.cpp#L292
+func anisotropicPhi(alphaX, alphaY, u float64) float64 {
+	phi := math.Atan(alphaY / alphaX * math.Tan(2*math.Pi*u+0.5*math.Pi))
+	if u > 0.5 {
+		phi += math.Pi
+	}
+	return phi
+}
@@ -0,0 +1,122 @@
+package mymath_test
+
+import (
+	"math"
+	"math/rand"
+	"pbrt-go/mymath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func microfacetDistributions(sampleVisibleArea bool) map[string]mymath.MicrofacetDistribution {
+	return map[string]mymath.MicrofacetDistribution{
+		"Beckmann isotropic":          mymath.NewBeckmannDistribution(0.4, 0.4, sampleVisibleArea),
+		"Beckmann anisotropic":        mymath.NewBeckmannDistribution(0.3, 0.6, sampleVisibleArea),
+		"TrowbridgeReitz isotropic":   mymath.NewTrowbridgeReitzDistribution(0.4, 0.4, sampleVisibleArea),
+		"TrowbridgeReitz anisotropic": mymath.NewTrowbridgeReitzDistribution(0.3, 0.6, sampleVisibleArea),
+	}
+}
+
+// integrateHemisphere estimates the integral of f over the upper hemisphere
+func integrateHemisphere(f func(w mymath.Vector3) float64, n int) float64 {
+	rng := rand.New(rand.NewSource(11))
+
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		w := mymath.UniformSampleHemisphere(mymath.NewPoint2(rng.Float64(), rng.Float64()))
+		sum += f(w) / mymath.UniformHemispherePdf()
+	}
+	return sum / float64(n)
+}
+
+func TestMicrofacetDistribution_D(t *testing.T) {
+	for name, d := range microfacetDistributions(false) {
+		// projected area of the microfacets is the area of the surface
+		projected := integrateHemisphere(func(wh mymath.Vector3) float64 {
+			return d.D(wh) * mymath.CosTheta(wh)
+		}, 200000)
+		assert.InDelta(t, 1, projected, 0.02, name)
+
+		// projected area of the visible microfacets is the projected area of the surface
+		wo := mymath.NewVector3(0.6, 0, 0.8)
+		visible := integrateHemisphere(func(wh mymath.Vector3) float64 {
+			return d.G1(wo) * math.Max(0, wo.Dot(wh)) * d.D(wh)
+		}, 200000)
+		assert.InDelta(t, mymath.CosTheta(wo), visible, 0.02, name)
+	}
+}
+
+func TestMicrofacetDistribution_G(t *testing.T) {
+	rng := rand.New(rand.NewSource(12))
+
+	for name, d := range microfacetDistributions(false) {
+		normal := mymath.NewVector3(0, 0, 1)
+		assert.Equal(t, 0.0, d.Lambda(normal), name)
+		assert.Equal(t, 1.0, d.G1(normal), name)
+
+		for i := 0; i < 100; i++ {
+			wo, wi := randomDirection(rng), randomDirection(rng)
+			g := d.G(wo, wi)
+			assert.InDelta(t, g, d.G(wi, wo), equalDelta, name)
+			// the rational approximation of the Beckmann Lambda gets slightly negative near its cut-off
+			assert.LessOrEqual(t, g, d.G1(wo)+1e-4, name)
+			assert.LessOrEqual(t, g, d.G1(wi)+1e-4, name)
+			assert.GreaterOrEqual(t, g, 0.0, name)
+		}
+	}
+}
+
+func TestMicrofacetDistribution_Pdf(t *testing.T) {
+	wo := mymath.NewVector3(0.6, 0, 0.8)
+
+	for name, d := range microfacetDistributions(false) {
+		integral := integrateHemisphere(func(wh mymath.Vector3) float64 {
+			return d.Pdf(wo, wh)
+		}, 200000)
+		assert.InDelta(t, 1, integral, 0.02, name)
+	}
+}
+
+// The mean of the sampled normals must match the mean computed from the density
+func TestMicrofacetDistribution_SampleWh(t *testing.T) {
+	for _, sampleVisibleArea := range []bool{false, true} {
+		for name, d := range microfacetDistributions(sampleVisibleArea) {
+			for _, wo := range []mymath.Vector3{mymath.NewVector3(0, 0, 1), mymath.NewVector3(0.6, 0, 0.8), mymath.NewVector3(0, 0.8, 0.6)} {
+				rng := rand.New(rand.NewSource(13))
+				n := 100000
+
+				sampled := mymath.Vector3{}
+				for i := 0; i < n; i++ {
+					wh := d.SampleWh(wo, mymath.NewPoint2(rng.Float64(), rng.Float64()))
+					assert.InDelta(t, 1, wh.Length(), equalDelta)
+					sampled = sampled.Add(wh)
+				}
+				sampled = sampled.Divide(float64(n))
+
+				// the density is normalized numerically, the Lambda of Beckmann is only approximation
+				pdf := func(wh mymath.Vector3) float64 {
+					if sampleVisibleArea {
+						return d.D(wh) * math.Max(0, wo.Dot(wh))
+					}
+					return d.D(wh) * mymath.CosTheta(wh)
+				}
+				norm := integrateHemisphere(pdf, 200000)
+				expected := mymath.NewVector3(
+					integrateHemisphere(func(wh mymath.Vector3) float64 { return pdf(wh) * wh.X }, 200000),
+					integrateHemisphere(func(wh mymath.Vector3) float64 { return pdf(wh) * wh.Y }, 200000),
+					integrateHemisphere(func(wh mymath.Vector3) float64 { return pdf(wh) * wh.Z }, 200000)).Divide(norm)
+
+				assert.InDelta(t, expected.X, sampled.X, 0.01, "%v visible %v wo %v", name, sampleVisibleArea, wo)
+				assert.InDelta(t, expected.Y, sampled.Y, 0.01, "%v visible %v wo %v", name, sampleVisibleArea, wo)
+				assert.InDelta(t, expected.Z, sampled.Z, 0.01, "%v visible %v wo %v", name, sampleVisibleArea, wo)
+			}
+		}
+	}
+}
+
+func TestRoughnessToAlpha(t *testing.T) {
+	assert.InDelta(t, 1.62142, mymath.RoughnessToAlpha(1), equalDelta)
+	assert.Less(t, mymath.RoughnessToAlpha(0.1), mymath.RoughnessToAlpha(0.5))
+	assert.Equal(t, mymath.RoughnessToAlpha(1e-3), mymath.RoughnessToAlpha(0))
+}
@@ -0,0 +1,87 @@
+package mymath
+
+import "pbrt-go/spectrum"
+
+// MicrofacetReflection is the Torrance-Sparrow model of the glossy reflection from the perfectly specular
+// microfacets
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L445
+type MicrofacetReflection struct {
+	R            spectrum.Spectrum
+	Distribution MicrofacetDistribution
+	Fresnel      Fresnel
+}
+
+func NewMicrofacetReflection(r spectrum.Spectrum, distribution MicrofacetDistribution, fresnel Fresnel) *MicrofacetReflection {
+	return &MicrofacetReflection{r, distribution, fresnel}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L222
+func (b *MicrofacetReflection) F(wo, wi Vector3) spectrum.Spectrum {
+	cosThetaO, cosThetaI := AbsCosTheta(wo), AbsCosTheta(wi)
+	wh := wi.Add(wo)
+
+	// Handle degenerate cases for microfacet reflection
+	if cosThetaI == 0 || cosThetaO == 0 {
+		return spectrum.NewSpectrum(0)
+	}
+	if wh.X == 0 && wh.Y == 0 && wh.Z == 0 {
+		return spectrum.NewSpectrum(0)
+	}
+	wh = wh.Normalize()
+
+	// For the Fresnel call, make sure that wh is in the same hemisphere as the surface normal, so that TIR
+	// is handled correctly
+	f := b.Fresnel.Evaluate(wi.Dot(faceForwardZ(wh)))
+	return b.R.MultiplyS(f).Multiply(b.Distribution.D(wh) * b.Distribution.G(wo, wi) / (4 * cosThetaI * cosThetaO))
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L466
+func (b *MicrofacetReflection) SampleF(wo Vector3, u Point2) (Vector3, spectrum.Spectrum, float64, BxDFType) {
+	// Sample microfacet orientation wh and reflected direction wi
+	if wo.Z == 0 {
+		return Vector3{}, spectrum.NewSpectrum(0), 0, b.Type()
+	}
+	wh := b.Distribution.SampleWh(wo, u)
+	// Should be rare
+	if wo.Dot(wh) < 0 {
+		return Vector3{}, spectrum.NewSpectrum(0), 0, b.Type()
+	}
+	wi := Reflect(wo, wh)
+	if !SameHemisphere(wo, wi) {
+		return wi, spectrum.NewSpectrum(0), 0, b.Type()
+	}
+
+	// Compute PDF of wi for microfacet reflection
+	pdf := b.Distribution.Pdf(wo, wh) / (4 * wo.Dot(wh))
+	return wi, b.F(wo, wi), pdf, b.Type()
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L481
+func (b *MicrofacetReflection) Pdf(wo, wi Vector3) float64 {
+	if !SameHemisphere(wo, wi) {
+		return 0
+	}
+	wh := wo.Add(wi).Normalize()
+	return b.Distribution.Pdf(wo, wh) / (4 * wo.Dot(wh))
+}
+
+func (b *MicrofacetReflection) Rho(wo Vector3, samples []Point2) spectrum.Spectrum {
+	return EstimateRho(b, wo, samples)
+}
+
+func (b *MicrofacetReflection) RhoHH(samples1, samples2 []Point2) spectrum.Spectrum {
+	return EstimateRhoHH(b, samples1, samples2)
+}
+
+func (b *MicrofacetReflection) Type() BxDFType {
+	return BSDFReflection | BSDFGlossy
+}
+
+// faceForwardZ flips w to the hemisphere of the shading normal
+func faceForwardZ(w Vector3) Vector3 {
+	if w.Z < 0 {
+		return w.Negate()
+	}
+	return w
+}
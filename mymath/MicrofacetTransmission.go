@@ -0,0 +1,115 @@
+package mymath
+
+import (
+	"math"
+	"pbrt-go/spectrum"
+)
+
+// MicrofacetTransmission is the Walter et al. model of the glossy transmission through the rough dielectric
+// interface
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L473
+type MicrofacetTransmission struct {
+	T            spectrum.Spectrum
+	Distribution MicrofacetDistribution
+	// EtaA and EtaB are indices of refraction above and below the surface
+	EtaA, EtaB float64
+	Fresnel    FresnelDielectric
+	Mode       TransportMode
+}
+
+func NewMicrofacetTransmission(t spectrum.Spectrum, distribution MicrofacetDistribution, etaA, etaB float64, mode TransportMode) *MicrofacetTransmission {
+	return &MicrofacetTransmission{t, distribution, etaA, etaB, FresnelDielectric{etaA, etaB}, mode}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L242
+func (b *MicrofacetTransmission) F(wo, wi Vector3) spectrum.Spectrum {
+	// transmission only
+	if SameHemisphere(wo, wi) {
+		return spectrum.NewSpectrum(0)
+	}
+
+	cosThetaO, cosThetaI := CosTheta(wo), CosTheta(wi)
+	if cosThetaI == 0 || cosThetaO == 0 {
+		return spectrum.NewSpectrum(0)
+	}
+
+	// Compute wh from wo and wi for microfacet transmission
+	eta := b.eta(wo)
+	wh := faceForwardZ(wo.Add(wi.Multiply(eta)).Normalize())
+
+	// Same side?
+	if wo.Dot(wh)*wi.Dot(wh) > 0 {
+		return spectrum.NewSpectrum(0)
+	}
+
+	f := b.Fresnel.Evaluate(wo.Dot(wh))
+
+	sqrtDenom := wo.Dot(wh) + eta*wi.Dot(wh)
+	factor := 1.0
+	if b.Mode == Radiance {
+		factor = 1 / eta
+	}
+
+	return spectrum.NewSpectrum(1).Subtract(f).MultiplyS(b.T).Multiply(math.Abs(
+		b.Distribution.D(wh) * b.Distribution.G(wo, wi) * eta * eta * math.Abs(wi.Dot(wh)) * math.Abs(wo.Dot(wh)) *
+			factor * factor / (cosThetaI * cosThetaO * sqrtDenom * sqrtDenom)))
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L489
+func (b *MicrofacetTransmission) SampleF(wo Vector3, u Point2) (Vector3, spectrum.Spectrum, float64, BxDFType) {
+	if wo.Z == 0 {
+		return Vector3{}, spectrum.NewSpectrum(0), 0, b.Type()
+	}
+	wh := b.Distribution.SampleWh(wo, u)
+	// Should be rare
+	if wo.Dot(wh) < 0 {
+		return Vector3{}, spectrum.NewSpectrum(0), 0, b.Type()
+	}
+
+	ok, wi := Refract(wo, NewNormal3V(wh), 1/b.eta(wo))
+	if !ok {
+		return Vector3{}, spectrum.NewSpectrum(0), 0, b.Type()
+	}
+	return wi, b.F(wo, wi), b.Pdf(wo, wi), b.Type()
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L503
+func (b *MicrofacetTransmission) Pdf(wo, wi Vector3) float64 {
+	if SameHemisphere(wo, wi) {
+		return 0
+	}
+
+	// Compute wh from wo and wi for microfacet transmission
+	eta := b.eta(wo)
+	wh := wo.Add(wi.Multiply(eta)).Normalize()
+
+	if wo.Dot(wh)*wi.Dot(wh) > 0 {
+		return 0
+	}
+
+	// Compute change of variables dwh_dwi for microfacet transmission
+	sqrtDenom := wo.Dot(wh) + eta*wi.Dot(wh)
+	dwhDwi := math.Abs((eta * eta * wi.Dot(wh)) / (sqrtDenom * sqrtDenom))
+	return b.Distribution.Pdf(wo, wh) * dwhDwi
+}
+
+func (b *MicrofacetTransmission) Rho(wo Vector3, samples []Point2) spectrum.Spectrum {
+	return EstimateRho(b, wo, samples)
+}
+
+func (b *MicrofacetTransmission) RhoHH(samples1, samples2 []Point2) spectrum.Spectrum {
+	return EstimateRhoHH(b, samples1, samples2)
+}
+
+func (b *MicrofacetTransmission) Type() BxDFType {
+	return BSDFTransmission | BSDFGlossy
+}
+
+// eta returns the ratio of the index of refraction of the side of wi to the side of wo
+func (b *MicrofacetTransmission) eta(wo Vector3) float64 {
+	if CosTheta(wo) > 0 {
+		return b.EtaB / b.EtaA
+	}
+	return b.EtaA / b.EtaB
+}
@@ -1,11 +1,29 @@
 package mymath
 
-import "math"
+import (
+	"math"
+	"math/bits"
+)
 
 var epsilon = math.Nextafter(1, 2) - 1
 
+// OneMinusEpsilon is the largest float64 value less than 1
+var OneMinusEpsilon = math.Nextafter(1, 0)
+
+// ShadowEpsilon shortens shadow rays so that they do not hit the target surface
+const ShadowEpsilon = 0.0001
+
+var Gamma2 = gamma(2)
 var Gamma3 = gamma(3)
 var Gamma5 = gamma(5)
+var Gamma6 = gamma(6)
+var Gamma7 = gamma(7)
+
+// transformGamma3 bounds error of points transformed to world space, matrix and its inverse are stored in float32
+// so they are not exact inverses and the round trip back to object space is only accurate to the float32 precision
+var transformGamma3 = 3 * epsilon32 / (1 - 3*epsilon32)
+
+var epsilon32 = float64(math.Nextafter32(1, 2) - 1)
 
 // Lerp returns value interpolated between v1 and v2 using parameter t.
 func Lerp(t, v1, v2 float64) float64 {
@@ -35,6 +53,32 @@ func Degrees(rad float64) float64 {
 	return (180 / math.Pi) * rad
 }
 
+// Log2Int returns floor of the base 2 logarithm of positive v
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/pbrt.h#L387
+func Log2Int(v int64) int {
+	return bits.Len64(uint64(v)) - 1
+}
+
+func IsPowerOf2(v int64) bool {
+	return v != 0 && (v&(v-1)) == 0
+}
+
+// RoundUpPow2 returns the smallest power of 2 greater than or equal to v
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/pbrt.h#L443
+func RoundUpPow2(v int64) int64 {
+	v--
+	v |= v >> 1
+	v |= v >> 2
+	v |= v >> 4
+	v |= v >> 8
+	v |= v >> 16
+	v |= v >> 32
+
+	return v + 1
+}
+
 func gamma(n int) float64 {
 	ne := float64(n) * epsilon
 	return ne / (1 - ne)
@@ -94,3 +138,72 @@ func CheckLE(v1, v2 float64) {
 		panic("Error")
 	}
 }
+
+// QuadraticFloat finds real roots of the quadratic equation, t0 <= t1
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/pbrt.h#L486
+func QuadraticFloat(a, b, c float64) (bool, float64, float64) {
+	// Find quadratic discriminant
+	discrim := b*b - 4*a*c
+	if discrim < 0 {
+		return false, 0, 0
+	}
+
+	rootDiscrim := math.Sqrt(discrim)
+
+	// Compute quadratic t values
+	var q float64
+	if b < 0 {
+		q = -0.5 * (b - rootDiscrim)
+	} else {
+		q = -0.5 * (b + rootDiscrim)
+	}
+
+	t0 := q / a
+	t1 := c / q
+	if t0 > t1 {
+		t0, t1 = t1, t0
+	}
+
+	return true, t0, t1
+}
+
+// FindInterval returns index i of the last element for which pred is true, pred must be true for the elements
+// at the beginning and false for the rest. The result is clamped to [0, size-2], so that i and i+1 are valid indices.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/pbrt.h#L458
+func FindInterval(size int, pred func(int) bool) int {
+	first, length := 0, size
+	for length > 0 {
+		half := length >> 1
+		middle := first + half
+
+		// Bisect range based on value of pred at middle
+		if pred(middle) {
+			first = middle + 1
+			length -= half + 1
+		} else {
+			length = half
+		}
+	}
+
+	return int(Clamp(float64(first-1), 0, float64(size-2)))
+}
+
+// SolveLinearSystem2x2 solves the linear system a * (x0, x1) = b, returns false when the matrix is (nearly) singular
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/geometry.cpp#L41
+func SolveLinearSystem2x2(a [2][2]float64, b [2]float64) (bool, float64, float64) {
+	det := a[0][0]*a[1][1] - a[0][1]*a[1][0]
+	if math.Abs(det) < 1e-10 {
+		return false, 0, 0
+	}
+
+	x0 := (a[1][1]*b[0] - a[0][1]*b[1]) / det
+	x1 := (a[0][0]*b[1] - a[1][0]*b[0]) / det
+	if math.IsNaN(x0) || math.IsNaN(x1) {
+		return false, 0, 0
+	}
+
+	return true, x0, x1
+}
@@ -26,3 +26,50 @@ func TestMyMath_Clamp(t *testing.T) {
 func TestMyMath_Radians(t *testing.T) {
 	assert.Equal(t, 0.0, mymath.Radians(0))
 }
+
+func TestMyMath_Log2Int(t *testing.T) {
+	assert.Equal(t, 0, mymath.Log2Int(1))
+	assert.Equal(t, 1, mymath.Log2Int(2))
+	assert.Equal(t, 1, mymath.Log2Int(3))
+	assert.Equal(t, 10, mymath.Log2Int(1024))
+	assert.Equal(t, 10, mymath.Log2Int(2047))
+}
+
+func TestMyMath_IsPowerOf2(t *testing.T) {
+	assert.Equal(t, false, mymath.IsPowerOf2(0))
+	assert.Equal(t, true, mymath.IsPowerOf2(1))
+	assert.Equal(t, true, mymath.IsPowerOf2(64))
+	assert.Equal(t, false, mymath.IsPowerOf2(65))
+}
+
+func TestMyMath_RoundUpPow2(t *testing.T) {
+	assert.Equal(t, int64(1), mymath.RoundUpPow2(1))
+	assert.Equal(t, int64(4), mymath.RoundUpPow2(3))
+	assert.Equal(t, int64(64), mymath.RoundUpPow2(64))
+	assert.Equal(t, int64(128), mymath.RoundUpPow2(65))
+}
+
+func TestMyMath_FindInterval(t *testing.T) {
+	values := []float64{0, 0.25, 0.5, 1}
+	find := func(v float64) int {
+		return mymath.FindInterval(len(values), func(i int) bool { return values[i] <= v })
+	}
+
+	assert.Equal(t, 0, find(0.1))
+	assert.Equal(t, 1, find(0.25))
+	assert.Equal(t, 2, find(0.7))
+
+	// clamped to the valid intervals
+	assert.Equal(t, 0, find(-1))
+	assert.Equal(t, 2, find(5))
+}
+
+func TestMyMath_SolveLinearSystem2x2(t *testing.T) {
+	ok, x0, x1 := mymath.SolveLinearSystem2x2([2][2]float64{{2, 1}, {1, 3}}, [2]float64{5, 10})
+	assert.True(t, ok)
+	assert.InDelta(t, 1.0, x0, 1e-12)
+	assert.InDelta(t, 3.0, x1, 1e-12)
+
+	ok, _, _ = mymath.SolveLinearSystem2x2([2][2]float64{{1, 2}, {2, 4}}, [2]float64{1, 2})
+	assert.False(t, ok)
+}
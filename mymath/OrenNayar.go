@@ -0,0 +1,75 @@
+package mymath
+
+import (
+	"math"
+	"pbrt-go/spectrum"
+)
+
+// OrenNayar is the diffuse reflection of the rough surface made of the Lambertian microfacets
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L400
+type OrenNayar struct {
+	R    spectrum.Spectrum
+	A, B float64
+}
+
+// NewOrenNayar creates the lobe with the standard deviation of the microfacet orientation angle sigma in degrees
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L403
+func NewOrenNayar(r spectrum.Spectrum, sigma float64) *OrenNayar {
+	sigma = Radians(sigma)
+	sigma2 := sigma * sigma
+	return &OrenNayar{
+		R: r,
+		A: 1 - (sigma2 / (2 * (sigma2 + 0.33))),
+		B: 0.45 * sigma2 / (sigma2 + 0.09),
+	}
+}
+
+// F evaluates the Oren-Nayar approximation
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L197
+func (b *OrenNayar) F(wo, wi Vector3) spectrum.Spectrum {
+	sinThetaI := SinTheta(wi)
+	sinThetaO := SinTheta(wo)
+
+	// Compute cosine term of Oren-Nayar model
+	maxCos := 0.0
+	if sinThetaI > 1e-4 && sinThetaO > 1e-4 {
+		sinPhiI, cosPhiI := SinPhi(wi), CosPhi(wi)
+		sinPhiO, cosPhiO := SinPhi(wo), CosPhi(wo)
+		dCos := cosPhiI*cosPhiO + sinPhiI*sinPhiO
+		maxCos = math.Max(0, dCos)
+	}
+
+	// Compute sine and tangent terms of Oren-Nayar model
+	var sinAlpha, tanBeta float64
+	if AbsCosTheta(wi) > AbsCosTheta(wo) {
+		sinAlpha = sinThetaO
+		tanBeta = sinThetaI / AbsCosTheta(wi)
+	} else {
+		sinAlpha = sinThetaI
+		tanBeta = sinThetaO / AbsCosTheta(wo)
+	}
+	return b.R.Multiply((b.A + b.B*maxCos*sinAlpha*tanBeta) / math.Pi)
+}
+
+func (b *OrenNayar) SampleF(wo Vector3, u Point2) (Vector3, spectrum.Spectrum, float64, BxDFType) {
+	return CosineSampleF(b, wo, u)
+}
+
+func (b *OrenNayar) Pdf(wo, wi Vector3) float64 {
+	return CosinePdf(wo, wi)
+}
+
+func (b *OrenNayar) Rho(wo Vector3, samples []Point2) spectrum.Spectrum {
+	return EstimateRho(b, wo, samples)
+}
+
+func (b *OrenNayar) RhoHH(samples1, samples2 []Point2) spectrum.Spectrum {
+	return EstimateRhoHH(b, samples1, samples2)
+}
+
+func (b *OrenNayar) Type() BxDFType {
+	return BSDFReflection | BSDFDiffuse
+}
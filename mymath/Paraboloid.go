@@ -0,0 +1,264 @@
+package mymath
+
+import (
+	"math"
+)
+
+// Paraboloid
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/paraboloid.h
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/paraboloid.cpp
+type Paraboloid struct {
+	Shape
+	Radius,
+	ZMin, ZMax,
+	PhiMax float64
+}
+
+func NewParaboloid(radius, z0, z1, phiMax float64, objectToWorld, worldToObject *Transform, reverseOrientation bool) *Paraboloid {
+	return &Paraboloid{
+		NewShape(objectToWorld, worldToObject, reverseOrientation),
+		radius,
+		math.Min(z0, z1),
+		math.Max(z0, z1),
+		Radians(Clamp(phiMax, 0, 360)),
+	}
+}
+
+func (par Paraboloid) ObjectBound() Bounds3 {
+	return NewBounds3(
+		NewPoint3(-par.Radius, -par.Radius, par.ZMin),
+		NewPoint3(par.Radius, par.Radius, par.ZMax))
+}
+
+// Intersect finds ray-shape collision point and its metadata
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/paraboloid.cpp#L57
+func (par Paraboloid) Intersect(r Ray, _ bool) (bool, float64, *SurfaceInteraction) {
+	// Transform Ray to object space
+	ray, oErr, dErr := par.WorldToObject.ApplyRError(r)
+
+	// Compute quadratic paraboloid coefficients
+
+	// Initialize EFloat ray coordinate values
+	ox := NewEFloatErr(ray.O.X, oErr.X)
+	oy := NewEFloatErr(ray.O.Y, oErr.Y)
+	oz := NewEFloatErr(ray.O.Z, oErr.Z)
+
+	dx := NewEFloatErr(ray.D.X, dErr.X)
+	dy := NewEFloatErr(ray.D.Y, dErr.Y)
+	dz := NewEFloatErr(ray.D.Z, dErr.Z)
+
+	k := NewEFloat(par.ZMax).Divide(NewEFloat(par.Radius).Multiply(NewEFloat(par.Radius)))
+
+	a := k.Multiply(dx.Multiply(dx).Add(dy.Multiply(dy)))
+	b := NewEFloat(2).Multiply(k).Multiply(dx.Multiply(ox).Add(dy.Multiply(oy))).Subtract(dz)
+	c := k.Multiply(ox.Multiply(ox).Add(oy.Multiply(oy))).Subtract(oz)
+
+	// Solve quadratic equation for t values
+	ok, t0, t1 := Quadratic(a, b, c)
+
+	if !ok {
+		return false, 0, nil
+	}
+
+	// Check quadratic shape t0 and t1 for nearest intersection
+	if t0.High > ray.TMax || t1.Low <= 0 {
+		return false, 0, nil
+	}
+
+	tShapeHit := t0
+	if tShapeHit.Low <= 0 {
+		tShapeHit = t1
+		if tShapeHit.High > ray.TMax {
+			return false, 0, nil
+		}
+	}
+
+	// Compute paraboloid inverse mapping
+	pHit := ray.Apply(tShapeHit.V)
+
+	phi := math.Atan2(pHit.Y, pHit.X)
+	if phi < 0 {
+		phi += 2 * math.Pi
+	}
+
+	// Test paraboloid intersection against clipping parameters
+	if pHit.Z < par.ZMin || pHit.Z > par.ZMax || phi > par.PhiMax {
+		if tShapeHit == t1 {
+			return false, 0, nil
+		}
+
+		tShapeHit = t1
+		if t1.High > ray.TMax {
+			return false, 0, nil
+		}
+
+		// Compute paraboloid inverse mapping
+		pHit = ray.Apply(tShapeHit.V)
+
+		phi = math.Atan2(pHit.Y, pHit.X)
+		if phi < 0 {
+			phi += 2 * math.Pi
+		}
+
+		if pHit.Z < par.ZMin || pHit.Z > par.ZMax || phi > par.PhiMax {
+			return false, 0, nil
+		}
+	}
+
+	// Find parametric representation of paraboloid hit
+	u := phi / par.PhiMax
+	v := (pHit.Z - par.ZMin) / (par.ZMax - par.ZMin)
+
+	// Compute paraboloid dpdu and dpdv
+	zRange := par.ZMax - par.ZMin
+	dpdu := NewVector3(-par.PhiMax*pHit.Y, par.PhiMax*pHit.X, 0)
+	dpdv := NewVector3(pHit.X/(2*pHit.Z), pHit.Y/(2*pHit.Z), 1).Multiply(zRange)
+
+	// Compute paraboloid dndu and dndv
+	d2Pduu := NewVector3(pHit.X, pHit.Y, 0).Multiply(-par.PhiMax * par.PhiMax)
+	d2Pduv := NewVector3(-pHit.Y/(2*pHit.Z), pHit.X/(2*pHit.Z), 0).Multiply(zRange * par.PhiMax)
+	d2Pdvv := NewVector3(pHit.X/(4*pHit.Z*pHit.Z), pHit.Y/(4*pHit.Z*pHit.Z), 0).Multiply(-zRange * zRange)
+
+	// Compute coefficients for fundamental forms
+	E := dpdu.Dot(dpdu)
+	F := dpdu.Dot(dpdv)
+	G := dpdv.Dot(dpdv)
+	N := dpdu.Cross(dpdv).Normalize()
+	e := N.Dot(d2Pduu)
+	f := N.Dot(d2Pduv)
+	g := N.Dot(d2Pdvv)
+
+	// Compute dndu and dndv from fundamental form coefficients
+	invEGF2 := 1 / (E*G - F*F)
+	dndu := NewNormal3V(dpdu.Multiply((f*F - e*G) * invEGF2).Add(dpdv.Multiply((e*F - f*E) * invEGF2)))
+	dndv := NewNormal3V(dpdu.Multiply((g*F - f*G) * invEGF2).Add(dpdv.Multiply((f*F - g*E) * invEGF2)))
+
+	// Compute error bounds for paraboloid intersection
+
+	// Compute error bounds for intersection computed with ray equation
+	px := ox.Add(tShapeHit.Multiply(dx))
+	py := oy.Add(tShapeHit.Multiply(dy))
+	pz := oz.Add(tShapeHit.Multiply(dz))
+	pError := NewVector3(px.GetAbsoluteError(), py.GetAbsoluteError(), pz.GetAbsoluteError())
+
+	// Initialize _SurfaceInteraction_ from parametric information
+	si := NewSurfaceInteraction(
+		pHit,
+		pError,
+		NewPoint2(u, v),
+		ray.D.Negate(),
+		dpdu,
+		dpdv,
+		dndu,
+		dndv,
+		float64(ray.Time),
+		&par.Shape)
+
+	isect := par.ObjectToWorld.ApplySI(&si)
+
+	// Update _tHit_ for quadric intersection
+	return true, tShapeHit.V, isect
+}
+
+// IntersectP finds if ray collides with this shape
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/paraboloid.cpp#L170
+func (par Paraboloid) IntersectP(_ Intersecter, r Ray, _ bool) bool {
+	// Transform Ray to object space
+	ray, oErr, dErr := par.WorldToObject.ApplyRError(r)
+
+	// Compute quadratic paraboloid coefficients
+
+	// Initialize EFloat ray coordinate values
+	ox := NewEFloatErr(ray.O.X, oErr.X)
+	oy := NewEFloatErr(ray.O.Y, oErr.Y)
+	oz := NewEFloatErr(ray.O.Z, oErr.Z)
+
+	dx := NewEFloatErr(ray.D.X, dErr.X)
+	dy := NewEFloatErr(ray.D.Y, dErr.Y)
+	dz := NewEFloatErr(ray.D.Z, dErr.Z)
+
+	k := NewEFloat(par.ZMax).Divide(NewEFloat(par.Radius).Multiply(NewEFloat(par.Radius)))
+
+	a := k.Multiply(dx.Multiply(dx).Add(dy.Multiply(dy)))
+	b := NewEFloat(2).Multiply(k).Multiply(dx.Multiply(ox).Add(dy.Multiply(oy))).Subtract(dz)
+	c := k.Multiply(ox.Multiply(ox).Add(oy.Multiply(oy))).Subtract(oz)
+
+	// Solve quadratic equation for t values
+	ok, t0, t1 := Quadratic(a, b, c)
+
+	if !ok {
+		return false
+	}
+
+	// Check quadratic shape t0 and t1 for nearest intersection
+	if t0.High > ray.TMax || t1.Low <= 0 {
+		return false
+	}
+
+	tShapeHit := t0
+	if tShapeHit.Low <= 0 {
+		tShapeHit = t1
+		if tShapeHit.High > ray.TMax {
+			return false
+		}
+	}
+
+	// Compute paraboloid inverse mapping
+	pHit := ray.Apply(tShapeHit.V)
+
+	phi := math.Atan2(pHit.Y, pHit.X)
+	if phi < 0 {
+		phi += 2 * math.Pi
+	}
+
+	// Test paraboloid intersection against clipping parameters
+	if pHit.Z < par.ZMin || pHit.Z > par.ZMax || phi > par.PhiMax {
+		if tShapeHit == t1 {
+			return false
+		}
+
+		tShapeHit = t1
+		if t1.High > ray.TMax {
+			return false
+		}
+
+		// Compute paraboloid inverse mapping
+		pHit = ray.Apply(tShapeHit.V)
+
+		phi = math.Atan2(pHit.Y, pHit.X)
+		if phi < 0 {
+			phi += 2 * math.Pi
+		}
+
+		if pHit.Z < par.ZMin || pHit.Z > par.ZMax || phi > par.PhiMax {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Area see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/paraboloid.cpp#L245
+func (par Paraboloid) Area() float64 {
+	radius2 := par.Radius * par.Radius
+	k := 4 * par.ZMax / radius2
+	return (radius2 * radius2 * par.PhiMax / (12 * par.ZMax * par.ZMax)) *
+		(math.Pow(k*par.ZMax+1, 1.5) - math.Pow(k*par.ZMin+1, 1.5))
+}
+
+// Sample is not implemented, the same as in pbrt
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/paraboloid.cpp
+func (par Paraboloid) Sample(_ Point2) Interaction {
+	panic("Paraboloid.Sample not implemented")
+}
+
+func (par Paraboloid) SampleRef(_ Interaction, u Point2) Interaction {
+	return par.Sample(u)
+}
+
+func (par Paraboloid) Pdf(ref Interaction, wi Vector3) float64 {
+	return par.Shape.pdf(par, ref, wi)
+}
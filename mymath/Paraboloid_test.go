@@ -0,0 +1,97 @@
+package mymath_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"pbrt-go/material"
+	"pbrt-go/mymath"
+	"testing"
+)
+
+func TestNewParaboloid(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	p := mymath.NewParaboloid(1, 2, 0.5, 360, &identity, &identity, false)
+
+	assert.Equal(t, 1.0, p.Radius)
+	assert.Equal(t, 0.5, p.ZMin)
+	assert.Equal(t, 2.0, p.ZMax)
+	assert.InDelta(t, 2*math.Pi, p.PhiMax, equalDelta)
+}
+
+func TestParaboloid_ObjectBound(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	p := mymath.NewParaboloid(1, 0.5, 2, 360, &identity, &identity, false)
+
+	assert.Equal(
+		t,
+		mymath.NewBounds3(mymath.NewPoint3(-1, -1, 0.5), mymath.NewPoint3(1, 1, 2)),
+		p.ObjectBound())
+}
+
+func TestParaboloid_Intersect(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	// z = x^2 + y^2
+	p := mymath.NewParaboloid(1, 0, 1, 360, &identity, &identity, false)
+
+	ray := mymath.NewRay(
+		mymath.NewPoint3(-5, 0, 0.25),
+		mymath.NewVector3(1, 0, 0),
+		50,
+		0,
+		material.Medium{})
+
+	ok, tHit, si := p.Intersect(ray, false)
+	assert.Equal(t, true, ok)
+	assert.InDelta(t, 4.5, tHit, equalDelta)
+
+	InDeltaPoint3(t, mymath.NewPoint3(-0.5, 0, 0.25), si.Interaction.P)
+	assert.InDelta(t, 0.5, si.Uv.X, equalDelta)
+	assert.InDelta(t, 0.25, si.Uv.Y, equalDelta)
+	InDeltaNormal3(t, mymath.NewNormal3(-1/math.Sqrt2, 0, -1/math.Sqrt2), si.Interaction.N)
+
+	InDeltaVector3(t, mymath.NewVector3(0, -math.Pi, 0), si.Dpdu)
+	InDeltaVector3(t, mymath.NewVector3(-1, 0, 1), si.Dpdv)
+	InDeltaNormal3(t, mymath.NewNormal3(0, -math.Sqrt2*math.Pi, 0), si.Dndu)
+	InDeltaNormal3(t, mymath.NewNormal3(-1/math.Sqrt2, 0, 1/math.Sqrt2), si.Dndv)
+}
+
+func TestParaboloid_Intersect_inside(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	p := mymath.NewParaboloid(1, 0.5, 1, 360, &identity, &identity, false)
+
+	// ray going down the axis misses the cut off bottom
+	ray := mymath.NewRay(mymath.NewPoint3(0, 0, 5), mymath.NewVector3(0, 0, -1), 50, 0, material.Medium{})
+	ok, _, _ := p.Intersect(ray, false)
+	assert.Equal(t, false, ok)
+
+	// ray starting inside hits the far wall
+	ray = mymath.NewRay(mymath.NewPoint3(0, 0, 0.81), mymath.NewVector3(1, 0, 0), 50, 0, material.Medium{})
+	ok, tHit, si := p.Intersect(ray, false)
+	assert.Equal(t, true, ok)
+	assert.InDelta(t, 0.9, tHit, equalDelta)
+	InDeltaPoint3(t, mymath.NewPoint3(0.9, 0, 0.81), si.Interaction.P)
+}
+
+func TestParaboloid_IntersectP(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	p := mymath.NewParaboloid(1, 0, 1, 360, &identity, &identity, false)
+
+	hit := mymath.NewRay(mymath.NewPoint3(-5, 0, 0.25), mymath.NewVector3(1, 0, 0), 50, 0, material.Medium{})
+	miss := mymath.NewRay(mymath.NewPoint3(-5, 0, -0.25), mymath.NewVector3(1, 0, 0), 50, 0, material.Medium{})
+
+	assert.Equal(t, true, p.IntersectP(p, hit, false))
+	assert.Equal(t, false, p.IntersectP(p, miss, false))
+}
+
+func TestParaboloid_Area(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	p := mymath.NewParaboloid(1, 0, 1, 360, &identity, &identity, false)
+
+	assert.InDelta(t, math.Pi/6*(math.Pow(5, 1.5)-1), p.Area(), equalDelta)
+}
@@ -0,0 +1,32 @@
+package mymath
+
+// Point2i is point with integer coordinates, used for pixel positions and resolutions
+type Point2i struct {
+	X, Y int
+}
+
+func NewPoint2i(x, y int) Point2i {
+	return Point2i{x, y}
+}
+
+func (p1 Point2i) Min(p2 Point2i) Point2i {
+	return NewPoint2i(minInt(p1.X, p2.X), minInt(p1.Y, p2.Y))
+}
+
+func (p1 Point2i) Max(p2 Point2i) Point2i {
+	return NewPoint2i(maxInt(p1.X, p2.X), maxInt(p1.Y, p2.Y))
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,33 @@
+package mymath
+
+// Primitive bridges geometry and shading, it is the object aggregates and integrators work with
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/primitive.h#L49
+type Primitive interface {
+	WorldBound() Bounds3
+
+	// Intersect finds the closest ray-primitive collision and shortens ray.TMax to its distance
+	Intersect(ray *Ray) (bool, *SurfaceInteraction)
+
+	// IntersectP finds if ray collides with this primitive
+	IntersectP(ray Ray) bool
+
+	GetAreaLight() AreaLight
+	GetMaterial() Material
+}
+
+// Aggregate provides Primitive methods for acceleration structures. The intersection returned
+// by aggregate always references the actual primitive hit, so these methods must not be used.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/primitive.h#L131
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/primitive.cpp#L73
+type Aggregate struct {
+}
+
+func (Aggregate) GetAreaLight() AreaLight {
+	panic("Aggregate.GetAreaLight() method called; should have gone to GeometricPrimitive")
+}
+
+func (Aggregate) GetMaterial() Material {
+	panic("Aggregate.GetMaterial() method called; should have gone to GeometricPrimitive")
+}
@@ -0,0 +1,129 @@
+package mymath_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pbrt-go/material"
+	"pbrt-go/mymath"
+	"testing"
+)
+
+type testMaterial struct {
+	name string
+}
+
+func (m *testMaterial) ComputeScatteringFunctions(si *mymath.SurfaceInteraction, _ mymath.TransportMode, _ bool) {
+	si.BSDF = mymath.NewBSDF(si, 1)
+}
+
+func newUnitSpherePrimitive(mat mymath.Material) *mymath.GeometricPrimitive {
+	identity := mymath.NewTransformEmpty()
+	sphere := mymath.NewSphere(1, -1, 1, 360, &identity, &identity, false)
+
+	return mymath.NewGeometricPrimitive(sphere, mat, nil, nil)
+}
+
+func TestGeometricPrimitive_Intersect(t *testing.T) {
+	mat := &testMaterial{"red"}
+	prim := newUnitSpherePrimitive(mat)
+
+	ray := mymath.NewRay(mymath.NewPoint3(-5, 0, 0), mymath.NewVector3(1, 0, 0), 100, 0, material.Medium{})
+
+	ok, si := prim.Intersect(&ray)
+	assert.True(t, ok)
+	assert.InDelta(t, 4.0, ray.TMax, equalDelta)
+	InDeltaPoint3(t, mymath.NewPoint3(-1, 0, 0), si.Interaction.P)
+	assert.Same(t, prim, si.Primitive)
+	assert.Equal(t, mat, si.Primitive.GetMaterial())
+	assert.Nil(t, si.Primitive.GetAreaLight())
+}
+
+func TestSurfaceInteraction_ComputeScatteringFunctions(t *testing.T) {
+	ray := mymath.NewRay(mymath.NewPoint3(-5, 0, 0), mymath.NewVector3(1, 0, 0), 100, 0, material.Medium{})
+
+	r := ray
+	_, si := newUnitSpherePrimitive(&testMaterial{"red"}).Intersect(&r)
+	si.ComputeScatteringFunctions(mymath.NewRayDifferentialRay(ray), mymath.Radiance, false)
+	assert.NotNil(t, si.BSDF)
+
+	// no material, no scattering
+	r = ray
+	_, si = newUnitSpherePrimitive(nil).Intersect(&r)
+	si.ComputeScatteringFunctions(mymath.NewRayDifferentialRay(ray), mymath.Radiance, false)
+	assert.Nil(t, si.BSDF)
+}
+
+func TestGeometricPrimitive_Intersect_miss(t *testing.T) {
+	prim := newUnitSpherePrimitive(nil)
+
+	ray := mymath.NewRay(mymath.NewPoint3(-5, 2, 0), mymath.NewVector3(1, 0, 0), 100, 0, material.Medium{})
+
+	ok, si := prim.Intersect(&ray)
+	assert.False(t, ok)
+	assert.Nil(t, si)
+	assert.Equal(t, 100.0, ray.TMax)
+	assert.False(t, prim.IntersectP(ray))
+}
+
+func TestTransformedPrimitive_WorldBound(t *testing.T) {
+	prim := newUnitSpherePrimitive(nil)
+
+	translate := mymath.NewTransformTranslate(mymath.NewVector3(10, 0, 0))
+	primToWorld, _ := mymath.NewAnimatedTransform(translate, 0, translate, 1)
+
+	instance, err := mymath.NewTransformedPrimitive(prim, primToWorld)
+	assert.NoError(t, err)
+
+	InDeltaPoint3(t, mymath.NewPoint3(9, -1, -1), instance.WorldBound().PMin)
+	InDeltaPoint3(t, mymath.NewPoint3(11, 1, 1), instance.WorldBound().PMax)
+}
+
+func TestTransformedPrimitive_Intersect(t *testing.T) {
+	mat := &testMaterial{"red"}
+	prim := newUnitSpherePrimitive(mat)
+
+	translate := mymath.NewTransformTranslate(mymath.NewVector3(10, 0, 0))
+	primToWorld, _ := mymath.NewAnimatedTransform(translate, 0, translate, 1)
+
+	instance, _ := mymath.NewTransformedPrimitive(prim, primToWorld)
+
+	ray := mymath.NewRay(mymath.NewPoint3(0, 0, 0), mymath.NewVector3(1, 0, 0), 100, 0, material.Medium{})
+
+	ok, si := instance.Intersect(&ray)
+	assert.True(t, ok)
+	assert.InDelta(t, 9.0, ray.TMax, equalDelta)
+	InDeltaPoint3(t, mymath.NewPoint3(9, 0, 0), si.Interaction.P)
+	InDeltaNormal3(t, mymath.NewNormal3(-1, 0, 0), si.Interaction.N)
+
+	// intersection references the instanced primitive, not the instance
+	assert.Same(t, prim, si.Primitive)
+	assert.Equal(t, mat, si.Primitive.GetMaterial())
+
+	assert.True(t, instance.IntersectP(mymath.NewRay(mymath.NewPoint3(0, 0, 0), mymath.NewVector3(1, 0, 0), 100, 0, material.Medium{})))
+	assert.False(t, instance.IntersectP(mymath.NewRay(mymath.NewPoint3(0, 0, 0), mymath.NewVector3(-1, 0, 0), 100, 0, material.Medium{})))
+
+	// the instance itself has no material nor area light
+	assert.Panics(t, func() { instance.GetMaterial() })
+	assert.Panics(t, func() { instance.GetAreaLight() })
+}
+
+// Instances share single primitive, each hit is reported in its own world position
+func TestTransformedPrimitive_Intersect_instancing(t *testing.T) {
+	prim := newUnitSpherePrimitive(nil)
+
+	instances := make([]*mymath.TransformedPrimitive, 3)
+	for i := range instances {
+		translate := mymath.NewTransformTranslate(mymath.NewVector3(0, 0, float64(5*i)))
+		primToWorld, _ := mymath.NewAnimatedTransform(translate, 0, translate, 1)
+		instances[i], _ = mymath.NewTransformedPrimitive(prim, primToWorld)
+	}
+
+	for i, instance := range instances {
+		z := float64(5 * i)
+		ray := mymath.NewRay(mymath.NewPoint3(0, -5, z), mymath.NewVector3(0, 1, 0), 100, 0, material.Medium{})
+
+		ok, si := instance.Intersect(&ray)
+		assert.True(t, ok)
+		assert.InDelta(t, 4.0, ray.TMax, equalDelta)
+		InDeltaPoint3(t, mymath.NewPoint3(0, -1, z), si.Interaction.P)
+	}
+}
@@ -0,0 +1,176 @@
+package mymath
+
+import (
+	"math"
+	"pbrt-go/spectrum"
+)
+
+// Refract computes direction of the ray refracted through the interface with normal n, eta is the ratio of
+// the indices of refraction of the incident and transmitted media. Returns false on total internal reflection.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L97
+func Refract(wi Vector3, n Normal3, eta float64) (bool, Vector3) {
+	// Compute cos(theta_t) using Snell's law
+	nv := NewVector3N(n)
+	cosThetaI := nv.Dot(wi)
+	sin2ThetaI := math.Max(0, 1-cosThetaI*cosThetaI)
+	sin2ThetaT := eta * eta * sin2ThetaI
+
+	// Handle total internal reflection for transmission
+	if sin2ThetaT >= 1 {
+		return false, Vector3{}
+	}
+
+	cosThetaT := math.Sqrt(1 - sin2ThetaT)
+	return true, wi.Negate().Multiply(eta).Add(nv.Multiply(eta*cosThetaI - cosThetaT))
+}
+
+// TransportMode tells whether the path carries radiance from the lights or importance from the camera,
+// the non-symmetric scattering functions differ for them
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/material.h#L48
+type TransportMode int
+
+const (
+	Radiance TransportMode = iota
+	Importance
+)
+
+// The trigonometric functions of the direction w in the local shading coordinate system, where the normal is
+// the z axis, theta is measured from the normal and phi around it
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L63
+
+func CosTheta(w Vector3) float64 {
+	return w.Z
+}
+
+func Cos2Theta(w Vector3) float64 {
+	return w.Z * w.Z
+}
+
+func AbsCosTheta(w Vector3) float64 {
+	return math.Abs(w.Z)
+}
+
+func Sin2Theta(w Vector3) float64 {
+	return math.Max(0, 1-Cos2Theta(w))
+}
+
+func SinTheta(w Vector3) float64 {
+	return math.Sqrt(Sin2Theta(w))
+}
+
+func TanTheta(w Vector3) float64 {
+	return SinTheta(w) / CosTheta(w)
+}
+
+func Tan2Theta(w Vector3) float64 {
+	return Sin2Theta(w) / Cos2Theta(w)
+}
+
+func CosPhi(w Vector3) float64 {
+	sinTheta := SinTheta(w)
+	if sinTheta == 0 {
+		return 1
+	}
+	return Clamp(w.X/sinTheta, -1, 1)
+}
+
+func SinPhi(w Vector3) float64 {
+	sinTheta := SinTheta(w)
+	if sinTheta == 0 {
+		return 0
+	}
+	return Clamp(w.Y/sinTheta, -1, 1)
+}
+
+func Cos2Phi(w Vector3) float64 {
+	return CosPhi(w) * CosPhi(w)
+}
+
+func Sin2Phi(w Vector3) float64 {
+	return SinPhi(w) * SinPhi(w)
+}
+
+// CosDPhi returns cosine of the angle between the projections of wa and wb to the xy plane
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L94
+func CosDPhi(wa, wb Vector3) float64 {
+	waxy := wa.X*wa.X + wa.Y*wa.Y
+	wbxy := wb.X*wb.X + wb.Y*wb.Y
+	if waxy == 0 || wbxy == 0 {
+		return 1
+	}
+	return Clamp((wa.X*wb.X+wa.Y*wb.Y)/math.Sqrt(waxy*wbxy), -1, 1)
+}
+
+// Reflect returns direction wo mirrored about the normal n
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L92
+func Reflect(wo, n Vector3) Vector3 {
+	return wo.Negate().Add(n.Multiply(2 * wo.Dot(n)))
+}
+
+// SameHemisphere tells whether the directions in the local shading coordinate system lie on the same side
+// of the surface
+func SameHemisphere(w, wp Vector3) bool {
+	return w.Z*wp.Z > 0
+}
+
+// FrDielectric returns Fresnel reflectance of the unpolarized light at the interface of the two dielectric media,
+// negative cosThetaI means the light comes from the side of etaT
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L66
+func FrDielectric(cosThetaI, etaI, etaT float64) float64 {
+	cosThetaI = Clamp(cosThetaI, -1, 1)
+
+	// Potentially swap indices of refraction
+	entering := cosThetaI > 0
+	if !entering {
+		etaI, etaT = etaT, etaI
+		cosThetaI = math.Abs(cosThetaI)
+	}
+
+	// Compute cosThetaT using Snell's law
+	sinThetaI := math.Sqrt(math.Max(0, 1-cosThetaI*cosThetaI))
+	sinThetaT := etaI / etaT * sinThetaI
+
+	// Handle total internal reflection
+	if sinThetaT >= 1 {
+		return 1
+	}
+	cosThetaT := math.Sqrt(math.Max(0, 1-sinThetaT*sinThetaT))
+
+	rParl := ((etaT * cosThetaI) - (etaI * cosThetaT)) / ((etaT * cosThetaI) + (etaI * cosThetaT))
+	rPerp := ((etaI * cosThetaI) - (etaT * cosThetaT)) / ((etaI * cosThetaI) + (etaT * cosThetaT))
+	return (rParl*rParl + rPerp*rPerp) / 2
+}
+
+// FrConductor returns Fresnel reflectance at the interface of the dielectric and the conductor with
+// the absorption coefficient k
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L96
+func FrConductor(cosThetaI float64, etaI, etaT, k spectrum.Spectrum) spectrum.Spectrum {
+	cosThetaI = Clamp(cosThetaI, -1, 1)
+	eta := etaT.DivideS(etaI)
+	etak := k.DivideS(etaI)
+
+	cosThetaI2 := cosThetaI * cosThetaI
+	sinThetaI2 := 1 - cosThetaI2
+	eta2 := eta.MultiplyS(eta)
+	etak2 := etak.MultiplyS(etak)
+
+	t0 := eta2.Subtract(etak2).Subtract(spectrum.NewSpectrum(sinThetaI2))
+	a2plusb2 := t0.MultiplyS(t0).Add(eta2.MultiplyS(etak2).Multiply(4)).Sqrt()
+	t1 := a2plusb2.Add(spectrum.NewSpectrum(cosThetaI2))
+	a := a2plusb2.Add(t0).Multiply(0.5).Sqrt()
+	t2 := a.Multiply(2 * cosThetaI)
+	rs := t1.Subtract(t2).DivideS(t1.Add(t2))
+
+	t3 := a2plusb2.Multiply(cosThetaI2).Add(spectrum.NewSpectrum(sinThetaI2 * sinThetaI2))
+	t4 := t2.Multiply(sinThetaI2)
+	rp := rs.MultiplyS(t3.Subtract(t4)).DivideS(t3.Add(t4))
+
+	return rp.Add(rs).Multiply(0.5)
+}
@@ -0,0 +1,85 @@
+package mymath_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"pbrt-go/mymath"
+	"pbrt-go/spectrum"
+	"testing"
+)
+
+func TestRefract(t *testing.T) {
+	n := mymath.NewNormal3(0, 0, 1)
+	wi := mymath.NewVector3(math.Sin(0.5), 0, math.Cos(0.5))
+
+	// Snell's law
+	ok, wt := mymath.Refract(wi, n, 1/1.5)
+	assert.True(t, ok)
+	assert.InDelta(t, 1, wt.Length(), equalDelta)
+	assert.InDelta(t, math.Sin(0.5)/1.5, -wt.X, equalDelta)
+	assert.Less(t, wt.Z, 0.0)
+
+	// total internal reflection
+	ok, _ = mymath.Refract(mymath.NewVector3(math.Sin(1.2), 0, math.Cos(1.2)), n, 1.5)
+	assert.False(t, ok)
+}
+
+func TestQuadraticFloat(t *testing.T) {
+	ok, t0, t1 := mymath.QuadraticFloat(2, -2, -12)
+	assert.True(t, ok)
+	assert.InDelta(t, -2, t0, equalDelta)
+	assert.InDelta(t, 3, t1, equalDelta)
+
+	ok, _, _ = mymath.QuadraticFloat(1, 0, 1)
+	assert.False(t, ok)
+}
+
+func TestFrDielectric(t *testing.T) {
+	// normal incidence ((n1 - n2) / (n1 + n2))^2
+	assert.InDelta(t, 0.04, mymath.FrDielectric(1, 1, 1.5), equalDelta)
+
+	// leaving the medium is the same as entering from the other side
+	assert.InDelta(t, mymath.FrDielectric(0.7, 1, 1.5), mymath.FrDielectric(-0.7, 1.5, 1), equalDelta)
+
+	// grazing angle
+	assert.InDelta(t, 1, mymath.FrDielectric(0, 1, 1.5), equalDelta)
+
+	// total internal reflection
+	assert.Equal(t, 1.0, mymath.FrDielectric(0.2, 1.5, 1))
+}
+
+func TestFrConductor(t *testing.T) {
+	// conductor without absorption is dielectric
+	etaI, etaT, k := spectrum.NewSpectrum(1), spectrum.NewSpectrum(1.5), spectrum.NewSpectrum(0)
+	for _, cosThetaI := range []float64{0.1, 0.5, 0.9, 1} {
+		InDeltaSpectrum(t, spectrum.NewSpectrum(mymath.FrDielectric(cosThetaI, 1, 1.5)), mymath.FrConductor(cosThetaI, etaI, etaT, k), 1e-4)
+	}
+
+	// normal incidence ((n - 1)^2 + k^2) / ((n + 1)^2 + k^2)
+	fr := mymath.FrConductor(1, spectrum.NewSpectrum(1), spectrum.NewSpectrum(0.2), spectrum.NewSpectrum(3))
+	InDeltaSpectrum(t, spectrum.NewSpectrum((0.64+9)/(1.44+9)), fr, 1e-4)
+
+	// the conductor Fresnel does not depend on the side
+	conductor := mymath.NewFresnelConductor(etaI, spectrum.NewSpectrum(0.2), spectrum.NewSpectrum(3))
+	InDeltaSpectrum(t, conductor.Evaluate(0.5), conductor.Evaluate(-0.5), equalDelta)
+}
+
+func TestReflect(t *testing.T) {
+	InDeltaVector3(t, mymath.NewVector3(-1, -2, 3), mymath.Reflect(mymath.NewVector3(1, 2, 3), mymath.NewVector3(0, 0, 1)))
+}
+
+func TestShadingTrigonometry(t *testing.T) {
+	w := mymath.SphericalDirection(math.Sin(0.3), math.Cos(0.3), 1.2)
+	assert.InDelta(t, math.Cos(0.3), mymath.CosTheta(w), equalDelta)
+	assert.InDelta(t, math.Sin(0.3), mymath.SinTheta(w), equalDelta)
+	assert.InDelta(t, math.Tan(0.3), mymath.TanTheta(w), equalDelta)
+	assert.InDelta(t, math.Cos(1.2), mymath.CosPhi(w), equalDelta)
+	assert.InDelta(t, math.Sin(1.2), mymath.SinPhi(w), equalDelta)
+
+	w2 := mymath.SphericalDirection(math.Sin(0.7), math.Cos(0.7), 0.5)
+	assert.InDelta(t, math.Cos(0.7), mymath.CosDPhi(w, w2), equalDelta)
+
+	// normal has no phi
+	assert.Equal(t, 1.0, mymath.CosPhi(mymath.NewVector3(0, 0, 1)))
+	assert.Equal(t, 0.0, mymath.SinPhi(mymath.NewVector3(0, 0, 1)))
+}
@@ -0,0 +1,133 @@
+package mymath
+
+import (
+	"math"
+)
+
+// UniformSampleSphere see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.cpp#L160
+func UniformSampleSphere(u Point2) Vector3 {
+	z := 1 - 2*u.X
+	r := math.Sqrt(math.Max(0, 1-z*z))
+	phi := 2 * math.Pi * u.Y
+
+	return NewVector3(r*math.Cos(phi), r*math.Sin(phi), z)
+}
+
+// UniformSpherePdf see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.cpp#L167
+func UniformSpherePdf() float64 {
+	return 1 / (4 * math.Pi)
+}
+
+// UniformSampleHemisphere samples the hemisphere around +z uniformly with respect to the solid angle
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.cpp#L108
+func UniformSampleHemisphere(u Point2) Vector3 {
+	z := u.X
+	r := math.Sqrt(math.Max(0, 1-z*z))
+	phi := 2 * math.Pi * u.Y
+
+	return NewVector3(r*math.Cos(phi), r*math.Sin(phi), z)
+}
+
+// UniformHemispherePdf see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.cpp#L115
+func UniformHemispherePdf() float64 {
+	return 1 / (2 * math.Pi)
+}
+
+// UniformSampleDisk samples the unit disk uniformly by the area, it distorts the strata more than ConcentricSampleDisk
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.cpp#L169
+func UniformSampleDisk(u Point2) Point2 {
+	r := math.Sqrt(u.X)
+	theta := 2 * math.Pi * u.Y
+
+	return NewPoint2(r*math.Cos(theta), r*math.Sin(theta))
+}
+
+// UniformDiskPdf returns the area density of the unit disk samples
+func UniformDiskPdf() float64 {
+	return 1 / math.Pi
+}
+
+// ConcentricSampleDisk maps the square to the unit disk preserving relative areas
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.cpp#L176
+func ConcentricSampleDisk(u Point2) Point2 {
+	// Map uniform random numbers to [-1,1]^2
+	uOffset := NewPoint2(2*u.X-1, 2*u.Y-1)
+
+	// Handle degeneracy at the origin
+	if uOffset.X == 0 && uOffset.Y == 0 {
+		return NewPoint2(0, 0)
+	}
+
+	// Apply concentric mapping to point
+	var theta, r float64
+	if math.Abs(uOffset.X) > math.Abs(uOffset.Y) {
+		r = uOffset.X
+		theta = math.Pi / 4 * (uOffset.Y / uOffset.X)
+	} else {
+		r = uOffset.Y
+		theta = math.Pi/2 - math.Pi/4*(uOffset.X/uOffset.Y)
+	}
+
+	return NewPoint2(r*math.Cos(theta), r*math.Sin(theta))
+}
+
+// CosineSampleHemisphere samples the hemisphere around +z with density proportional to cos(theta),
+// it projects the samples of the disk up to the hemisphere (Malley's method)
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.h#L219
+func CosineSampleHemisphere(u Point2) Vector3 {
+	d := ConcentricSampleDisk(u)
+	z := math.Sqrt(math.Max(0, 1-d.X*d.X-d.Y*d.Y))
+
+	return NewVector3(d.X, d.Y, z)
+}
+
+// CosineHemispherePdf see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.h#L225
+func CosineHemispherePdf(cosTheta float64) float64 {
+	return cosTheta / math.Pi
+}
+
+// UniformSampleCone samples directions around +z within the angle acos(cosThetaMax) uniformly with respect
+// to the solid angle
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.cpp#L191
+func UniformSampleCone(u Point2, cosThetaMax float64) Vector3 {
+	cosTheta := (1 - u.X) + u.X*cosThetaMax
+	sinTheta := math.Sqrt(1 - cosTheta*cosTheta)
+	phi := u.Y * 2 * math.Pi
+
+	return NewVector3(math.Cos(phi)*sinTheta, math.Sin(phi)*sinTheta, cosTheta)
+}
+
+// UniformSampleConeFrame is UniformSampleCone around the z axis of the given coordinate system
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.cpp#L198
+func UniformSampleConeFrame(u Point2, cosThetaMax float64, x, y, z Vector3) Vector3 {
+	cosTheta := Lerp(u.X, cosThetaMax, 1)
+	sinTheta := math.Sqrt(1 - cosTheta*cosTheta)
+	phi := u.Y * 2 * math.Pi
+
+	return x.Multiply(math.Cos(phi) * sinTheta).Add(y.Multiply(math.Sin(phi) * sinTheta)).Add(z.Multiply(cosTheta))
+}
+
+// UniformConePdf see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.cpp#L190
+func UniformConePdf(cosThetaMax float64) float64 {
+	return 1 / (2 * math.Pi * (1 - cosThetaMax))
+}
+
+// UniformSampleTriangle returns barycentric coordinates b0, b1 of uniformly distributed point
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.cpp#L206
+func UniformSampleTriangle(u Point2) Point2 {
+	su0 := math.Sqrt(u.X)
+	return NewPoint2(1-su0, u.Y*su0)
+}
+
+// UniformSampleTrianglePoint returns uniformly distributed point of the triangle p0, p1, p2
+func UniformSampleTrianglePoint(u Point2, p0, p1, p2 Point3) Point3 {
+	b := UniformSampleTriangle(u)
+	return p0.Multiply(b.X).AddP(p1.Multiply(b.Y)).AddP(p2.Multiply(1 - b.X - b.Y))
+}
@@ -0,0 +1,73 @@
+package mymath_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"pbrt-go/mymath"
+	"testing"
+)
+
+func TestUniformSampleSphere(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		w := mymath.UniformSampleSphere(mymath.NewPoint2(rng.Float64(), rng.Float64()))
+		assert.InDelta(t, 1.0, w.Length(), equalDelta)
+	}
+
+	InDeltaVector3(t, mymath.NewVector3(0, 0, 1), mymath.UniformSampleSphere(mymath.NewPoint2(0, 0)))
+	InDeltaVector3(t, mymath.NewVector3(0, 0, -1), mymath.UniformSampleSphere(mymath.NewPoint2(1, 0)))
+}
+
+func TestConcentricSampleDisk(t *testing.T) {
+	assert.Equal(t, mymath.NewPoint2(0, 0), mymath.ConcentricSampleDisk(mymath.NewPoint2(0.5, 0.5)))
+
+	// square boundary maps to the circle boundary
+	for _, u := range []mymath.Point2{mymath.NewPoint2(1, 0.5), mymath.NewPoint2(0, 0.3), mymath.NewPoint2(0.8, 1), mymath.NewPoint2(1, 1)} {
+		p := mymath.ConcentricSampleDisk(u)
+		assert.InDelta(t, 1.0, math.Hypot(p.X, p.Y), equalDelta)
+	}
+
+	// the mapping preserves areas, quarter of samples falls inside the circle of radius 1/2
+	rng := rand.New(rand.NewSource(2))
+	inside := 0
+	n := 100000
+	for i := 0; i < n; i++ {
+		p := mymath.ConcentricSampleDisk(mymath.NewPoint2(rng.Float64(), rng.Float64()))
+		if math.Hypot(p.X, p.Y) < 0.5 {
+			inside++
+		}
+	}
+	assert.InDelta(t, 0.25, float64(inside)/float64(n), 0.01)
+}
+
+func TestUniformSampleTriangle(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+
+	for i := 0; i < 100; i++ {
+		b := mymath.UniformSampleTriangle(mymath.NewPoint2(rng.Float64(), rng.Float64()))
+		assert.True(t, b.X >= 0 && b.Y >= 0 && b.X+b.Y <= 1)
+	}
+}
+
+func TestSphericalDirection(t *testing.T) {
+	InDeltaVector3(t, mymath.NewVector3(0, 1, 0), mymath.SphericalDirection(1, 0, math.Pi/2))
+	InDeltaVector3(t, mymath.NewVector3(0, 0, -1), mymath.SphericalDirection(0, -1, 1))
+
+	x, y, z := mymath.NewVector3(0, 1, 0), mymath.NewVector3(0, 0, 1), mymath.NewVector3(1, 0, 0)
+	InDeltaVector3(t, mymath.NewVector3(0, 0, 1), mymath.SphericalDirectionBasis(1, 0, math.Pi/2, x, y, z))
+}
+
+// integrateSolidAnglePdf estimates the integral of the shape solid angle density over all directions
+func integrateSolidAnglePdf(shape mymath.IShape, ref mymath.Interaction, n int) float64 {
+	rng := rand.New(rand.NewSource(4))
+
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		wi := mymath.UniformSampleSphere(mymath.NewPoint2(rng.Float64(), rng.Float64()))
+		sum += shape.Pdf(ref, wi) / mymath.UniformSpherePdf()
+	}
+
+	return sum / float64(n)
+}
@@ -0,0 +1,40 @@
+package mymath
+
+import "pbrt-go/spectrum"
+
+// ScaledBxDF scales contribution of the lobe, it is used to blend the materials
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L223
+type ScaledBxDF struct {
+	BxDF  BxDF
+	Scale spectrum.Spectrum
+}
+
+func NewScaledBxDF(bxdf BxDF, scale spectrum.Spectrum) *ScaledBxDF {
+	return &ScaledBxDF{bxdf, scale}
+}
+
+func (b *ScaledBxDF) F(wo, wi Vector3) spectrum.Spectrum {
+	return b.Scale.MultiplyS(b.BxDF.F(wo, wi))
+}
+
+func (b *ScaledBxDF) SampleF(wo Vector3, u Point2) (Vector3, spectrum.Spectrum, float64, BxDFType) {
+	wi, f, pdf, sampledType := b.BxDF.SampleF(wo, u)
+	return wi, b.Scale.MultiplyS(f), pdf, sampledType
+}
+
+func (b *ScaledBxDF) Pdf(wo, wi Vector3) float64 {
+	return b.BxDF.Pdf(wo, wi)
+}
+
+func (b *ScaledBxDF) Rho(wo Vector3, samples []Point2) spectrum.Spectrum {
+	return b.Scale.MultiplyS(b.BxDF.Rho(wo, samples))
+}
+
+func (b *ScaledBxDF) RhoHH(samples1, samples2 []Point2) spectrum.Spectrum {
+	return b.Scale.MultiplyS(b.BxDF.RhoHH(samples1, samples2))
+}
+
+func (b *ScaledBxDF) Type() BxDFType {
+	return b.BxDF.Type()
+}
@@ -1,5 +1,9 @@
 package mymath
 
+import (
+	"math"
+)
+
 // Shape see https://github.com/mmp/pbrt-v3/blob/master/src/core/shape.h, https://github.com/mmp/pbrt-v3/blob/master/src/core/shape.cpp
 type Shape struct {
 	ObjectToWorld, WorldToObject                 *Transform
@@ -26,12 +30,26 @@ type Areaer interface {
 	Area() float64
 }
 
+// PointSampler samples point uniformly by area on the shape surface, density of the samples is 1/Area
+type PointSampler interface {
+	Sample(u Point2) Interaction
+}
+
+// RefSampler samples point on the shape surface as seen from the reference point, density of the samples
+// with respect to the solid angle at the reference point is given by Pdf
+type RefSampler interface {
+	SampleRef(ref Interaction, u Point2) Interaction
+	Pdf(ref Interaction, wi Vector3) float64
+}
+
 type IShape interface {
 	ObjectBounder
 	WorldBounder
 	Intersecter
 	IntersectPer
 	Areaer
+	PointSampler
+	RefSampler
 }
 
 func NewShape(objectToWorld, worldToObject *Transform, reverseOrientation bool) Shape {
@@ -51,3 +69,24 @@ func (s Shape) IntersectP(i Intersecter, ray Ray, testAlphaTexture bool) bool {
 	intersects, _, _ := i.Intersect(ray, testAlphaTexture)
 	return intersects
 }
+
+// pdf converts the uniform area density of the shape to the solid angle density at the reference point
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/shape.cpp#L73
+func (s Shape) pdf(shape IShape, ref Interaction, wi Vector3) float64 {
+	// Intersect sample ray with area light geometry
+	ray := ref.SpawnRay(wi)
+	ok, _, isectLight := shape.Intersect(ray, false)
+	if !ok {
+		return 0
+	}
+
+	// Convert light sample weight to solid angle measure
+	pdf := ref.P.DistanceSq(isectLight.P) /
+		(math.Abs(NewVector3N(isectLight.N).Dot(wi.Negate())) * shape.Area())
+	if math.IsInf(pdf, 0) {
+		pdf = 0
+	}
+
+	return pdf
+}
@@ -0,0 +1,46 @@
+package mymath
+
+import "pbrt-go/spectrum"
+
+// SpecularReflection is the perfect mirror reflection scaled by the Fresnel term
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L247
+type SpecularReflection struct {
+	R       spectrum.Spectrum
+	Fresnel Fresnel
+}
+
+func NewSpecularReflection(r spectrum.Spectrum, fresnel Fresnel) *SpecularReflection {
+	return &SpecularReflection{r, fresnel}
+}
+
+// F is zero, the delta distribution is handled only by SampleF
+func (b *SpecularReflection) F(_, _ Vector3) spectrum.Spectrum {
+	return spectrum.NewSpectrum(0)
+}
+
+// SampleF returns the mirrored direction of wo
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L180
+func (b *SpecularReflection) SampleF(wo Vector3, _ Point2) (Vector3, spectrum.Spectrum, float64, BxDFType) {
+	// Compute perfect specular reflection direction
+	wi := NewVector3(-wo.X, -wo.Y, wo.Z)
+	f := b.Fresnel.Evaluate(CosTheta(wi)).MultiplyS(b.R).Divide(AbsCosTheta(wi))
+	return wi, f, 1, b.Type()
+}
+
+func (b *SpecularReflection) Pdf(_, _ Vector3) float64 {
+	return 0
+}
+
+func (b *SpecularReflection) Rho(wo Vector3, samples []Point2) spectrum.Spectrum {
+	return EstimateRho(b, wo, samples)
+}
+
+func (b *SpecularReflection) RhoHH(samples1, samples2 []Point2) spectrum.Spectrum {
+	return EstimateRhoHH(b, samples1, samples2)
+}
+
+func (b *SpecularReflection) Type() BxDFType {
+	return BSDFReflection | BSDFSpecular
+}
@@ -0,0 +1,65 @@
+package mymath
+
+import "pbrt-go/spectrum"
+
+// SpecularTransmission is the perfect refraction through the dielectric interface
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.h#L272
+type SpecularTransmission struct {
+	T spectrum.Spectrum
+	// EtaA and EtaB are indices of refraction above and below the surface
+	EtaA, EtaB float64
+	Fresnel    FresnelDielectric
+	Mode       TransportMode
+}
+
+func NewSpecularTransmission(t spectrum.Spectrum, etaA, etaB float64, mode TransportMode) *SpecularTransmission {
+	return &SpecularTransmission{t, etaA, etaB, FresnelDielectric{etaA, etaB}, mode}
+}
+
+// F is zero, the delta distribution is handled only by SampleF
+func (b *SpecularTransmission) F(_, _ Vector3) spectrum.Spectrum {
+	return spectrum.NewSpectrum(0)
+}
+
+// SampleF returns the refracted direction of wo, the pdf is zero on total internal reflection
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/reflection.cpp#L194
+func (b *SpecularTransmission) SampleF(wo Vector3, _ Point2) (Vector3, spectrum.Spectrum, float64, BxDFType) {
+	// Figure out which eta is incident and which is transmitted
+	entering := CosTheta(wo) > 0
+	etaI, etaT := b.EtaA, b.EtaB
+	if !entering {
+		etaI, etaT = etaT, etaI
+	}
+
+	// Compute ray direction for specular transmission
+	ok, wi := Refract(wo, NewNormal3(0, 0, 1).FaceForward(NewNormal3V(wo)), etaI/etaT)
+	if !ok {
+		return Vector3{}, spectrum.NewSpectrum(0), 0, b.Type()
+	}
+
+	ft := spectrum.NewSpectrum(1).Subtract(b.Fresnel.Evaluate(CosTheta(wi))).MultiplyS(b.T)
+
+	// Account for non-symmetry with transmission to different medium
+	if b.Mode == Radiance {
+		ft = ft.Multiply((etaI * etaI) / (etaT * etaT))
+	}
+	return wi, ft.Divide(AbsCosTheta(wi)), 1, b.Type()
+}
+
+func (b *SpecularTransmission) Pdf(_, _ Vector3) float64 {
+	return 0
+}
+
+func (b *SpecularTransmission) Rho(wo Vector3, samples []Point2) spectrum.Spectrum {
+	return EstimateRho(b, wo, samples)
+}
+
+func (b *SpecularTransmission) RhoHH(samples1, samples2 []Point2) spectrum.Spectrum {
+	return EstimateRhoHH(b, samples1, samples2)
+}
+
+func (b *SpecularTransmission) Type() BxDFType {
+	return BSDFTransmission | BSDFSpecular
+}
@@ -272,3 +272,99 @@ func (s Sphere) IntersectP(_ Intersecter, r Ray, _ bool) bool {
 func (s Sphere) Area() float64 {
 	return s.PhiMax * s.Radius * (s.ZMax - s.ZMin)
 }
+
+// Sample samples point uniformly on the whole sphere surface
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/sphere.cpp#L240
+func (s Sphere) Sample(u Point2) Interaction {
+	pObj := NewPoint3(0, 0, 0).AddV(UniformSampleSphere(u).Multiply(s.Radius))
+
+	var it Interaction
+	it.N = s.ObjectToWorld.ApplyN(NewNormal3(pObj.X, pObj.Y, pObj.Z)).Normalize()
+	if s.ReverseOrientation {
+		it.N = it.N.Negate()
+	}
+
+	// Reproject pObj to sphere surface and compute pObjError
+	pObj = pObj.Multiply(s.Radius / pObj.Distance(NewPoint3(0, 0, 0)))
+	pObjError := NewVector3P(pObj).Abs().Multiply(Gamma5)
+	it.P, it.PError = s.ObjectToWorld.ApplyPPError(pObj, pObjError)
+
+	return it
+}
+
+// SampleRef samples point uniformly inside the cone subtended by the sphere as seen from the reference point
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/sphere.cpp#L253
+func (s Sphere) SampleRef(ref Interaction, u Point2) Interaction {
+	pCenter := s.ObjectToWorld.ApplyP(NewPoint3(0, 0, 0))
+
+	// Sample uniformly on sphere if p is inside it
+	pOrigin := OffsetRayOrigin(ref.P, ref.PError, ref.N, pCenter.SubtractP(ref.P))
+	if pOrigin.DistanceSq(pCenter) <= s.Radius*s.Radius {
+		return s.Sample(u)
+	}
+
+	// Sample sphere uniformly inside subtended cone
+
+	// Compute coordinate system for sphere sampling
+	dc := ref.P.Distance(pCenter)
+	invDc := 1 / dc
+	wc := pCenter.SubtractP(ref.P).Multiply(invDc)
+	wcX, wcY := wc.CoordinateSystem()
+
+	// Compute theta and phi values for sample in cone
+	sinThetaMax := s.Radius * invDc
+	sinThetaMax2 := sinThetaMax * sinThetaMax
+	invSinThetaMax := 1 / sinThetaMax
+	cosThetaMax := math.Sqrt(math.Max(0, 1-sinThetaMax2))
+
+	cosTheta := (cosThetaMax-1)*u.X + 1
+	sinTheta2 := 1 - cosTheta*cosTheta
+
+	if sinThetaMax2 < 0.00068523 /* sin^2(1.5 deg) */ {
+		// Compute cone sample via Taylor series expansion for small angles
+		sinTheta2 = sinThetaMax2 * u.X
+		cosTheta = math.Sqrt(1 - sinTheta2)
+	}
+
+	// Compute angle alpha from center of sphere to sampled point on surface
+	cosAlpha := sinTheta2*invSinThetaMax +
+		cosTheta*math.Sqrt(math.Max(0, 1-sinTheta2*invSinThetaMax*invSinThetaMax))
+	sinAlpha := math.Sqrt(math.Max(0, 1-cosAlpha*cosAlpha))
+	phi := u.Y * 2 * math.Pi
+
+	// Compute surface normal and sampled point on sphere
+	nWorld := SphericalDirectionBasis(sinAlpha, cosAlpha, phi, wcX.Negate(), wcY.Negate(), wc.Negate())
+	pWorld := pCenter.AddV(nWorld.Multiply(s.Radius))
+
+	// Return Interaction for sampled point on sphere
+	var it Interaction
+	it.P = pWorld
+	it.PError = NewVector3P(pWorld).Abs().Multiply(Gamma5)
+	it.N = NewNormal3V(nWorld)
+	if s.ReverseOrientation {
+		it.N = it.N.Negate()
+	}
+
+	return it
+}
+
+// Pdf returns solid angle density of SampleRef, that is uniform cone density for reference points outside the sphere
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/sphere.cpp#L316
+func (s Sphere) Pdf(ref Interaction, wi Vector3) float64 {
+	pCenter := s.ObjectToWorld.ApplyP(NewPoint3(0, 0, 0))
+
+	// Return uniform PDF if point is inside sphere
+	pOrigin := OffsetRayOrigin(ref.P, ref.PError, ref.N, pCenter.SubtractP(ref.P))
+	if pOrigin.DistanceSq(pCenter) <= s.Radius*s.Radius {
+		return s.Shape.pdf(s, ref, wi)
+	}
+
+	// Compute general sphere PDF
+	sinThetaMax2 := s.Radius * s.Radius / ref.P.DistanceSq(pCenter)
+	cosThetaMax := math.Sqrt(math.Max(0, 1-sinThetaMax2))
+
+	return UniformConePdf(cosThetaMax)
+}
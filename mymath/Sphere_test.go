@@ -120,3 +120,51 @@ func TestSphere_Area(t *testing.T) {
 
 	assert.InDelta(t, 4*math.Pi*16.1*16.1, s.Area(), equalDelta)
 }
+
+func TestSphere_Sample(t *testing.T) {
+	objectToWorld := mymath.NewTransformTranslate(mymath.NewVector3(1, 2, 3))
+	worldToObject := objectToWorld.Inverse()
+	s := mymath.NewSphere(2, -2, 2, 360, &objectToWorld, &worldToObject, false)
+	center := mymath.NewPoint3(1, 2, 3)
+
+	for i := 0; i < 10; i++ {
+		it := s.Sample(mymath.NewPoint2(float64(i)/10, 0.37))
+
+		assert.InDelta(t, 2.0, it.P.Distance(center), equalDelta)
+		InDeltaNormal3(t, mymath.NewNormal3V(it.P.SubtractP(center).Normalize()), it.N)
+	}
+}
+
+func TestSphere_SampleRef(t *testing.T) {
+	objectToWorld := mymath.NewTransformTranslate(mymath.NewVector3(0, 0, 5))
+	worldToObject := objectToWorld.Inverse()
+	s := mymath.NewSphere(1, -1, 1, 360, &objectToWorld, &worldToObject, false)
+	center := mymath.NewPoint3(0, 0, 5)
+	ref := mymath.NewInteraction(mymath.NewPoint3(0, 0, 0), mymath.NewNormal3(0, 0, 0), mymath.NewVector3(0, 0, 0), mymath.NewVector3(0, 0, 0), 0, nil)
+
+	cosThetaMax := math.Sqrt(1 - 1.0/25)
+
+	for i := 0; i < 10; i++ {
+		it := s.SampleRef(ref, mymath.NewPoint2(float64(i)/10, 0.71))
+
+		// sampled point lies on the visible part of the sphere
+		assert.InDelta(t, 1.0, it.P.Distance(center), equalDelta)
+		wi := it.P.SubtractP(ref.P).Normalize()
+		assert.GreaterOrEqual(t, wi.Z, cosThetaMax-equalDelta)
+		assert.Less(t, mymath.NewVector3N(it.N).Dot(wi), 0.0)
+
+		assert.InDelta(t, mymath.UniformConePdf(cosThetaMax), s.Pdf(ref, wi), equalDelta)
+	}
+
+	// the cone density is returned for any direction, the caller has already found the sphere hit
+	assert.InDelta(t, mymath.UniformConePdf(cosThetaMax), s.Pdf(ref, mymath.NewVector3(0, 1, 0)), equalDelta)
+}
+
+func TestSphere_Pdf_inside(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+	s := mymath.NewSphere(2, -2, 2, 360, &identity, &identity, false)
+	ref := mymath.NewInteraction(mymath.NewPoint3(0, 0, 0), mymath.NewNormal3(0, 0, 0), mymath.NewVector3(0, 0, 0), mymath.NewVector3(0, 0, 0), 0, nil)
+
+	// from the center, the area density converts to the uniform sphere density
+	assert.InDelta(t, mymath.UniformSpherePdf(), s.Pdf(ref, mymath.NewVector3(0, 0.6, 0.8)), equalDelta)
+}
@@ -1,5 +1,7 @@
 package mymath
 
+import "math"
+
 // SurfaceInteraction describes local metadata for ray-shape collision point
 //
 // see https://github.com/mmp/pbrt-v3/blob/aaa552a4b9cbf9dccb71450f47b268e0ed6370e2/src/core/interaction.cpp
@@ -9,10 +11,19 @@ type SurfaceInteraction struct {
 	Dpdu, Dpdv Vector3
 	Dndu, Dndv Normal3
 	Shape      *Shape
-	shading    shading
+	Primitive  Primitive
+	Shading    Shading
+
+	// Screen space partial derivatives of the position and of the (u, v) coordinates, see ComputeDifferentials
+	Dpdx, Dpdy             Vector3
+	Dudx, Dvdx, Dudy, Dvdy float64
+
+	// BSDF is set by Material.ComputeScatteringFunctions
+	BSDF *BSDF
 }
 
-type shading struct {
+// Shading holds the shading geometry, it may differ from the true geometry e.g. by the interpolated normals or bump mapping
+type Shading struct {
 	N          Normal3
 	Dpdu, Dpdv Vector3
 	Dndu, Dndv Normal3
@@ -34,8 +45,13 @@ func NewSurfaceInteraction(p Point3, pError Vector3, uv Point2, wo Vector3, dpdu
 		dndu,
 		dndv,
 		shape,
+		nil,
 		// Initialize shading geometry from true geometry
-		shading{n, dpdu, dpdv, dndu, dndv},
+		Shading{n, dpdu, dpdv, dndu, dndv},
+		Vector3{},
+		Vector3{},
+		0, 0, 0, 0,
+		nil,
 	}
 
 	return surfaceInteraction
@@ -50,17 +66,88 @@ func (si *SurfaceInteraction) SetShadingGeometry(dpdus, dpdvs Vector3, dndus, dn
 		n = n.Negate()
 	}
 
-	si.shading.N = n
+	si.Shading.N = n
 
 	if orientationIsAuthoritative {
-		si.N = si.N.FaceForward(si.shading.N)
+		si.N = si.N.FaceForward(si.Shading.N)
 	} else {
-		si.shading.N = si.shading.N.FaceForward(si.N)
+		si.Shading.N = si.Shading.N.FaceForward(si.N)
 	}
 
 	// Initialize shading partial derivative values
-	si.shading.Dpdu = dpdus
-	si.shading.Dpdv = dpdvs
-	si.shading.Dndu = dndus
-	si.shading.Dndv = dndvs
+	si.Shading.Dpdu = dpdus
+	si.Shading.Dpdv = dpdvs
+	si.Shading.Dndu = dndus
+	si.Shading.Dndv = dndvs
+}
+
+// ComputeDifferentials estimates the screen space partial derivatives of the position and of the (u, v) coordinates
+// by intersecting the offset rays of the ray differential with the tangent plane. The derivatives are zero when
+// the ray has no differentials.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/interaction.cpp#L86
+func (si *SurfaceInteraction) ComputeDifferentials(ray RayDifferential) {
+	si.Dudx, si.Dvdx, si.Dudy, si.Dvdy = 0, 0, 0, 0
+	si.Dpdx, si.Dpdy = Vector3{}, Vector3{}
+
+	if !ray.HasDifferentials {
+		return
+	}
+
+	// Estimate screen space change in p and (u,v)
+
+	// Compute auxiliary intersection points with plane
+	n := NewVector3N(si.N)
+	d := n.Dot(NewVector3P(si.P))
+	tx := -(n.Dot(NewVector3P(ray.RxOrigin)) - d) / n.Dot(ray.RxDirection)
+	ty := -(n.Dot(NewVector3P(ray.RyOrigin)) - d) / n.Dot(ray.RyDirection)
+	if math.IsInf(tx, 0) || math.IsNaN(tx) || math.IsInf(ty, 0) || math.IsNaN(ty) {
+		return
+	}
+	px := ray.RxOrigin.AddV(ray.RxDirection.Multiply(tx))
+	py := ray.RyOrigin.AddV(ray.RyDirection.Multiply(ty))
+
+	si.Dpdx = px.SubtractP(si.P)
+	si.Dpdy = py.SubtractP(si.P)
+
+	// Compute (u,v) offsets at auxiliary points
+
+	// Choose two dimensions to use for ray offset computation
+	var dim [2]int
+	if math.Abs(n.X) > math.Abs(n.Y) && math.Abs(n.X) > math.Abs(n.Z) {
+		dim = [2]int{1, 2}
+	} else if math.Abs(n.Y) > math.Abs(n.Z) {
+		dim = [2]int{0, 2}
+	} else {
+		dim = [2]int{0, 1}
+	}
+
+	// Initialize A, Bx, and By matrices for offset computation
+	a := [2][2]float64{
+		{si.Dpdu.Get(dim[0]), si.Dpdv.Get(dim[0])},
+		{si.Dpdu.Get(dim[1]), si.Dpdv.Get(dim[1])},
+	}
+	bx := [2]float64{si.Dpdx.Get(dim[0]), si.Dpdx.Get(dim[1])}
+	by := [2]float64{si.Dpdy.Get(dim[0]), si.Dpdy.Get(dim[1])}
+
+	if ok, dudx, dvdx := SolveLinearSystem2x2(a, bx); ok {
+		si.Dudx, si.Dvdx = dudx, dvdx
+	}
+	if ok, dudy, dvdy := SolveLinearSystem2x2(a, by); ok {
+		si.Dudy, si.Dvdy = dudy, dvdy
+	}
+}
+
+// ComputeScatteringFunctions computes the ray differentials and sets the BSDF by the material of the primitive hit,
+// the BSDF stays nil when there is no material
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/interaction.cpp#L74
+func (si *SurfaceInteraction) ComputeScatteringFunctions(ray RayDifferential, mode TransportMode, allowMultipleLobes bool) {
+	si.ComputeDifferentials(ray)
+	if si.Primitive == nil {
+		return
+	}
+	if material := si.Primitive.GetMaterial(); material != nil {
+		material.ComputeScatteringFunctions(si, mode, allowMultipleLobes)
+	}
 }
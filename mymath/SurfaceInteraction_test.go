@@ -0,0 +1,42 @@
+package mymath_test
+
+import (
+	"pbrt-go/material"
+	"pbrt-go/mymath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSurfaceInteraction_ComputeDifferentials(t *testing.T) {
+	// plane z=0 parametrized by u = x/2 and v = y/4
+	si := mymath.NewSurfaceInteraction(mymath.NewPoint3(1, 2, 0), mymath.Vector3{}, mymath.NewPoint2(0.5, 0.5), mymath.NewVector3(0, 0, 1),
+		mymath.NewVector3(2, 0, 0), mymath.NewVector3(0, 4, 0), mymath.Normal3{}, mymath.Normal3{}, 0, nil)
+
+	// rays from the point above, the offset rays are tilted
+	ray := mymath.NewRayDifferentialRay(mymath.NewRay(mymath.NewPoint3(1, 2, 1), mymath.NewVector3(0, 0, -1), 10, 0, material.Medium{}))
+	ray.HasDifferentials = true
+	ray.RxOrigin = ray.O
+	ray.RxDirection = mymath.NewVector3(0.1, 0, -1)
+	ray.RyOrigin = ray.O.AddV(mymath.NewVector3(0, 0.2, 0))
+	ray.RyDirection = ray.D
+
+	si.ComputeDifferentials(ray)
+	InDeltaVector3(t, mymath.NewVector3(0.1, 0, 0), si.Dpdx)
+	InDeltaVector3(t, mymath.NewVector3(0, 0.2, 0), si.Dpdy)
+	assert.InDelta(t, 0.05, si.Dudx, 1e-12)
+	assert.InDelta(t, 0.0, si.Dvdx, 1e-12)
+	assert.InDelta(t, 0.0, si.Dudy, 1e-12)
+	assert.InDelta(t, 0.05, si.Dvdy, 1e-12)
+
+	// offset ray parallel to the plane
+	ray.RxDirection = mymath.NewVector3(1, 0, 0)
+	si.ComputeDifferentials(ray)
+	assert.Equal(t, mymath.Vector3{}, si.Dpdx)
+	assert.Equal(t, 0.0, si.Dvdy)
+
+	// no differentials
+	si.ComputeDifferentials(mymath.NewRayDifferentialRay(ray.Ray))
+	assert.Equal(t, mymath.Vector3{}, si.Dpdy)
+	assert.Equal(t, 0.0, si.Dudx)
+}
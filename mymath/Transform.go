@@ -172,6 +172,39 @@ func NewTransformLookAt(pos, look Point3, up Vector3) (Transform, error) {
 	return NewTransformFull(ctwInv, cameraToWorld), nil
 }
 
+// NewTransformOrthographic maps z values between zNear and zFar to [0, 1], x and y are left unchanged
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/transform.cpp#L189
+func NewTransformOrthographic(zNear, zFar float64) Transform {
+	return NewTransformScale(1, 1, float32(1/(zFar-zNear))).ApplyT(NewTransformTranslate(NewVector3(0, 0, -zNear)))
+}
+
+// NewTransformPerspective projects points onto the plane z = 1 and maps the field of view fov (in degrees)
+// to [-1, 1] in x and y, z values between n and f are mapped to [0, 1]
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/transform.cpp#L193
+func NewTransformPerspective(fov, n, f float64) Transform {
+	// Perform projective divide for perspective projection
+	a := f / (f - n)
+	b := -f * n / (f - n)
+
+	persp := NewTransformFull(
+		NewMatrix4x4AllF64(
+			1, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, a, b,
+			0, 0, 1, 0),
+		NewMatrix4x4AllF64(
+			1, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, 0, 1,
+			0, 0, 1/b, -a/b))
+
+	// Scale canonical perspective view to specified field of view
+	invTanAng := float32(1 / math.Tan(Radians(fov)/2))
+	return NewTransformScale(invTanAng, invTanAng, 1).ApplyT(persp)
+}
+
 // Applies transformation to Point
 //
 // see https://github.com/mmp/pbrt-v3/blob/master/src/core/transform.h#L222
@@ -179,7 +212,8 @@ func (t Transform) ApplyP(p Point3) Point3 {
 	return t.M.MultiplyP(p)
 }
 
-// Applies transformation to Point, also returns error vector
+// Applies transformation to Point, also returns error vector. The error uses float32 precision of the matrix,
+// see ApplyPPError.
 //
 // see https://github.com/mmp/pbrt-v3/blob/master/src/core/transform.h#L278
 func (t Transform) ApplyPError(p Point3) (Point3, Vector3) {
@@ -201,37 +235,38 @@ func (t Transform) ApplyPError(p Point3) (Point3, Vector3) {
 		math.Abs(float64(t.M.M[2][2])*p.Z) +
 		math.Abs(float64(t.M.M[2][3]))
 
-	pError := NewVector3(xAbsSum, yAbsSum, zAbsSum).Multiply(Gamma3)
+	pError := NewVector3(xAbsSum, yAbsSum, zAbsSum).Multiply(transformGamma3)
 
 	return pt, pError
 }
 
-// Applies transformation to Point, also returns error vector
+// Applies transformation to Point with existing error, also returns error vector. The error uses float32 precision
+// so that the point stays on the correct side of the surface when transformed back by the inverse.
 //
 // see https://github.com/mmp/pbrt-v3/blob/master/src/core/transform.h#L303
 func (t Transform) ApplyPPError(p Point3, pError Vector3) (Point3, Vector3) {
 	pt := t.M.MultiplyP(p)
 
-	xAbsSum := (Gamma3+1)*(math.Abs(float64(t.M.M[0][0]))*pError.X+
+	xAbsSum := (transformGamma3+1)*(math.Abs(float64(t.M.M[0][0]))*pError.X+
 		math.Abs(float64(t.M.M[0][1]))*pError.Y+
 		math.Abs(float64(t.M.M[0][2])*pError.Z)) +
-		Gamma3*(math.Abs(float64(t.M.M[0][0])*p.X)+
+		transformGamma3*(math.Abs(float64(t.M.M[0][0])*p.X)+
 			math.Abs(float64(t.M.M[0][1])*p.Y)+
 			math.Abs(float64(t.M.M[0][2])*p.Z)+
 			math.Abs(float64(t.M.M[0][3])))
 
-	yAbsSum := (Gamma3+1)*(math.Abs(float64(t.M.M[1][0]))*pError.X+
+	yAbsSum := (transformGamma3+1)*(math.Abs(float64(t.M.M[1][0]))*pError.X+
 		math.Abs(float64(t.M.M[1][1]))*pError.Y+
 		math.Abs(float64(t.M.M[1][2])*pError.Z)) +
-		Gamma3*(math.Abs(float64(t.M.M[1][0])*p.X)+
+		transformGamma3*(math.Abs(float64(t.M.M[1][0])*p.X)+
 			math.Abs(float64(t.M.M[1][1])*p.Y)+
 			math.Abs(float64(t.M.M[1][2])*p.Z)+
 			math.Abs(float64(t.M.M[1][3])))
 
-	zAbsSum := (Gamma3+1)*(math.Abs(float64(t.M.M[2][0]))*pError.X+
+	zAbsSum := (transformGamma3+1)*(math.Abs(float64(t.M.M[2][0]))*pError.X+
 		math.Abs(float64(t.M.M[2][1]))*pError.Y+
 		math.Abs(float64(t.M.M[2][2])*pError.Z)) +
-		Gamma3*(math.Abs(float64(t.M.M[2][0])*p.X)+
+		transformGamma3*(math.Abs(float64(t.M.M[2][0])*p.X)+
 			math.Abs(float64(t.M.M[2][1])*p.Y)+
 			math.Abs(float64(t.M.M[2][2])*p.Z)+
 			math.Abs(float64(t.M.M[2][3])))
@@ -248,7 +283,8 @@ func (t Transform) ApplyV(v Vector3) Vector3 {
 	return t.M.MultiplyV(v)
 }
 
-// ApplyVError applies transformation to Vector, also returns error vector
+// ApplyVError applies transformation to Vector, also returns error vector. The error uses float32 precision
+// of the matrix, see ApplyPPError.
 //
 // see https://github.com/mmp/pbrt-v3/blob/master/src/core/transform.h#L337
 func (t Transform) ApplyVError(v Vector3) (Vector3, Vector3) {
@@ -267,16 +303,16 @@ func (t Transform) ApplyVError(v Vector3) (Vector3, Vector3) {
 		math.Abs(float64(t.M.M[2][1])*v.Y) +
 		math.Abs(float64(t.M.M[2][2])*v.Z)
 
-	vError := NewVector3(xAbsSum, yAbsSum, zAbsSum).Multiply(Gamma3)
+	vError := NewVector3(xAbsSum, yAbsSum, zAbsSum).Multiply(transformGamma3)
 
 	return vt, vError
 }
 
-// Applies transformation to Normal
+// Applies transformation to Normal, normals are transformed by the inverse transpose matrix
 //
 // see https://github.com/mmp/pbrt-v3/blob/master/src/core/transform.h#L244
 func (t Transform) ApplyN(n Normal3) Normal3 {
-	return t.M.MultiplyN(n)
+	return t.MInv.MultiplyN(n)
 }
 
 // Applies transformation to Ray
@@ -372,29 +408,29 @@ func (t1 Transform) ApplySI(si *SurfaceInteraction) *SurfaceInteraction {
 			t1.ApplyV(si.Wo),
 			si.Time,
 			si.MediumInterface),
-		Uv:    si.Uv,
-		Dpdu:  t1.ApplyV(si.Dpdu),
-		Dpdv:  t1.ApplyV(si.Dpdv),
-		Dndu:  t1.ApplyN(si.Dndu),
-		Dndv:  t1.ApplyN(si.Dndv),
-		Shape: si.Shape,
-		shading: shading{
-			t1.ApplyN(si.shading.N).Normalize(),
-			t1.ApplyV(si.shading.Dpdu),
-			t1.ApplyV(si.shading.Dpdv),
-			t1.ApplyN(si.shading.Dndu),
-			t1.ApplyN(si.shading.Dndv),
+		Uv:        si.Uv,
+		Dpdu:      t1.ApplyV(si.Dpdu),
+		Dpdv:      t1.ApplyV(si.Dpdv),
+		Dndu:      t1.ApplyN(si.Dndu),
+		Dndv:      t1.ApplyN(si.Dndv),
+		Shape:     si.Shape,
+		Primitive: si.Primitive,
+		Shading: Shading{
+			t1.ApplyN(si.Shading.N).Normalize(),
+			t1.ApplyV(si.Shading.Dpdu),
+			t1.ApplyV(si.Shading.Dpdv),
+			t1.ApplyN(si.Shading.Dndu),
+			t1.ApplyN(si.Shading.Dndv),
 		},
+		Dudx: si.Dudx,
+		Dvdx: si.Dvdx,
+		Dudy: si.Dudy,
+		Dvdy: si.Dvdy,
+		Dpdx: t1.ApplyV(si.Dpdx),
+		Dpdy: t1.ApplyV(si.Dpdy),
+		BSDF: si.BSDF,
 		// TODO in another chapter
-		//ret.dudx = si.dudx;
-		//ret.dvdx = si.dvdx;
-		//ret.dudy = si.dudy;
-		//ret.dvdy = si.dvdy;
-		//ret.dpdx = t(si.dpdx);
-		//ret.dpdy = t(si.dpdy);
-		//ret.bsdf = si.bsdf;
 		//ret.bssrdf = si.bssrdf;
-		//ret.primitive = si.primitive;
 		////    ret.n = Faceforward(ret.n, ret.shading.n);
 		//ret.shading.n = Faceforward(ret.shading.n, ret.n);
 	}
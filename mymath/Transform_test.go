@@ -147,6 +147,21 @@ func TestTransform_NewTransformLookAt(t *testing.T) {
 	assert.Equal(t, mymath.NewPoint3(-2, 1, 30), res)
 }
 
+func TestTransform_NewTransformPerspective(t *testing.T) {
+	tr := mymath.NewTransformPerspective(90, 1, 10)
+
+	InDeltaPoint3(t, mymath.NewPoint3(1, -0.5, 0), tr.ApplyP(mymath.NewPoint3(1, -0.5, 1)))
+	InDeltaPoint3(t, mymath.NewPoint3(0.1, 0.2, 1), tr.ApplyP(mymath.NewPoint3(1, 2, 10)))
+	InDeltaPoint3(t, mymath.NewPoint3(1, 2, 10), tr.Inverse().ApplyP(mymath.NewPoint3(0.1, 0.2, 1)))
+}
+
+func TestTransform_NewTransformOrthographic(t *testing.T) {
+	tr := mymath.NewTransformOrthographic(2, 6)
+
+	InDeltaPoint3(t, mymath.NewPoint3(1, -3, 0), tr.ApplyP(mymath.NewPoint3(1, -3, 2)))
+	InDeltaPoint3(t, mymath.NewPoint3(1, -3, 0.75), tr.ApplyP(mymath.NewPoint3(1, -3, 5)))
+}
+
 func TestTransform_ApplyB(t *testing.T) {
 	tr := mymath.NewTransformTranslate(mymath.NewVector3(1, 2, 3))
 
@@ -163,6 +178,70 @@ func TestTransform_ApplyB(t *testing.T) {
 	assert.Equal(t, expected, res)
 }
 
+func TestTransform_ApplyN(t *testing.T) {
+	// non-uniform scale keeps the normal perpendicular to the transformed tangent plane
+	tr := mymath.NewTransformScale(1, 4, 1)
+	n := tr.ApplyN(mymath.NewNormal3(1, 1, 0))
+	tangent := tr.ApplyV(mymath.NewVector3(1, -1, 0))
+
+	assert.InDelta(t, 0.0, mymath.NewVector3N(n).Dot(tangent), equalDelta)
+	InDeltaNormal3(t, mymath.NewNormal3(1, 0.25, 0), n)
+}
+
+func TestTransform_ApplyN_inverseTranspose(t *testing.T) {
+	tr := mymath.NewTransformTranslate(mymath.NewVector3(5, -2, 1)).
+		ApplyT(mymath.NewTransformRotate(0.7, mymath.NewVector3(1, 2, 3))).
+		ApplyT(mymath.NewTransformScale(0.5, 2, 3))
+
+	n := mymath.NewNormal3(0.3, -0.8, 0.5)
+	nT := tr.ApplyN(n)
+
+	// every tangent of the plane stays perpendicular to the transformed normal, translation has no effect
+	for _, tangent := range []mymath.Vector3{mymath.NewVector3(0.8, 0.3, 0), mymath.NewVector3(0, 0.5, 0.8), mymath.NewVector3(1, 1, 1)} {
+		assert.InDelta(t, 0.0, mymath.NewVector3N(n).Dot(tangent), equalDelta)
+		assert.InDelta(t, 0.0, mymath.NewVector3N(nT).Dot(tr.ApplyV(tangent)), equalDelta)
+	}
+
+	// the inverse transformation brings the normal back
+	InDeltaNormal3(t, n, tr.Inverse().ApplyN(nT))
+}
+
+// Error of the transformed point must cover the round trip back to object space, the matrix and its inverse
+// are stored in float32 so they are not exact inverses of each other
+func TestTransform_errorBounds_roundTrip(t *testing.T) {
+	tr := mymath.NewTransformTranslate(mymath.NewVector3(10, -20, 30)).
+		ApplyT(mymath.NewTransformRotate(1.3, mymath.NewVector3(-1, 2, 0.5))).
+		ApplyT(mymath.NewTransformScale(3, 0.7, 1.9))
+	inv := tr.Inverse()
+
+	// world space error box mapped back to object space bounds the deviation of each component
+	assertBound := func(expected, back, err mymath.Vector3, msgAndArgs ...interface{}) {
+		for i := 0; i < 3; i++ {
+			bound := math.Abs(float64(inv.M.M[i][0]))*err.X +
+				math.Abs(float64(inv.M.M[i][1]))*err.Y +
+				math.Abs(float64(inv.M.M[i][2]))*err.Z
+			assert.LessOrEqual(t, math.Abs(back.Get(i)-expected.Get(i)), bound, msgAndArgs...)
+		}
+	}
+
+	for _, p := range []mymath.Point3{mymath.NewPoint3(1, 2, 3), mymath.NewPoint3(-7.5, 0.1, 4), mymath.NewPoint3(100, -300, 0.001)} {
+		pT, pError := tr.ApplyPPError(p, mymath.NewVector3(0, 0, 0))
+		assertBound(mymath.NewVector3P(p), mymath.NewVector3P(inv.ApplyP(pT)), pError, "ApplyPPError %v", p)
+
+		pT, pError = tr.ApplyPError(p)
+		assertBound(mymath.NewVector3P(p), mymath.NewVector3P(inv.ApplyP(pT)), pError, "ApplyPError %v", p)
+
+		v := mymath.NewVector3P(p)
+		vT, vError := tr.ApplyVError(v)
+		assertBound(v, inv.ApplyV(vT), vError, "ApplyVError %v", v)
+
+		// ray errors are those of its origin and direction
+		_, oError, dError := tr.ApplyRError(mymath.NewRay(p, v, math.Inf(1), 0, material.Medium{}))
+		assert.Equal(t, pError, oError)
+		assert.Equal(t, vError, dError)
+	}
+}
+
 func TestTransform_ApplyT(t *testing.T) {
 	tr := mymath.NewTransformTranslate(mymath.NewVector3(1, 2, 3))
 
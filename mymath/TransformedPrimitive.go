@@ -0,0 +1,69 @@
+package mymath
+
+// TransformedPrimitive places shared primitive (e.g. aggregate) into the scene using its own,
+// possibly animated, transformation. This allows object instancing without duplicating geometry.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/primitive.h#L104
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/primitive.cpp#L51
+type TransformedPrimitive struct {
+	Primitive        Primitive
+	PrimitiveToWorld AnimatedTransform
+	worldBound       Bounds3
+}
+
+func NewTransformedPrimitive(primitive Primitive, primitiveToWorld AnimatedTransform) (*TransformedPrimitive, error) {
+	worldBound, err := primitiveToWorld.MotionBounds(primitive.WorldBound())
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransformedPrimitive{primitive, primitiveToWorld, worldBound}, nil
+}
+
+func (p *TransformedPrimitive) WorldBound() Bounds3 {
+	return p.worldBound
+}
+
+// Intersect see https://github.com/mmp/pbrt-v3/blob/master/src/core/primitive.cpp#L51
+func (p *TransformedPrimitive) Intersect(r *Ray) (bool, *SurfaceInteraction) {
+	// Compute ray after transformation by PrimitiveToWorld
+	interpolatedPrimToWorld, err := p.PrimitiveToWorld.Interpolate(float64(r.Time))
+	if err != nil {
+		return false, nil
+	}
+	ray := interpolatedPrimToWorld.Inverse().ApplyR(*r)
+
+	ok, isect := p.Primitive.Intersect(&ray)
+	if !ok {
+		return false, nil
+	}
+
+	r.TMax = ray.TMax
+
+	// Transform instance's intersection data to world space
+	if !interpolatedPrimToWorld.IsIdentity() {
+		isect = interpolatedPrimToWorld.ApplySI(isect)
+	}
+
+	return true, isect
+}
+
+// IntersectP see https://github.com/mmp/pbrt-v3/blob/master/src/core/primitive.cpp#L67
+func (p *TransformedPrimitive) IntersectP(r Ray) bool {
+	interpolatedPrimToWorld, err := p.PrimitiveToWorld.Interpolate(float64(r.Time))
+	if err != nil {
+		return false
+	}
+
+	return p.Primitive.IntersectP(interpolatedPrimToWorld.Inverse().ApplyR(r))
+}
+
+// GetAreaLight must not be called, intersection references the primitive actually hit
+func (p *TransformedPrimitive) GetAreaLight() AreaLight {
+	panic("TransformedPrimitive.GetAreaLight() shouldn't be called")
+}
+
+// GetMaterial must not be called, intersection references the primitive actually hit
+func (p *TransformedPrimitive) GetMaterial() Material {
+	panic("TransformedPrimitive.GetMaterial() shouldn't be called")
+}
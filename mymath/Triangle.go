@@ -0,0 +1,423 @@
+package mymath
+
+import (
+	"math"
+)
+
+// TriangleMesh stores vertex data shared by all triangles of the mesh. Positions, normals and tangents
+// are transformed to world space once at creation.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/triangle.h#L48
+type TriangleMesh struct {
+	NTriangles, NVertices int
+	VertexIndices         []int
+	P                     []Point3
+	N                     []Normal3
+	S                     []Vector3
+	Uv                    []Point2
+}
+
+// NewTriangleMesh creates mesh with vertices transformed to world space. Arrays s, n and uv are optional and may be nil.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/triangle.cpp#L51
+func NewTriangleMesh(objectToWorld *Transform, nTriangles int, vertexIndices []int, nVertices int, p []Point3, s []Vector3, n []Normal3, uv []Point2) *TriangleMesh {
+	mesh := &TriangleMesh{
+		NTriangles:    nTriangles,
+		NVertices:     nVertices,
+		VertexIndices: append([]int(nil), vertexIndices[:3*nTriangles]...),
+	}
+
+	// Transform mesh vertices to world space
+	mesh.P = make([]Point3, nVertices)
+	for i := 0; i < nVertices; i++ {
+		mesh.P[i] = objectToWorld.ApplyP(p[i])
+	}
+
+	// Copy uv, n, and s vertex data, if present
+	if uv != nil {
+		mesh.Uv = append([]Point2(nil), uv[:nVertices]...)
+	}
+
+	if n != nil {
+		mesh.N = make([]Normal3, nVertices)
+		for i := 0; i < nVertices; i++ {
+			mesh.N[i] = objectToWorld.ApplyN(n[i])
+		}
+	}
+
+	if s != nil {
+		mesh.S = make([]Vector3, nVertices)
+		for i := 0; i < nVertices; i++ {
+			mesh.S[i] = objectToWorld.ApplyV(s[i])
+		}
+	}
+
+	return mesh
+}
+
+// Triangle references single triangle of the TriangleMesh
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/triangle.h#L68
+type Triangle struct {
+	Shape
+	Mesh *TriangleMesh
+	V    [3]int
+}
+
+func NewTriangle(objectToWorld, worldToObject *Transform, reverseOrientation bool, mesh *TriangleMesh, triNumber int) *Triangle {
+	return &Triangle{
+		NewShape(objectToWorld, worldToObject, reverseOrientation),
+		mesh,
+		[3]int{
+			mesh.VertexIndices[3*triNumber],
+			mesh.VertexIndices[3*triNumber+1],
+			mesh.VertexIndices[3*triNumber+2],
+		},
+	}
+}
+
+// CreateTriangleMesh creates the shared mesh and one Triangle shape for each of its triangles
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/triangle.cpp#L82
+func CreateTriangleMesh(objectToWorld, worldToObject *Transform, reverseOrientation bool, nTriangles int, vertexIndices []int, nVertices int, p []Point3, s []Vector3, n []Normal3, uv []Point2) []IShape {
+	mesh := NewTriangleMesh(objectToWorld, nTriangles, vertexIndices, nVertices, p, s, n, uv)
+
+	tris := make([]IShape, 0, nTriangles)
+	for i := 0; i < nTriangles; i++ {
+		tris = append(tris, NewTriangle(objectToWorld, worldToObject, reverseOrientation, mesh, i))
+	}
+
+	return tris
+}
+
+func (tri Triangle) vertices() (Point3, Point3, Point3) {
+	return tri.Mesh.P[tri.V[0]], tri.Mesh.P[tri.V[1]], tri.Mesh.P[tri.V[2]]
+}
+
+// getUVs returns parametric coordinates of the vertices, default parametrization is used for meshes without uv
+func (tri Triangle) getUVs() [3]Point2 {
+	if tri.Mesh.Uv == nil {
+		return [3]Point2{NewPoint2(0, 0), NewPoint2(1, 0), NewPoint2(1, 1)}
+	}
+
+	return [3]Point2{tri.Mesh.Uv[tri.V[0]], tri.Mesh.Uv[tri.V[1]], tri.Mesh.Uv[tri.V[2]]}
+}
+
+func (tri Triangle) ObjectBound() Bounds3 {
+	p0, p1, p2 := tri.vertices()
+
+	return NewBounds3(tri.WorldToObject.ApplyP(p0), tri.WorldToObject.ApplyP(p1)).
+		UnionP(tri.WorldToObject.ApplyP(p2))
+}
+
+// WorldBound returns bounds of the world space vertices, there is no need to transform the object bounds
+func (tri Triangle) WorldBound(_ ObjectBounder) Bounds3 {
+	p0, p1, p2 := tri.vertices()
+
+	return NewBounds3(p0, p1).UnionP(p2)
+}
+
+// intersect performs watertight ray-triangle test and returns hit distance and barycentric coordinates
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/triangle.cpp#L159
+func (tri Triangle) intersect(ray Ray) (bool, float64, float64, float64, float64) {
+	// Get triangle vertices in p0, p1, and p2
+	p0, p1, p2 := tri.vertices()
+
+	// Transform triangle vertices to ray coordinate space
+
+	// Translate vertices based on ray origin
+	o := NewVector3P(ray.O)
+	p0t := p0.SubtractV(o)
+	p1t := p1.SubtractV(o)
+	p2t := p2.SubtractV(o)
+
+	// Permute components of triangle vertices and ray direction
+	kz := ray.D.Abs().GetMaxDimension()
+	kx := kz + 1
+	if kx == 3 {
+		kx = 0
+	}
+
+	ky := kx + 1
+	if ky == 3 {
+		ky = 0
+	}
+
+	d := ray.D.Permute(kx, ky, kz)
+	p0t = p0t.Permute(kx, ky, kz)
+	p1t = p1t.Permute(kx, ky, kz)
+	p2t = p2t.Permute(kx, ky, kz)
+
+	// Apply shear transformation to translated vertex positions
+	sx := -d.X / d.Z
+	sy := -d.Y / d.Z
+	sz := 1 / d.Z
+	p0t.X += sx * p0t.Z
+	p0t.Y += sy * p0t.Z
+	p1t.X += sx * p1t.Z
+	p1t.Y += sy * p1t.Z
+	p2t.X += sx * p2t.Z
+	p2t.Y += sy * p2t.Z
+
+	// Compute edge function coefficients e0, e1, and e2
+	e0 := p1t.X*p2t.Y - p1t.Y*p2t.X
+	e1 := p2t.X*p0t.Y - p2t.Y*p0t.X
+	e2 := p0t.X*p1t.Y - p0t.Y*p1t.X
+
+	// Perform triangle edge and determinant tests
+	if (e0 < 0 || e1 < 0 || e2 < 0) && (e0 > 0 || e1 > 0 || e2 > 0) {
+		return false, 0, 0, 0, 0
+	}
+
+	det := e0 + e1 + e2
+	if det == 0 {
+		return false, 0, 0, 0, 0
+	}
+
+	// Compute scaled hit distance to triangle and test against ray t range
+	p0t.Z *= sz
+	p1t.Z *= sz
+	p2t.Z *= sz
+	tScaled := e0*p0t.Z + e1*p1t.Z + e2*p2t.Z
+
+	if det < 0 && (tScaled >= 0 || tScaled < ray.TMax*det) {
+		return false, 0, 0, 0, 0
+	} else if det > 0 && (tScaled <= 0 || tScaled > ray.TMax*det) {
+		return false, 0, 0, 0, 0
+	}
+
+	// Compute barycentric coordinates and t value for triangle intersection
+	invDet := 1 / det
+	b0 := e0 * invDet
+	b1 := e1 * invDet
+	b2 := e2 * invDet
+	t := tScaled * invDet
+
+	// Ensure that computed triangle t is conservatively greater than zero
+
+	// Compute deltaZ term for triangle t error bounds
+	maxZt := NewVector3(p0t.Z, p1t.Z, p2t.Z).Abs().GetMaxComponent()
+	deltaZ := Gamma3 * maxZt
+
+	// Compute deltaX and deltaY terms for triangle t error bounds
+	maxXt := NewVector3(p0t.X, p1t.X, p2t.X).Abs().GetMaxComponent()
+	maxYt := NewVector3(p0t.Y, p1t.Y, p2t.Y).Abs().GetMaxComponent()
+	deltaX := Gamma5 * (maxXt + maxZt)
+	deltaY := Gamma5 * (maxYt + maxZt)
+
+	// Compute deltaE term for triangle t error bounds
+	deltaE := 2 * (Gamma2*maxXt*maxYt + deltaY*maxXt + deltaX*maxYt)
+
+	// Compute deltaT term for triangle t error bounds and check t
+	maxE := NewVector3(e0, e1, e2).Abs().GetMaxComponent()
+	deltaT := 3 * (Gamma3*maxE*maxZt + deltaE*maxZt + deltaZ*maxE) * math.Abs(invDet)
+	if t <= deltaT {
+		return false, 0, 0, 0, 0
+	}
+
+	return true, t, b0, b1, b2
+}
+
+// Intersect finds ray-shape collision point and its metadata
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/triangle.cpp#L159
+func (tri Triangle) Intersect(ray Ray, _ bool) (bool, float64, *SurfaceInteraction) {
+	ok, t, b0, b1, b2 := tri.intersect(ray)
+	if !ok {
+		return false, 0, nil
+	}
+
+	p0, p1, p2 := tri.vertices()
+
+	// Compute triangle partial derivatives
+	var dpdu, dpdv Vector3
+	uv := tri.getUVs()
+
+	// Compute deltas for triangle partial derivatives
+	duv02 := NewPoint2(uv[0].X-uv[2].X, uv[0].Y-uv[2].Y)
+	duv12 := NewPoint2(uv[1].X-uv[2].X, uv[1].Y-uv[2].Y)
+	dp02 := p0.SubtractP(p2)
+	dp12 := p1.SubtractP(p2)
+	determinant := duv02.X*duv12.Y - duv02.Y*duv12.X
+	degenerateUV := math.Abs(determinant) < 1e-8
+
+	if !degenerateUV {
+		invDet := 1 / determinant
+		dpdu = dp02.Multiply(duv12.Y).Subtract(dp12.Multiply(duv02.Y)).Multiply(invDet)
+		dpdv = dp12.Multiply(duv02.X).Subtract(dp02.Multiply(duv12.X)).Multiply(invDet)
+	}
+
+	if degenerateUV || dpdu.Cross(dpdv).LengthSq() == 0 {
+		// Handle zero determinant for triangle partial derivative matrix
+		ng := p2.SubtractP(p0).Cross(p1.SubtractP(p0))
+		if ng.LengthSq() == 0 {
+			// The triangle is actually degenerate; the intersection is bogus
+			return false, 0, nil
+		}
+
+		dpdu, dpdv = ng.Normalize().CoordinateSystem()
+	}
+
+	// Compute error bounds for triangle intersection
+	xAbsSum := math.Abs(b0*p0.X) + math.Abs(b1*p1.X) + math.Abs(b2*p2.X)
+	yAbsSum := math.Abs(b0*p0.Y) + math.Abs(b1*p1.Y) + math.Abs(b2*p2.Y)
+	zAbsSum := math.Abs(b0*p0.Z) + math.Abs(b1*p1.Z) + math.Abs(b2*p2.Z)
+	pError := NewVector3(xAbsSum, yAbsSum, zAbsSum).Multiply(Gamma7)
+
+	// Interpolate (u,v) parametric coordinates and hit point
+	pHit := p0.Multiply(b0).AddP(p1.Multiply(b1)).AddP(p2.Multiply(b2))
+	uvHit := NewPoint2(
+		b0*uv[0].X+b1*uv[1].X+b2*uv[2].X,
+		b0*uv[0].Y+b1*uv[1].Y+b2*uv[2].Y)
+
+	// Fill in SurfaceInteraction from triangle hit
+	isect := NewSurfaceInteraction(
+		pHit,
+		pError,
+		uvHit,
+		ray.D.Negate(),
+		dpdu,
+		dpdv,
+		NewNormal3(0, 0, 0),
+		NewNormal3(0, 0, 0),
+		float64(ray.Time),
+		&tri.Shape)
+
+	// Override surface normal in isect for triangle
+	isect.N = NewNormal3V(dp02.Cross(dp12).Normalize())
+	if tri.ReverseOrientation != tri.TransformSwapsHandedness {
+		isect.N = isect.N.Negate()
+	}
+	isect.Shading.N = isect.N
+
+	if tri.Mesh.N != nil || tri.Mesh.S != nil {
+		// Initialize Triangle shading geometry
+
+		// Compute shading normal ns for triangle
+		ns := isect.N
+		if tri.Mesh.N != nil {
+			n := tri.Mesh.N[tri.V[0]].Multiply(b0).
+				Add(tri.Mesh.N[tri.V[1]].Multiply(b1)).
+				Add(tri.Mesh.N[tri.V[2]].Multiply(b2))
+
+			if n.LengthSq() > 0 {
+				ns = n.Normalize()
+			}
+		}
+
+		// Compute shading tangent ss for triangle
+		ss := isect.Dpdu
+		if tri.Mesh.S != nil {
+			s := tri.Mesh.S[tri.V[0]].Multiply(b0).
+				Add(tri.Mesh.S[tri.V[1]].Multiply(b1)).
+				Add(tri.Mesh.S[tri.V[2]].Multiply(b2))
+
+			if s.LengthSq() > 0 {
+				ss = s
+			}
+		}
+
+		// Compute shading bitangent ts for triangle and adjust ss
+		nsv := Vector3(ns)
+		ts := nsv.Cross(ss)
+		if ts.LengthSq() > 0 {
+			ts = ts.Normalize()
+			ss = ts.Cross(nsv)
+		} else {
+			ss, ts = nsv.CoordinateSystem()
+		}
+
+		// Compute dndu and dndv for triangle shading geometry
+		var dndu, dndv Normal3
+		if tri.Mesh.N != nil {
+			// Compute deltas for triangle partial derivatives of normal
+			dn1 := tri.Mesh.N[tri.V[0]].Subtract(tri.Mesh.N[tri.V[2]])
+			dn2 := tri.Mesh.N[tri.V[1]].Subtract(tri.Mesh.N[tri.V[2]])
+
+			if degenerateUV {
+				// We can still compute dndu and dndv, with respect to the
+				// same arbitrary coordinate system we use to compute dpdu
+				// and dpdv when this happens. It's important to do this
+				// (rather than giving up) so that ray differentials for
+				// rays reflected from triangles with degenerate
+				// parameterizations are still reasonable.
+				dn := Vector3(tri.Mesh.N[tri.V[2]].Subtract(tri.Mesh.N[tri.V[0]])).
+					Cross(Vector3(tri.Mesh.N[tri.V[1]].Subtract(tri.Mesh.N[tri.V[0]])))
+
+				if dn.LengthSq() != 0 {
+					dnu, dnv := dn.CoordinateSystem()
+					dndu = NewNormal3V(dnu)
+					dndv = NewNormal3V(dnv)
+				}
+			} else {
+				invDet := 1 / determinant
+				dndu = dn1.Multiply(duv12.Y).Subtract(dn2.Multiply(duv02.Y)).Multiply(invDet)
+				dndv = dn2.Multiply(duv02.X).Subtract(dn1.Multiply(duv12.X)).Multiply(invDet)
+			}
+		}
+
+		// Mesh normals are already in world space, compensate the handedness flip done by SetShadingGeometry
+		if tri.TransformSwapsHandedness {
+			ts = ts.Negate()
+		}
+
+		isect.SetShadingGeometry(ss, ts, dndu, dndv, true)
+	}
+
+	return true, t, &isect
+}
+
+// IntersectP finds if ray collides with this shape
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/triangle.cpp#L408
+func (tri Triangle) IntersectP(_ Intersecter, ray Ray, _ bool) bool {
+	ok, _, _, _, _ := tri.intersect(ray)
+	return ok
+}
+
+func (tri Triangle) Area() float64 {
+	p0, p1, p2 := tri.vertices()
+
+	return 0.5 * p1.SubtractP(p0).Cross(p2.SubtractP(p0)).Length()
+}
+
+// Sample samples point uniformly on the triangle
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/shapes/triangle.cpp#L650
+func (tri Triangle) Sample(u Point2) Interaction {
+	b := UniformSampleTriangle(u)
+
+	// Get triangle vertices in p0, p1, and p2
+	p0, p1, p2 := tri.vertices()
+
+	var it Interaction
+	it.P = p0.Multiply(b.X).AddP(p1.Multiply(b.Y)).AddP(p2.Multiply(1 - b.X - b.Y))
+
+	// Compute surface normal for sampled point on triangle
+	it.N = NewNormal3V(p1.SubtractP(p0).Cross(p2.SubtractP(p0)).Normalize())
+
+	// Ensure correct orientation of the geometric normal; normal flipped if indicated by Shape
+	if tri.Mesh.N != nil {
+		ns := tri.Mesh.N[tri.V[0]].Multiply(b.X).
+			Add(tri.Mesh.N[tri.V[1]].Multiply(b.Y)).
+			Add(tri.Mesh.N[tri.V[2]].Multiply(1 - b.X - b.Y))
+		it.N = it.N.FaceForward(ns)
+	} else if tri.ReverseOrientation != tri.TransformSwapsHandedness {
+		it.N = it.N.Negate()
+	}
+
+	// Compute error bounds for sampled point on triangle
+	pAbsSum := p0.Multiply(b.X).Abs().AddP(p1.Multiply(b.Y).Abs()).AddP(p2.Multiply(1 - b.X - b.Y).Abs())
+	it.PError = NewVector3P(pAbsSum).Multiply(Gamma6)
+
+	return it
+}
+
+func (tri Triangle) SampleRef(_ Interaction, u Point2) Interaction {
+	return tri.Sample(u)
+}
+
+func (tri Triangle) Pdf(ref Interaction, wi Vector3) float64 {
+	return tri.Shape.pdf(tri, ref, wi)
+}
@@ -0,0 +1,246 @@
+package mymath_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"pbrt-go/material"
+	"pbrt-go/mymath"
+	"testing"
+)
+
+// quad in z=0 plane made of two triangles sharing the (0,0,0)-(1,1,0) edge
+func newQuadMesh(objectToWorld, worldToObject *mymath.Transform, n []mymath.Normal3) []mymath.IShape {
+	p := []mymath.Point3{
+		mymath.NewPoint3(0, 0, 0),
+		mymath.NewPoint3(1, 0, 0),
+		mymath.NewPoint3(1, 1, 0),
+		mymath.NewPoint3(0, 1, 0),
+	}
+
+	uv := []mymath.Point2{
+		mymath.NewPoint2(0, 0),
+		mymath.NewPoint2(1, 0),
+		mymath.NewPoint2(1, 1),
+		mymath.NewPoint2(0, 1),
+	}
+
+	return mymath.CreateTriangleMesh(
+		objectToWorld,
+		worldToObject,
+		false,
+		2,
+		[]int{0, 1, 2, 0, 2, 3},
+		4,
+		p,
+		nil,
+		n,
+		uv)
+}
+
+func TestNewTriangleMesh(t *testing.T) {
+	translate := mymath.NewTransformTranslate(mymath.NewVector3(1, 2, 3))
+
+	mesh := mymath.NewTriangleMesh(
+		&translate,
+		1,
+		[]int{0, 1, 2},
+		3,
+		[]mymath.Point3{mymath.NewPoint3(0, 0, 0), mymath.NewPoint3(1, 0, 0), mymath.NewPoint3(0, 1, 0)},
+		nil,
+		[]mymath.Normal3{mymath.NewNormal3(0, 0, 1), mymath.NewNormal3(0, 0, 1), mymath.NewNormal3(0, 0, 1)},
+		nil)
+
+	assert.Equal(t, 1, mesh.NTriangles)
+	assert.Equal(t, 3, mesh.NVertices)
+	assert.Equal(t, []int{0, 1, 2}, mesh.VertexIndices)
+	assert.Equal(t, mymath.NewPoint3(1, 2, 3), mesh.P[0])
+	assert.Equal(t, mymath.NewPoint3(2, 2, 3), mesh.P[1])
+	assert.Equal(t, mymath.NewPoint3(1, 3, 3), mesh.P[2])
+	assert.Equal(t, mymath.NewNormal3(0, 0, 1), mesh.N[0])
+	assert.Nil(t, mesh.S)
+	assert.Nil(t, mesh.Uv)
+}
+
+func TestTriangle_ObjectBound(t *testing.T) {
+	translate := mymath.NewTransformTranslate(mymath.NewVector3(1, 2, 3))
+	translateInv := translate.Inverse()
+
+	tris := newQuadMesh(&translate, &translateInv, nil)
+
+	InDeltaPoint3(t, mymath.NewPoint3(0, 0, 0), tris[0].ObjectBound().PMin)
+	InDeltaPoint3(t, mymath.NewPoint3(1, 1, 0), tris[0].ObjectBound().PMax)
+}
+
+func TestTriangle_WorldBound(t *testing.T) {
+	translate := mymath.NewTransformTranslate(mymath.NewVector3(1, 2, 3))
+	translateInv := translate.Inverse()
+
+	tris := newQuadMesh(&translate, &translateInv, nil)
+
+	assert.Equal(
+		t,
+		mymath.NewBounds3(mymath.NewPoint3(1, 2, 3), mymath.NewPoint3(2, 3, 3)),
+		tris[1].WorldBound(tris[1]))
+}
+
+func TestTriangle_Intersect(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	tris := newQuadMesh(&identity, &identity, nil)
+
+	ray := mymath.NewRay(
+		mymath.NewPoint3(0.75, 0.25, 5),
+		mymath.NewVector3(0, 0, -1),
+		50,
+		0,
+		material.Medium{})
+
+	ok, tHit, si := tris[0].Intersect(ray, false)
+	assert.Equal(t, true, ok)
+	assert.InDelta(t, 5.0, tHit, equalDelta)
+
+	InDeltaPoint3(t, mymath.NewPoint3(0.75, 0.25, 0), si.Interaction.P)
+	assert.InDelta(t, 0.75, si.Uv.X, equalDelta)
+	assert.InDelta(t, 0.25, si.Uv.Y, equalDelta)
+	assert.Equal(t, mymath.NewVector3(0, 0, 1), si.Interaction.Wo)
+	InDeltaNormal3(t, mymath.NewNormal3(0, 0, 1), si.Interaction.N)
+	InDeltaVector3(t, mymath.NewVector3(1, 0, 0), si.Dpdu)
+	InDeltaVector3(t, mymath.NewVector3(0, 1, 0), si.Dpdv)
+	InDeltaNormal3(t, mymath.NewNormal3(0, 0, 0), si.Dndu)
+	InDeltaNormal3(t, mymath.NewNormal3(0, 0, 0), si.Dndv)
+
+	// the other triangle of the quad is missed
+	ok, _, si = tris[1].Intersect(ray, false)
+	assert.Equal(t, false, ok)
+	assert.Nil(t, si)
+}
+
+func TestTriangle_Intersect_tMax(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	tris := newQuadMesh(&identity, &identity, nil)
+
+	ray := mymath.NewRay(
+		mymath.NewPoint3(0.75, 0.25, 5),
+		mymath.NewVector3(0, 0, -1),
+		4,
+		0,
+		material.Medium{})
+
+	ok, _, _ := tris[0].Intersect(ray, false)
+	assert.Equal(t, false, ok)
+}
+
+func TestTriangle_Intersect_shadingNormals(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	// shading normals pointing below the geometric normal
+	n := []mymath.Normal3{
+		mymath.NewNormal3(0, 0, -1),
+		mymath.NewNormal3(0, 0, -1),
+		mymath.NewNormal3(0, 0, -1),
+		mymath.NewNormal3(0, 0, -1),
+	}
+
+	tris := newQuadMesh(&identity, &identity, n)
+
+	ray := mymath.NewRay(
+		mymath.NewPoint3(0.25, 0.75, 5),
+		mymath.NewVector3(0, 0, -1),
+		50,
+		0,
+		material.Medium{})
+
+	ok, _, si := tris[1].Intersect(ray, false)
+	assert.Equal(t, true, ok)
+
+	// shading normal is authoritative, geometric normal is flipped towards it
+	InDeltaNormal3(t, mymath.NewNormal3(0, 0, -1), si.Interaction.N)
+	InDeltaNormal3(t, mymath.NewNormal3(0, 0, -1), si.Shading.N)
+}
+
+func TestTriangle_Intersect_transformed(t *testing.T) {
+	rotate := mymath.NewTransformRotateX(1.2).ApplyT(mymath.NewTransformScale(2, 3, 4))
+	rotateInv := rotate.Inverse()
+
+	tris := newQuadMesh(&rotate, &rotateInv, nil)
+
+	o := rotate.ApplyP(mymath.NewPoint3(0.25, 0.75, 5))
+	target := rotate.ApplyP(mymath.NewPoint3(0.25, 0.75, 0))
+
+	ray := mymath.NewRay(o, target.SubtractP(o), 50, 0, material.Medium{})
+
+	ok, tHit, si := tris[1].Intersect(ray, false)
+	assert.Equal(t, true, ok)
+	assert.InDelta(t, 1.0, tHit, equalDelta)
+	InDeltaPoint3(t, target, si.Interaction.P)
+
+	// error bounds contain the exact hit point
+	diff := si.Interaction.P.SubtractP(target).Abs()
+	assert.LessOrEqual(t, diff.X, si.Interaction.PError.X+1e-12)
+	assert.LessOrEqual(t, diff.Y, si.Interaction.PError.Y+1e-12)
+	assert.LessOrEqual(t, diff.Z, si.Interaction.PError.Z+1e-12)
+}
+
+// Rays through the shared edge must hit at least one of the triangles
+func TestTriangle_Intersect_watertight(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	tris := newQuadMesh(&identity, &identity, nil)
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		s := rng.Float64()
+		onEdge := mymath.NewPoint3(s, s, 0)
+		o := mymath.NewPoint3(rng.Float64()*10-5, rng.Float64()*10-5, rng.Float64()*10+0.1)
+
+		ray := mymath.NewRay(o, onEdge.SubtractP(o), 50, 0, material.Medium{})
+
+		hit0 := tris[0].IntersectP(tris[0], ray, false)
+		hit1 := tris[1].IntersectP(tris[1], ray, false)
+		assert.True(t, hit0 || hit1, "ray %v leaks through the edge", ray)
+	}
+}
+
+func TestTriangle_IntersectP(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	tris := newQuadMesh(&identity, &identity, nil)
+
+	ray := mymath.NewRay(
+		mymath.NewPoint3(0.75, 0.25, 5),
+		mymath.NewVector3(0, 0, -1),
+		50,
+		0,
+		material.Medium{})
+
+	assert.Equal(t, true, tris[0].IntersectP(tris[0], ray, false))
+	assert.Equal(t, false, tris[1].IntersectP(tris[1], ray, false))
+}
+
+func TestTriangle_Area(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+
+	tris := newQuadMesh(&identity, &identity, nil)
+
+	assert.InDelta(t, 0.5, tris[0].Area(), equalDelta)
+	assert.InDelta(t, 0.5, tris[1].Area(), equalDelta)
+}
+
+func TestTriangle_Sample(t *testing.T) {
+	identity := mymath.NewTransformEmpty()
+	p := []mymath.Point3{mymath.NewPoint3(0, 0, 1), mymath.NewPoint3(2, 0, 1), mymath.NewPoint3(0, 2, 1)}
+	tri := mymath.CreateTriangleMesh(&identity, &identity, false, 1, []int{0, 1, 2}, 3, p, nil, nil, nil)[0]
+
+	for i := 0; i < 10; i++ {
+		it := tri.Sample(mymath.NewPoint2(float64(i)/10, 0.6))
+
+		assert.Equal(t, 1.0, it.P.Z)
+		assert.True(t, it.P.X >= 0 && it.P.Y >= 0 && it.P.X+it.P.Y <= 2+equalDelta)
+		InDeltaNormal3(t, mymath.NewNormal3(0, 0, 1), it.N)
+	}
+
+	ref := mymath.NewInteraction(mymath.NewPoint3(0.5, 0.5, 0), mymath.NewNormal3(0, 0, 0), mymath.NewVector3(0, 0, 0), mymath.NewVector3(0, 0, 0), 0, nil)
+	assert.InDelta(t, 1/tri.Area(), tri.Pdf(ref, mymath.NewVector3(0, 0, 1)), equalDelta)
+	assert.InDelta(t, 1.0, integrateSolidAnglePdf(tri, ref, 200000), 0.05)
+}
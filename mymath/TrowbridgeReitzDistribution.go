@@ -0,0 +1,127 @@
+package mymath
+
+import "math"
+
+// TrowbridgeReitzDistribution is the GGX distribution of the microfacet normals, it has longer tails than Beckmann,
+// AlphaX and AlphaY are the anisotropic roughnesses along the tangent and the bitangent
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/microfacet.h#L113
+type TrowbridgeReitzDistribution struct {
+	AlphaX, AlphaY    float64
+	SampleVisibleArea bool
+}
+
+func NewTrowbridgeReitzDistribution(alphaX, alphaY float64, sampleVisibleArea bool) *TrowbridgeReitzDistribution {
+	return &TrowbridgeReitzDistribution{
+		AlphaX:            math.Max(1e-3, alphaX),
+		AlphaY:            math.Max(1e-3, alphaY),
+		SampleVisibleArea: sampleVisibleArea,
+	}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/microfacet.cpp#L54
+func (d *TrowbridgeReitzDistribution) D(wh Vector3) float64 {
+	tan2Theta := Tan2Theta(wh)
+	if math.IsInf(tan2Theta, 0) || math.IsNaN(tan2Theta) {
+		return 0
+	}
+	cos4Theta := Cos2Theta(wh) * Cos2Theta(wh)
+	e := (Cos2Phi(wh)/(d.AlphaX*d.AlphaX) + Sin2Phi(wh)/(d.AlphaY*d.AlphaY)) * tan2Theta
+	return 1 / (math.Pi * d.AlphaX * d.AlphaY * cos4Theta * (1 + e) * (1 + e))
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/microfacet.cpp#L74
+func (d *TrowbridgeReitzDistribution) Lambda(w Vector3) float64 {
+	absTanTheta := math.Abs(TanTheta(w))
+	if math.IsInf(absTanTheta, 0) || math.IsNaN(absTanTheta) {
+		return 0
+	}
+
+	// Compute alpha for direction w
+	alpha := math.Sqrt(Cos2Phi(w)*d.AlphaX*d.AlphaX + Sin2Phi(w)*d.AlphaY*d.AlphaY)
+	alpha2Tan2Theta := (alpha * absTanTheta) * (alpha * absTanTheta)
+	return (-1 + math.Sqrt(1+alpha2Tan2Theta)) / 2
+}
+
+func (d *TrowbridgeReitzDistribution) G1(w Vector3) float64 {
+	return MicrofacetG1(d, w)
+}
+
+func (d *TrowbridgeReitzDistribution) G(wo, wi Vector3) float64 {
+	return MicrofacetG(d, wo, wi)
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/microfacet.cpp#L300
+func (d *TrowbridgeReitzDistribution) SampleWh(wo Vector3, u Point2) Vector3 {
+	if d.SampleVisibleArea {
+		return sampleVisible(wo, d.AlphaX, d.AlphaY, u, trowbridgeReitzSample11)
+	}
+
+	var cosTheta float64
+	phi := 2 * math.Pi * u.Y
+	if d.AlphaX == d.AlphaY {
+		tanTheta2 := d.AlphaX * d.AlphaX * u.X / (1 - u.X)
+		cosTheta = 1 / math.Sqrt(1+tanTheta2)
+	} else {
+		phi = anisotropicPhi(d.AlphaX, d.AlphaY, u.Y)
+		sinPhi, cosPhi := math.Sin(phi), math.Cos(phi)
+		alpha2 := 1 / (cosPhi*cosPhi/(d.AlphaX*d.AlphaX) + sinPhi*sinPhi/(d.AlphaY*d.AlphaY))
+		tanTheta2 := alpha2 * u.X / (1 - u.X)
+		cosTheta = 1 / math.Sqrt(1+tanTheta2)
+	}
+	sinTheta := math.Sqrt(math.Max(0, 1-cosTheta*cosTheta))
+	wh := SphericalDirection(sinTheta, cosTheta, phi)
+	if !SameHemisphere(wo, wh) {
+		wh = wh.Negate()
+	}
+	return wh
+}
+
+func (d *TrowbridgeReitzDistribution) Pdf(wo, wh Vector3) float64 {
+	return MicrofacetPdf(d, d.SampleVisibleArea, wo, wh)
+}
+
+// trowbridgeReitzSample11 samples the slopes of the visible normals of the isotropic Trowbridge-Reitz distribution
+// with unit roughness
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/microfacet.cpp#L145
+func trowbridgeReitzSample11(cosTheta, u1, u2 float64) (slopeX, slopeY float64) {
+	// special case (normal incidence)
+	if cosTheta > .9999 {
+		r := math.Sqrt(u1 / (1 - u1))
+		sinPhi, cosPhi := math.Sincos(2 * math.Pi * u2)
+		return r * cosPhi, r * sinPhi
+	}
+
+	sinTheta := math.Sqrt(math.Max(0, 1-cosTheta*cosTheta))
+	tanTheta := sinTheta / cosTheta
+	a := 1 / tanTheta
+	g1 := 2 / (1 + math.Sqrt(1+1/(a*a)))
+
+	// sample slope_x
+	A := 2*u1/g1 - 1
+	tmp := math.Min(1/(A*A-1), 1e10)
+	B := tanTheta
+	D := math.Sqrt(math.Max(B*B*tmp*tmp-(A*A-B*B)*tmp, 0))
+	slopeX1 := B*tmp - D
+	slopeX2 := B*tmp + D
+	if A < 0 || slopeX2 > 1/tanTheta {
+		slopeX = slopeX1
+	} else {
+		slopeX = slopeX2
+	}
+
+	// sample slope_y
+	var S float64
+	if u2 > 0.5 {
+		S = 1
+		u2 = 2 * (u2 - .5)
+	} else {
+		S = -1
+		u2 = 2 * (.5 - u2)
+	}
+	z := (u2 * (u2*(u2*0.27385-0.73369) + 0.46341)) /
+		(u2*(u2*(u2*0.093073+0.309420)-1.000000) + 0.597999)
+	slopeY = S * z * math.Sqrt(1+slopeX*slopeX)
+	return slopeX, slopeY
+}
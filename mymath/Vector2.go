@@ -0,0 +1,13 @@
+package mymath
+
+type Vector2 struct {
+	X, Y float64
+}
+
+func NewVector2(x, y float64) Vector2 {
+	return Vector2{x, y}
+}
+
+func (v Vector2) Multiply(d float64) Vector2 {
+	return NewVector2(v.X*d, v.Y*d)
+}
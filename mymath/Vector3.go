@@ -12,6 +12,10 @@ func NewVector3(x, y, z float64) Vector3 {
 	return Vector3{x, y, z}
 }
 
+func NewVector3N(n Normal3) Vector3 {
+	return Vector3{n.X, n.Y, n.Z}
+}
+
 func NewVector3P(p Point3) Vector3 {
 	return Vector3{p.X, p.Y, p.Z}
 }
@@ -118,3 +122,56 @@ func (v Vector3) Permute(x, y, z int) Vector3 {
 		v.Get(y),
 		v.Get(z))
 }
+
+// CoordinateSystem constructs two vectors that together with normalized v form an orthonormal coordinate system
+//
+// see https://github.com/mmp/pbrt-v3/blob/aaa552a4b9cbf9dccb71450f47b268e0ed6370e2/src/core/geometry.h#L1074
+func (v Vector3) CoordinateSystem() (Vector3, Vector3) {
+	var v2 Vector3
+
+	if math.Abs(v.X) > math.Abs(v.Y) {
+		v2 = NewVector3(-v.Z, 0, v.X).Divide(math.Sqrt(v.X*v.X + v.Z*v.Z))
+	} else {
+		v2 = NewVector3(0, v.Z, -v.Y).Divide(math.Sqrt(v.Y*v.Y + v.Z*v.Z))
+	}
+
+	return v2, v.Cross(v2)
+}
+
+// SphericalDirection converts spherical coordinates to the direction vector
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/geometry.h#L1313
+func SphericalDirection(sinTheta, cosTheta, phi float64) Vector3 {
+	return NewVector3(
+		Clamp(sinTheta, -1, 1)*math.Cos(phi),
+		Clamp(sinTheta, -1, 1)*math.Sin(phi),
+		Clamp(cosTheta, -1, 1))
+}
+
+// SphericalDirectionBasis converts spherical coordinates to the direction vector in the coordinate system x, y, z
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/geometry.h#L1320
+func SphericalDirectionBasis(sinTheta, cosTheta, phi float64, x, y, z Vector3) Vector3 {
+	return x.Multiply(sinTheta * math.Cos(phi)).
+		Add(y.Multiply(sinTheta * math.Sin(phi))).
+		Add(z.Multiply(cosTheta))
+}
+
+// SphericalTheta returns polar angle of the normalized vector v measured from the z axis
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/geometry.h#L1327
+func SphericalTheta(v Vector3) float64 {
+	return math.Acos(Clamp(v.Z, -1, 1))
+}
+
+// SphericalPhi returns azimuthal angle of the vector v in the range [0, 2*Pi)
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/geometry.h#L1329
+func SphericalPhi(v Vector3) float64 {
+	p := math.Atan2(v.Y, v.X)
+	if p < 0 {
+		return p + 2*math.Pi
+	}
+
+	return p
+}
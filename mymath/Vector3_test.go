@@ -1,6 +1,7 @@
 package mymath_test
 
 import (
+	"math"
 	"pbrt-go/mymath"
 	"testing"
 
@@ -441,3 +442,11 @@ func BenchmarkVector3_Permute(b *testing.B) {
 
 	assert.NotNil(b, res)
 }
+
+func TestVector3_SphericalThetaPhi(t *testing.T) {
+	v := mymath.SphericalDirection(math.Sin(0.3), math.Cos(0.3), 4)
+
+	assert.InDelta(t, 0.3, mymath.SphericalTheta(v), equalDelta)
+	assert.InDelta(t, 4, mymath.SphericalPhi(v), equalDelta)
+	assert.InDelta(t, 1.5*math.Pi, mymath.SphericalPhi(mymath.NewVector3(0, -1, 0)), equalDelta)
+}
@@ -0,0 +1,16 @@
+// Package mymathtest provides the assertions of the mymath types for the tests of the other packages
+package mymathtest
+
+import (
+	"pbrt-go/mymath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func InDeltaVector3(t *testing.T, expected, actual mymath.Vector3, delta float64, msgAndArgs ...interface{}) {
+	t.Helper()
+	assert.InDelta(t, expected.X, actual.X, delta, msgAndArgs...)
+	assert.InDelta(t, expected.Y, actual.Y, delta, msgAndArgs...)
+	assert.InDelta(t, expected.Z, actual.Z, delta, msgAndArgs...)
+}
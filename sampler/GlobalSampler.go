@@ -0,0 +1,111 @@
+package sampler
+
+import "pbrt-go/mymath"
+
+// arrayStartDim is the first dimension used for the sample arrays, the previous dimensions are kept
+// for the camera samples
+const arrayStartDim = 5
+
+// globalSequence maps the pixel samples to the samples of the sequence spanning the whole image
+type globalSequence interface {
+	// GetIndexForSample returns the sequence index of the sampleNum-th sample of the current pixel
+	GetIndexForSample(sampleNum int64) int64
+	// SampleDimension returns the dimension of the index-th sample of the sequence, the first two dimensions
+	// are relative to the current pixel
+	SampleDimension(index int64, dimension int) float64
+}
+
+// GlobalSampler generates samples of a sequence covering the whole image, the samples of the sequence
+// are distributed among the pixels by the globalSequence implementation
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampler.h#L118
+type GlobalSampler struct {
+	SamplerBase
+
+	sequence            globalSequence
+	dimension           int
+	intervalSampleIndex int64
+	arrayEndDim         int
+}
+
+func NewGlobalSampler(samplesPerPixel int64, sequence globalSequence) GlobalSampler {
+	return GlobalSampler{SamplerBase: NewSamplerBase(samplesPerPixel), sequence: sequence}
+}
+
+// StartPixel see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampler.cpp#L140
+func (s *GlobalSampler) StartPixel(p mymath.Point2i) {
+	s.SamplerBase.StartPixel(p)
+	s.dimension = 0
+	s.intervalSampleIndex = s.sequence.GetIndexForSample(0)
+
+	// Compute arrayEndDim for dimensions used for array samples
+	s.arrayEndDim = arrayStartDim + len(s.sampleArray1D) + 2*len(s.sampleArray2D)
+
+	// Compute 1D array samples for GlobalSampler
+	for i, size := range s.samples1DArraySizes {
+		nSamples := int64(size) * s.SamplesPerPixel
+		for j := int64(0); j < nSamples; j++ {
+			index := s.sequence.GetIndexForSample(j)
+			s.sampleArray1D[i][j] = s.sequence.SampleDimension(index, arrayStartDim+i)
+		}
+	}
+
+	// Compute 2D array samples for GlobalSampler
+	dim := arrayStartDim + len(s.samples1DArraySizes)
+	for i, size := range s.samples2DArraySizes {
+		nSamples := int64(size) * s.SamplesPerPixel
+		for j := int64(0); j < nSamples; j++ {
+			index := s.sequence.GetIndexForSample(j)
+			s.sampleArray2D[i][j] = mymath.NewPoint2(
+				s.sequence.SampleDimension(index, dim),
+				s.sequence.SampleDimension(index, dim+1))
+		}
+		dim += 2
+	}
+}
+
+// StartNextSample see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampler.cpp#L167
+func (s *GlobalSampler) StartNextSample() bool {
+	s.dimension = 0
+	s.intervalSampleIndex = s.sequence.GetIndexForSample(s.currentPixelSampleIndex + 1)
+	return s.SamplerBase.StartNextSample()
+}
+
+// SetSampleNumber see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampler.cpp#L173
+func (s *GlobalSampler) SetSampleNumber(sampleNum int64) bool {
+	s.dimension = 0
+	s.intervalSampleIndex = s.sequence.GetIndexForSample(sampleNum)
+	return s.SamplerBase.SetSampleNumber(sampleNum)
+}
+
+// Get1D see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampler.cpp#L179
+func (s *GlobalSampler) Get1D() float64 {
+	if s.dimension >= arrayStartDim && s.dimension < s.arrayEndDim {
+		s.dimension = s.arrayEndDim
+	}
+
+	v := s.sequence.SampleDimension(s.intervalSampleIndex, s.dimension)
+	s.dimension++
+	return v
+}
+
+// Get2D see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampler.cpp#L185
+func (s *GlobalSampler) Get2D() mymath.Point2 {
+	if s.dimension+1 >= arrayStartDim && s.dimension < s.arrayEndDim {
+		s.dimension = s.arrayEndDim
+	}
+
+	p := mymath.NewPoint2(
+		s.sequence.SampleDimension(s.intervalSampleIndex, s.dimension),
+		s.sequence.SampleDimension(s.intervalSampleIndex, s.dimension+1))
+	s.dimension += 2
+	return p
+}
+
+// clone returns deep copy driven by the given sequence
+func (s *GlobalSampler) clone(sequence globalSequence) GlobalSampler {
+	c := *s
+	c.SamplerBase = s.SamplerBase.clone()
+	c.sequence = sequence
+	return c
+}
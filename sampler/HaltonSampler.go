@@ -0,0 +1,173 @@
+package sampler
+
+import (
+	"math"
+	"pbrt-go/mymath"
+	"sync"
+)
+
+// haltonMaxResolution is the size of the pixel area the Halton samples are spread over, the pattern repeats beyond it
+const haltonMaxResolution = 128
+
+// HaltonSampler generates samples of the Halton sequence, the first two dimensions are spread over the image
+// so that each pixel gets its own samples. The higher dimensions use the digits scrambled by Faure permutations.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/samplers/halton.h
+type HaltonSampler struct {
+	GlobalSampler
+	BaseScales          mymath.Point2i
+	BaseExponents       mymath.Point2i
+	SampleAtPixelCenter bool
+
+	sampleStride          int64
+	multInverse           [2]int64
+	pixelForOffset        mymath.Point2i
+	offsetForCurrentPixel int64
+}
+
+// NewHaltonSampler see https://github.com/mmp/pbrt-v3/blob/master/src/samplers/halton.cpp#L56
+func NewHaltonSampler(samplesPerPixel int64, sampleBounds mymath.Bounds2i, sampleAtPixelCenter bool) *HaltonSampler {
+	s := &HaltonSampler{SampleAtPixelCenter: sampleAtPixelCenter}
+
+	// Find radical inverse base scales and exponents that cover sampling area
+	res := sampleBounds.Diagonal()
+	var baseScales, baseExponents [2]int
+	for i, r := range [2]int{res.X, res.Y} {
+		base := 2
+		if i == 1 {
+			base = 3
+		}
+
+		scale, exp := 1, 0
+		for scale < minInt(r, haltonMaxResolution) {
+			scale *= base
+			exp++
+		}
+		baseScales[i], baseExponents[i] = scale, exp
+	}
+	s.BaseScales = mymath.NewPoint2i(baseScales[0], baseScales[1])
+	s.BaseExponents = mymath.NewPoint2i(baseExponents[0], baseExponents[1])
+
+	// Compute stride in samples for visiting each pixel area
+	s.sampleStride = int64(baseScales[0] * baseScales[1])
+
+	// Compute multiplicative inverses for baseScales
+	s.multInverse[0] = multiplicativeInverse(int64(baseScales[1]), int64(baseScales[0]))
+	s.multInverse[1] = multiplicativeInverse(int64(baseScales[0]), int64(baseScales[1]))
+
+	s.pixelForOffset = mymath.NewPoint2i(math.MaxInt32, math.MaxInt32)
+	s.GlobalSampler = NewGlobalSampler(samplesPerPixel, s)
+	return s
+}
+
+// GetIndexForSample see https://github.com/mmp/pbrt-v3/blob/master/src/samplers/halton.cpp#L93
+func (s *HaltonSampler) GetIndexForSample(sampleNum int64) int64 {
+	if s.currentPixel != s.pixelForOffset {
+		// Compute Halton sample offset for currentPixel
+		s.offsetForCurrentPixel = 0
+		if s.sampleStride > 1 {
+			pm := [2]int64{
+				mod(int64(s.currentPixel.X), haltonMaxResolution),
+				mod(int64(s.currentPixel.Y), haltonMaxResolution),
+			}
+
+			for i, base := range [2]uint64{2, 3} {
+				baseScale, baseExponent := s.BaseScales.X, s.BaseExponents.X
+				if i == 1 {
+					baseScale, baseExponent = s.BaseScales.Y, s.BaseExponents.Y
+				}
+
+				dimOffset := int64(mymath.InverseRadicalInverse(base, uint64(pm[i]), baseExponent))
+				s.offsetForCurrentPixel += dimOffset * (s.sampleStride / int64(baseScale)) * s.multInverse[i]
+			}
+			s.offsetForCurrentPixel %= s.sampleStride
+		}
+		s.pixelForOffset = s.currentPixel
+	}
+
+	return s.offsetForCurrentPixel + sampleNum*s.sampleStride
+}
+
+// SampleDimension see https://github.com/mmp/pbrt-v3/blob/master/src/samplers/halton.cpp#L118
+func (s *HaltonSampler) SampleDimension(index int64, dim int) float64 {
+	if s.SampleAtPixelCenter && (dim == 0 || dim == 1) {
+		return 0.5
+	}
+
+	switch dim {
+	case 0:
+		return mymath.RadicalInverse(dim, uint64(index>>s.BaseExponents.X))
+	case 1:
+		return mymath.RadicalInverse(dim, uint64(index/int64(s.BaseScales.Y)))
+	default:
+		return mymath.ScrambledRadicalInverse(dim, uint64(index), permutationForDimension(dim))
+	}
+}
+
+func (s *HaltonSampler) Clone(_ int64) Sampler {
+	c := *s
+	c.GlobalSampler = s.GlobalSampler.clone(&c)
+	return &c
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/samplers/halton.h#L66
+func permutationForDimension(dim int) []uint16 {
+	if dim >= mymath.PrimeTableSize {
+		panic("HaltonSampler can only sample up to 1000 dimensions")
+	}
+
+	radicalInversePermutationsOnce.Do(computeRadicalInversePermutations)
+	start := primeSums[dim]
+	return radicalInversePermutations[start : start+mymath.Primes[dim]]
+}
+
+// radicalInversePermutations holds the digit permutations of all the bases in mymath.Primes, the permutation
+// of Primes[i] starts at primeSums[i]. They are shared by all the samplers and built on the first use.
+var (
+	radicalInversePermutations     []uint16
+	primeSums                      [mymath.PrimeTableSize]uint64
+	radicalInversePermutationsOnce sync.Once
+)
+
+func computeRadicalInversePermutations() {
+	for i := 1; i < mymath.PrimeTableSize; i++ {
+		primeSums[i] = primeSums[i-1] + mymath.Primes[i-1]
+	}
+
+	radicalInversePermutations = make([]uint16, 0, primeSums[mymath.PrimeTableSize-1]+mymath.Primes[mymath.PrimeTableSize-1])
+	for _, p := range mymath.Primes {
+		radicalInversePermutations = append(radicalInversePermutations, mymath.FaurePermutation(int(p))...)
+	}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/samplers/halton.cpp#L43
+func extendedGCD(a, b int64) (int64, int64) {
+	if b == 0 {
+		return 1, 0
+	}
+
+	d := a / b
+	xp, yp := extendedGCD(b, a%b)
+	return yp, xp - d*yp
+}
+
+func multiplicativeInverse(a, n int64) int64 {
+	x, _ := extendedGCD(a, n)
+	return mod(x, n)
+}
+
+// mod returns non-negative remainder
+func mod(a, b int64) int64 {
+	result := a - (a/b)*b
+	if result < 0 {
+		return result + b
+	}
+	return result
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,37 @@
+package sampler
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/sampling"
+)
+
+// vanDerCorput fills samples with scrambled van der Corput sequence, the values are shuffled within
+// each pixel sample and then the pixel samples are shuffled as whole
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/lowdiscrepancy.h#L252
+func vanDerCorput(nSamplesPerPixelSample, nPixelSamples int, samples []float64, rng *sampling.RNG) {
+	scramble := rng.UniformUInt32()
+	totalSamples := nSamplesPerPixelSample * nPixelSamples
+	mymath.GrayCodeSample(CVanDerCorput[:], scramble, samples[:totalSamples])
+
+	// Randomly shuffle 1D sample points
+	for i := 0; i < nPixelSamples; i++ {
+		sampling.Shuffle(samples[i*nSamplesPerPixelSample:], nSamplesPerPixelSample, 1, rng)
+	}
+	sampling.Shuffle(samples, nPixelSamples, nSamplesPerPixelSample, rng)
+}
+
+// sobol2D fills samples with scrambled (0,2)-sequence, shuffled the same way as in vanDerCorput
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/lowdiscrepancy.h#L272
+func sobol2D(nSamplesPerPixelSample, nPixelSamples int, samples []mymath.Point2, rng *sampling.RNG) {
+	scrambleX, scrambleY := rng.UniformUInt32(), rng.UniformUInt32()
+	totalSamples := nSamplesPerPixelSample * nPixelSamples
+	mymath.GrayCodeSample2D(CSobol[0][:], CSobol[1][:], scrambleX, scrambleY, samples[:totalSamples])
+
+	// Randomly shuffle 2D sample points
+	for i := 0; i < nPixelSamples; i++ {
+		sampling.Shuffle2D(samples[i*nSamplesPerPixelSample:], nSamplesPerPixelSample, 1, rng)
+	}
+	sampling.Shuffle2D(samples, nPixelSamples, nSamplesPerPixelSample, rng)
+}
@@ -0,0 +1,114 @@
+package sampler
+
+// CMaxMinDist holds generator matrices of the MaxMinDistSampler, the row log2Samples generates the points
+// (i/n, C*i) of the (0,2)-net of n = 2^log2Samples points with the large minimal toroidal distance between them.
+// The columns are stored with the first row in the most significant bit.
+//
+// Each matrix was chosen among the unit lower triangular candidates (these keep the (0,2)-net property) by
+// the search with the fixed seed. Smaller sets were evaluated with more candidates, at most 1024.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/lowdiscrepancy.cpp#L43
+var CMaxMinDist = [17][SobolMatrixSize]uint32{
+	{
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x8000bb55, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0xc0166ceb, 0x771c9287, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0xc1013d84, 0x6083c2b0, 0x2222b4b0, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0xcf05a7d3, 0x6c01ed20, 0x3361610b, 0x1380d3d8, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0xd959d04b, 0x6329650a, 0x2eb7d8b2, 0x19322ec3, 0x0d08117e, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0xe89036b7, 0x63064c4c, 0x30c3656e, 0x1fd9af40, 0x0d39b0dc, 0x056cf35a, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0xe401e8c3, 0x50a89a32, 0x30421824, 0x13b93dad, 0x0b989e5f, 0x045e8858, 0x02a40208, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0xe2595ba8, 0x6e6d4174, 0x3172ba3f, 0x18d312cc, 0x0ea9f916, 0x041ee4c4, 0x0341556e, 0x011c5604,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0xcf1a1d6e, 0x5a85d092, 0x369705ae, 0x19974e7c, 0x0d55042e, 0x04aa41b2, 0x0236d1dc, 0x01105062,
+		0x0080266e, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0xa306b284, 0x792db599, 0x3173c867, 0x19304941, 0x0cbac0fd, 0x0795940e, 0x035b98c7, 0x018dcdd6,
+		0x00f080ab, 0x0043ad60, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0xb7bc7beb, 0x568e561f, 0x20b2fcc7, 0x1615f86a, 0x0b5ef6a2, 0x05df2192, 0x0261c876, 0x01cc13b1,
+		0x00dd798d, 0x004ac206, 0x0039fe9b, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x9def7f7a, 0x673dc164, 0x27afdf23, 0x1931e6b4, 0x0ed2ef71, 0x0597d805, 0x038f607f, 0x0198d8ae,
+		0x00ec16c0, 0x00615f34, 0x002cc9cc, 0x001b35f0, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0xbafebf3d, 0x5566d41f, 0x374f240c, 0x1b1e8fbe, 0x0f2b09a9, 0x051a05e0, 0x024f3200, 0x01809494,
+		0x00917e4c, 0x0065c6b8, 0x003a4246, 0x00140d53, 0x000a6661, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0xbab2883a, 0x4d59d85e, 0x3af0f955, 0x19b1570d, 0x09a77434, 0x05ee116b, 0x03788b03, 0x01b0de87,
+		0x00e17fed, 0x005ddc96, 0x00337c49, 0x001cdde0, 0x000d97d3, 0x000729ca, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x9f589faf, 0x41af46e4, 0x36775e5a, 0x19e2650f, 0x0f26f757, 0x07e5d3cb, 0x034f1359, 0x0104214f,
+		0x0095154b, 0x0045b7c4, 0x002bfea4, 0x00173dc6, 0x000e7648, 0x0006f68e, 0x00036096, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0xae04120f, 0x5098c832, 0x2265c462, 0x160379c4, 0x0c33985b, 0x04e6720e, 0x03301e5c, 0x01dfc192,
+		0x00e1a1aa, 0x0052d7a4, 0x002e54b1, 0x00139483, 0x000e7ce3, 0x00055d9b, 0x0002a35b, 0x000154ae,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+}
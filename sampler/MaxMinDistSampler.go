@@ -0,0 +1,69 @@
+package sampler
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/sampling"
+)
+
+// MaxMinDistSampler places the pixel samples at the points of (0,2)-net maximizing the minimal distance between them,
+// the other dimensions are sampled the same way as in ZeroTwoSequenceSampler
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/samplers/maxmin.h
+type MaxMinDistSampler struct {
+	PixelSampler
+	CPixel []uint32
+}
+
+// NewMaxMinDistSampler creates sampler with samplesPerPixel rounded up to power of 2
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/samplers/maxmin.h#L51
+func NewMaxMinDistSampler(samplesPerPixel int64, nSampledDimensions int, seed int64) *MaxMinDistSampler {
+	samplesPerPixel = mymath.RoundUpPow2(samplesPerPixel)
+	log2Samples := mymath.Log2Int(samplesPerPixel)
+	if log2Samples >= len(CMaxMinDist) {
+		panic("MaxMinDistSampler supports at most 65536 samples per pixel")
+	}
+
+	return &MaxMinDistSampler{
+		PixelSampler: NewPixelSampler(samplesPerPixel, nSampledDimensions, seed),
+		CPixel:       CMaxMinDist[log2Samples][:],
+	}
+}
+
+// StartPixel see https://github.com/mmp/pbrt-v3/blob/master/src/samplers/maxmin.cpp#L42
+func (s *MaxMinDistSampler) StartPixel(p mymath.Point2i) {
+	spp := int(s.SamplesPerPixel)
+	invSPP := 1 / float64(spp)
+
+	if len(s.samples2D) > 0 {
+		for i := 0; i < spp; i++ {
+			s.samples2D[0][i] = mymath.NewPoint2(float64(i)*invSPP, mymath.SampleGeneratorMatrix(s.CPixel, uint32(i), 0))
+		}
+		sampling.Shuffle2D(s.samples2D[0], spp, 1, s.rng)
+	}
+
+	// Generate remaining samples for MaxMinDistSampler
+	for _, samples := range s.samples1D {
+		vanDerCorput(1, spp, samples, s.rng)
+	}
+	for i := 1; i < len(s.samples2D); i++ {
+		sobol2D(1, spp, s.samples2D[i], s.rng)
+	}
+	for i, count := range s.samples1DArraySizes {
+		vanDerCorput(count, spp, s.sampleArray1D[i], s.rng)
+	}
+	for i, count := range s.samples2DArraySizes {
+		sobol2D(count, spp, s.sampleArray2D[i], s.rng)
+	}
+
+	s.PixelSampler.StartPixel(p)
+}
+
+// RoundCount returns power of 2, the (0,2)-sequence is well distributed only for such counts
+func (s *MaxMinDistSampler) RoundCount(n int) int {
+	return int(mymath.RoundUpPow2(int64(n)))
+}
+
+func (s *MaxMinDistSampler) Clone(seed int64) Sampler {
+	return &MaxMinDistSampler{s.PixelSampler.clone(seed), s.CPixel}
+}
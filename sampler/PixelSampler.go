@@ -0,0 +1,84 @@
+package sampler
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/sampling"
+)
+
+// PixelSampler generates all the samples of the pixel at once in StartPixel, the dimensions beyond
+// nSampledDimensions are uniform random values
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampler.h#L94
+type PixelSampler struct {
+	SamplerBase
+
+	// samples1D and samples2D are indexed by dimension and sample number
+	samples1D                              [][]float64
+	samples2D                              [][]mymath.Point2
+	current1DDimension, current2DDimension int
+	rng                                    *sampling.RNG
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampler.cpp#L106
+func NewPixelSampler(samplesPerPixel int64, nSampledDimensions int, seed int64) PixelSampler {
+	s := PixelSampler{
+		SamplerBase: NewSamplerBase(samplesPerPixel),
+		rng:         sampling.NewRNGSequence(uint64(seed)),
+	}
+
+	for i := 0; i < nSampledDimensions; i++ {
+		s.samples1D = append(s.samples1D, make([]float64, samplesPerPixel))
+		s.samples2D = append(s.samples2D, make([]mymath.Point2, samplesPerPixel))
+	}
+
+	return s
+}
+
+func (s *PixelSampler) StartPixel(p mymath.Point2i) {
+	s.current1DDimension, s.current2DDimension = 0, 0
+	s.SamplerBase.StartPixel(p)
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampler.cpp#L114
+func (s *PixelSampler) StartNextSample() bool {
+	s.current1DDimension, s.current2DDimension = 0, 0
+	return s.SamplerBase.StartNextSample()
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampler.cpp#L119
+func (s *PixelSampler) SetSampleNumber(sampleNum int64) bool {
+	s.current1DDimension, s.current2DDimension = 0, 0
+	return s.SamplerBase.SetSampleNumber(sampleNum)
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampler.cpp#L124
+func (s *PixelSampler) Get1D() float64 {
+	if s.current1DDimension < len(s.samples1D) {
+		v := s.samples1D[s.current1DDimension][s.currentPixelSampleIndex]
+		s.current1DDimension++
+		return v
+	}
+
+	return s.rng.UniformFloat()
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampler.cpp#L132
+func (s *PixelSampler) Get2D() mymath.Point2 {
+	if s.current2DDimension < len(s.samples2D) {
+		v := s.samples2D[s.current2DDimension][s.currentPixelSampleIndex]
+		s.current2DDimension++
+		return v
+	}
+
+	return mymath.NewPoint2(s.rng.UniformFloat(), s.rng.UniformFloat())
+}
+
+// clone returns deep copy with the random generator seeded by seed
+func (s *PixelSampler) clone(seed int64) PixelSampler {
+	c := *s
+	c.SamplerBase = s.SamplerBase.clone()
+	c.samples1D = copy1D(s.samples1D)
+	c.samples2D = copy2D(s.samples2D)
+	c.rng = sampling.NewRNGSequence(uint64(seed))
+	return c
+}
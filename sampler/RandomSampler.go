@@ -0,0 +1,47 @@
+package sampler
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/sampling"
+)
+
+// RandomSampler generates independent uniform random samples
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/samplers/random.h
+type RandomSampler struct {
+	SamplerBase
+	rng *sampling.RNG
+}
+
+func NewRandomSampler(samplesPerPixel int64, seed int64) *RandomSampler {
+	return &RandomSampler{NewSamplerBase(samplesPerPixel), sampling.NewRNGSequence(uint64(seed))}
+}
+
+func (s *RandomSampler) Get1D() float64 {
+	return s.rng.UniformFloat()
+}
+
+func (s *RandomSampler) Get2D() mymath.Point2 {
+	return mymath.NewPoint2(s.rng.UniformFloat(), s.rng.UniformFloat())
+}
+
+// StartPixel see https://github.com/mmp/pbrt-v3/blob/master/src/samplers/random.cpp#L57
+func (s *RandomSampler) StartPixel(p mymath.Point2i) {
+	for _, array := range s.sampleArray1D {
+		for i := range array {
+			array[i] = s.rng.UniformFloat()
+		}
+	}
+
+	for _, array := range s.sampleArray2D {
+		for i := range array {
+			array[i] = mymath.NewPoint2(s.rng.UniformFloat(), s.rng.UniformFloat())
+		}
+	}
+
+	s.SamplerBase.StartPixel(p)
+}
+
+func (s *RandomSampler) Clone(seed int64) Sampler {
+	return &RandomSampler{s.SamplerBase.clone(), sampling.NewRNGSequence(uint64(seed))}
+}
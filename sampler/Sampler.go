@@ -0,0 +1,179 @@
+package sampler
+
+import (
+	"fmt"
+	"pbrt-go/camera"
+	"pbrt-go/mymath"
+)
+
+// Sampler generates sample vectors for the pixels of the image. The sample values are consumed dimension by dimension
+// using Get1D and Get2D, the arrays of samples must be requested before the rendering starts.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampler.h#L48
+type Sampler interface {
+	// StartPixel starts generating samples for the pixel p
+	StartPixel(p mymath.Point2i)
+	Get1D() float64
+	Get2D() mymath.Point2
+	// Request1DArray requests array of n 1D samples for each sample of the pixel
+	Request1DArray(n int)
+	// Request2DArray requests array of n 2D samples for each sample of the pixel
+	Request2DArray(n int)
+	// RoundCount returns the array size the sampler can generate well, it is at least n
+	RoundCount(n int) int
+	// Get1DArray returns the next requested 1D array for the current sample or nil when all of them were consumed
+	Get1DArray(n int) []float64
+	// Get2DArray returns the next requested 2D array for the current sample or nil when all of them were consumed
+	Get2DArray(n int) []mymath.Point2
+	// StartNextSample moves to the next sample of the pixel, returns false when all the pixel samples were generated
+	StartNextSample() bool
+	// SetSampleNumber moves to the given sample of the pixel
+	SetSampleNumber(sampleNum int64) bool
+	CurrentSampleNumber() int64
+	GetSamplesPerPixel() int64
+	// Clone creates independent copy of the sampler, clones with the same seed generate the same samples
+	Clone(seed int64) Sampler
+}
+
+// GetCameraSample returns camera sample for the pixel pRaster
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampler.cpp#L50
+func GetCameraSample(sampler Sampler, pRaster mymath.Point2i) camera.CameraSample {
+	pFilm := sampler.Get2D()
+	pFilm.X += float64(pRaster.X)
+	pFilm.Y += float64(pRaster.Y)
+
+	time := sampler.Get1D()
+	pLens := sampler.Get2D()
+
+	return camera.CameraSample{PFilm: pFilm, PLens: pLens, Time: time}
+}
+
+// SamplerBase holds the state shared by all the samplers
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampler.h#L48
+type SamplerBase struct {
+	SamplesPerPixel int64
+
+	currentPixel                             mymath.Point2i
+	currentPixelSampleIndex                  int64
+	samples1DArraySizes, samples2DArraySizes []int
+	sampleArray1D                            [][]float64
+	sampleArray2D                            [][]mymath.Point2
+	array1DOffset, array2DOffset             int
+}
+
+func NewSamplerBase(samplesPerPixel int64) SamplerBase {
+	return SamplerBase{SamplesPerPixel: samplesPerPixel}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampler.cpp#L58
+func (s *SamplerBase) StartPixel(p mymath.Point2i) {
+	s.currentPixel = p
+	s.currentPixelSampleIndex = 0
+
+	// Reset array offsets for next pixel sample
+	s.array1DOffset, s.array2DOffset = 0, 0
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampler.cpp#L65
+func (s *SamplerBase) StartNextSample() bool {
+	// Reset array offsets for next pixel sample
+	s.array1DOffset, s.array2DOffset = 0, 0
+	s.currentPixelSampleIndex++
+	return s.currentPixelSampleIndex < s.SamplesPerPixel
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampler.cpp#L71
+func (s *SamplerBase) SetSampleNumber(sampleNum int64) bool {
+	// Reset array offsets for next pixel sample
+	s.array1DOffset, s.array2DOffset = 0, 0
+	s.currentPixelSampleIndex = sampleNum
+	return s.currentPixelSampleIndex < s.SamplesPerPixel
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampler.cpp#L78
+func (s *SamplerBase) Request1DArray(n int) {
+	s.samples1DArraySizes = append(s.samples1DArraySizes, n)
+	s.sampleArray1D = append(s.sampleArray1D, make([]float64, int64(n)*s.SamplesPerPixel))
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampler.cpp#L84
+func (s *SamplerBase) Request2DArray(n int) {
+	s.samples2DArraySizes = append(s.samples2DArraySizes, n)
+	s.sampleArray2D = append(s.sampleArray2D, make([]mymath.Point2, int64(n)*s.SamplesPerPixel))
+}
+
+func (s *SamplerBase) RoundCount(n int) int {
+	return n
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampler.cpp#L90
+func (s *SamplerBase) Get1DArray(n int) []float64 {
+	if s.array1DOffset == len(s.sampleArray1D) {
+		return nil
+	}
+	if s.samples1DArraySizes[s.array1DOffset] != n {
+		panic(fmt.Sprintf("Get1DArray(%v) does not match the requested array size %v", n, s.samples1DArraySizes[s.array1DOffset]))
+	}
+	if s.currentPixelSampleIndex >= s.SamplesPerPixel {
+		panic("Get1DArray called after the last sample of the pixel")
+	}
+
+	start := s.currentPixelSampleIndex * int64(n)
+	array := s.sampleArray1D[s.array1DOffset][start : start+int64(n)]
+	s.array1DOffset++
+	return array
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampler.cpp#L98
+func (s *SamplerBase) Get2DArray(n int) []mymath.Point2 {
+	if s.array2DOffset == len(s.sampleArray2D) {
+		return nil
+	}
+	if s.samples2DArraySizes[s.array2DOffset] != n {
+		panic(fmt.Sprintf("Get2DArray(%v) does not match the requested array size %v", n, s.samples2DArraySizes[s.array2DOffset]))
+	}
+	if s.currentPixelSampleIndex >= s.SamplesPerPixel {
+		panic("Get2DArray called after the last sample of the pixel")
+	}
+
+	start := s.currentPixelSampleIndex * int64(n)
+	array := s.sampleArray2D[s.array2DOffset][start : start+int64(n)]
+	s.array2DOffset++
+	return array
+}
+
+func (s *SamplerBase) CurrentSampleNumber() int64 {
+	return s.currentPixelSampleIndex
+}
+
+func (s *SamplerBase) GetSamplesPerPixel() int64 {
+	return s.SamplesPerPixel
+}
+
+// clone returns deep copy so that the clones do not share the sample arrays
+func (s *SamplerBase) clone() SamplerBase {
+	c := *s
+	c.samples1DArraySizes = append([]int(nil), s.samples1DArraySizes...)
+	c.samples2DArraySizes = append([]int(nil), s.samples2DArraySizes...)
+	c.sampleArray1D = copy1D(s.sampleArray1D)
+	c.sampleArray2D = copy2D(s.sampleArray2D)
+	return c
+}
+
+func copy1D(arrays [][]float64) [][]float64 {
+	c := make([][]float64, len(arrays))
+	for i, a := range arrays {
+		c[i] = append([]float64(nil), a...)
+	}
+	return c
+}
+
+func copy2D(arrays [][]mymath.Point2) [][]mymath.Point2 {
+	c := make([][]mymath.Point2, len(arrays))
+	for i, a := range arrays {
+		c[i] = append([]mymath.Point2(nil), a...)
+	}
+	return c
+}
@@ -0,0 +1,362 @@
+package sampler_test
+
+import (
+	"math"
+	"pbrt-go/mymath"
+	"pbrt-go/sampler"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var sampleBounds = mymath.NewBounds2i(mymath.NewPoint2i(0, 0), mymath.NewPoint2i(100, 50))
+
+func allSamplers() map[string]sampler.Sampler {
+	return map[string]sampler.Sampler{
+		"random":     sampler.NewRandomSampler(16, 0),
+		"stratified": sampler.NewStratifiedSampler(4, 4, true, 4, 0),
+		"halton":     sampler.NewHaltonSampler(16, sampleBounds, false),
+		"sobol":      sampler.NewSobolSampler(16, sampleBounds),
+		"zerotwo":    sampler.NewZeroTwoSequenceSampler(16, 4, 0),
+		"maxmindist": sampler.NewMaxMinDistSampler(16, 4, 0),
+	}
+}
+
+// sampleAll consumes all the samples of the pixel the same way as the integrator would
+func sampleAll(s sampler.Sampler, p mymath.Point2i) []float64 {
+	var values []float64
+
+	s.StartPixel(p)
+	for {
+		values = append(values, s.Get1D())
+		for _, a := range s.Get1DArray(s.RoundCount(3)) {
+			values = append(values, a)
+		}
+		for _, a := range s.Get2DArray(s.RoundCount(4)) {
+			values = append(values, a.X, a.Y)
+		}
+		for i := 0; i < 6; i++ {
+			p := s.Get2D()
+			values = append(values, p.X, p.Y)
+		}
+
+		if !s.StartNextSample() {
+			break
+		}
+	}
+
+	return values
+}
+
+func TestSampler_Clone(t *testing.T) {
+	for name, s := range allSamplers() {
+		s.Request1DArray(s.RoundCount(3))
+		s.Request2DArray(s.RoundCount(4))
+
+		c1, c2, c3 := s.Clone(7), s.Clone(7), s.Clone(8)
+		values1 := sampleAll(c1, mymath.NewPoint2i(3, 5))
+		values2 := sampleAll(c2, mymath.NewPoint2i(3, 5))
+		values3 := sampleAll(c3, mymath.NewPoint2i(3, 5))
+
+		assert.Equal(t, values1, values2, name)
+		if name != "halton" && name != "sobol" {
+			assert.NotEqual(t, values1, values3, name)
+		}
+
+		// clones are independent of each other
+		assert.Equal(t, values1, sampleAll(s.Clone(7), mymath.NewPoint2i(3, 5)), name)
+	}
+}
+
+func TestSampler_range(t *testing.T) {
+	for name, s := range allSamplers() {
+		s.Request1DArray(s.RoundCount(3))
+		s.Request2DArray(s.RoundCount(4))
+
+		for _, p := range []mymath.Point2i{mymath.NewPoint2i(0, 0), mymath.NewPoint2i(99, 49), mymath.NewPoint2i(42, 17)} {
+			for _, v := range sampleAll(s, p) {
+				assert.True(t, v >= 0 && v < 1, "%v: %v", name, v)
+			}
+		}
+	}
+}
+
+func TestSampler_arrays(t *testing.T) {
+	for name, s := range allSamplers() {
+		n1, n2 := s.RoundCount(3), s.RoundCount(5)
+		s.Request1DArray(n1)
+		s.Request2DArray(n2)
+		s.Request2DArray(n2)
+
+		s.StartPixel(mymath.NewPoint2i(1, 2))
+		assert.Equal(t, int64(16), s.GetSamplesPerPixel(), name)
+
+		for i := int64(0); i < s.GetSamplesPerPixel(); i++ {
+			assert.Equal(t, i, s.CurrentSampleNumber(), name)
+			assert.Len(t, s.Get1DArray(n1), n1, name)
+			assert.Nil(t, s.Get1DArray(n1), name)
+			assert.Len(t, s.Get2DArray(n2), n2, name)
+			assert.Len(t, s.Get2DArray(n2), n2, name)
+			assert.Nil(t, s.Get2DArray(n2), name)
+
+			assert.Equal(t, i+1 < s.GetSamplesPerPixel(), s.StartNextSample(), name)
+		}
+
+		// jump back to the beginning
+		assert.True(t, s.SetSampleNumber(0), name)
+		assert.Len(t, s.Get1DArray(n1), n1, name)
+
+		// the size must match the requested one
+		assert.Panics(t, func() { s.Get2DArray(n2 + 1) }, name)
+
+		// no arrays past the last sample
+		assert.False(t, s.SetSampleNumber(16), name)
+		assert.Panics(t, func() { s.Get2DArray(n2) }, name)
+	}
+}
+
+func TestGetCameraSample(t *testing.T) {
+	for name, s := range allSamplers() {
+		s.StartPixel(mymath.NewPoint2i(7, 9))
+		for {
+			cs := sampler.GetCameraSample(s, mymath.NewPoint2i(7, 9))
+			assert.True(t, cs.PFilm.X >= 7 && cs.PFilm.X < 8, name)
+			assert.True(t, cs.PFilm.Y >= 9 && cs.PFilm.Y < 10, name)
+
+			if !s.StartNextSample() {
+				break
+			}
+		}
+	}
+}
+
+// assertNet checks that each elementary interval of area 1/len(points) holds exactly one point
+func assertNet(t *testing.T, points []mymath.Point2, msgAndArgs ...interface{}) {
+	n := len(points)
+	m := mymath.Log2Int(int64(n))
+
+	for d := 0; d <= m; d++ {
+		nx, ny := 1<<d, 1<<(m-d)
+		counts := make([]int, n)
+		for _, p := range points {
+			counts[int(p.Y*float64(ny))*nx+int(p.X*float64(nx))]++
+		}
+
+		for _, c := range counts {
+			if !assert.Equal(t, 1, c, msgAndArgs...) {
+				return
+			}
+		}
+	}
+}
+
+func pixelSamples2D(s sampler.Sampler, p mymath.Point2i) []mymath.Point2 {
+	var points []mymath.Point2
+
+	s.StartPixel(p)
+	for {
+		points = append(points, s.Get2D())
+		if !s.StartNextSample() {
+			break
+		}
+	}
+
+	return points
+}
+
+func TestStratifiedSampler(t *testing.T) {
+	s := sampler.NewStratifiedSampler(4, 2, false, 1, 0)
+	points := pixelSamples2D(s, mymath.NewPoint2i(0, 0))
+	assert.Len(t, points, 8)
+
+	// without jitter the samples are at the strata centers
+	expected := map[mymath.Point2]bool{}
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			expected[mymath.NewPoint2((float64(x)+0.5)/4, (float64(y)+0.5)/2)] = true
+		}
+	}
+	for _, p := range points {
+		assert.True(t, expected[p], "%v", p)
+		delete(expected, p)
+	}
+
+	// with jitter each stratum still has one sample
+	s = sampler.NewStratifiedSampler(4, 4, true, 1, 0)
+	counts := map[mymath.Point2i]int{}
+	for _, p := range pixelSamples2D(s, mymath.NewPoint2i(0, 0)) {
+		counts[mymath.NewPoint2i(int(p.X*4), int(p.Y*4))]++
+	}
+	assert.Len(t, counts, 16)
+}
+
+func TestStratifiedSampler_arrays(t *testing.T) {
+	s := sampler.NewStratifiedSampler(2, 2, true, 1, 0)
+	s.Request1DArray(8)
+	s.Request2DArray(8)
+	s.StartPixel(mymath.NewPoint2i(0, 0))
+
+	strata := map[int]bool{}
+	for _, v := range s.Get1DArray(8) {
+		strata[int(v*8)] = true
+	}
+	assert.Len(t, strata, 8)
+
+	// Latin hypercube has one sample in each row and column
+	rows, columns := map[int]bool{}, map[int]bool{}
+	for _, p := range s.Get2DArray(8) {
+		columns[int(p.X*8)] = true
+		rows[int(p.Y*8)] = true
+	}
+	assert.Len(t, rows, 8)
+	assert.Len(t, columns, 8)
+}
+
+func TestHaltonSampler_GetIndexForSample(t *testing.T) {
+	s := sampler.NewHaltonSampler(16, sampleBounds, false)
+	assert.Equal(t, mymath.NewPoint2i(128, 81), s.BaseScales)
+	assert.Equal(t, mymath.NewPoint2i(7, 4), s.BaseExponents)
+
+	for _, p := range []mymath.Point2i{mymath.NewPoint2i(0, 0), mymath.NewPoint2i(99, 49), mymath.NewPoint2i(42, 17)} {
+		s.StartPixel(p)
+		for i := int64(0); i < 16; i++ {
+			index := s.GetIndexForSample(i)
+
+			// the sample of the whole sequence scaled to the pixel area falls into the pixel
+			x := mymath.RadicalInverse(0, uint64(index)) * 128
+			y := mymath.RadicalInverse(1, uint64(index)) * 81
+			assert.Equal(t, p.X, int(x))
+			assert.Equal(t, p.Y, int(y))
+			assert.InDelta(t, x-math.Floor(x), s.SampleDimension(index, 0), 1e-9)
+			assert.InDelta(t, y-math.Floor(y), s.SampleDimension(index, 1), 1e-9)
+		}
+	}
+}
+
+func TestHaltonSampler_sampleAtPixelCenter(t *testing.T) {
+	s := sampler.NewHaltonSampler(16, sampleBounds, true)
+	for _, p := range pixelSamples2D(s, mymath.NewPoint2i(0, 0)) {
+		assert.Equal(t, mymath.NewPoint2(0.5, 0.5), p)
+	}
+}
+
+func TestSobolMatrices32(t *testing.T) {
+	assert.Len(t, sampler.SobolMatrices32(), sampler.NumSobolDimensions*sampler.SobolMatrixSize)
+
+	// the second dimension is the Pascal matrix
+	assert.Equal(t, []uint32{0x80000000, 0xc0000000, 0xa0000000, 0xf0000000, 0x88000000}, sampler.CSobol[1][:5])
+
+	// every dimension is (0,1)-sequence
+	for _, dim := range []int{0, 1, 2, 7, 100, 1023} {
+		for m := 1; m <= 8; m++ {
+			n := 1 << m
+			strata := make([]bool, n)
+			for i := 0; i < n; i++ {
+				strata[int(sampler.SobolSample(uint32(i), dim, 0)*float64(n))] = true
+			}
+			assert.NotContains(t, strata, false, "dim %v, m %v", dim, m)
+		}
+	}
+}
+
+func TestVdCSobolMatrices(t *testing.T) {
+	// pixelBits returns the pixel (x << m | y) of the 2^m x 2^m grid the index falls into
+	pixelBits := func(m int, index uint64) uint64 {
+		x, y := uint32(0), uint32(0)
+		for c := 0; index != 0; index, c = index>>1, c+1 {
+			if index&1 != 0 {
+				x ^= sampler.CSobol[0][c]
+				y ^= sampler.CSobol[1][c]
+			}
+		}
+
+		return uint64(x>>(32-m))<<m | uint64(y>>(32-m))
+	}
+
+	for m := 1; m <= len(sampler.VdCSobolMatrices); m++ {
+		for c := 0; c < 2*m; c++ {
+			assert.Equal(t, uint64(1)<<c, pixelBits(m, sampler.VdCSobolMatricesInv[m-1][c]), "m %v, c %v", m, c)
+		}
+
+		for c := 0; 2*m+c < sampler.SobolMatrixSize; c++ {
+			assert.Equal(t, pixelBits(m, uint64(1)<<(2*m+c)), sampler.VdCSobolMatrices[m-1][c], "m %v, c %v", m, c)
+		}
+	}
+}
+
+func TestSobolSampler_GetIndexForSample(t *testing.T) {
+	bounds := mymath.NewBounds2i(mymath.NewPoint2i(-4, 10), mymath.NewPoint2i(60, 40))
+	s := sampler.NewSobolSampler(10, bounds)
+	assert.Equal(t, int64(16), s.GetSamplesPerPixel())
+	assert.Equal(t, 64, s.Resolution)
+	assert.Equal(t, 6, s.Log2Resolution)
+
+	for _, p := range []mymath.Point2i{mymath.NewPoint2i(-4, 10), mymath.NewPoint2i(59, 39), mymath.NewPoint2i(42, 17)} {
+		s.StartPixel(p)
+		indices := map[int64]bool{}
+		for i := int64(0); i < 16; i++ {
+			index := s.GetIndexForSample(i)
+			indices[index] = true
+
+			x := sampler.SobolSample(uint32(index), 0, 0) * 64
+			y := sampler.SobolSample(uint32(index), 1, 0) * 64
+			assert.Equal(t, p.X-bounds.PMin.X, int(x))
+			assert.Equal(t, p.Y-bounds.PMin.Y, int(y))
+			assert.InDelta(t, x-math.Floor(x), s.SampleDimension(index, 0), 1e-9)
+		}
+		assert.Len(t, indices, 16)
+	}
+
+	// the pixel samples are stratified
+	assertNet(t, pixelSamples2D(s, mymath.NewPoint2i(20, 30)))
+}
+
+func TestZeroTwoSequenceSampler(t *testing.T) {
+	s := sampler.NewZeroTwoSequenceSampler(50, 2, 0)
+	assert.Equal(t, int64(64), s.GetSamplesPerPixel())
+	assert.Equal(t, 8, s.RoundCount(5))
+
+	assertNet(t, pixelSamples2D(s, mymath.NewPoint2i(0, 0)))
+
+	s.StartPixel(mymath.NewPoint2i(0, 0))
+	strata := map[int]bool{}
+	for {
+		strata[int(s.Get1D()*64)] = true
+		if !s.StartNextSample() {
+			break
+		}
+	}
+	assert.Len(t, strata, 64)
+}
+
+func TestMaxMinDistSampler(t *testing.T) {
+	for _, spp := range []int64{1, 2, 16, 128, 1024} {
+		s := sampler.NewMaxMinDistSampler(spp, 2, 0)
+		assertNet(t, pixelSamples2D(s, mymath.NewPoint2i(0, 0)), "spp %v", spp)
+	}
+}
+
+func TestCMaxMinDist(t *testing.T) {
+	C := sampler.CMaxMinDist[6][:]
+
+	// the points are better spread than those of the plain (0,2)-sequence
+	minDist := func(points []mymath.Point2) float64 {
+		d := math.Inf(1)
+		for i, p1 := range points {
+			for _, p2 := range points[i+1:] {
+				dx := math.Abs(p1.X - p2.X)
+				dy := math.Abs(p1.Y - p2.Y)
+				d = math.Min(d, math.Hypot(math.Min(dx, 1-dx), math.Min(dy, 1-dy)))
+			}
+		}
+		return d
+	}
+
+	maxMin := make([]mymath.Point2, 64)
+	sobol := make([]mymath.Point2, 64)
+	for i := range maxMin {
+		maxMin[i] = mymath.NewPoint2(float64(i)/64, mymath.SampleGeneratorMatrix(C, uint32(i), 0))
+		sobol[i] = mymath.NewPoint2(sampler.SobolSample(uint32(i), 0, 0), sampler.SobolSample(uint32(i), 1, 0))
+	}
+	assert.Greater(t, minDist(maxMin), minDist(sobol))
+}
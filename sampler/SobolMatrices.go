@@ -0,0 +1,423 @@
+package sampler
+
+import (
+	"math"
+	"math/bits"
+	"math/rand"
+	"pbrt-go/mymath"
+	"sync"
+)
+
+// NumSobolDimensions is number of dimensions available in SobolMatrices32
+const NumSobolDimensions = 1024
+
+// SobolMatrixSize is number of columns of each Sobol generator matrix
+const SobolMatrixSize = 32
+
+// sobolMatrices32 holds generator matrices of the Sobol sequence, the column j of the dimension d
+// is at d*SobolMatrixSize + j with the first row in the most significant bit. They are built on the first use
+// by SobolMatrices32.
+var (
+	sobolMatrices32     []uint32
+	sobolMatrices32Once sync.Once
+)
+
+// SobolMatrices32 returns generator matrices of the Sobol sequence, the column j of the dimension d
+// is at d*SobolMatrixSize + j with the first row in the most significant bit.
+//
+// The first dimension is the van der Corput sequence, the others use the primitive polynomials over GF(2)
+// in the increasing order and Sobol's recurrence for the direction numbers, the same construction as the one
+// of Joe and Kuo. pbrt ships their tabulated matrices, which use the initial direction numbers optimized for
+// the two dimensional projections. The initial direction numbers here are only drawn from the fixed seed
+// (the second dimension uses m_1 = 1 to form (0,2)-sequence with the first one), so the matrices have the same
+// layout and the (0,1)-sequence property in each dimension, but they do not match pbrt's values beyond
+// the first two dimensions.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sobolmatrices.h
+// see https://web.maths.unsw.edu.au/~fkuo/sobol/
+func SobolMatrices32() []uint32 {
+	sobolMatrices32Once.Do(func() {
+		sobolMatrices32 = computeSobolMatrices()
+	})
+
+	return sobolMatrices32
+}
+
+// CVanDerCorput is generator matrix of the van der Corput sequence
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/lowdiscrepancy.h
+var CVanDerCorput = [SobolMatrixSize]uint32{
+	0x80000000, 0x40000000, 0x20000000, 0x10000000, 0x08000000, 0x04000000, 0x02000000, 0x01000000,
+	0x00800000, 0x00400000, 0x00200000, 0x00100000, 0x00080000, 0x00040000, 0x00020000, 0x00010000,
+	0x00008000, 0x00004000, 0x00002000, 0x00001000, 0x00000800, 0x00000400, 0x00000200, 0x00000100,
+	0x00000080, 0x00000040, 0x00000020, 0x00000010, 0x00000008, 0x00000004, 0x00000002, 0x00000001,
+}
+
+// CSobol holds generator matrices of the first two Sobol dimensions, they form (0,2)-sequence
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/lowdiscrepancy.h
+var CSobol = [2][SobolMatrixSize]uint32{
+	{
+		0x80000000, 0x40000000, 0x20000000, 0x10000000, 0x08000000, 0x04000000, 0x02000000, 0x01000000,
+		0x00800000, 0x00400000, 0x00200000, 0x00100000, 0x00080000, 0x00040000, 0x00020000, 0x00010000,
+		0x00008000, 0x00004000, 0x00002000, 0x00001000, 0x00000800, 0x00000400, 0x00000200, 0x00000100,
+		0x00000080, 0x00000040, 0x00000020, 0x00000010, 0x00000008, 0x00000004, 0x00000002, 0x00000001,
+	},
+	{
+		0x80000000, 0xc0000000, 0xa0000000, 0xf0000000, 0x88000000, 0xcc000000, 0xaa000000, 0xff000000,
+		0x80800000, 0xc0c00000, 0xa0a00000, 0xf0f00000, 0x88880000, 0xcccc0000, 0xaaaa0000, 0xffff0000,
+		0x80008000, 0xc000c000, 0xa000a000, 0xf000f000, 0x88008800, 0xcc00cc00, 0xaa00aa00, 0xff00ff00,
+		0x80808080, 0xc0c0c0c0, 0xa0a0a0a0, 0xf0f0f0f0, 0x88888888, 0xcccccccc, 0xaaaaaaaa, 0xffffffff,
+	},
+}
+
+// VdCSobolMatrices holds for each resolution 2^m x 2^m (row m-1) the pixel bits (x << m | y) flipped by the index
+// bit 2m+c of the first two Sobol dimensions, column c. The index bits beyond the 32 bits of the matrices are zero.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sobolmatrices.cpp
+var VdCSobolMatrices = [16][SobolMatrixSize]uint64{
+	{
+		0x00000001, 0x00000001, 0x00000001, 0x00000001, 0x00000001, 0x00000001, 0x00000001, 0x00000001,
+		0x00000001, 0x00000001, 0x00000001, 0x00000001, 0x00000001, 0x00000001, 0x00000001, 0x00000001,
+		0x00000001, 0x00000001, 0x00000001, 0x00000001, 0x00000001, 0x00000001, 0x00000001, 0x00000001,
+		0x00000001, 0x00000001, 0x00000001, 0x00000001, 0x00000001, 0x00000001, 0x00000000, 0x00000000,
+	},
+	{
+		0x00000002, 0x00000003, 0x00000002, 0x00000003, 0x00000002, 0x00000003, 0x00000002, 0x00000003,
+		0x00000002, 0x00000003, 0x00000002, 0x00000003, 0x00000002, 0x00000003, 0x00000002, 0x00000003,
+		0x00000002, 0x00000003, 0x00000002, 0x00000003, 0x00000002, 0x00000003, 0x00000002, 0x00000003,
+		0x00000002, 0x00000003, 0x00000002, 0x00000003, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x00000005, 0x00000007, 0x00000004, 0x00000006, 0x00000005, 0x00000007, 0x00000004, 0x00000006,
+		0x00000005, 0x00000007, 0x00000004, 0x00000006, 0x00000005, 0x00000007, 0x00000004, 0x00000006,
+		0x00000005, 0x00000007, 0x00000004, 0x00000006, 0x00000005, 0x00000007, 0x00000004, 0x00000006,
+		0x00000005, 0x00000007, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x00000008, 0x0000000c, 0x0000000a, 0x0000000f, 0x00000008, 0x0000000c, 0x0000000a, 0x0000000f,
+		0x00000008, 0x0000000c, 0x0000000a, 0x0000000f, 0x00000008, 0x0000000c, 0x0000000a, 0x0000000f,
+		0x00000008, 0x0000000c, 0x0000000a, 0x0000000f, 0x00000008, 0x0000000c, 0x0000000a, 0x0000000f,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x00000014, 0x0000001e, 0x00000011, 0x00000019, 0x00000015, 0x0000001f, 0x00000010, 0x00000018,
+		0x00000014, 0x0000001e, 0x00000011, 0x00000019, 0x00000015, 0x0000001f, 0x00000010, 0x00000018,
+		0x00000014, 0x0000001e, 0x00000011, 0x00000019, 0x00000015, 0x0000001f, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x00000022, 0x00000033, 0x0000002a, 0x0000003f, 0x00000020, 0x00000030, 0x00000028, 0x0000003c,
+		0x00000022, 0x00000033, 0x0000002a, 0x0000003f, 0x00000020, 0x00000030, 0x00000028, 0x0000003c,
+		0x00000022, 0x00000033, 0x0000002a, 0x0000003f, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x00000055, 0x0000007f, 0x00000040, 0x00000060, 0x00000050, 0x00000078, 0x00000044, 0x00000066,
+		0x00000055, 0x0000007f, 0x00000040, 0x00000060, 0x00000050, 0x00000078, 0x00000044, 0x00000066,
+		0x00000055, 0x0000007f, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x00000080, 0x000000c0, 0x000000a0, 0x000000f0, 0x00000088, 0x000000cc, 0x000000aa, 0x000000ff,
+		0x00000080, 0x000000c0, 0x000000a0, 0x000000f0, 0x00000088, 0x000000cc, 0x000000aa, 0x000000ff,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x00000140, 0x000001e0, 0x00000110, 0x00000198, 0x00000154, 0x000001fe, 0x00000101, 0x00000181,
+		0x00000141, 0x000001e1, 0x00000111, 0x00000199, 0x00000155, 0x000001ff, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x00000220, 0x00000330, 0x000002a8, 0x000003fc, 0x00000202, 0x00000303, 0x00000282, 0x000003c3,
+		0x00000222, 0x00000333, 0x000002aa, 0x000003ff, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x00000550, 0x000007f8, 0x00000404, 0x00000606, 0x00000505, 0x00000787, 0x00000444, 0x00000666,
+		0x00000555, 0x000007ff, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x00000808, 0x00000c0c, 0x00000a0a, 0x00000f0f, 0x00000888, 0x00000ccc, 0x00000aaa, 0x00000fff,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x00001414, 0x00001e1e, 0x00001111, 0x00001999, 0x00001555, 0x00001fff, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x00002222, 0x00003333, 0x00002aaa, 0x00003fff, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x00005555, 0x00007fff, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+}
+
+// VdCSobolMatricesInv holds for each resolution 2^m x 2^m (row m-1) the lowest 2m index bits that set
+// the pixel bit c of (x << m | y), the inverse of the first 2m columns of the first two Sobol dimensions.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sobolmatrices.cpp
+var VdCSobolMatricesInv = [16][SobolMatrixSize]uint64{
+	{
+		0x00000002, 0x00000003, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x0000000c, 0x00000004, 0x0000000a, 0x00000005, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x00000028, 0x00000030, 0x00000010, 0x0000003c, 0x00000022, 0x00000011, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x000000f0, 0x00000050, 0x00000030, 0x00000010, 0x00000088, 0x00000044, 0x00000022, 0x00000011,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x00000220, 0x000003c0, 0x00000360, 0x00000300, 0x00000100, 0x00000330, 0x000002a8, 0x00000264,
+		0x00000202, 0x00000101, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x00000cc0, 0x00000440, 0x00000f00, 0x00000500, 0x00000300, 0x00000100, 0x00000aa0, 0x00000550,
+		0x00000808, 0x00000404, 0x00000202, 0x00000101, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x00002a80, 0x00003300, 0x00001100, 0x00000f00, 0x00000500, 0x00000300, 0x00000100, 0x00003fc0,
+		0x00002020, 0x00001010, 0x00000808, 0x00000404, 0x00000202, 0x00000101, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x0000ff00, 0x00005500, 0x00003300, 0x00001100, 0x00000f00, 0x00000500, 0x00000300, 0x00000100,
+		0x00008080, 0x00004040, 0x00002020, 0x00001010, 0x00000808, 0x00000404, 0x00000202, 0x00000101,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x00020200, 0x0003fc00, 0x00035600, 0x00033000, 0x00031200, 0x00030c00, 0x00030600, 0x00030000,
+		0x00010000, 0x00030300, 0x00028280, 0x00024240, 0x00022220, 0x00021210, 0x00020a08, 0x00020604,
+		0x00020002, 0x00010001, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x000c0c00, 0x00040400, 0x000ff000, 0x00055000, 0x000f3c00, 0x00051400, 0x000f0000, 0x00050000,
+		0x00030000, 0x00010000, 0x000a0a00, 0x00050500, 0x00088880, 0x00044440, 0x00082820, 0x00041410,
+		0x00080008, 0x00040004, 0x00020002, 0x00010001, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x00282800, 0x00303000, 0x00101000, 0x000ff000, 0x002d7800, 0x00330000, 0x00110000, 0x000f0000,
+		0x00050000, 0x00030000, 0x00010000, 0x003c3c00, 0x00222200, 0x00111100, 0x00088880, 0x00387840,
+		0x00200020, 0x00100010, 0x00080008, 0x00040004, 0x00020002, 0x00010001, 0x00000000, 0x00000000,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x00f0f000, 0x00505000, 0x00303000, 0x00101000, 0x00ff0000, 0x00550000, 0x00330000, 0x00110000,
+		0x000f0000, 0x00050000, 0x00030000, 0x00010000, 0x00888800, 0x00444400, 0x00222200, 0x00111100,
+		0x00800080, 0x00400040, 0x00200020, 0x00100010, 0x00080008, 0x00040004, 0x00020002, 0x00010001,
+		0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x02222000, 0x03c3c000, 0x03636000, 0x03030000, 0x01010000, 0x00ff0000, 0x00550000, 0x00330000,
+		0x00110000, 0x000f0000, 0x00050000, 0x00030000, 0x00010000, 0x03333000, 0x02aaa800, 0x02666400,
+		0x02000200, 0x01000100, 0x00800080, 0x00400040, 0x00200020, 0x00100010, 0x00080008, 0x00040004,
+		0x00020002, 0x00010001, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x0cccc000, 0x04444000, 0x0f0f0000, 0x05050000, 0x03030000, 0x01010000, 0x00ff0000, 0x00550000,
+		0x00330000, 0x00110000, 0x000f0000, 0x00050000, 0x00030000, 0x00010000, 0x0aaaa000, 0x05555000,
+		0x08000800, 0x04000400, 0x02000200, 0x01000100, 0x00800080, 0x00400040, 0x00200020, 0x00100010,
+		0x00080008, 0x00040004, 0x00020002, 0x00010001, 0x00000000, 0x00000000, 0x00000000, 0x00000000,
+	},
+	{
+		0x2aaa8000, 0x33330000, 0x11110000, 0x0f0f0000, 0x05050000, 0x03030000, 0x01010000, 0x00ff0000,
+		0x00550000, 0x00330000, 0x00110000, 0x000f0000, 0x00050000, 0x00030000, 0x00010000, 0x3fffc000,
+		0x20002000, 0x10001000, 0x08000800, 0x04000400, 0x02000200, 0x01000100, 0x00800080, 0x00400040,
+		0x00200020, 0x00100010, 0x00080008, 0x00040004, 0x00020002, 0x00010001, 0x00000000, 0x00000000,
+	},
+	{
+		0xffff0000, 0x55550000, 0x33330000, 0x11110000, 0x0f0f0000, 0x05050000, 0x03030000, 0x01010000,
+		0x00ff0000, 0x00550000, 0x00330000, 0x00110000, 0x000f0000, 0x00050000, 0x00030000, 0x00010000,
+		0x80008000, 0x40004000, 0x20002000, 0x10001000, 0x08000800, 0x04000400, 0x02000200, 0x01000100,
+		0x00800080, 0x00400040, 0x00200020, 0x00100010, 0x00080008, 0x00040004, 0x00020002, 0x00010001,
+	},
+}
+
+// SobolSample returns the dimension of the index-th Sobol sample scrambled by XOR with scramble
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/lowdiscrepancy.h#L284
+func SobolSample(index uint32, dimension int, scramble uint32) float64 {
+	matrices := SobolMatrices32()
+
+	v := scramble
+	for i := dimension * SobolMatrixSize; index != 0; i, index = i+1, index>>1 {
+		if index&1 != 0 {
+			v ^= matrices[i]
+		}
+	}
+
+	return math.Min(float64(v)*0x1p-32, mymath.OneMinusEpsilon)
+}
+
+func computeSobolMatrices() []uint32 {
+	matrices := make([]uint32, 0, NumSobolDimensions*SobolMatrixSize)
+
+	// van der Corput
+	for j := 0; j < SobolMatrixSize; j++ {
+		matrices = append(matrices, 1<<(31-j))
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	polynomials := primitivePolynomials(NumSobolDimensions - 1)
+	for d, poly := range polynomials {
+		degree := bits.Len64(poly) - 1
+
+		// Direction numbers m_k are odd and less than 2^k
+		m := make([]uint64, SobolMatrixSize)
+		for k := 0; k < degree && k < SobolMatrixSize; k++ {
+			if d == 0 {
+				m[k] = 1
+			} else {
+				m[k] = 2*uint64(rng.Int63n(1<<k)) + 1
+			}
+		}
+
+		// m_k = 2 a_1 m_{k-1} ^ 4 a_2 m_{k-2} ^ ... ^ 2^s m_{k-s} ^ m_{k-s}
+		for k := degree; k < SobolMatrixSize; k++ {
+			v := m[k-degree] ^ (m[k-degree] << degree)
+			for i := 1; i < degree; i++ {
+				if poly>>(degree-i)&1 != 0 {
+					v ^= m[k-i] << i
+				}
+			}
+			m[k] = v
+		}
+
+		for k := 0; k < SobolMatrixSize; k++ {
+			matrices = append(matrices, uint32(m[k]<<(31-k)))
+		}
+	}
+
+	return matrices
+}
+
+// primitivePolynomials returns the first n primitive polynomials over GF(2) ordered by degree and value,
+// the bit i holds coefficient of x^i
+func primitivePolynomials(n int) []uint64 {
+	polynomials := make([]uint64, 0, n)
+
+	for degree := 1; len(polynomials) < n; degree++ {
+		order := uint64(1)<<degree - 1
+		factors := primeFactors(order)
+
+		// The constant term of primitive polynomial is always one
+		for poly := uint64(1)<<degree | 1; poly < 1<<(degree+1) && len(polynomials) < n; poly += 2 {
+			if isPrimitive(poly, degree, order, factors) {
+				polynomials = append(polynomials, poly)
+			}
+		}
+	}
+
+	return polynomials
+}
+
+// isPrimitive tells if x has the multiplicative order 2^degree - 1 modulo poly
+func isPrimitive(poly uint64, degree int, order uint64, factors []uint64) bool {
+	if polyPowX(poly, degree, order) != 1 {
+		return false
+	}
+
+	for _, q := range factors {
+		if polyPowX(poly, degree, order/q) == 1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// polyPowX returns x^e modulo poly
+func polyPowX(poly uint64, degree int, e uint64) uint64 {
+	result, base := uint64(1), polyMod(2, poly, degree)
+	for ; e != 0; e >>= 1 {
+		if e&1 != 0 {
+			result = polyMulMod(result, base, poly, degree)
+		}
+		base = polyMulMod(base, base, poly, degree)
+	}
+
+	return result
+}
+
+func polyMulMod(a, b, poly uint64, degree int) uint64 {
+	product := uint64(0)
+	for ; b != 0; b >>= 1 {
+		if b&1 != 0 {
+			product ^= a
+		}
+
+		a = polyMod(a<<1, poly, degree)
+	}
+
+	return product
+}
+
+func polyMod(a, poly uint64, degree int) uint64 {
+	for bits.Len64(a)-1 >= degree {
+		a ^= poly << (bits.Len64(a) - 1 - degree)
+	}
+
+	return a
+}
+
+func primeFactors(v uint64) []uint64 {
+	var factors []uint64
+	for p := uint64(2); p*p <= v; p++ {
+		if v%p == 0 {
+			factors = append(factors, p)
+			for v%p == 0 {
+				v /= p
+			}
+		}
+	}
+
+	if v > 1 {
+		factors = append(factors, v)
+	}
+
+	return factors
+}
@@ -0,0 +1,107 @@
+package sampler
+
+import (
+	"pbrt-go/mymath"
+)
+
+// SobolSampler generates samples of the Sobol sequence, the first two dimensions are spread over the image
+// in the square of power of two size covering the sample bounds
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/samplers/sobol.h
+type SobolSampler struct {
+	GlobalSampler
+	SampleBounds   mymath.Bounds2i
+	Resolution     int
+	Log2Resolution int
+}
+
+// NewSobolSampler creates sampler for the pixels within sampleBounds, samplesPerPixel is rounded up to power of 2
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/samplers/sobol.h#L49
+func NewSobolSampler(samplesPerPixel int64, sampleBounds mymath.Bounds2i) *SobolSampler {
+	diagonal := sampleBounds.Diagonal()
+	resolution := mymath.RoundUpPow2(int64(maxInt(diagonal.X, diagonal.Y)))
+	log2Resolution := mymath.Log2Int(resolution)
+
+	s := &SobolSampler{
+		SampleBounds:   sampleBounds,
+		Resolution:     int(resolution),
+		Log2Resolution: log2Resolution,
+	}
+	s.GlobalSampler = NewGlobalSampler(mymath.RoundUpPow2(samplesPerPixel), s)
+	return s
+}
+
+// GetIndexForSample see https://github.com/mmp/pbrt-v3/blob/master/src/samplers/sobol.cpp#L41
+func (s *SobolSampler) GetIndexForSample(sampleNum int64) int64 {
+	p := mymath.NewPoint2i(s.currentPixel.X-s.SampleBounds.PMin.X, s.currentPixel.Y-s.SampleBounds.PMin.Y)
+	return int64(sobolIntervalToIndex(s.Log2Resolution, uint64(sampleNum), p))
+}
+
+// SampleDimension see https://github.com/mmp/pbrt-v3/blob/master/src/samplers/sobol.cpp#L46
+func (s *SobolSampler) SampleDimension(index int64, dim int) float64 {
+	if dim >= NumSobolDimensions {
+		panic("SobolSampler can only sample up to 1024 dimensions")
+	}
+
+	v := SobolSample(uint32(index), dim, 0)
+
+	// Remap Sobol dimensions used for pixel samples
+	switch dim {
+	case 0:
+		v = v*float64(s.Resolution) + float64(s.SampleBounds.PMin.X)
+		v = mymath.Clamp(v-float64(s.currentPixel.X), 0, mymath.OneMinusEpsilon)
+	case 1:
+		v = v*float64(s.Resolution) + float64(s.SampleBounds.PMin.Y)
+		v = mymath.Clamp(v-float64(s.currentPixel.Y), 0, mymath.OneMinusEpsilon)
+	}
+
+	return v
+}
+
+func (s *SobolSampler) Clone(_ int64) Sampler {
+	c := *s
+	c.GlobalSampler = s.GlobalSampler.clone(&c)
+	return &c
+}
+
+// sobolIntervalToIndex finds the Sobol sample index of the frame-th sample within the pixel p of the 2^m x 2^m grid.
+// The lowest 2m bits of the index select the pixel, they are solved by VdCSobolMatricesInv after removing the pixel
+// bits set by the higher index bits.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/lowdiscrepancy.h#L300
+func sobolIntervalToIndex(m int, frame uint64, p mymath.Point2i) uint64 {
+	if m == 0 {
+		return frame
+	}
+
+	if m > len(VdCSobolMatrices) {
+		panic("SobolSampler can only sample up to 2^16 x 2^16 pixels")
+	}
+
+	m2 := uint(m << 1)
+	index := frame << m2
+
+	delta := uint64(0)
+	for c := 0; frame != 0; frame, c = frame>>1, c+1 {
+		if frame&1 != 0 {
+			delta ^= VdCSobolMatrices[m-1][c]
+		}
+	}
+
+	b := (uint64(p.X)<<uint(m) | uint64(p.Y)) ^ delta
+	for c := 0; b != 0; b, c = b>>1, c+1 {
+		if b&1 != 0 {
+			index ^= VdCSobolMatricesInv[m-1][c]
+		}
+	}
+
+	return index
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
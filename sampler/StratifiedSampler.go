@@ -0,0 +1,62 @@
+package sampler
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/sampling"
+)
+
+// StratifiedSampler divides the pixel into the grid of strata and places one sample into each of them,
+// with jitter the sample is randomly offset within its stratum, otherwise it is at the stratum center
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/samplers/stratified.h
+type StratifiedSampler struct {
+	PixelSampler
+	XPixelSamples, YPixelSamples int
+	JitterSamples                bool
+}
+
+func NewStratifiedSampler(xPixelSamples, yPixelSamples int, jitterSamples bool, nSampledDimensions int, seed int64) *StratifiedSampler {
+	return &StratifiedSampler{
+		PixelSampler:  NewPixelSampler(int64(xPixelSamples*yPixelSamples), nSampledDimensions, seed),
+		XPixelSamples: xPixelSamples,
+		YPixelSamples: yPixelSamples,
+		JitterSamples: jitterSamples,
+	}
+}
+
+// StartPixel see https://github.com/mmp/pbrt-v3/blob/master/src/samplers/stratified.cpp#L43
+func (s *StratifiedSampler) StartPixel(p mymath.Point2i) {
+	nSamples := s.XPixelSamples * s.YPixelSamples
+
+	// Generate single stratified samples for the pixel
+	for _, samples := range s.samples1D {
+		sampling.StratifiedSample1D(samples, s.rng, s.JitterSamples)
+		sampling.Shuffle(samples, nSamples, 1, s.rng)
+	}
+	for _, samples := range s.samples2D {
+		sampling.StratifiedSample2D(samples, s.XPixelSamples, s.YPixelSamples, s.rng, s.JitterSamples)
+		sampling.Shuffle2D(samples, nSamples, 1, s.rng)
+	}
+
+	// Generate arrays of stratified samples for the pixel
+	for i, count := range s.samples1DArraySizes {
+		for j := 0; j < nSamples; j++ {
+			samples := s.sampleArray1D[i][j*count : (j+1)*count]
+			sampling.StratifiedSample1D(samples, s.rng, s.JitterSamples)
+			sampling.Shuffle(samples, count, 1, s.rng)
+		}
+	}
+	for i, count := range s.samples2DArraySizes {
+		for j := 0; j < nSamples; j++ {
+			sampling.LatinHypercube2D(s.sampleArray2D[i][j*count:(j+1)*count], s.rng)
+		}
+	}
+
+	s.PixelSampler.StartPixel(p)
+}
+
+func (s *StratifiedSampler) Clone(seed int64) Sampler {
+	c := *s
+	c.PixelSampler = s.PixelSampler.clone(seed)
+	return &c
+}
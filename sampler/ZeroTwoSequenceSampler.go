@@ -0,0 +1,47 @@
+package sampler
+
+import "pbrt-go/mymath"
+
+// ZeroTwoSequenceSampler uses randomly scrambled van der Corput sequence for the 1D samples and (0,2)-sequence
+// for the 2D samples, the number of samples is rounded up to power of 2
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/samplers/zerotwosequence.h
+type ZeroTwoSequenceSampler struct {
+	PixelSampler
+}
+
+func NewZeroTwoSequenceSampler(samplesPerPixel int64, nSampledDimensions int, seed int64) *ZeroTwoSequenceSampler {
+	return &ZeroTwoSequenceSampler{NewPixelSampler(mymath.RoundUpPow2(samplesPerPixel), nSampledDimensions, seed)}
+}
+
+// StartPixel see https://github.com/mmp/pbrt-v3/blob/master/src/samplers/zerotwosequence.cpp#L50
+func (s *ZeroTwoSequenceSampler) StartPixel(p mymath.Point2i) {
+	spp := int(s.SamplesPerPixel)
+
+	// Generate 1D and 2D pixel sample components using (0,2)-sequence
+	for _, samples := range s.samples1D {
+		vanDerCorput(1, spp, samples, s.rng)
+	}
+	for _, samples := range s.samples2D {
+		sobol2D(1, spp, samples, s.rng)
+	}
+
+	// Generate 1D and 2D array samples using (0,2)-sequence
+	for i, count := range s.samples1DArraySizes {
+		vanDerCorput(count, spp, s.sampleArray1D[i], s.rng)
+	}
+	for i, count := range s.samples2DArraySizes {
+		sobol2D(count, spp, s.sampleArray2D[i], s.rng)
+	}
+
+	s.PixelSampler.StartPixel(p)
+}
+
+// RoundCount returns power of 2, the (0,2)-sequence is well distributed only for such counts
+func (s *ZeroTwoSequenceSampler) RoundCount(n int) int {
+	return int(mymath.RoundUpPow2(int64(n)))
+}
+
+func (s *ZeroTwoSequenceSampler) Clone(seed int64) Sampler {
+	return &ZeroTwoSequenceSampler{s.PixelSampler.clone(seed)}
+}
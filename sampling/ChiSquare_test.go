@@ -0,0 +1,121 @@
+package sampling_test
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// chiSquareSignificance is the probability of rejecting the correct sampler
+const chiSquareSignificance = 0.01
+
+// assertChiSquare checks that the observed histogram matches the expected frequencies, the bins with small
+// expected frequencies are pooled together
+//
+// see https://github.com/mitsuba-renderer/mitsuba/blob/master/src/libcore/chisquare.cpp
+func assertChiSquare(t *testing.T, observed, expected []float64, msgAndArgs ...interface{}) bool {
+	indices := make([]int, len(expected))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.Slice(indices, func(i, j int) bool { return expected[indices[i]] < expected[indices[j]] })
+
+	var pooledObserved, pooledExpected, chsq float64
+	pooledBins, dof := 0, 0
+	for _, i := range indices {
+		if expected[i] == 0 {
+			if observed[i] > 0 {
+				return assert.Fail(t, "sample in the bin with zero probability", msgAndArgs...)
+			}
+			continue
+		}
+
+		if expected[i] < 5 {
+			pooledObserved += observed[i]
+			pooledExpected += expected[i]
+			pooledBins++
+			continue
+		}
+
+		if pooledBins > 0 && pooledExpected < 5 {
+			// merge the rest of the small bins into the current one
+			pooledObserved += observed[i]
+			pooledExpected += expected[i]
+			pooledBins++
+			continue
+		}
+
+		diff := observed[i] - expected[i]
+		chsq += diff * diff / expected[i]
+		dof++
+	}
+
+	if pooledBins > 0 {
+		diff := pooledObserved - pooledExpected
+		chsq += diff * diff / pooledExpected
+		dof++
+	}
+	dof--
+
+	pValue := regularizedGammaQ(float64(dof)/2, chsq/2)
+	return assert.Greater(t, pValue, chiSquareSignificance, msgAndArgs...)
+}
+
+// regularizedGammaQ returns the upper regularized incomplete gamma function Q(a, x), it is the chi-square
+// tail probability for a = dof/2 and x = chsq/2
+func regularizedGammaQ(a, x float64) float64 {
+	lgammaA, _ := math.Lgamma(a)
+
+	if x < a+1 {
+		// Series expansion of P(a, x)
+		sum, term := 1/a, 1/a
+		for n := 1; n < 1000; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*1e-15 {
+				break
+			}
+		}
+		return 1 - sum*math.Exp(-x+a*math.Log(x)-lgammaA)
+	}
+
+	// Continued fraction of Q(a, x) by modified Lentz's method
+	tiny := 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1; i < 1000; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < 1e-15 {
+			break
+		}
+	}
+
+	return math.Exp(-x+a*math.Log(x)-lgammaA) * h
+}
+
+func TestRegularizedGammaQ(t *testing.T) {
+	// Q(1, x) = exp(-x)
+	assert.InDelta(t, math.Exp(-0.5), regularizedGammaQ(1, 0.5), 1e-12)
+	assert.InDelta(t, math.Exp(-7), regularizedGammaQ(1, 7), 1e-12)
+
+	// chi-square with 2 dof: 95 % quantile is 5.991
+	assert.InDelta(t, 0.05, regularizedGammaQ(1, 5.991/2), 1e-4)
+	// chi-square with 10 dof: 99 % quantile is 23.209
+	assert.InDelta(t, 0.01, regularizedGammaQ(5, 23.209/2), 1e-4)
+}
@@ -0,0 +1,149 @@
+package sampling
+
+import (
+	"pbrt-go/mymath"
+)
+
+// Distribution1D samples piecewise-constant function over [0, 1] proportionally to its values
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.h#L56
+type Distribution1D struct {
+	Func, Cdf []float64
+	FuncInt   float64
+}
+
+// NewDistribution1D creates distribution of the non-negative function values f over the equal sized segments
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.h#L58
+func NewDistribution1D(f []float64) *Distribution1D {
+	n := len(f)
+	d := &Distribution1D{
+		Func: append([]float64(nil), f...),
+		Cdf:  make([]float64, n+1),
+	}
+
+	// Compute integral of step function at x_i
+	for i := 1; i < n+1; i++ {
+		d.Cdf[i] = d.Cdf[i-1] + d.Func[i-1]/float64(n)
+	}
+
+	// Transform step function integral into CDF
+	d.FuncInt = d.Cdf[n]
+	if d.FuncInt == 0 {
+		for i := 1; i < n+1; i++ {
+			d.Cdf[i] = float64(i) / float64(n)
+		}
+	} else {
+		for i := 1; i < n+1; i++ {
+			d.Cdf[i] /= d.FuncInt
+		}
+	}
+
+	return d
+}
+
+func (d *Distribution1D) Count() int {
+	return len(d.Func)
+}
+
+// SampleContinuous returns sample x in [0, 1), its density and the index of the segment containing x
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.h#L77
+func (d *Distribution1D) SampleContinuous(u float64) (float64, float64, int) {
+	// Find surrounding CDF segments and offset
+	offset := d.findSegment(u)
+
+	// Compute offset along CDF segment
+	du := u - d.Cdf[offset]
+	if d.Cdf[offset+1]-d.Cdf[offset] > 0 {
+		du /= d.Cdf[offset+1] - d.Cdf[offset]
+	}
+
+	// Compute PDF for sampled offset
+	pdf := 0.0
+	if d.FuncInt > 0 {
+		pdf = d.Func[offset] / d.FuncInt
+	}
+
+	// Return x in [0,1) corresponding to sample
+	return (float64(offset) + du) / float64(d.Count()), pdf, offset
+}
+
+// SampleDiscrete returns segment index chosen proportionally to the function value, its probability
+// and u remapped to [0, 1) within the chosen segment so that it can be reused
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.h#L99
+func (d *Distribution1D) SampleDiscrete(u float64) (int, float64, float64) {
+	// Find surrounding CDF segments and offset
+	offset := d.findSegment(u)
+
+	pdf := 0.0
+	if d.FuncInt > 0 {
+		pdf = d.Func[offset] / (d.FuncInt * float64(d.Count()))
+	}
+
+	uRemapped := (u - d.Cdf[offset]) / (d.Cdf[offset+1] - d.Cdf[offset])
+	return offset, pdf, uRemapped
+}
+
+// DiscretePDF returns probability of SampleDiscrete choosing the segment index
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.h#L112
+func (d *Distribution1D) DiscretePDF(index int) float64 {
+	return d.Func[index] / (d.FuncInt * float64(d.Count()))
+}
+
+func (d *Distribution1D) findSegment(u float64) int {
+	return mymath.FindInterval(len(d.Cdf), func(index int) bool {
+		return d.Cdf[index] <= u
+	})
+}
+
+// Distribution2D samples piecewise-constant function over [0, 1]^2, it samples v from the marginal distribution
+// and then u from the conditional distribution of the chosen row
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.h#L123
+type Distribution2D struct {
+	PConditionalV []*Distribution1D
+	PMarginal     *Distribution1D
+}
+
+// NewDistribution2D creates distribution of the function values f stored in nv rows of nu values
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.cpp#L239
+func NewDistribution2D(f []float64, nu, nv int) *Distribution2D {
+	d := &Distribution2D{}
+
+	// Compute conditional sampling distribution for v
+	marginalFunc := make([]float64, nv)
+	for v := 0; v < nv; v++ {
+		conditional := NewDistribution1D(f[v*nu : (v+1)*nu])
+		d.PConditionalV = append(d.PConditionalV, conditional)
+		marginalFunc[v] = conditional.FuncInt
+	}
+
+	// Compute marginal sampling distribution p[v]
+	d.PMarginal = NewDistribution1D(marginalFunc)
+	return d
+}
+
+// SampleContinuous returns sample in [0, 1)^2 and its density
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.h#L130
+func (d *Distribution2D) SampleContinuous(u mymath.Point2) (mymath.Point2, float64) {
+	d1, pdf1, v := d.PMarginal.SampleContinuous(u.Y)
+	d0, pdf0, _ := d.PConditionalV[v].SampleContinuous(u.X)
+
+	return mymath.NewPoint2(d0, d1), pdf0 * pdf1
+}
+
+// Pdf returns density of sampling the point p
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.h#L137
+func (d *Distribution2D) Pdf(p mymath.Point2) float64 {
+	nu, nv := d.PConditionalV[0].Count(), d.PMarginal.Count()
+	iu := int(mymath.Clamp(float64(int(p.X*float64(nu))), 0, float64(nu-1)))
+	iv := int(mymath.Clamp(float64(int(p.Y*float64(nv))), 0, float64(nv-1)))
+
+	return d.PConditionalV[iv].Func[iu] / d.PMarginal.FuncInt
+}
@@ -0,0 +1,117 @@
+package sampling_test
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/sampling"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDistribution1D(t *testing.T) {
+	d := sampling.NewDistribution1D([]float64{1, 2, 0, 4, 3})
+
+	assert.Equal(t, 5, d.Count())
+	assert.InDelta(t, 2, d.FuncInt, 1e-12)
+	assert.InDeltaSlice(t, []float64{0, 0.1, 0.3, 0.3, 0.7, 1}, d.Cdf, 1e-12)
+	assert.InDelta(t, 0.4, d.DiscretePDF(3), 1e-12)
+
+	// zero function is sampled uniformly
+	d = sampling.NewDistribution1D([]float64{0, 0})
+	assert.Equal(t, []float64{0, 0.5, 1}, d.Cdf)
+	x, pdf, offset := d.SampleContinuous(0.75)
+	assert.Equal(t, 0.75, x)
+	assert.Equal(t, 0.0, pdf)
+	assert.Equal(t, 1, offset)
+}
+
+func TestDistribution1D_SampleContinuous(t *testing.T) {
+	f := []float64{1, 2, 0, 4, 3}
+	d := sampling.NewDistribution1D(f)
+
+	x, pdf, offset := d.SampleContinuous(0.5)
+	assert.Equal(t, 3, offset)
+	assert.InDelta(t, 0.7, x, 1e-12)
+	assert.InDelta(t, 2, pdf, 1e-12)
+
+	const bins = 50
+	rng := sampling.NewRNG()
+	observed := make([]float64, bins)
+	for i := 0; i < chiSquareSamples; i++ {
+		x, pdf, offset := d.SampleContinuous(rng.UniformFloat())
+		assert.Equal(t, offset, int(x*5))
+		assert.InDelta(t, f[offset]/d.FuncInt, pdf, 1e-12)
+		observed[int(x*bins)]++
+	}
+
+	expected := make([]float64, bins)
+	for i := range expected {
+		expected[i] = f[i*5/bins] / d.FuncInt / bins * chiSquareSamples
+	}
+	assertChiSquare(t, observed, expected)
+}
+
+func TestDistribution1D_SampleDiscrete(t *testing.T) {
+	f := []float64{1, 2, 0, 4, 3}
+	d := sampling.NewDistribution1D(f)
+
+	offset, pdf, uRemapped := d.SampleDiscrete(0.5)
+	assert.Equal(t, 3, offset)
+	assert.InDelta(t, 0.4, pdf, 1e-12)
+	assert.InDelta(t, 0.5, uRemapped, 1e-12)
+
+	rng := sampling.NewRNG()
+	observed := make([]float64, len(f))
+	remapped := make([]float64, 10)
+	for i := 0; i < chiSquareSamples; i++ {
+		offset, pdf, uRemapped := d.SampleDiscrete(rng.UniformFloat())
+		assert.Equal(t, d.DiscretePDF(offset), pdf)
+		observed[offset]++
+		remapped[int(uRemapped*10)]++
+	}
+
+	expected := make([]float64, len(f))
+	for i := range expected {
+		expected[i] = d.DiscretePDF(i) * chiSquareSamples
+	}
+	assertChiSquare(t, observed, expected)
+
+	// remapped value is uniform again
+	uniform := make([]float64, 10)
+	for i := range uniform {
+		uniform[i] = chiSquareSamples / 10
+	}
+	assertChiSquare(t, remapped, uniform)
+}
+
+func TestDistribution2D(t *testing.T) {
+	const nu, nv = 4, 3
+	f := []float64{
+		1, 2, 0, 4,
+		0, 0, 0, 0,
+		3, 1, 1, 5,
+	}
+	d := sampling.NewDistribution2D(f, nu, nv)
+	assert.Len(t, d.PConditionalV, nv)
+	assert.InDelta(t, 17./12., d.PMarginal.FuncInt, 1e-12)
+	assert.InDelta(t, 5/d.PMarginal.FuncInt, d.Pdf(mymath.NewPoint2(0.9, 0.9)), 1e-12)
+	assert.Equal(t, 0.0, d.Pdf(mymath.NewPoint2(0.5, 0.5)))
+
+	const binsU, binsV = 8, 6
+	rng := sampling.NewRNG()
+	observed := make([]float64, binsU*binsV)
+	for i := 0; i < chiSquareSamples; i++ {
+		p, pdf := d.SampleContinuous(mymath.NewPoint2(rng.UniformFloat(), rng.UniformFloat()))
+		assert.InDelta(t, d.Pdf(p), pdf, 1e-9)
+		observed[int(p.Y*binsV)*binsU+int(p.X*binsU)]++
+	}
+
+	expected := make([]float64, binsU*binsV)
+	for v := 0; v < binsV; v++ {
+		for u := 0; u < binsU; u++ {
+			center := mymath.NewPoint2((float64(u)+0.5)/binsU, (float64(v)+0.5)/binsV)
+			expected[v*binsU+u] = d.Pdf(center) / binsU / binsV * chiSquareSamples
+		}
+	}
+	assertChiSquare(t, observed, expected)
+}
@@ -0,0 +1,92 @@
+package sampling
+
+import (
+	"math"
+	"pbrt-go/mymath"
+)
+
+const (
+	pcg32DefaultState  = 0x853c49e6748fea9b
+	pcg32DefaultStream = 0xda3e39cb94b95bdb
+	pcg32Mult          = 0x5851f42d4c957f2d
+)
+
+// RNG is PCG32 pseudo-random number generator by M. E. O'Neill, it generates the same sequences as pbrt-v3
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/rng.h
+type RNG struct {
+	state, inc uint64
+}
+
+// NewRNG creates generator with the default state and stream
+func NewRNG() *RNG {
+	return &RNG{pcg32DefaultState, pcg32DefaultStream}
+}
+
+// NewRNGSequence creates generator for the given sequence
+func NewRNGSequence(sequenceIndex uint64) *RNG {
+	rng := &RNG{}
+	rng.SetSequence(sequenceIndex)
+	return rng
+}
+
+// SetSequence selects one of the 2^63 independent sequences and restarts it
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/rng.h#L118
+func (rng *RNG) SetSequence(initSeq uint64) {
+	rng.state = 0
+	rng.inc = initSeq<<1 | 1
+	rng.UniformUInt32()
+	rng.state += pcg32DefaultState
+	rng.UniformUInt32()
+}
+
+// UniformUInt32 returns uniformly distributed value from the full uint32 range
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/rng.h#L126
+func (rng *RNG) UniformUInt32() uint32 {
+	oldState := rng.state
+	rng.state = oldState*pcg32Mult + rng.inc
+	xorShifted := uint32(((oldState >> 18) ^ oldState) >> 27)
+	rot := uint32(oldState >> 59)
+	return (xorShifted >> rot) | (xorShifted << ((^rot + 1) & 31))
+}
+
+// UniformUInt32Bounded returns uniformly distributed value in [0, b) without the modulo bias
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/rng.h#L133
+func (rng *RNG) UniformUInt32Bounded(b uint32) uint32 {
+	threshold := (^b + 1) % b
+	for {
+		r := rng.UniformUInt32()
+		if r >= threshold {
+			return r % b
+		}
+	}
+}
+
+// UniformFloat returns uniformly distributed value in [0, 1)
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/rng.h#L141
+func (rng *RNG) UniformFloat() float64 {
+	return math.Min(mymath.OneMinusEpsilon, float64(rng.UniformUInt32())*0x1p-32)
+}
+
+// Advance skips delta values of the sequence in O(log delta) steps, negative delta moves backwards
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/rng.h#L155
+func (rng *RNG) Advance(delta int64) {
+	curMult, curPlus := uint64(pcg32Mult), rng.inc
+	accMult, accPlus := uint64(1), uint64(0)
+
+	for d := uint64(delta); d > 0; d /= 2 {
+		if d&1 != 0 {
+			accMult *= curMult
+			accPlus = accPlus*curMult + curPlus
+		}
+		curPlus = (curMult + 1) * curPlus
+		curMult *= curMult
+	}
+
+	rng.state = accMult*rng.state + accPlus
+}
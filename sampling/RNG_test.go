@@ -0,0 +1,117 @@
+package sampling_test
+
+import (
+	"pbrt-go/sampling"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The reference values in the tests below are printed by pbrt-v3 RNG class (Float being float)
+
+func TestRNG_UniformUInt32(t *testing.T) {
+	rng := sampling.NewRNG()
+	for _, e := range []uint32{0x152ca78d, 0x027c6003, 0xcb07bbf3, 0xf98befee, 0x1cd777e3, 0xa4e29590} {
+		assert.Equal(t, e, rng.UniformUInt32())
+	}
+
+	// RNG rng(7)
+	rng = sampling.NewRNGSequence(7)
+	for _, e := range []uint32{0x8afcb028, 0x91074212, 0x8fe2d8b6, 0xe21bced9, 0x578c3e61, 0x2fef0cd1} {
+		assert.Equal(t, e, rng.UniformUInt32())
+	}
+
+	// rng.SetSequence(54) restarts the generator
+	rng.SetSequence(54)
+	for _, e := range []uint32{0x17db8d6f, 0x1c505f00, 0xe103320b, 0xe3b3081f, 0x2b251581, 0xa29ffcdf} {
+		assert.Equal(t, e, rng.UniformUInt32())
+	}
+}
+
+func TestRNG_UniformFloat_reference(t *testing.T) {
+	rng := sampling.NewRNGSequence(3)
+
+	// pbrt multiplies in float32, so the values match only to its precision
+	for _, e := range []float64{0.756358981, 0.0922442302, 0.964055479, 0.854135811, 0.325112343, 0.0628864691} {
+		assert.InDelta(t, e, rng.UniformFloat(), 1e-7)
+	}
+
+	rng = sampling.NewRNGSequence(3)
+	for _, e := range []uint32{4, 0, 6, 6, 1, 0, 4, 2} {
+		assert.Equal(t, e, rng.UniformUInt32Bounded(7))
+	}
+}
+
+func TestRNG_Advance_reference(t *testing.T) {
+	rng := sampling.NewRNGSequence(11)
+
+	rng.Advance(1000)
+	for _, e := range []uint32{0x9ad92f7d, 0x77bb372d, 0x28575931} {
+		assert.Equal(t, e, rng.UniformUInt32())
+	}
+
+	rng.Advance(-503)
+	for _, e := range []uint32{0xc6ee0bba, 0x60ba2673, 0xc7ddbb5f} {
+		assert.Equal(t, e, rng.UniformUInt32())
+	}
+}
+
+func TestRNG_SetSequence(t *testing.T) {
+	rng1 := sampling.NewRNGSequence(7)
+	rng2 := sampling.NewRNG()
+	rng2.SetSequence(7)
+	rng3 := sampling.NewRNGSequence(8)
+
+	different := false
+	for i := 0; i < 100; i++ {
+		v := rng1.UniformUInt32()
+		assert.Equal(t, v, rng2.UniformUInt32())
+		different = different || v != rng3.UniformUInt32()
+	}
+	assert.True(t, different)
+}
+
+func TestRNG_UniformUInt32Bounded(t *testing.T) {
+	rng := sampling.NewRNG()
+
+	counts := make([]int, 7)
+	for i := 0; i < 70000; i++ {
+		counts[rng.UniformUInt32Bounded(7)]++
+	}
+
+	for _, c := range counts {
+		assert.InDelta(t, 10000, c, 400)
+	}
+}
+
+func TestRNG_UniformFloat(t *testing.T) {
+	rng := sampling.NewRNG()
+
+	sum := 0.0
+	for i := 0; i < 100000; i++ {
+		v := rng.UniformFloat()
+		assert.True(t, v >= 0 && v < 1)
+		sum += v
+	}
+	assert.InDelta(t, 0.5, sum/100000, 0.005)
+}
+
+func TestRNG_Advance(t *testing.T) {
+	rng := sampling.NewRNGSequence(3)
+	reference := *rng
+
+	values := make([]uint32, 1000)
+	for i := range values {
+		values[i] = rng.UniformUInt32()
+	}
+
+	for _, delta := range []int64{0, 1, 17, 999} {
+		r := reference
+		r.Advance(delta)
+		assert.Equal(t, values[delta], r.UniformUInt32(), "delta %v", delta)
+	}
+
+	// backwards
+	rng.Advance(-500)
+	assert.Equal(t, values[500], rng.UniformUInt32())
+}
@@ -0,0 +1,119 @@
+package sampling
+
+import (
+	"math"
+	"pbrt-go/mymath"
+)
+
+// StratifiedSample1D fills samp with one sample per stratum of the equal sized strata, without jitter
+// the samples are at the strata centers
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.cpp#L43
+func StratifiedSample1D(samp []float64, rng *RNG, jitter bool) {
+	invNSamples := 1 / float64(len(samp))
+	for i := range samp {
+		delta := 0.5
+		if jitter {
+			delta = rng.UniformFloat()
+		}
+		samp[i] = math.Min((float64(i)+delta)*invNSamples, mymath.OneMinusEpsilon)
+	}
+}
+
+// StratifiedSample2D fills samp with one sample per cell of the nx*ny grid
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.cpp#L52
+func StratifiedSample2D(samp []mymath.Point2, nx, ny int, rng *RNG, jitter bool) {
+	dx, dy := 1/float64(nx), 1/float64(ny)
+	i := 0
+	for y := 0; y < ny; y++ {
+		for x := 0; x < nx; x++ {
+			jx, jy := 0.5, 0.5
+			if jitter {
+				jx, jy = rng.UniformFloat(), rng.UniformFloat()
+			}
+			samp[i] = mymath.NewPoint2(
+				math.Min((float64(x)+jx)*dx, mymath.OneMinusEpsilon),
+				math.Min((float64(y)+jy)*dy, mymath.OneMinusEpsilon))
+			i++
+		}
+	}
+}
+
+// LatinHypercube fills samples with nSamples points of nDim dimensions, each dimension has exactly one sample
+// in each of the nSamples strata
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.cpp#L65
+func LatinHypercube(samples []float64, nSamples, nDim int, rng *RNG) {
+	// Generate LHS samples along diagonal
+	invNSamples := 1 / float64(nSamples)
+	for i := 0; i < nSamples; i++ {
+		for j := 0; j < nDim; j++ {
+			sj := (float64(i) + rng.UniformFloat()) * invNSamples
+			samples[nDim*i+j] = math.Min(sj, mymath.OneMinusEpsilon)
+		}
+	}
+
+	// Permute LHS samples in each dimension
+	for i := 0; i < nDim; i++ {
+		for j := 0; j < nSamples; j++ {
+			other := j + int(rng.UniformUInt32Bounded(uint32(nSamples-j)))
+			samples[nDim*j+i], samples[nDim*other+i] = samples[nDim*other+i], samples[nDim*j+i]
+		}
+	}
+}
+
+// LatinHypercube2D is LatinHypercube for 2D points
+func LatinHypercube2D(samples []mymath.Point2, rng *RNG) {
+	// Generate LHS samples along diagonal
+	invNSamples := 1 / float64(len(samples))
+	for i := range samples {
+		samples[i] = mymath.NewPoint2(
+			math.Min((float64(i)+rng.UniformFloat())*invNSamples, mymath.OneMinusEpsilon),
+			math.Min((float64(i)+rng.UniformFloat())*invNSamples, mymath.OneMinusEpsilon))
+	}
+
+	// Permute LHS samples in each dimension
+	for i := range samples {
+		other := i + int(rng.UniformUInt32Bounded(uint32(len(samples)-i)))
+		samples[i].X, samples[other].X = samples[other].X, samples[i].X
+	}
+	for i := range samples {
+		other := i + int(rng.UniformUInt32Bounded(uint32(len(samples)-i)))
+		samples[i].Y, samples[other].Y = samples[other].Y, samples[i].Y
+	}
+}
+
+// Shuffle randomly permutes count blocks of nDimensions values
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.h#L110
+func Shuffle(samp []float64, count, nDimensions int, rng *RNG) {
+	for i := 0; i < count; i++ {
+		other := i + int(rng.UniformUInt32Bounded(uint32(count-i)))
+		for j := 0; j < nDimensions; j++ {
+			samp[nDimensions*i+j], samp[nDimensions*other+j] = samp[nDimensions*other+j], samp[nDimensions*i+j]
+		}
+	}
+}
+
+// Shuffle2D is Shuffle for 2D points
+func Shuffle2D(samp []mymath.Point2, count, nDimensions int, rng *RNG) {
+	for i := 0; i < count; i++ {
+		other := i + int(rng.UniformUInt32Bounded(uint32(count-i)))
+		for j := 0; j < nDimensions; j++ {
+			samp[nDimensions*i+j], samp[nDimensions*other+j] = samp[nDimensions*other+j], samp[nDimensions*i+j]
+		}
+	}
+}
+
+// RejectionSampleDisk samples the unit disk by drawing points of the enclosing square until one falls inside
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/sampling.cpp#L99
+func RejectionSampleDisk(rng *RNG) mymath.Point2 {
+	for {
+		p := mymath.NewPoint2(1-2*rng.UniformFloat(), 1-2*rng.UniformFloat())
+		if p.X*p.X+p.Y*p.Y <= 1 {
+			return p
+		}
+	}
+}
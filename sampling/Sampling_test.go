@@ -0,0 +1,303 @@
+package sampling_test
+
+import (
+	"math"
+	"pbrt-go/mymath"
+	"pbrt-go/sampling"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	chiSquareSamples = 100000
+	thetaBins        = 20
+	phiBins          = 40
+)
+
+// assertDirectionPdf compares histogram of the sampled directions with the solid angle density pdf, the bins are
+// uniform in cos(theta) and phi so that each of them covers the same solid angle
+func assertDirectionPdf(t *testing.T, sample func(u mymath.Point2) mymath.Vector3, pdf func(w mymath.Vector3) float64, name string) {
+	rng := sampling.NewRNG()
+	observed := make([]float64, thetaBins*phiBins)
+	for i := 0; i < chiSquareSamples; i++ {
+		w := sample(mymath.NewPoint2(rng.UniformFloat(), rng.UniformFloat()))
+		assert.InDelta(t, 1, w.Length(), 1e-9, name)
+
+		z := int(mymath.Clamp((w.Z+1)/2*thetaBins, 0, thetaBins-1))
+		phi := int(mymath.Clamp(mymath.SphericalPhi(w)/(2*math.Pi)*phiBins, 0, phiBins-1))
+		observed[z*phiBins+phi]++
+	}
+
+	// Integrate the density over each bin, dw = dz dphi
+	const sub = 8
+	dz, dphi := 2./thetaBins/sub, 2*math.Pi/phiBins/sub
+	expected := make([]float64, thetaBins*phiBins)
+	for zi := 0; zi < thetaBins*sub; zi++ {
+		for phii := 0; phii < phiBins*sub; phii++ {
+			z := -1 + (float64(zi)+0.5)*dz
+			phi := (float64(phii) + 0.5) * dphi
+			sinTheta := math.Sqrt(1 - z*z)
+			w := mymath.NewVector3(sinTheta*math.Cos(phi), sinTheta*math.Sin(phi), z)
+
+			expected[(zi/sub)*phiBins+phii/sub] += pdf(w) * dz * dphi * chiSquareSamples
+		}
+	}
+
+	assertChiSquare(t, observed, expected, name)
+}
+
+func TestUniformSampleSphere(t *testing.T) {
+	assertDirectionPdf(t, mymath.UniformSampleSphere, func(w mymath.Vector3) float64 {
+		return mymath.UniformSpherePdf()
+	}, "sphere")
+}
+
+func TestUniformSampleHemisphere(t *testing.T) {
+	assertDirectionPdf(t, mymath.UniformSampleHemisphere, func(w mymath.Vector3) float64 {
+		if w.Z < 0 {
+			return 0
+		}
+		return mymath.UniformHemispherePdf()
+	}, "hemisphere")
+}
+
+func TestCosineSampleHemisphere(t *testing.T) {
+	assertDirectionPdf(t, mymath.CosineSampleHemisphere, func(w mymath.Vector3) float64 {
+		return math.Max(0, mymath.CosineHemispherePdf(w.Z))
+	}, "cosine hemisphere")
+}
+
+func TestUniformSampleCone(t *testing.T) {
+	// the cone boundary lies on the bin boundary
+	cosThetaMax := 0.5
+	assertDirectionPdf(t, func(u mymath.Point2) mymath.Vector3 {
+		return mymath.UniformSampleCone(u, cosThetaMax)
+	}, func(w mymath.Vector3) float64 {
+		if w.Z < cosThetaMax {
+			return 0
+		}
+		return mymath.UniformConePdf(cosThetaMax)
+	}, "cone")
+}
+
+func TestUniformSampleConeFrame(t *testing.T) {
+	z := mymath.NewVector3(1, 2, -1).Normalize()
+	x, y := z.CoordinateSystem()
+	cosThetaMax := 0.5
+
+	// direction mapped back to the local frame follows the cone distribution
+	assertDirectionPdf(t, func(u mymath.Point2) mymath.Vector3 {
+		w := mymath.UniformSampleConeFrame(u, cosThetaMax, x, y, z)
+		return mymath.NewVector3(w.Dot(x), w.Dot(y), w.Dot(z))
+	}, func(w mymath.Vector3) float64 {
+		if w.Z < cosThetaMax {
+			return 0
+		}
+		return mymath.UniformConePdf(cosThetaMax)
+	}, "cone frame")
+}
+
+// assertDiskUniform bins the disk samples by r^2 and angle, each bin covers the same area of the disk
+func assertDiskUniform(t *testing.T, sample func(u mymath.Point2) mymath.Point2, name string) {
+	const rBins, angleBins = 10, 20
+
+	rng := sampling.NewRNG()
+	observed := make([]float64, rBins*angleBins)
+	for i := 0; i < chiSquareSamples; i++ {
+		p := sample(mymath.NewPoint2(rng.UniformFloat(), rng.UniformFloat()))
+		r2 := p.X*p.X + p.Y*p.Y
+		assert.LessOrEqual(t, r2, 1+1e-12, name)
+
+		angle := math.Atan2(p.Y, p.X)
+		if angle < 0 {
+			angle += 2 * math.Pi
+		}
+
+		ri := int(mymath.Clamp(r2*rBins, 0, rBins-1))
+		ai := int(mymath.Clamp(angle/(2*math.Pi)*angleBins, 0, angleBins-1))
+		observed[ri*angleBins+ai]++
+	}
+
+	expected := make([]float64, rBins*angleBins)
+	for i := range expected {
+		// pdf is constant over the disk area
+		expected[i] = mymath.UniformDiskPdf() * math.Pi / float64(len(expected)) * chiSquareSamples
+	}
+
+	assertChiSquare(t, observed, expected, name)
+}
+
+func TestUniformSampleDisk(t *testing.T) {
+	assertDiskUniform(t, mymath.UniformSampleDisk, "uniform disk")
+}
+
+func TestConcentricSampleDisk(t *testing.T) {
+	assertDiskUniform(t, mymath.ConcentricSampleDisk, "concentric disk")
+}
+
+func TestRejectionSampleDisk(t *testing.T) {
+	rng := sampling.NewRNG()
+	assertDiskUniform(t, func(u mymath.Point2) mymath.Point2 {
+		return sampling.RejectionSampleDisk(rng)
+	}, "rejection disk")
+}
+
+func TestUniformSampleTriangle(t *testing.T) {
+	const bins = 10
+
+	rng := sampling.NewRNG()
+	observed := make([]float64, bins*bins)
+	for i := 0; i < chiSquareSamples; i++ {
+		b := mymath.UniformSampleTriangle(mymath.NewPoint2(rng.UniformFloat(), rng.UniformFloat()))
+		assert.LessOrEqual(t, b.X+b.Y, 1.0)
+
+		observed[int(b.Y*bins)*bins+int(b.X*bins)]++
+	}
+
+	// density is 2 inside of the half of the unit square, the bins on the diagonal are cut in half
+	expected := make([]float64, bins*bins)
+	for y := 0; y < bins; y++ {
+		for x := 0; x < bins; x++ {
+			switch {
+			case x+y < bins-1:
+				expected[y*bins+x] = 2. / bins / bins * chiSquareSamples
+			case x+y == bins-1:
+				expected[y*bins+x] = 1. / bins / bins * chiSquareSamples
+			}
+		}
+	}
+
+	assertChiSquare(t, observed, expected)
+}
+
+func TestUniformSampleTrianglePoint(t *testing.T) {
+	p0, p1, p2 := mymath.NewPoint3(0, 0, 1), mymath.NewPoint3(2, 0, 1), mymath.NewPoint3(0, 4, 1)
+	rng := sampling.NewRNG()
+
+	for i := 0; i < 100; i++ {
+		p := mymath.UniformSampleTrianglePoint(mymath.NewPoint2(rng.UniformFloat(), rng.UniformFloat()), p0, p1, p2)
+		assert.InDelta(t, 1, p.Z, 1e-12)
+		assert.True(t, p.X >= 0 && p.Y >= 0 && p.X/2+p.Y/4 <= 1+1e-12)
+	}
+
+	assert.Equal(t, p1, mymath.UniformSampleTrianglePoint(mymath.NewPoint2(1, 1), p0, p1, p2))
+}
+
+func TestStratifiedSample1D(t *testing.T) {
+	rng := sampling.NewRNG()
+
+	samples := make([]float64, 8)
+	sampling.StratifiedSample1D(samples, rng, false)
+	assert.Equal(t, []float64{0.0625, 0.1875, 0.3125, 0.4375, 0.5625, 0.6875, 0.8125, 0.9375}, samples)
+
+	// jittered samples stay in their strata and are uniform within them
+	observed := make([]float64, 80)
+	for i := 0; i < chiSquareSamples/8; i++ {
+		sampling.StratifiedSample1D(samples, rng, true)
+		for j, s := range samples {
+			assert.Equal(t, j, int(s*8))
+			observed[int(s*80)]++
+		}
+	}
+
+	expected := make([]float64, 80)
+	for i := range expected {
+		expected[i] = float64(chiSquareSamples) / 80
+	}
+	assertChiSquare(t, observed, expected)
+}
+
+func TestStratifiedSample2D(t *testing.T) {
+	rng := sampling.NewRNG()
+	samples := make([]mymath.Point2, 4*2)
+
+	sampling.StratifiedSample2D(samples, 4, 2, rng, false)
+	assert.Equal(t, mymath.NewPoint2(0.125, 0.25), samples[0])
+	assert.Equal(t, mymath.NewPoint2(0.875, 0.75), samples[7])
+
+	observed := make([]float64, 16*16)
+	for i := 0; i < chiSquareSamples/8; i++ {
+		sampling.StratifiedSample2D(samples, 4, 2, rng, true)
+		for j, s := range samples {
+			assert.Equal(t, j%4, int(s.X*4))
+			assert.Equal(t, j/4, int(s.Y*2))
+			observed[int(s.Y*16)*16+int(s.X*16)]++
+		}
+	}
+
+	expected := make([]float64, 16*16)
+	for i := range expected {
+		expected[i] = float64(chiSquareSamples) / 256
+	}
+	assertChiSquare(t, observed, expected)
+}
+
+func TestLatinHypercube(t *testing.T) {
+	rng := sampling.NewRNG()
+
+	const n, dim = 16, 3
+	samples := make([]float64, n*dim)
+	sampling.LatinHypercube(samples, n, dim, rng)
+
+	// each dimension has one sample in each stratum
+	for d := 0; d < dim; d++ {
+		strata := map[int]bool{}
+		for i := 0; i < n; i++ {
+			strata[int(samples[i*dim+d]*n)] = true
+		}
+		assert.Len(t, strata, n)
+	}
+
+	points := make([]mymath.Point2, n)
+	observed := make([]float64, 64)
+	for i := 0; i < chiSquareSamples/n; i++ {
+		sampling.LatinHypercube2D(points, rng)
+
+		rows, columns := map[int]bool{}, map[int]bool{}
+		for _, p := range points {
+			rows[int(p.Y*n)] = true
+			columns[int(p.X*n)] = true
+			observed[int(p.Y*8)*8+int(p.X*8)]++
+		}
+		assert.Len(t, rows, n)
+		assert.Len(t, columns, n)
+	}
+
+	expected := make([]float64, 64)
+	for i := range expected {
+		expected[i] = float64(chiSquareSamples) / 64
+	}
+	assertChiSquare(t, observed, expected)
+}
+
+func TestShuffle(t *testing.T) {
+	rng := sampling.NewRNG()
+
+	// blocks of values are kept together
+	samples := []float64{0, 1, 10, 11, 20, 21, 30, 31}
+	sampling.Shuffle(samples, 4, 2, rng)
+	sum := 0.0
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, samples[2*i]+1, samples[2*i+1])
+		sum += samples[2*i]
+	}
+	assert.Equal(t, 60.0, sum)
+
+	// every permutation is equally likely
+	observed := make([]float64, 6)
+	permutationIndex := map[[3]mymath.Point2]int{}
+	for i := 0; i < 60000; i++ {
+		points := []mymath.Point2{mymath.NewPoint2(0, 0), mymath.NewPoint2(1, 0), mymath.NewPoint2(2, 0)}
+		sampling.Shuffle2D(points, 3, 1, rng)
+
+		key := [3]mymath.Point2{points[0], points[1], points[2]}
+		if _, ok := permutationIndex[key]; !ok {
+			permutationIndex[key] = len(permutationIndex)
+		}
+		observed[permutationIndex[key]]++
+	}
+
+	assert.Len(t, permutationIndex, 6)
+	assertChiSquare(t, observed, []float64{10000, 10000, 10000, 10000, 10000, 10000})
+}
@@ -0,0 +1,106 @@
+package shading
+
+import "pbrt-go/spectrum"
+
+// Conductor is the measured complex index of refraction N + i*K of the metal at the wavelengths Lambda in nm
+type Conductor struct {
+	Lambda, N, K []float64
+}
+
+// Spectra returns the index of refraction and the absorption coefficient as spectra
+func (c Conductor) Spectra() (eta, k spectrum.Spectrum) {
+	return spectrum.SpectrumFromSampled(c.Lambda, c.N), spectrum.SpectrumFromSampled(c.Lambda, c.K)
+}
+
+// Copper is the default metal of pbrt
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/metal.cpp#L75
+var Copper = Conductor{
+	Lambda: []float64{
+		298.7570554, 302.4004341, 306.1337728, 309.960445, 313.8839949, 317.9081487, 322.036826, 326.2741526,
+		330.6244747, 335.092373, 339.6826795, 344.4004944, 349.2512056, 354.2405086, 359.374429, 364.6593471,
+		370.1020239, 375.7096303, 381.4897785, 387.4505563, 393.6005651, 399.9489613, 406.5055016, 413.2805933,
+		420.2853492, 427.5316483, 435.0322035, 442.8006357, 450.8515564, 459.2006593, 467.8648226, 476.8622231,
+		486.2124627, 495.936712, 506.0578694, 516.6007417, 527.5922468, 539.0616435, 551.0407911, 563.5644455,
+		576.6705953, 590.4008476, 604.8008683, 619.92089, 635.8162974, 652.5483053, 670.1847459, 688.8009889,
+		708.4810171, 729.3186941, 751.4192606, 774.9011125, 799.8979226, 826.5611867, 855.0632966, 885.6012714,
+	},
+	N: []float64{
+		1.400313, 1.38, 1.358438, 1.34, 1.329063, 1.325, 1.3325, 1.34,
+		1.334375, 1.325, 1.317812, 1.31, 1.300313, 1.29, 1.281563, 1.27,
+		1.249062, 1.225, 1.2, 1.18, 1.174375, 1.175, 1.1775, 1.18,
+		1.178125, 1.175, 1.172812, 1.17, 1.165312, 1.16, 1.155312, 1.15,
+		1.142812, 1.135, 1.131562, 1.12, 1.092437, 1.04, 0.950375, 0.826,
+		0.645875, 0.468, 0.35125, 0.272, 0.230813, 0.214, 0.20925, 0.213,
+		0.21625, 0.223, 0.2365, 0.25, 0.254188, 0.26, 0.28, 0.3,
+	},
+	K: []float64{
+		1.662125, 1.687, 1.703313, 1.72, 1.744563, 1.77, 1.791625, 1.81,
+		1.822125, 1.834, 1.85175, 1.872, 1.89425, 1.916, 1.931688, 1.95,
+		1.972438, 2.015, 2.121562, 2.21, 2.177188, 2.13, 2.160063, 2.21,
+		2.249938, 2.289, 2.326, 2.362, 2.397625, 2.433, 2.469187, 2.504,
+		2.535875, 2.564, 2.589625, 2.605, 2.595562, 2.583, 2.5765, 2.599,
+		2.678062, 2.809, 3.01075, 3.24, 3.458187, 3.67, 3.863125, 4.05,
+		4.239563, 4.43, 4.619563, 4.817, 5.034125, 5.26, 5.485625, 5.717,
+	},
+}
+
+// Gold is the full table of Johnson and Christy, Optical Constants of the Noble Metals, 1972, the measured photon
+// energies 0.64 - 6.6 eV are converted to the wavelengths
+var Gold = Conductor{
+	Lambda: []float64{
+		187.9, 191.6, 195.3, 199.3, 203.3, 207.3, 211.9, 216.4, 221.4, 226.2,
+		231.3, 237.1, 242.6, 249.0, 255.1, 261.6, 268.9, 276.1, 284.4, 292.4,
+		300.9, 310.7, 320.4, 331.5, 342.5, 354.2, 367.9, 381.5, 397.4, 413.3,
+		430.5, 450.9, 471.4, 495.9, 520.9, 548.6, 582.1, 616.8, 659.5, 704.5,
+		756.0, 821.1, 892.0, 984.0, 1087.6, 1215.5, 1393.1, 1610.2, 1937.3,
+	},
+	N: []float64{
+		1.30, 1.30, 1.30, 1.30, 1.30, 1.30, 1.30, 1.30, 1.30, 1.31,
+		1.30, 1.32, 1.32, 1.33, 1.33, 1.35, 1.38, 1.43, 1.47, 1.49,
+		1.53, 1.53, 1.54, 1.48, 1.48, 1.50, 1.48, 1.46, 1.47, 1.46,
+		1.45, 1.38, 1.31, 1.04, 0.62, 0.43, 0.29, 0.21, 0.14, 0.13,
+		0.14, 0.16, 0.17, 0.22, 0.27, 0.35, 0.43, 0.56, 0.92,
+	},
+	K: []float64{
+		1.188, 1.203, 1.226, 1.251, 1.277, 1.304, 1.350, 1.387, 1.427, 1.460,
+		1.497, 1.536, 1.577, 1.631, 1.688, 1.749, 1.803, 1.847, 1.869, 1.878,
+		1.889, 1.893, 1.898, 1.883, 1.871, 1.866, 1.895, 1.933, 1.952, 1.958,
+		1.948, 1.914, 1.849, 1.833, 2.081, 2.455, 2.863, 3.272, 3.697, 4.103,
+		4.542, 5.083, 5.663, 6.350, 7.150, 8.145, 9.519, 11.21, 13.78,
+	},
+}
+
+// Silver is the full table of Johnson and Christy, Optical Constants of the Noble Metals, 1972, the measured photon
+// energies 0.64 - 6.6 eV are converted to the wavelengths
+var Silver = Conductor{
+	Lambda: []float64{
+		187.9, 191.6, 195.3, 199.3, 203.3, 207.3, 211.9, 216.4, 221.4, 226.2,
+		231.3, 237.1, 242.6, 249.0, 255.1, 261.6, 268.9, 276.1, 284.4, 292.4,
+		300.9, 310.7, 320.4, 331.5, 342.5, 354.2, 367.9, 381.5, 397.4, 413.3,
+		430.5, 450.9, 471.4, 495.9, 520.9, 548.6, 582.1, 616.8, 659.5, 704.5,
+		756.0, 821.1, 892.0, 984.0, 1087.6, 1215.5, 1393.1, 1610.2, 1937.3,
+	},
+	N: []float64{
+		1.07, 1.10, 1.12, 1.14, 1.15, 1.18, 1.20, 1.22, 1.25, 1.26,
+		1.28, 1.28, 1.30, 1.31, 1.33, 1.35, 1.38, 1.41, 1.41, 1.39,
+		1.34, 1.13, 0.81, 0.17, 0.14, 0.10, 0.07, 0.05, 0.05, 0.05,
+		0.04, 0.04, 0.05, 0.05, 0.05, 0.06, 0.05, 0.06, 0.05, 0.04,
+		0.03, 0.04, 0.04, 0.04, 0.04, 0.09, 0.13, 0.15, 0.24,
+	},
+	K: []float64{
+		1.212, 1.232, 1.255, 1.277, 1.296, 1.312, 1.325, 1.336, 1.342, 1.344,
+		1.357, 1.367, 1.378, 1.389, 1.393, 1.387, 1.372, 1.331, 1.264, 1.161,
+		0.964, 0.616, 0.392, 0.829, 1.142, 1.419, 1.657, 1.864, 2.070, 2.275,
+		2.462, 2.657, 2.869, 3.093, 3.324, 3.586, 3.858, 4.152, 4.483, 4.838,
+		5.242, 5.727, 6.312, 6.992, 7.795, 8.828, 10.10, 11.85, 14.08,
+	},
+}
+
+// Aluminium is tabulated over the visible range from Rakić, Algorithm for the determination of intrinsic optical
+// constants of metal films: application to aluminum, 1995
+var Aluminium = Conductor{
+	Lambda: []float64{400, 450, 500, 550, 600, 650, 700, 750},
+	N:      []float64{0.49, 0.62, 0.77, 0.96, 1.20, 1.49, 1.83, 2.24},
+	K:      []float64{4.86, 5.47, 6.08, 6.69, 7.26, 7.79, 8.31, 8.78},
+}
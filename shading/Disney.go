@@ -0,0 +1,321 @@
+package shading
+
+import (
+	"math"
+	"pbrt-go/mymath"
+	"pbrt-go/spectrum"
+)
+
+// The lobes of the Disney BSDF, Burley, Physically Based Shading at Disney 2012 and Extending the Disney BRDF
+// to a BSDF with Integrated Subsurface Scattering 2015
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/disney.cpp
+
+func sqr(x float64) float64 {
+	return x * x
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/disney.cpp#L51
+func schlickWeight(cosTheta float64) float64 {
+	m := mymath.Clamp(1-cosTheta, 0, 1)
+	return (m * m) * (m * m) * m
+}
+
+func frSchlick(r0 float64, cosTheta float64) float64 {
+	return mymath.Lerp(schlickWeight(cosTheta), r0, 1)
+}
+
+func frSchlickSpectrum(r0 spectrum.Spectrum, cosTheta float64) spectrum.Spectrum {
+	return r0.Lerp(schlickWeight(cosTheta), spectrum.NewSpectrum(1))
+}
+
+// schlickR0FromEta returns reflectance at the normal incidence of the dielectric
+func schlickR0FromEta(eta float64) float64 {
+	return sqr(eta-1) / sqr(eta+1)
+}
+
+// halfVector returns normalized wo + wi, false when the directions are opposite
+func halfVector(wo, wi mymath.Vector3) (bool, mymath.Vector3) {
+	wh := wi.Add(wo)
+	if wh.X == 0 && wh.Y == 0 && wh.Z == 0 {
+		return false, mymath.Vector3{}
+	}
+	return true, wh.Normalize()
+}
+
+// disneyDiffuse is Lambertian modified by the diffuse Fresnel, it goes from 1 at the normal incidence
+// to .5 at grazing
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/disney.cpp#L78
+type disneyDiffuse struct {
+	R spectrum.Spectrum
+}
+
+func (b *disneyDiffuse) F(wo, wi mymath.Vector3) spectrum.Spectrum {
+	fo, fi := schlickWeight(mymath.AbsCosTheta(wo)), schlickWeight(mymath.AbsCosTheta(wi))
+
+	// Diffuse fresnel - go from 1 at normal incidence to .5 at grazing. Burley 2015, eq (4).
+	return b.R.Multiply((1 - fo/2) * (1 - fi/2) / math.Pi)
+}
+
+func (b *disneyDiffuse) SampleF(wo mymath.Vector3, u mymath.Point2) (mymath.Vector3, spectrum.Spectrum, float64, mymath.BxDFType) {
+	return mymath.CosineSampleF(b, wo, u)
+}
+
+func (b *disneyDiffuse) Pdf(wo, wi mymath.Vector3) float64 {
+	return mymath.CosinePdf(wo, wi)
+}
+
+func (b *disneyDiffuse) Rho(_ mymath.Vector3, _ []mymath.Point2) spectrum.Spectrum {
+	return b.R
+}
+
+func (b *disneyDiffuse) RhoHH(_, _ []mymath.Point2) spectrum.Spectrum {
+	return b.R
+}
+
+func (b *disneyDiffuse) Type() mymath.BxDFType {
+	return mymath.BSDFReflection | mymath.BSDFDiffuse
+}
+
+// disneyFakeSS approximates the subsurface scattering of the thin surfaces by Hanrahan-Krueger
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/disney.cpp#L115
+type disneyFakeSS struct {
+	R         spectrum.Spectrum
+	Roughness float64
+}
+
+func (b *disneyFakeSS) F(wo, wi mymath.Vector3) spectrum.Spectrum {
+	ok, wh := halfVector(wo, wi)
+	if !ok {
+		return spectrum.NewSpectrum(0)
+	}
+	cosThetaD := wi.Dot(wh)
+
+	// Fss90 used to "flatten" retroreflection based on roughness
+	fss90 := cosThetaD * cosThetaD * b.Roughness
+	fo, fi := schlickWeight(mymath.AbsCosTheta(wo)), schlickWeight(mymath.AbsCosTheta(wi))
+	fss := mymath.Lerp(fo, 1, fss90) * mymath.Lerp(fi, 1, fss90)
+
+	// 1.25 scale is used to (roughly) preserve albedo
+	ss := 1.25 * (fss*(1/(mymath.AbsCosTheta(wo)+mymath.AbsCosTheta(wi))-.5) + .5)
+	return b.R.Multiply(ss / math.Pi)
+}
+
+func (b *disneyFakeSS) SampleF(wo mymath.Vector3, u mymath.Point2) (mymath.Vector3, spectrum.Spectrum, float64, mymath.BxDFType) {
+	return mymath.CosineSampleF(b, wo, u)
+}
+
+func (b *disneyFakeSS) Pdf(wo, wi mymath.Vector3) float64 {
+	return mymath.CosinePdf(wo, wi)
+}
+
+func (b *disneyFakeSS) Rho(_ mymath.Vector3, _ []mymath.Point2) spectrum.Spectrum {
+	return b.R
+}
+
+func (b *disneyFakeSS) RhoHH(_, _ []mymath.Point2) spectrum.Spectrum {
+	return b.R
+}
+
+func (b *disneyFakeSS) Type() mymath.BxDFType {
+	return mymath.BSDFReflection | mymath.BSDFDiffuse
+}
+
+// disneyRetro is the retro-reflection of the rough surfaces
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/disney.cpp#L161
+type disneyRetro struct {
+	R         spectrum.Spectrum
+	Roughness float64
+}
+
+func (b *disneyRetro) F(wo, wi mymath.Vector3) spectrum.Spectrum {
+	ok, wh := halfVector(wo, wi)
+	if !ok {
+		return spectrum.NewSpectrum(0)
+	}
+	cosThetaD := wi.Dot(wh)
+
+	fo, fi := schlickWeight(mymath.AbsCosTheta(wo)), schlickWeight(mymath.AbsCosTheta(wi))
+	rr := 2 * b.Roughness * cosThetaD * cosThetaD
+
+	// Burley 2015, eq (4).
+	return b.R.Multiply(rr * (fo + fi + fo*fi*(rr-1)) / math.Pi)
+}
+
+func (b *disneyRetro) SampleF(wo mymath.Vector3, u mymath.Point2) (mymath.Vector3, spectrum.Spectrum, float64, mymath.BxDFType) {
+	return mymath.CosineSampleF(b, wo, u)
+}
+
+func (b *disneyRetro) Pdf(wo, wi mymath.Vector3) float64 {
+	return mymath.CosinePdf(wo, wi)
+}
+
+func (b *disneyRetro) Rho(_ mymath.Vector3, _ []mymath.Point2) spectrum.Spectrum {
+	return b.R
+}
+
+func (b *disneyRetro) RhoHH(_, _ []mymath.Point2) spectrum.Spectrum {
+	return b.R
+}
+
+func (b *disneyRetro) Type() mymath.BxDFType {
+	return mymath.BSDFReflection | mymath.BSDFDiffuse
+}
+
+// disneySheen is the grazing retro-reflection of the cloth
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/disney.cpp#L203
+type disneySheen struct {
+	R spectrum.Spectrum
+}
+
+func (b *disneySheen) F(wo, wi mymath.Vector3) spectrum.Spectrum {
+	ok, wh := halfVector(wo, wi)
+	if !ok {
+		return spectrum.NewSpectrum(0)
+	}
+	cosThetaD := wi.Dot(wh)
+	return b.R.Multiply(schlickWeight(cosThetaD))
+}
+
+func (b *disneySheen) SampleF(wo mymath.Vector3, u mymath.Point2) (mymath.Vector3, spectrum.Spectrum, float64, mymath.BxDFType) {
+	return mymath.CosineSampleF(b, wo, u)
+}
+
+func (b *disneySheen) Pdf(wo, wi mymath.Vector3) float64 {
+	return mymath.CosinePdf(wo, wi)
+}
+
+func (b *disneySheen) Rho(_ mymath.Vector3, _ []mymath.Point2) spectrum.Spectrum {
+	return b.R
+}
+
+func (b *disneySheen) RhoHH(_, _ []mymath.Point2) spectrum.Spectrum {
+	return b.R
+}
+
+func (b *disneySheen) Type() mymath.BxDFType {
+	return mymath.BSDFReflection | mymath.BSDFDiffuse
+}
+
+// gtr1 is the Generalized-Trowbridge-Reitz distribution with gamma 1, its tails are even fatter than those
+// of Trowbridge-Reitz (which is GTR2)
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/disney.cpp#L241
+func gtr1(cosTheta, alpha float64) float64 {
+	alpha2 := alpha * alpha
+	return (alpha2 - 1) / (math.Pi * math.Log(alpha2) * (1 + (alpha2-1)*cosTheta*cosTheta))
+}
+
+// smithGGGX is the Smith masking/shadowing term
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/disney.cpp#L247
+func smithGGGX(cosTheta, alpha float64) float64 {
+	alpha2 := alpha * alpha
+	cosTheta2 := cosTheta * cosTheta
+	return 1 / (cosTheta + math.Sqrt(alpha2+cosTheta2-alpha2*cosTheta2))
+}
+
+// disneyClearcoat is the second specular lobe of the coating with the index of refraction 1.5
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/disney.cpp#L253
+type disneyClearcoat struct {
+	Weight, Gloss float64
+}
+
+func (b *disneyClearcoat) F(wo, wi mymath.Vector3) spectrum.Spectrum {
+	ok, wh := halfVector(wo, wi)
+	if !ok {
+		return spectrum.NewSpectrum(0)
+	}
+
+	// Clearcoat has ior = 1.5 hardcoded -> F0 = 0.04. It then uses the GTR1 distribution, which has even fatter
+	// tails than Trowbridge-Reitz (which is GTR2).
+	dr := gtr1(mymath.AbsCosTheta(wh), b.Gloss)
+	fr := frSchlick(.04, wo.Dot(wh))
+
+	// The geometric term always based on alpha = 0.25.
+	gr := smithGGGX(mymath.AbsCosTheta(wo), .25) * smithGGGX(mymath.AbsCosTheta(wi), .25)
+
+	return spectrum.NewSpectrum(b.Weight * gr * fr * dr / 4)
+}
+
+// SampleF samples the half vector from the GTR1 distribution
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/disney.cpp#L299
+func (b *disneyClearcoat) SampleF(wo mymath.Vector3, u mymath.Point2) (mymath.Vector3, spectrum.Spectrum, float64, mymath.BxDFType) {
+	if wo.Z == 0 {
+		return mymath.Vector3{}, spectrum.NewSpectrum(0), 0, b.Type()
+	}
+
+	alpha2 := b.Gloss * b.Gloss
+	cosTheta := math.Sqrt(math.Max(0, (1-math.Pow(alpha2, 1-u.X))/(1-alpha2)))
+	sinTheta := math.Sqrt(math.Max(0, 1-cosTheta*cosTheta))
+	phi := 2 * math.Pi * u.Y
+	wh := mymath.SphericalDirection(sinTheta, cosTheta, phi)
+	if !mymath.SameHemisphere(wo, wh) {
+		wh = wh.Negate()
+	}
+
+	wi := mymath.Reflect(wo, wh)
+	if !mymath.SameHemisphere(wo, wi) {
+		return wi, spectrum.NewSpectrum(0), 0, b.Type()
+	}
+	return wi, b.F(wo, wi), b.Pdf(wo, wi), b.Type()
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/disney.cpp#L325
+func (b *disneyClearcoat) Pdf(wo, wi mymath.Vector3) float64 {
+	if !mymath.SameHemisphere(wo, wi) {
+		return 0
+	}
+	ok, wh := halfVector(wo, wi)
+	if !ok {
+		return 0
+	}
+
+	// The sampling routine samples wh exactly from the GTR1 distribution. Thus, the final value of the PDF is just
+	// the value of the distribution for wh converted to a mesure with respect to the surface normal.
+	dr := gtr1(mymath.AbsCosTheta(wh), b.Gloss)
+	return dr * mymath.AbsCosTheta(wh) / (4 * wo.Dot(wh))
+}
+
+func (b *disneyClearcoat) Rho(wo mymath.Vector3, samples []mymath.Point2) spectrum.Spectrum {
+	return mymath.EstimateRho(b, wo, samples)
+}
+
+func (b *disneyClearcoat) RhoHH(samples1, samples2 []mymath.Point2) spectrum.Spectrum {
+	return mymath.EstimateRhoHH(b, samples1, samples2)
+}
+
+func (b *disneyClearcoat) Type() mymath.BxDFType {
+	return mymath.BSDFReflection | mymath.BSDFGlossy
+}
+
+// disneyFresnel blends the dielectric Fresnel and the Schlick's approximation of the metal
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/disney.cpp#L350
+type disneyFresnel struct {
+	R0       spectrum.Spectrum
+	Metallic float64
+	Eta      float64
+}
+
+func (f *disneyFresnel) Evaluate(cosI float64) spectrum.Spectrum {
+	return spectrum.NewSpectrum(mymath.FrDielectric(cosI, 1, f.Eta)).Lerp(f.Metallic, frSchlickSpectrum(f.R0, cosI))
+}
+
+// disneyMicrofacetDistribution is Trowbridge-Reitz with the separable masking-shadowing model
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/disney.cpp#L371
+type disneyMicrofacetDistribution struct {
+	*mymath.TrowbridgeReitzDistribution
+}
+
+func (d disneyMicrofacetDistribution) G(wo, wi mymath.Vector3) float64 {
+	// Disney uses the separable masking-shadowing model.
+	return d.G1(wo) * d.G1(wi)
+}
@@ -0,0 +1,142 @@
+package shading
+
+import (
+	"math"
+	"pbrt-go/mymath"
+	"pbrt-go/spectrum"
+	"pbrt-go/texture"
+)
+
+// DisneyMaterial is the principled material of Burley, the thin surfaces have no interior and also transmit
+// the light diffusely by DiffTrans, the solid ones refract it by SpecTrans. The subsurface scattering is not
+// supported, the solid surfaces use the Fresnel modified diffuse instead.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/disney.h#L48
+type DisneyMaterial struct {
+	Color                                texture.SpectrumTexture
+	Metallic, Eta                        texture.FloatTexture
+	Roughness, SpecularTint, Anisotropic texture.FloatTexture
+	Sheen, SheenTint                     texture.FloatTexture
+	Clearcoat, ClearcoatGloss            texture.FloatTexture
+	SpecTrans                            texture.FloatTexture
+	Thin                                 bool
+	Flatness, DiffTrans                  texture.FloatTexture
+	BumpMap                              texture.FloatTexture
+}
+
+// NewDisneyMaterial creates the material with the default parameters of pbrt, only the base color is set
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/disney.cpp#L486
+func NewDisneyMaterial(color texture.SpectrumTexture) *DisneyMaterial {
+	return &DisneyMaterial{
+		Color:          color,
+		Metallic:       texture.NewConstantTexture[texture.Float](0.0),
+		Eta:            texture.NewConstantTexture[texture.Float](1.5),
+		Roughness:      texture.NewConstantTexture[texture.Float](0.5),
+		SpecularTint:   texture.NewConstantTexture[texture.Float](0.0),
+		Anisotropic:    texture.NewConstantTexture[texture.Float](0.0),
+		Sheen:          texture.NewConstantTexture[texture.Float](0.0),
+		SheenTint:      texture.NewConstantTexture[texture.Float](0.5),
+		Clearcoat:      texture.NewConstantTexture[texture.Float](0.0),
+		ClearcoatGloss: texture.NewConstantTexture[texture.Float](1.0),
+		SpecTrans:      texture.NewConstantTexture[texture.Float](0.0),
+		Flatness:       texture.NewConstantTexture[texture.Float](0.0),
+		DiffTrans:      texture.NewConstantTexture[texture.Float](1.0),
+	}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/disney.cpp#L382
+func (m *DisneyMaterial) ComputeScatteringFunctions(si *mymath.SurfaceInteraction, mode mymath.TransportMode, _ bool) {
+	// Perform bump mapping with bumpMap, if present
+	if m.BumpMap != nil {
+		Bump(m.BumpMap, si)
+	}
+
+	// Evaluate textures for DisneyMaterial material and allocate BRDF
+	si.BSDF = mymath.NewBSDF(si, 1)
+
+	// Diffuse
+	c := evaluateSpectrum(m.Color, si)
+	metallicWeight := float64(m.Metallic.Evaluate(si))
+	e := float64(m.Eta.Evaluate(si))
+	strans := float64(m.SpecTrans.Evaluate(si))
+	diffuseWeight := (1 - metallicWeight) * (1 - strans)
+	// 0: all diffuse is reflected -> 1, transmitted
+	dt := float64(m.DiffTrans.Evaluate(si)) / 2
+	rough := float64(m.Roughness.Evaluate(si))
+	lum := c.Y()
+
+	// normalize lum. to isolate hue+sat
+	cTint := spectrum.NewSpectrum(1)
+	if lum > 0 {
+		cTint = c.Divide(lum)
+	}
+
+	sheenWeight := float64(m.Sheen.Evaluate(si))
+	var cSheen spectrum.Spectrum
+	if sheenWeight > 0 {
+		stint := float64(m.SheenTint.Evaluate(si))
+		cSheen = spectrum.NewSpectrum(1).Lerp(stint, cTint)
+	}
+
+	if diffuseWeight > 0 {
+		if m.Thin {
+			flat := float64(m.Flatness.Evaluate(si))
+
+			// Blend between DisneyDiffuse and fake subsurface based on flatness. Additionally, weight using diffTrans.
+			si.BSDF.Add(&disneyDiffuse{c.Multiply(diffuseWeight * (1 - flat) * (1 - dt))})
+			si.BSDF.Add(&disneyFakeSS{c.Multiply(diffuseWeight * flat * (1 - dt)), rough})
+		} else {
+			// No subsurface scattering; use regular (Fresnel modified) diffuse.
+			si.BSDF.Add(&disneyDiffuse{c.Multiply(diffuseWeight)})
+		}
+
+		// Retro-reflection.
+		si.BSDF.Add(&disneyRetro{c.Multiply(diffuseWeight), rough})
+
+		// Sheen (if enabled)
+		if sheenWeight > 0 {
+			si.BSDF.Add(&disneySheen{cSheen.Multiply(diffuseWeight * sheenWeight)})
+		}
+	}
+
+	// Create the microfacet distribution for metallic and/or specular transmission.
+	aspect := math.Sqrt(1 - float64(m.Anisotropic.Evaluate(si))*.9)
+	ax := math.Max(.001, sqr(rough)/aspect)
+	ay := math.Max(.001, sqr(rough)*aspect)
+	distrib := disneyMicrofacetDistribution{mymath.NewTrowbridgeReitzDistribution(ax, ay, true)}
+
+	// Specular is Trowbridge-Reitz with a modified Fresnel function.
+	specTint := float64(m.SpecularTint.Evaluate(si))
+	cSpec0 := spectrum.NewSpectrum(1).Lerp(specTint, cTint).Multiply(schlickR0FromEta(e)).Lerp(metallicWeight, c)
+	fresnel := &disneyFresnel{cSpec0, metallicWeight, e}
+	si.BSDF.Add(mymath.NewMicrofacetReflection(spectrum.NewSpectrum(1), distrib, fresnel))
+
+	// Clearcoat
+	cc := float64(m.Clearcoat.Evaluate(si))
+	if cc > 0 {
+		si.BSDF.Add(&disneyClearcoat{cc, mymath.Lerp(float64(m.ClearcoatGloss.Evaluate(si)), .1, .001)})
+	}
+
+	// BTDF
+	if strans > 0 {
+		// Walter et al's model, with the provided transmissive term scaled by sqrt(color), so that after two
+		// refractions, we're back to the provided color.
+		t := c.Sqrt().Multiply(strans)
+		if m.Thin {
+			// Scale roughness based on IOR (Burley 2015, Figure 15).
+			rscaled := (0.65*e - 0.35) * rough
+			ax := math.Max(.001, sqr(rscaled)/aspect)
+			ay := math.Max(.001, sqr(rscaled)*aspect)
+			scaledDistrib := mymath.NewTrowbridgeReitzDistribution(ax, ay, true)
+			si.BSDF.Add(mymath.NewMicrofacetTransmission(t, scaledDistrib, 1, e, mode))
+		} else {
+			si.BSDF.Add(mymath.NewMicrofacetTransmission(t, distrib, 1, e, mode))
+		}
+	}
+
+	if m.Thin {
+		// Lambertian, weighted by (1 - diffTrans)
+		si.BSDF.Add(mymath.NewLambertianTransmission(c.Multiply(dt)))
+	}
+}
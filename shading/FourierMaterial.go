@@ -0,0 +1,51 @@
+package shading
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/texture"
+	"sync"
+)
+
+// FourierMaterial is described by the measured or simulated BSDF stored in the Fourier basis
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/fourier.h#L48
+type FourierMaterial struct {
+	Table   *mymath.FourierBSDFTable
+	BumpMap texture.FloatTexture
+}
+
+var (
+	// loadedBSDFs caches the tables by the file name, so that the materials using the same file share its data
+	loadedBSDFs      = map[string]*mymath.FourierBSDFTable{}
+	loadedBSDFsMutex sync.Mutex
+)
+
+// NewFourierMaterial loads the table from the binary .bsdf file, the file is read only once
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/fourier.cpp#L47
+func NewFourierMaterial(filename string, bumpMap texture.FloatTexture) (*FourierMaterial, error) {
+	loadedBSDFsMutex.Lock()
+	defer loadedBSDFsMutex.Unlock()
+
+	table, ok := loadedBSDFs[filename]
+	if !ok {
+		var err error
+		table, err = mymath.ReadFourierBSDFTable(filename)
+		if err != nil {
+			return nil, err
+		}
+		loadedBSDFs[filename] = table
+	}
+
+	return &FourierMaterial{table, bumpMap}, nil
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/fourier.cpp#L61
+func (m *FourierMaterial) ComputeScatteringFunctions(si *mymath.SurfaceInteraction, mode mymath.TransportMode, _ bool) {
+	// Perform bump mapping with bumpMap, if present
+	if m.BumpMap != nil {
+		Bump(m.BumpMap, si)
+	}
+	si.BSDF = mymath.NewBSDF(si, 1)
+	si.BSDF.Add(mymath.NewFourierBSDF(m.Table, mode))
+}
@@ -0,0 +1,56 @@
+package shading_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"pbrt-go/mymath"
+	"pbrt-go/shading"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeFourierBSDFFile writes the table of two cosines with a single constant coefficient for all pairs
+func writeFourierBSDFFile(t *testing.T, filename string) {
+	var buffer bytes.Buffer
+	for _, data := range []any{
+		[]byte("SCATFUN\x01"),
+		[]int32{1, 2, 1, 1, 1, 1, 0, 0, 0},
+		float32(1),
+		[]int32{0, 0, 0, 0},
+		[]float32{-1, 1},
+		[]float32{0, 0.2, 0, 0.2},
+		[]int32{0, 1, 0, 1, 0, 1, 0, 1},
+		[]float32{0.1},
+	} {
+		assert.NoError(t, binary.Write(&buffer, binary.LittleEndian, data))
+	}
+	assert.NoError(t, os.WriteFile(filename, buffer.Bytes(), 0o644))
+}
+
+func TestFourierMaterial(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "paint.bsdf")
+	writeFourierBSDFFile(t, filename)
+
+	m, err := shading.NewFourierMaterial(filename, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{-1, 1}, m.Table.Mu)
+
+	// the table is loaded once
+	m2, err := shading.NewFourierMaterial(filename, nil)
+	assert.NoError(t, err)
+	assert.Same(t, m.Table, m2.Table)
+
+	si := newSurfaceInteraction()
+	m.ComputeScatteringFunctions(si, mymath.Radiance, false)
+	assert.Equal(t, []mymath.BxDFType{mymath.BSDFReflection | mymath.BSDFTransmission | mymath.BSDFGlossy}, bxdfTypes(si.BSDF))
+	assert.Equal(t, 1.0, si.BSDF.Eta)
+
+	wo := mymath.NewVector3(0, 0.6, 0.8)
+	assertSpectrum(t, 0.1/0.8, si.BSDF.F(wo, mymath.NewVector3(0.6, 0, 0.8), mymath.BSDFAll), 1e-6)
+
+	_, err = shading.NewFourierMaterial(filepath.Join(t.TempDir(), "missing.bsdf"), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,71 @@
+package shading
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/texture"
+)
+
+// GlassMaterial is the dielectric with the index of refraction Index, it is perfectly specular when both the
+// roughnesses are zero
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/glass.h#L48
+type GlassMaterial struct {
+	Kr, Kt                 texture.SpectrumTexture
+	URoughness, VRoughness texture.FloatTexture
+	Index                  texture.FloatTexture
+	BumpMap                texture.FloatTexture
+	RemapRoughness         bool
+}
+
+func NewGlassMaterial(kr, kt texture.SpectrumTexture, uRoughness, vRoughness, index, bumpMap texture.FloatTexture, remapRoughness bool) *GlassMaterial {
+	return &GlassMaterial{kr, kt, uRoughness, vRoughness, index, bumpMap, remapRoughness}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/glass.cpp#L44
+func (m *GlassMaterial) ComputeScatteringFunctions(si *mymath.SurfaceInteraction, mode mymath.TransportMode, allowMultipleLobes bool) {
+	// Perform bump mapping with bumpMap, if present
+	if m.BumpMap != nil {
+		Bump(m.BumpMap, si)
+	}
+	eta := float64(m.Index.Evaluate(si))
+	uRough := float64(m.URoughness.Evaluate(si))
+	vRough := float64(m.VRoughness.Evaluate(si))
+	r := evaluateSpectrum(m.Kr, si)
+	t := evaluateSpectrum(m.Kt, si)
+	si.BSDF = mymath.NewBSDF(si, eta)
+
+	if r.IsBlack() && t.IsBlack() {
+		return
+	}
+
+	isSpecular := uRough == 0 && vRough == 0
+	if isSpecular && allowMultipleLobes {
+		si.BSDF.Add(mymath.NewFresnelSpecular(r, t, 1, eta, mode))
+		return
+	}
+
+	if m.RemapRoughness {
+		uRough = mymath.RoughnessToAlpha(uRough)
+		vRough = mymath.RoughnessToAlpha(vRough)
+	}
+	var distrib mymath.MicrofacetDistribution
+	if !isSpecular {
+		distrib = mymath.NewTrowbridgeReitzDistribution(uRough, vRough, true)
+	}
+
+	if !r.IsBlack() {
+		fresnel := mymath.NewFresnelDielectric(1, eta)
+		if isSpecular {
+			si.BSDF.Add(mymath.NewSpecularReflection(r, fresnel))
+		} else {
+			si.BSDF.Add(mymath.NewMicrofacetReflection(r, distrib, fresnel))
+		}
+	}
+	if !t.IsBlack() {
+		if isSpecular {
+			si.BSDF.Add(mymath.NewSpecularTransmission(t, 1, eta, mode))
+		} else {
+			si.BSDF.Add(mymath.NewMicrofacetTransmission(t, distrib, 1, eta, mode))
+		}
+	}
+}
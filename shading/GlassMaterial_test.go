@@ -0,0 +1,42 @@
+package shading_test
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/shading"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlassMaterial(t *testing.T) {
+	glass := shading.NewGlassMaterial(constantSpectrum(1), constantSpectrum(1), constant(0), constant(0), constant(1.5), nil, false)
+
+	// single lobe for both the reflection and the transmission
+	si := newSurfaceInteraction()
+	glass.ComputeScatteringFunctions(si, mymath.Radiance, true)
+	assert.Equal(t, 1.5, si.BSDF.Eta)
+	assert.Equal(t, []mymath.BxDFType{mymath.BSDFReflection | mymath.BSDFTransmission | mymath.BSDFSpecular}, bxdfTypes(si.BSDF))
+
+	si = newSurfaceInteraction()
+	glass.ComputeScatteringFunctions(si, mymath.Radiance, false)
+	assert.Equal(t, []mymath.BxDFType{
+		mymath.BSDFReflection | mymath.BSDFSpecular,
+		mymath.BSDFTransmission | mymath.BSDFSpecular,
+	}, bxdfTypes(si.BSDF))
+
+	// frosted glass
+	frosted := shading.NewGlassMaterial(constantSpectrum(1), constantSpectrum(1), constant(0.2), constant(0.2), constant(1.5), nil, true)
+	si = newSurfaceInteraction()
+	frosted.ComputeScatteringFunctions(si, mymath.Radiance, true)
+	assert.Equal(t, []mymath.BxDFType{
+		mymath.BSDFReflection | mymath.BSDFGlossy,
+		mymath.BSDFTransmission | mymath.BSDFGlossy,
+	}, bxdfTypes(si.BSDF))
+
+	// the reflected and the transmitted energy sum to one
+	wo := mymath.NewVector3(0.6, 0, 0.8)
+	si = newSurfaceInteraction()
+	glass.ComputeScatteringFunctions(si, mymath.Importance, true)
+	rho := si.BSDF.Rho(wo, randomSamples(1000), mymath.BSDFAll)
+	assertSpectrum(t, 1, rho, 1e-9)
+}
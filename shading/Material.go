@@ -0,0 +1,64 @@
+package shading
+
+import (
+	"math"
+	"pbrt-go/mymath"
+	"pbrt-go/spectrum"
+	"pbrt-go/texture"
+)
+
+// Bump perturbs the shading geometry of the interaction by the displacement texture d, the displacement is along
+// the shading normal
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/material.cpp#L47
+func Bump(d texture.FloatTexture, si *mymath.SurfaceInteraction) {
+	// Compute offset positions and evaluate displacement texture
+	siEval := *si
+	shadingNormal := mymath.NewNormal3V(si.Shading.Dpdu.Cross(si.Shading.Dpdv))
+
+	// Shift siEval du in the u direction
+	du := .5 * (math.Abs(si.Dudx) + math.Abs(si.Dudy))
+
+	// The most common reason for du to be zero is for ray that start from light sources, where no differentials
+	// are available. In this case, we try to choose a small enough du so that we still get a decently accurate
+	// bump value.
+	if du == 0 {
+		du = .0005
+	}
+	siEval.P = si.P.AddV(si.Shading.Dpdu.Multiply(du))
+	siEval.Uv = mymath.NewPoint2(si.Uv.X+du, si.Uv.Y)
+	siEval.N = shadingNormal.Add(si.Dndu.Multiply(du)).Normalize()
+	uDisplace := float64(d.Evaluate(&siEval))
+
+	// Shift siEval dv in the v direction
+	dv := .5 * (math.Abs(si.Dvdx) + math.Abs(si.Dvdy))
+	if dv == 0 {
+		dv = .0005
+	}
+	siEval.P = si.P.AddV(si.Shading.Dpdv.Multiply(dv))
+	siEval.Uv = mymath.NewPoint2(si.Uv.X, si.Uv.Y+dv)
+	siEval.N = shadingNormal.Add(si.Dndv.Multiply(dv)).Normalize()
+	vDisplace := float64(d.Evaluate(&siEval))
+	displace := float64(d.Evaluate(si))
+
+	// Compute bump-mapped differential geometry
+	n := mymath.NewVector3N(si.Shading.N)
+	dpdu := si.Shading.Dpdu.Add(n.Multiply((uDisplace - displace) / du)).Add(mymath.NewVector3N(si.Shading.Dndu).Multiply(displace))
+	dpdv := si.Shading.Dpdv.Add(n.Multiply((vDisplace - displace) / dv)).Add(mymath.NewVector3N(si.Shading.Dndv).Multiply(displace))
+	si.SetShadingGeometry(dpdu, dpdv, si.Shading.Dndu, si.Shading.Dndv, false)
+}
+
+// evaluateSpectrum evaluates the texture clamped to the non-negative values
+func evaluateSpectrum(t texture.SpectrumTexture, si *mymath.SurfaceInteraction) spectrum.Spectrum {
+	return t.Evaluate(si).Clamp(0, math.Inf(1))
+}
+
+// evaluateRoughness evaluates the roughness texture and optionally maps it to the alpha of the microfacet
+// distribution
+func evaluateRoughness(t texture.FloatTexture, si *mymath.SurfaceInteraction, remapRoughness bool) float64 {
+	roughness := float64(t.Evaluate(si))
+	if remapRoughness {
+		return mymath.RoughnessToAlpha(roughness)
+	}
+	return roughness
+}
@@ -0,0 +1,87 @@
+package shading_test
+
+import (
+	"math"
+	"math/rand"
+	"pbrt-go/mymath"
+	"pbrt-go/mymath/mymathtest"
+	"pbrt-go/shading"
+	"pbrt-go/spectrum"
+	"pbrt-go/texture"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newSurfaceInteraction creates interaction on the plane z=0 parametrized by u=x and v=y
+func newSurfaceInteraction() *mymath.SurfaceInteraction {
+	si := mymath.NewSurfaceInteraction(mymath.NewPoint3(0.3, 0.4, 0), mymath.Vector3{}, mymath.NewPoint2(0.3, 0.4), mymath.NewVector3(0, 0, 1),
+		mymath.NewVector3(1, 0, 0), mymath.NewVector3(0, 1, 0), mymath.Normal3{}, mymath.Normal3{}, 0, nil)
+	return &si
+}
+
+func constant(v float64) texture.FloatTexture {
+	return texture.NewConstantTexture(texture.Float(v))
+}
+
+func constantSpectrum(v float64) texture.SpectrumTexture {
+	return texture.NewConstantTexture(spectrum.NewSpectrum(v))
+}
+
+// bxdfTypes returns types of the lobes of the BSDF
+func bxdfTypes(bsdf *mymath.BSDF) []mymath.BxDFType {
+	types := make([]mymath.BxDFType, len(bsdf.BxDFs))
+	for i, bxdf := range bsdf.BxDFs {
+		types[i] = bxdf.Type()
+	}
+	return types
+}
+
+func assertSpectrum(t *testing.T, expected float64, actual spectrum.Spectrum, delta float64, msgAndArgs ...interface{}) {
+	expectedSpectrum := spectrum.NewSpectrum(expected)
+	d := expectedSpectrum.Subtract(actual)
+	assert.LessOrEqual(t, math.Max(d.MaxComponentValue(), d.Negate().MaxComponentValue()), delta, msgAndArgs...)
+}
+
+func TestBump(t *testing.T) {
+	// constant displacement keeps the shading geometry
+	si := newSurfaceInteraction()
+	shading.Bump(constant(0.5), si)
+	assert.Equal(t, mymath.NewNormal3(0, 0, 1), si.Shading.N)
+	assert.Equal(t, mymath.NewVector3(1, 0, 0), si.Shading.Dpdu)
+
+	// displacement growing along u tilts the shading normal against u
+	si = newSurfaceInteraction()
+	shading.Bump(texture.NewBilerpTexture[texture.Float](texture.NewUVMapping2D(1, 1, 0, 0), 0, 0, 0.1, 0.1), si)
+	mymathtest.InDeltaVector3(t, mymath.NewVector3(1, 0, 0.1), si.Shading.Dpdu, 1e-5)
+	mymathtest.InDeltaVector3(t, mymath.NewVector3(0, 1, 0), si.Shading.Dpdv, 1e-5)
+	mymathtest.InDeltaVector3(t, mymath.NewVector3(-0.1, 0, 1).Normalize(), mymath.NewVector3N(si.Shading.N), 1e-5)
+
+	// the true geometry is kept
+	assert.Equal(t, mymath.NewNormal3(0, 0, 1), si.N)
+
+	// the differentials set the offset
+	si = newSurfaceInteraction()
+	si.Dudx, si.Dvdy = 0.01, 0.02
+	shading.Bump(texture.NewBilerpTexture[texture.Float](texture.NewUVMapping2D(1, 1, 0, 0), 0, 0.2, 0, 0.2), si)
+	mymathtest.InDeltaVector3(t, mymath.NewVector3(0, -0.2, 1).Normalize(), mymath.NewVector3N(si.Shading.N), 1e-5)
+}
+
+// The bump mapped materials build the BSDF in the perturbed shading frame
+func TestBump_material(t *testing.T) {
+	bumpMap := texture.NewBilerpTexture[texture.Float](texture.NewUVMapping2D(1, 1, 0, 0), 0, 0, 0.1, 0.1)
+	si := newSurfaceInteraction()
+	shading.NewMatteMaterial(constantSpectrum(0.5), constant(0), bumpMap).ComputeScatteringFunctions(si, mymath.Radiance, false)
+
+	mymathtest.InDeltaVector3(t, mymath.NewVector3(-0.1, 0, 1).Normalize(), mymath.NewVector3N(si.BSDF.Ns), 1e-5)
+	assert.Equal(t, mymath.NewNormal3(0, 0, 1), si.BSDF.Ng)
+}
+
+func randomSamples(n int) []mymath.Point2 {
+	rng := rand.New(rand.NewSource(1))
+	samples := make([]mymath.Point2, n)
+	for i := range samples {
+		samples[i] = mymath.NewPoint2(rng.Float64(), rng.Float64())
+	}
+	return samples
+}
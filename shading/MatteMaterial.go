@@ -0,0 +1,40 @@
+package shading
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/texture"
+)
+
+// MatteMaterial is the purely diffuse surface, Sigma is the roughness in degrees of the Oren-Nayar model,
+// zero is Lambertian
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/matte.h#L48
+type MatteMaterial struct {
+	Kd      texture.SpectrumTexture
+	Sigma   texture.FloatTexture
+	BumpMap texture.FloatTexture
+}
+
+func NewMatteMaterial(kd texture.SpectrumTexture, sigma, bumpMap texture.FloatTexture) *MatteMaterial {
+	return &MatteMaterial{kd, sigma, bumpMap}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/matte.cpp#L44
+func (m *MatteMaterial) ComputeScatteringFunctions(si *mymath.SurfaceInteraction, _ mymath.TransportMode, _ bool) {
+	// Perform bump mapping with bumpMap, if present
+	if m.BumpMap != nil {
+		Bump(m.BumpMap, si)
+	}
+
+	// Evaluate textures for MatteMaterial material and allocate BRDF
+	si.BSDF = mymath.NewBSDF(si, 1)
+	r := evaluateSpectrum(m.Kd, si)
+	sig := mymath.Clamp(float64(m.Sigma.Evaluate(si)), 0, 90)
+	if !r.IsBlack() {
+		if sig == 0 {
+			si.BSDF.Add(mymath.NewLambertianReflection(r))
+		} else {
+			si.BSDF.Add(mymath.NewOrenNayar(r, sig))
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package shading_test
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/shading"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatteMaterial(t *testing.T) {
+	si := newSurfaceInteraction()
+	shading.NewMatteMaterial(constantSpectrum(0.5), constant(0), nil).ComputeScatteringFunctions(si, mymath.Radiance, false)
+	assert.Equal(t, 1.0, si.BSDF.Eta)
+	assert.IsType(t, &mymath.LambertianReflection{}, si.BSDF.BxDFs[0])
+	assertSpectrum(t, 0.5, si.BSDF.Rho(mymath.NewVector3(0, 0, 1), nil, mymath.BSDFAll), 1e-9)
+
+	// rough surface
+	si = newSurfaceInteraction()
+	shading.NewMatteMaterial(constantSpectrum(0.5), constant(20), nil).ComputeScatteringFunctions(si, mymath.Radiance, false)
+	assert.IsType(t, &mymath.OrenNayar{}, si.BSDF.BxDFs[0])
+
+	// black surface has no lobes
+	si = newSurfaceInteraction()
+	shading.NewMatteMaterial(constantSpectrum(0), constant(0), nil).ComputeScatteringFunctions(si, mymath.Radiance, false)
+	assert.NotNil(t, si.BSDF)
+	assert.Empty(t, si.BSDF.BxDFs)
+}
@@ -0,0 +1,52 @@
+package shading
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/spectrum"
+	"pbrt-go/texture"
+)
+
+// MetalMaterial is the rough conductor described by its complex index of refraction Eta + i*K, URoughness and
+// VRoughness override Roughness for the anisotropic surfaces
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/metal.h#L48
+type MetalMaterial struct {
+	Eta, K                            texture.SpectrumTexture
+	Roughness, URoughness, VRoughness texture.FloatTexture
+	BumpMap                           texture.FloatTexture
+	RemapRoughness                    bool
+}
+
+func NewMetalMaterial(eta, k texture.SpectrumTexture, roughness, uRoughness, vRoughness, bumpMap texture.FloatTexture, remapRoughness bool) *MetalMaterial {
+	return &MetalMaterial{eta, k, roughness, uRoughness, vRoughness, bumpMap, remapRoughness}
+}
+
+// NewConductorMetalMaterial creates the isotropic metal of the measured conductor
+func NewConductorMetalMaterial(conductor Conductor, roughness texture.FloatTexture, remapRoughness bool) *MetalMaterial {
+	eta, k := conductor.Spectra()
+	return NewMetalMaterial(texture.NewConstantTexture[spectrum.Spectrum](eta), texture.NewConstantTexture[spectrum.Spectrum](k),
+		roughness, nil, nil, nil, remapRoughness)
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/metal.cpp#L62
+func (m *MetalMaterial) ComputeScatteringFunctions(si *mymath.SurfaceInteraction, _ mymath.TransportMode, _ bool) {
+	// Perform bump mapping with bumpMap, if present
+	if m.BumpMap != nil {
+		Bump(m.BumpMap, si)
+	}
+	si.BSDF = mymath.NewBSDF(si, 1)
+
+	uRoughness, vRoughness := m.Roughness, m.Roughness
+	if m.URoughness != nil {
+		uRoughness = m.URoughness
+	}
+	if m.VRoughness != nil {
+		vRoughness = m.VRoughness
+	}
+	uRough := evaluateRoughness(uRoughness, si, m.RemapRoughness)
+	vRough := evaluateRoughness(vRoughness, si, m.RemapRoughness)
+
+	frMf := mymath.NewFresnelConductor(spectrum.NewSpectrum(1), m.Eta.Evaluate(si), m.K.Evaluate(si))
+	distrib := mymath.NewTrowbridgeReitzDistribution(uRough, vRough, true)
+	si.BSDF.Add(mymath.NewMicrofacetReflection(spectrum.NewSpectrum(1), distrib, frMf))
+}
@@ -0,0 +1,57 @@
+package shading_test
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/shading"
+	"pbrt-go/spectrum"
+	"pbrt-go/texture"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetalMaterial(t *testing.T) {
+	si := newSurfaceInteraction()
+	shading.NewConductorMetalMaterial(shading.Copper, constant(0.1), false).ComputeScatteringFunctions(si, mymath.Radiance, false)
+	assert.Equal(t, []mymath.BxDFType{mymath.BSDFReflection | mymath.BSDFGlossy}, bxdfTypes(si.BSDF))
+
+	distrib := si.BSDF.BxDFs[0].(*mymath.MicrofacetReflection).Distribution.(*mymath.TrowbridgeReitzDistribution)
+	assert.Equal(t, 0.1, distrib.AlphaX)
+	assert.Equal(t, 0.1, distrib.AlphaY)
+
+	// anisotropic roughness overrides the roughness
+	eta, k := shading.Gold.Spectra()
+	metal := shading.NewMetalMaterial(texture.NewConstantTexture[spectrum.Spectrum](eta), texture.NewConstantTexture[spectrum.Spectrum](k), constant(0.1), constant(0.2), constant(0.3), nil, false)
+	si = newSurfaceInteraction()
+	metal.ComputeScatteringFunctions(si, mymath.Radiance, false)
+	distrib = si.BSDF.BxDFs[0].(*mymath.MicrofacetReflection).Distribution.(*mymath.TrowbridgeReitzDistribution)
+	assert.Equal(t, 0.2, distrib.AlphaX)
+	assert.Equal(t, 0.3, distrib.AlphaY)
+}
+
+func TestConductor(t *testing.T) {
+	for _, conductor := range []shading.Conductor{shading.Copper, shading.Gold, shading.Silver, shading.Aluminium} {
+		assert.Equal(t, len(conductor.Lambda), len(conductor.N))
+		assert.Equal(t, len(conductor.Lambda), len(conductor.K))
+	}
+
+	// reflectance at normal incidence
+	reflectance := func(conductor shading.Conductor) [3]float64 {
+		eta, k := conductor.Spectra()
+		return mymath.FrConductor(1, spectrum.NewSpectrum(1), eta, k).ToRGB()
+	}
+
+	// copper and gold reflect mostly red
+	for _, rgb := range [][3]float64{reflectance(shading.Copper), reflectance(shading.Gold)} {
+		assert.Greater(t, rgb[0], 0.85)
+		assert.Greater(t, rgb[0], rgb[1])
+		assert.Greater(t, rgb[1], rgb[2])
+	}
+
+	// silver and aluminium are nearly white
+	for _, rgb := range [][3]float64{reflectance(shading.Silver), reflectance(shading.Aluminium)} {
+		for _, c := range rgb {
+			assert.Greater(t, c, 0.8)
+		}
+	}
+}
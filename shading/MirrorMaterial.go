@@ -0,0 +1,31 @@
+package shading
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/texture"
+)
+
+// MirrorMaterial is the perfect specular reflector
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/mirror.h#L48
+type MirrorMaterial struct {
+	Kr      texture.SpectrumTexture
+	BumpMap texture.FloatTexture
+}
+
+func NewMirrorMaterial(kr texture.SpectrumTexture, bumpMap texture.FloatTexture) *MirrorMaterial {
+	return &MirrorMaterial{kr, bumpMap}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/mirror.cpp#L44
+func (m *MirrorMaterial) ComputeScatteringFunctions(si *mymath.SurfaceInteraction, _ mymath.TransportMode, _ bool) {
+	// Perform bump mapping with bumpMap, if present
+	if m.BumpMap != nil {
+		Bump(m.BumpMap, si)
+	}
+	si.BSDF = mymath.NewBSDF(si, 1)
+	r := evaluateSpectrum(m.Kr, si)
+	if !r.IsBlack() {
+		si.BSDF.Add(mymath.NewSpecularReflection(r, mymath.FresnelNoOp{}))
+	}
+}
@@ -0,0 +1,22 @@
+package shading_test
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/mymath/mymathtest"
+	"pbrt-go/shading"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMirrorMaterial(t *testing.T) {
+	si := newSurfaceInteraction()
+	shading.NewMirrorMaterial(constantSpectrum(0.9), nil).ComputeScatteringFunctions(si, mymath.Radiance, false)
+	assert.Equal(t, []mymath.BxDFType{mymath.BSDFReflection | mymath.BSDFSpecular}, bxdfTypes(si.BSDF))
+
+	wo := mymath.NewVector3(0.6, 0, 0.8)
+	wi, f, pdf, _ := si.BSDF.SampleF(wo, mymath.NewPoint2(0.5, 0.5), mymath.BSDFAll)
+	mymathtest.InDeltaVector3(t, mymath.NewVector3(-0.6, 0, 0.8), wi, 1e-5)
+	assert.Equal(t, 1.0, pdf)
+	assertSpectrum(t, 0.9, f.Multiply(0.8), 1e-9)
+}
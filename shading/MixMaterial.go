@@ -0,0 +1,38 @@
+package shading
+
+import (
+	"math"
+	"pbrt-go/mymath"
+	"pbrt-go/spectrum"
+	"pbrt-go/texture"
+)
+
+// MixMaterial blends two materials, Scale is the weight of M1 and 1 - Scale of M2
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/mixmat.h#L48
+type MixMaterial struct {
+	M1, M2 mymath.Material
+	Scale  texture.SpectrumTexture
+}
+
+func NewMixMaterial(m1, m2 mymath.Material, scale texture.SpectrumTexture) *MixMaterial {
+	return &MixMaterial{m1, m2, scale}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/mixmat.cpp#L44
+func (m *MixMaterial) ComputeScatteringFunctions(si *mymath.SurfaceInteraction, mode mymath.TransportMode, allowMultipleLobes bool) {
+	// Compute weights and original BxDFs for mix material
+	s1 := evaluateSpectrum(m.Scale, si)
+	s2 := spectrum.NewSpectrum(1).Subtract(s1).Clamp(0, math.Inf(1))
+	si2 := *si
+	m.M1.ComputeScatteringFunctions(si, mode, allowMultipleLobes)
+	m.M2.ComputeScatteringFunctions(&si2, mode, allowMultipleLobes)
+
+	// Initialize si.BSDF with weighted mixture of BxDFs
+	for i, bxdf := range si.BSDF.BxDFs {
+		si.BSDF.BxDFs[i] = mymath.NewScaledBxDF(bxdf, s1)
+	}
+	for _, bxdf := range si2.BSDF.BxDFs {
+		si.BSDF.Add(mymath.NewScaledBxDF(bxdf, s2))
+	}
+}
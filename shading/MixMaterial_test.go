@@ -0,0 +1,25 @@
+package shading_test
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/shading"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMixMaterial(t *testing.T) {
+	matte := shading.NewMatteMaterial(constantSpectrum(1), constant(0), nil)
+	mirror := shading.NewMirrorMaterial(constantSpectrum(1), nil)
+
+	si := newSurfaceInteraction()
+	shading.NewMixMaterial(matte, mirror, constantSpectrum(0.25)).ComputeScatteringFunctions(si, mymath.Radiance, false)
+	assert.Equal(t, []mymath.BxDFType{
+		mymath.BSDFReflection | mymath.BSDFDiffuse,
+		mymath.BSDFReflection | mymath.BSDFSpecular,
+	}, bxdfTypes(si.BSDF))
+
+	wo := mymath.NewVector3(0, 0, 1)
+	assertSpectrum(t, 0.25, si.BSDF.Rho(wo, nil, mymath.BSDFReflection|mymath.BSDFDiffuse), 1e-9)
+	assertSpectrum(t, 0.75, si.BSDF.Rho(wo, randomSamples(10), mymath.BSDFReflection|mymath.BSDFSpecular), 1e-9)
+}
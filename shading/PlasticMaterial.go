@@ -0,0 +1,46 @@
+package shading
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/texture"
+)
+
+// PlasticMaterial is the diffuse surface with the glossy specular highlight
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/plastic.h#L48
+type PlasticMaterial struct {
+	Kd, Ks         texture.SpectrumTexture
+	Roughness      texture.FloatTexture
+	BumpMap        texture.FloatTexture
+	RemapRoughness bool
+}
+
+func NewPlasticMaterial(kd, ks texture.SpectrumTexture, roughness, bumpMap texture.FloatTexture, remapRoughness bool) *PlasticMaterial {
+	return &PlasticMaterial{kd, ks, roughness, bumpMap, remapRoughness}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/plastic.cpp#L44
+func (m *PlasticMaterial) ComputeScatteringFunctions(si *mymath.SurfaceInteraction, _ mymath.TransportMode, _ bool) {
+	// Perform bump mapping with bumpMap, if present
+	if m.BumpMap != nil {
+		Bump(m.BumpMap, si)
+	}
+	si.BSDF = mymath.NewBSDF(si, 1)
+
+	// Initialize diffuse component of plastic material
+	kd := evaluateSpectrum(m.Kd, si)
+	if !kd.IsBlack() {
+		si.BSDF.Add(mymath.NewLambertianReflection(kd))
+	}
+
+	// Initialize specular component of plastic material
+	ks := evaluateSpectrum(m.Ks, si)
+	if !ks.IsBlack() {
+		fresnel := mymath.NewFresnelDielectric(1.5, 1)
+
+		// Create microfacet distribution distrib for plastic material
+		rough := evaluateRoughness(m.Roughness, si, m.RemapRoughness)
+		distrib := mymath.NewTrowbridgeReitzDistribution(rough, rough, true)
+		si.BSDF.Add(mymath.NewMicrofacetReflection(ks, distrib, fresnel))
+	}
+}
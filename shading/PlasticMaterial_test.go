@@ -0,0 +1,27 @@
+package shading_test
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/shading"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlasticMaterial(t *testing.T) {
+	si := newSurfaceInteraction()
+	shading.NewPlasticMaterial(constantSpectrum(0.25), constantSpectrum(0.25), constant(0.1), nil, true).ComputeScatteringFunctions(si, mymath.Radiance, false)
+	assert.Equal(t, []mymath.BxDFType{
+		mymath.BSDFReflection | mymath.BSDFDiffuse,
+		mymath.BSDFReflection | mymath.BSDFGlossy,
+	}, bxdfTypes(si.BSDF))
+
+	// the roughness is remapped to the alpha
+	distrib := si.BSDF.BxDFs[1].(*mymath.MicrofacetReflection).Distribution.(*mymath.TrowbridgeReitzDistribution)
+	assert.Equal(t, mymath.RoughnessToAlpha(0.1), distrib.AlphaX)
+
+	// no specular component
+	si = newSurfaceInteraction()
+	shading.NewPlasticMaterial(constantSpectrum(0.25), constantSpectrum(0), constant(0.1), nil, false).ComputeScatteringFunctions(si, mymath.Radiance, false)
+	assert.Equal(t, []mymath.BxDFType{mymath.BSDFReflection | mymath.BSDFDiffuse}, bxdfTypes(si.BSDF))
+}
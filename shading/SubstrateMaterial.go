@@ -0,0 +1,38 @@
+package shading
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/texture"
+)
+
+// SubstrateMaterial is the diffuse base under the glossy coating, NU and NV are the roughnesses of the coating
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/substrate.h#L48
+type SubstrateMaterial struct {
+	Kd, Ks         texture.SpectrumTexture
+	NU, NV         texture.FloatTexture
+	BumpMap        texture.FloatTexture
+	RemapRoughness bool
+}
+
+func NewSubstrateMaterial(kd, ks texture.SpectrumTexture, nu, nv, bumpMap texture.FloatTexture, remapRoughness bool) *SubstrateMaterial {
+	return &SubstrateMaterial{kd, ks, nu, nv, bumpMap, remapRoughness}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/substrate.cpp#L44
+func (m *SubstrateMaterial) ComputeScatteringFunctions(si *mymath.SurfaceInteraction, _ mymath.TransportMode, _ bool) {
+	// Perform bump mapping with bumpMap, if present
+	if m.BumpMap != nil {
+		Bump(m.BumpMap, si)
+	}
+	si.BSDF = mymath.NewBSDF(si, 1)
+	d := evaluateSpectrum(m.Kd, si)
+	s := evaluateSpectrum(m.Ks, si)
+	roughU := evaluateRoughness(m.NU, si, m.RemapRoughness)
+	roughV := evaluateRoughness(m.NV, si, m.RemapRoughness)
+
+	if !d.IsBlack() || !s.IsBlack() {
+		distrib := mymath.NewTrowbridgeReitzDistribution(roughU, roughV, true)
+		si.BSDF.Add(mymath.NewFresnelBlend(d, s, distrib))
+	}
+}
@@ -0,0 +1,23 @@
+package shading_test
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/shading"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubstrateMaterial(t *testing.T) {
+	si := newSurfaceInteraction()
+	shading.NewSubstrateMaterial(constantSpectrum(0.5), constantSpectrum(0.5), constant(0.1), constant(0.2), nil, false).ComputeScatteringFunctions(si, mymath.Radiance, false)
+	assert.IsType(t, &mymath.FresnelBlend{}, si.BSDF.BxDFs[0])
+
+	distrib := si.BSDF.BxDFs[0].(*mymath.FresnelBlend).Distribution.(*mymath.TrowbridgeReitzDistribution)
+	assert.Equal(t, 0.1, distrib.AlphaX)
+	assert.Equal(t, 0.2, distrib.AlphaY)
+
+	// energy conservation
+	rho := si.BSDF.Rho(mymath.NewVector3(0.6, 0, 0.8), randomSamples(10000), mymath.BSDFAll)
+	assert.LessOrEqual(t, rho.MaxComponentValue(), 1.0)
+}
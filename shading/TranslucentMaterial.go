@@ -0,0 +1,63 @@
+package shading
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/texture"
+)
+
+// TranslucentMaterial is the thin surface that both reflects and transmits the light, diffusely by Kd and glossily
+// by Ks, Reflect and Transmit scale the reflected and the transmitted part
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/translucent.h#L48
+type TranslucentMaterial struct {
+	Kd, Ks            texture.SpectrumTexture
+	Roughness         texture.FloatTexture
+	Reflect, Transmit texture.SpectrumTexture
+	BumpMap           texture.FloatTexture
+	RemapRoughness    bool
+}
+
+func NewTranslucentMaterial(kd, ks texture.SpectrumTexture, roughness texture.FloatTexture, reflect, transmit texture.SpectrumTexture, bumpMap texture.FloatTexture, remapRoughness bool) *TranslucentMaterial {
+	return &TranslucentMaterial{kd, ks, roughness, reflect, transmit, bumpMap, remapRoughness}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/translucent.cpp#L44
+func (m *TranslucentMaterial) ComputeScatteringFunctions(si *mymath.SurfaceInteraction, mode mymath.TransportMode, _ bool) {
+	// Declare TranslucentMaterial coefficients
+	eta := 1.5
+
+	// Perform bump mapping with bumpMap, if present
+	if m.BumpMap != nil {
+		Bump(m.BumpMap, si)
+	}
+	si.BSDF = mymath.NewBSDF(si, eta)
+
+	r := evaluateSpectrum(m.Reflect, si)
+	t := evaluateSpectrum(m.Transmit, si)
+	if r.IsBlack() && t.IsBlack() {
+		return
+	}
+
+	kd := evaluateSpectrum(m.Kd, si)
+	if !kd.IsBlack() {
+		if !r.IsBlack() {
+			si.BSDF.Add(mymath.NewLambertianReflection(r.MultiplyS(kd)))
+		}
+		if !t.IsBlack() {
+			si.BSDF.Add(mymath.NewLambertianTransmission(t.MultiplyS(kd)))
+		}
+	}
+
+	ks := evaluateSpectrum(m.Ks, si)
+	if !ks.IsBlack() && (!r.IsBlack() || !t.IsBlack()) {
+		rough := evaluateRoughness(m.Roughness, si, m.RemapRoughness)
+		distrib := mymath.NewTrowbridgeReitzDistribution(rough, rough, true)
+		if !r.IsBlack() {
+			fresnel := mymath.NewFresnelDielectric(1, eta)
+			si.BSDF.Add(mymath.NewMicrofacetReflection(r.MultiplyS(ks), distrib, fresnel))
+		}
+		if !t.IsBlack() {
+			si.BSDF.Add(mymath.NewMicrofacetTransmission(t.MultiplyS(ks), distrib, 1, eta, mode))
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package shading_test
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/shading"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslucentMaterial(t *testing.T) {
+	si := newSurfaceInteraction()
+	shading.NewTranslucentMaterial(constantSpectrum(0.25), constantSpectrum(0.25), constant(0.1), constantSpectrum(0.5), constantSpectrum(0.5), nil, false).
+		ComputeScatteringFunctions(si, mymath.Radiance, false)
+	assert.Equal(t, 1.5, si.BSDF.Eta)
+	assert.Equal(t, []mymath.BxDFType{
+		mymath.BSDFReflection | mymath.BSDFDiffuse,
+		mymath.BSDFTransmission | mymath.BSDFDiffuse,
+		mymath.BSDFReflection | mymath.BSDFGlossy,
+		mymath.BSDFTransmission | mymath.BSDFGlossy,
+	}, bxdfTypes(si.BSDF))
+
+	// diffuse transmission is scaled by Transmit
+	assertSpectrum(t, 0.125, si.BSDF.Rho(mymath.NewVector3(0, 0, 1), nil, mymath.BSDFTransmission|mymath.BSDFDiffuse), 1e-9)
+
+	// no reflection
+	si = newSurfaceInteraction()
+	shading.NewTranslucentMaterial(constantSpectrum(0.25), constantSpectrum(0.25), constant(0.1), constantSpectrum(0), constantSpectrum(0.5), nil, false).
+		ComputeScatteringFunctions(si, mymath.Radiance, false)
+	assert.Equal(t, []mymath.BxDFType{
+		mymath.BSDFTransmission | mymath.BSDFDiffuse,
+		mymath.BSDFTransmission | mymath.BSDFGlossy,
+	}, bxdfTypes(si.BSDF))
+}
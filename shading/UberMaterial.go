@@ -0,0 +1,88 @@
+package shading
+
+import (
+	"math"
+	"pbrt-go/mymath"
+	"pbrt-go/spectrum"
+	"pbrt-go/texture"
+)
+
+// UberMaterial combines the diffuse, glossy and specular lobes, Opacity makes the surface partially transparent,
+// URoughness and VRoughness override Roughness for the anisotropic surfaces
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/uber.h#L48
+type UberMaterial struct {
+	Kd, Ks, Kr, Kt                    texture.SpectrumTexture
+	Opacity                           texture.SpectrumTexture
+	Roughness, URoughness, VRoughness texture.FloatTexture
+	Eta                               texture.FloatTexture
+	BumpMap                           texture.FloatTexture
+	RemapRoughness                    bool
+}
+
+func NewUberMaterial(kd, ks, kr, kt texture.SpectrumTexture, roughness, uRoughness, vRoughness texture.FloatTexture,
+	opacity texture.SpectrumTexture, eta, bumpMap texture.FloatTexture, remapRoughness bool) *UberMaterial {
+	return &UberMaterial{
+		Kd:             kd,
+		Ks:             ks,
+		Kr:             kr,
+		Kt:             kt,
+		Opacity:        opacity,
+		Roughness:      roughness,
+		URoughness:     uRoughness,
+		VRoughness:     vRoughness,
+		Eta:            eta,
+		BumpMap:        bumpMap,
+		RemapRoughness: remapRoughness,
+	}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/materials/uber.cpp#L45
+func (m *UberMaterial) ComputeScatteringFunctions(si *mymath.SurfaceInteraction, mode mymath.TransportMode, _ bool) {
+	// Perform bump mapping with bumpMap, if present
+	if m.BumpMap != nil {
+		Bump(m.BumpMap, si)
+	}
+	e := float64(m.Eta.Evaluate(si))
+
+	op := evaluateSpectrum(m.Opacity, si)
+	t := spectrum.NewSpectrum(1).Subtract(op).Clamp(0, math.Inf(1))
+	if !t.IsBlack() {
+		si.BSDF = mymath.NewBSDF(si, 1)
+		si.BSDF.Add(mymath.NewSpecularTransmission(t, 1, 1, mode))
+	} else {
+		si.BSDF = mymath.NewBSDF(si, e)
+	}
+
+	kd := op.MultiplyS(evaluateSpectrum(m.Kd, si))
+	if !kd.IsBlack() {
+		si.BSDF.Add(mymath.NewLambertianReflection(kd))
+	}
+
+	ks := op.MultiplyS(evaluateSpectrum(m.Ks, si))
+	if !ks.IsBlack() {
+		fresnel := mymath.NewFresnelDielectric(1, e)
+		uRoughness, vRoughness := m.Roughness, m.Roughness
+		if m.URoughness != nil {
+			uRoughness = m.URoughness
+		}
+		if m.VRoughness != nil {
+			vRoughness = m.VRoughness
+		}
+		uRough := evaluateRoughness(uRoughness, si, m.RemapRoughness)
+		vRough := evaluateRoughness(vRoughness, si, m.RemapRoughness)
+		distrib := mymath.NewTrowbridgeReitzDistribution(uRough, vRough, true)
+		si.BSDF.Add(mymath.NewMicrofacetReflection(ks, distrib, fresnel))
+	}
+
+	kr := op.MultiplyS(evaluateSpectrum(m.Kr, si))
+	if !kr.IsBlack() {
+		fresnel := mymath.NewFresnelDielectric(1, e)
+		si.BSDF.Add(mymath.NewSpecularReflection(kr, fresnel))
+	}
+
+	kt := op.MultiplyS(evaluateSpectrum(m.Kt, si))
+	if !kt.IsBlack() {
+		si.BSDF.Add(mymath.NewSpecularTransmission(kt, 1, e, mode))
+	}
+}
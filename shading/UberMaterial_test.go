@@ -0,0 +1,39 @@
+package shading_test
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/mymath/mymathtest"
+	"pbrt-go/shading"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUberMaterial(t *testing.T) {
+	uber := shading.NewUberMaterial(constantSpectrum(0.25), constantSpectrum(0.25), constantSpectrum(0.1), constantSpectrum(0.1),
+		constant(0.1), nil, nil, constantSpectrum(1), constant(1.5), nil, false)
+
+	si := newSurfaceInteraction()
+	uber.ComputeScatteringFunctions(si, mymath.Radiance, false)
+	assert.Equal(t, 1.5, si.BSDF.Eta)
+	assert.Equal(t, []mymath.BxDFType{
+		mymath.BSDFReflection | mymath.BSDFDiffuse,
+		mymath.BSDFReflection | mymath.BSDFGlossy,
+		mymath.BSDFReflection | mymath.BSDFSpecular,
+		mymath.BSDFTransmission | mymath.BSDFSpecular,
+	}, bxdfTypes(si.BSDF))
+
+	// half transparent surface passes the light straight through
+	uber.Opacity = constantSpectrum(0.5)
+	si = newSurfaceInteraction()
+	uber.ComputeScatteringFunctions(si, mymath.Radiance, false)
+	assert.Equal(t, 1.0, si.BSDF.Eta)
+	assert.Len(t, si.BSDF.BxDFs, 5)
+
+	wo := mymath.NewVector3(0.6, 0, 0.8)
+	transparency := si.BSDF.BxDFs[0]
+	wi, f, _, _ := transparency.SampleF(wo, mymath.NewPoint2(0.5, 0.5))
+	mymathtest.InDeltaVector3(t, wo.Negate(), wi, 1e-5)
+	assertSpectrum(t, 0.5, f.Multiply(mymath.AbsCosTheta(wi)), 1e-9)
+	assertSpectrum(t, 0.125, si.BSDF.Rho(wo, nil, mymath.BSDFReflection|mymath.BSDFDiffuse), 1e-9)
+}
@@ -0,0 +1,42 @@
+package spectrum
+
+import "math"
+
+// Blackbody returns emitted radiance of the blackbody at temperature T in Kelvin for the wavelengths lambda in nm
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/spectrum.cpp#L1082
+func Blackbody(lambda []float64, T float64) []float64 {
+	const c = 299792458
+	const h = 6.62606957e-34
+	const kb = 1.3806488e-23
+
+	Le := make([]float64, len(lambda))
+	if T <= 0 {
+		return Le
+	}
+
+	for i, lambdaNm := range lambda {
+		// Compute emitted radiance for blackbody at wavelength lambda[i]
+		l := lambdaNm * 1e-9
+		lambda5 := (l * l) * (l * l) * l
+		Le[i] = (2 * h * c * c) / (lambda5 * (math.Exp((h*c)/(l*kb*T)) - 1))
+	}
+
+	return Le
+}
+
+// BlackbodyNormalized returns blackbody radiance scaled so that its maximum over all the wavelengths is 1
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/spectrum.cpp#L1099
+func BlackbodyNormalized(lambda []float64, T float64) []float64 {
+	Le := Blackbody(lambda, T)
+
+	// Normalize Le values based on maximum blackbody radiance, Wien's displacement law gives its wavelength
+	lambdaMax := 2.8977721e-3 / T
+	maxL := Blackbody([]float64{lambdaMax * 1e9}, T)[0]
+	for i := range Le {
+		Le[i] /= maxL
+	}
+
+	return Le
+}
@@ -0,0 +1,37 @@
+package spectrum_test
+
+import (
+	"pbrt-go/spectrum"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlackbody(t *testing.T) {
+	// Values from the pbrt test suite
+	// see https://github.com/mmp/pbrt-v3/blob/master/src/tests/spectrum.cpp#L8
+	v := [][3]float64{
+		{483, 6000, 3.1849e13},
+		{600, 6000, 2.86772e13},
+		{500, 3700, 1.59845e12},
+		{600, 4500, 7.46497e12},
+	}
+	for _, e := range v {
+		Le := spectrum.Blackbody([]float64{e[0]}, e[1])
+		assert.InDelta(t, 1, Le[0]/e[2], 1e-4)
+	}
+
+	assert.Equal(t, []float64{0}, spectrum.Blackbody([]float64{500}, 0))
+}
+
+func TestBlackbodyNormalized(t *testing.T) {
+	// Normalized values peak at the wavelength given by Wien's displacement law
+	for _, T := range []float64{2700, 3000, 4500, 5600, 6000} {
+		lambdaMax := 2.8977721e-3 / T * 1e9
+		Le := spectrum.BlackbodyNormalized([]float64{lambdaMax, lambdaMax - 10, lambdaMax + 10}, T)
+
+		assert.InDelta(t, 1, Le[0], 1e-12)
+		assert.Less(t, Le[1], 1.0)
+		assert.Less(t, Le[2], 1.0)
+	}
+}
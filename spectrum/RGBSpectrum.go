@@ -0,0 +1,170 @@
+package spectrum
+
+import "math"
+
+// RGBSpectrum represents the spectrum by the weights of the linear sRGB primaries
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/spectrum.h#L384
+type RGBSpectrum [3]float64
+
+// NewRGBSpectrum creates constant spectrum
+func NewRGBSpectrum(v float64) RGBSpectrum {
+	return RGBSpectrum{v, v, v}
+}
+
+// RGBSpectrumFromRGB creates spectrum of the linear sRGB color, the spectrum type has no effect
+func RGBSpectrumFromRGB(rgb [3]float64, _ SpectrumType) RGBSpectrum {
+	return RGBSpectrum(rgb)
+}
+
+// FromRGB is RGBSpectrumFromRGB usable through the value, e.g. by the generic textures
+func (RGBSpectrum) FromRGB(rgb [3]float64, spectrumType SpectrumType) RGBSpectrum {
+	return RGBSpectrumFromRGB(rgb, spectrumType)
+}
+
+// RGBSpectrumFromXYZ creates spectrum of the CIE XYZ color
+func RGBSpectrumFromXYZ(xyz [3]float64, _ SpectrumType) RGBSpectrum {
+	return RGBSpectrum(XYZToRGB(xyz))
+}
+
+// RGBSpectrumFromSampled creates spectrum of the piecewise-linear function given by the samples (lambda, v),
+// the wavelengths are in nm and need not to be sorted
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/spectrum.h#L422
+func RGBSpectrumFromSampled(lambda, v []float64) RGBSpectrum {
+	lambda, v = sortSpectrumSamples(lambda, v)
+
+	var xyz [3]float64
+	for i := 0; i < NCIESamples; i++ {
+		val := InterpolateSpectrumSamples(lambda, v, CIELambda[i])
+		xyz[0] += val * CIEX[i]
+		xyz[1] += val * CIEY[i]
+		xyz[2] += val * CIEZ[i]
+	}
+
+	scale := CIELambdaStep / CIEYIntegral
+	for i := range xyz {
+		xyz[i] *= scale
+	}
+
+	return RGBSpectrumFromXYZ(xyz, Reflectance)
+}
+
+func (s RGBSpectrum) Add(s2 RGBSpectrum) RGBSpectrum {
+	for i := range s {
+		s[i] += s2[i]
+	}
+	return s
+}
+
+func (s RGBSpectrum) Subtract(s2 RGBSpectrum) RGBSpectrum {
+	for i := range s {
+		s[i] -= s2[i]
+	}
+	return s
+}
+
+// MultiplyS multiplies the spectra component-wise
+func (s RGBSpectrum) MultiplyS(s2 RGBSpectrum) RGBSpectrum {
+	for i := range s {
+		s[i] *= s2[i]
+	}
+	return s
+}
+
+// DivideS divides the spectra component-wise
+func (s RGBSpectrum) DivideS(s2 RGBSpectrum) RGBSpectrum {
+	for i := range s {
+		s[i] /= s2[i]
+	}
+	return s
+}
+
+func (s RGBSpectrum) Multiply(d float64) RGBSpectrum {
+	for i := range s {
+		s[i] *= d
+	}
+	return s
+}
+
+func (s RGBSpectrum) Divide(d float64) RGBSpectrum {
+	inv := 1 / d
+	return s.Multiply(inv)
+}
+
+func (s RGBSpectrum) Negate() RGBSpectrum {
+	return s.Multiply(-1)
+}
+
+func (s RGBSpectrum) Sqrt() RGBSpectrum {
+	for i := range s {
+		s[i] = math.Sqrt(s[i])
+	}
+	return s
+}
+
+func (s RGBSpectrum) Exp() RGBSpectrum {
+	for i := range s {
+		s[i] = math.Exp(s[i])
+	}
+	return s
+}
+
+func (s RGBSpectrum) Pow(e float64) RGBSpectrum {
+	for i := range s {
+		s[i] = math.Pow(s[i], e)
+	}
+	return s
+}
+
+func (s1 RGBSpectrum) Lerp(t float64, s2 RGBSpectrum) RGBSpectrum {
+	return s1.Multiply(1 - t).Add(s2.Multiply(t))
+}
+
+func (s RGBSpectrum) Clamp(low, high float64) RGBSpectrum {
+	for i := range s {
+		s[i] = math.Min(math.Max(s[i], low), high)
+	}
+	return s
+}
+
+func (s RGBSpectrum) IsBlack() bool {
+	for _, c := range s {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (s RGBSpectrum) HasNaNs() bool {
+	for _, c := range s {
+		if math.IsNaN(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s RGBSpectrum) MaxComponentValue() float64 {
+	return math.Max(s[0], math.Max(s[1], s[2]))
+}
+
+func (s RGBSpectrum) ToRGB() [3]float64 {
+	return s
+}
+
+func (s RGBSpectrum) ToXYZ() [3]float64 {
+	return RGBToXYZ(s)
+}
+
+func (s RGBSpectrum) ToRGBSpectrum() RGBSpectrum {
+	return s
+}
+
+// Y returns the luminance
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/spectrum.h#L460
+func (s RGBSpectrum) Y() float64 {
+	return 0.212671*s[0] + 0.715160*s[1] + 0.072169*s[2]
+}
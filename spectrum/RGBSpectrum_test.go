@@ -0,0 +1,33 @@
+package spectrum_test
+
+import (
+	"pbrt-go/spectrum"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRGBSpectrum(t *testing.T) {
+	s := spectrum.RGBSpectrumFromRGB([3]float64{0.2, 0.5, 0.9}, spectrum.Reflectance)
+
+	assert.Equal(t, [3]float64{0.2, 0.5, 0.9}, s.ToRGB())
+	assert.InDelta(t, 0.212671*0.2+0.715160*0.5+0.072169*0.9, s.Y(), 1e-12)
+	assert.InDelta(t, s.ToXYZ()[1], s.Y(), 1e-12)
+	assert.InDeltaSlice(t, toSlice(s), toSlice(spectrum.RGBSpectrumFromXYZ(s.ToXYZ(), spectrum.Reflectance)), 1e-5)
+	assert.Equal(t, 0.9, s.MaxComponentValue())
+}
+
+func TestRGBSpectrumFromSampled(t *testing.T) {
+	// spectrum converted from the samples matches the sampled spectrum color
+	lambda := []float64{400, 450, 500, 550, 600, 650, 700}
+	vals := []float64{0.1, 0.3, 0.8, 0.6, 0.4, 0.5, 0.2}
+
+	rgb := spectrum.RGBSpectrumFromSampled(lambda, vals)
+	sampled := spectrum.SampledSpectrumFromSampled(lambda, vals)
+	assert.InDeltaSlice(t, toSlice(sampled.ToRGB()), rgb[:], 1e-2)
+}
+
+func TestSampledSpectrum_ToRGBSpectrum(t *testing.T) {
+	s := spectrum.SampledSpectrumFromRGB([3]float64{0.3, 0.6, 0.1}, spectrum.Illuminant)
+	assert.InDeltaSlice(t, []float64{0.3, 0.6, 0.1}, toSlice(s.ToRGBSpectrum()), 1e-6)
+}
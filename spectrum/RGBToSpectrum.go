@@ -0,0 +1,212 @@
+package spectrum
+
+// rgbToSpectrumBasis holds the spectra used by Smits' RGB to spectrum conversion
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/spectrum.h#L300
+type rgbToSpectrumBasis struct {
+	white, cyan, magenta, yellow, red, green, blue SampledSpectrum
+}
+
+// The basis spectra for the illuminants and for the reflectances
+var (
+	rgbIllumBasis = newRGBToSpectrumBasis(rgbIllum2SpectWhite, rgbIllum2SpectCyan, rgbIllum2SpectMagenta,
+		rgbIllum2SpectYellow, rgbIllum2SpectRed, rgbIllum2SpectGreen, rgbIllum2SpectBlue)
+	rgbReflBasis = newRGBToSpectrumBasis(rgbRefl2SpectWhite, rgbRefl2SpectCyan, rgbRefl2SpectMagenta,
+		rgbRefl2SpectYellow, rgbRefl2SpectRed, rgbRefl2SpectGreen, rgbRefl2SpectBlue)
+)
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/spectrum.h#L296
+func newRGBToSpectrumBasis(white, cyan, magenta, yellow, red, green, blue []float64) rgbToSpectrumBasis {
+	fromSampled := func(v []float64) SampledSpectrum {
+		return SampledSpectrumFromSampled(rgb2SpectLambda, v)
+	}
+
+	return rgbToSpectrumBasis{
+		fromSampled(white), fromSampled(cyan), fromSampled(magenta), fromSampled(yellow),
+		fromSampled(red), fromSampled(green), fromSampled(blue),
+	}
+}
+
+// The scales of the spectra combined from the reflectance and illuminant basis
+const (
+	rgbReflScale  = 0.94
+	rgbIllumScale = 0.86445
+)
+
+// nRGB2SpectSamples is number of samples of the basis spectra tables
+const nRGB2SpectSamples = 32
+
+// rgb2SpectLambda holds wavelengths of the basis spectra tables, they are uniformly spaced from 380 nm to 720 nm
+var rgb2SpectLambda = func() []float64 {
+	lambda := make([]float64, nRGB2SpectSamples)
+	for i := range lambda {
+		lambda[i] = 380 + float64(i)*(720-380)/(nRGB2SpectSamples-1)
+	}
+	return lambda
+}()
+
+// The tables use the wavelengths of pbrt, but their values are not Smits' ones. Each basis spectrum is the smoothest
+// non-negative spectrum (minimal sum of squared differences of the adjacent samples) having the exact color of its
+// basis RGB under the CIE tables of this package once scaled by rgbReflScale or rgbIllumScale, so every color
+// converts back to itself. As both sets of spectra meet the same constraints, they differ only by the ratio
+// of the scales. The scaled reflectances exceed 1 at their peaks, by 10 % for white and up to 29 % for magenta.
+// They cannot stay within [0, 1] and still reflect the white of sRGB as the equal energy spectrum appears reddish,
+// approximately (1.20, 0.95, 0.91).
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/spectrum.cpp
+
+// The reflectance basis spectra
+var rgbRefl2SpectWhite = []float64{
+	1.1570033714878853e+00, 1.1570033714878853e+00, 1.1570047120595437e+00, 1.1570844721951379e+00,
+	1.1574881552601970e+00, 1.1587394565394482e+00, 1.1612443164967672e+00, 1.1648623941015603e+00,
+	1.1690262592675109e+00, 1.1728459433401437e+00, 1.1752600272255544e+00, 1.1751625462774933e+00,
+	1.1711452495553549e+00, 1.1614914715591700e+00, 1.1449874029184626e+00, 1.1215689328672134e+00,
+	1.0921815281660550e+00, 1.0586126190721370e+00, 1.0233363486813607e+00, 9.8923085669228750e-01,
+	9.5906188392136649e-01, 9.3481781213275494e-01, 9.1720735699284262e-01, 9.0563402491428224e-01,
+	8.9869928410308908e-01, 8.9491720585286150e-01, 8.9303757412169416e-01, 8.9218351382252437e-01,
+	8.9184453108419226e-01, 8.9175403191820513e-01, 8.9175228467843748e-01, 8.9175228467843759e-01,
+}
+
+var rgbRefl2SpectCyan = []float64{
+	1.0650249171208594e+00, 1.0650249171208594e+00, 1.0650368700002832e+00, 1.0657495763657110e+00,
+	1.0693814926743324e+00, 1.0808106115724248e+00, 1.1043069149274773e+00, 1.1398043834873750e+00,
+	1.1840126010148804e+00, 1.2310124081689957e+00, 1.2733062376878106e+00, 1.3034201235487746e+00,
+	1.3126983323083412e+00, 1.2909741896382851e+00, 1.2308665275178892e+00, 1.1313870938260098e+00,
+	9.9715413559906130e-01, 8.3744683488206428e-01, 6.6535252867499839e-01, 4.9626093731010124e-01,
+	3.4511034112805078e-01, 2.2282654977582894e-01, 1.3362582614358487e-01, 7.4847349325095069e-02,
+	3.9567390377959456e-02, 2.0306680254343491e-02, 1.0728909901041176e-02, 6.3755678440913684e-03,
+	4.6473356279753582e-03, 4.1858978616466785e-03, 4.1769888160621826e-03, 4.1769888160624316e-03,
+}
+
+var rgbRefl2SpectMagenta = []float64{
+	1.3676352959783813e+00, 1.3676352959783813e+00, 1.3675915712537861e+00, 1.3649797354494699e+00,
+	1.3515958840230997e+00, 1.3089806767098306e+00, 1.2196197618476048e+00, 1.0803240964742735e+00,
+	8.9803076389430436e-01, 6.8869631556376021e-01, 4.7444255056163409e-01, 2.7637974571321400e-01,
+	1.1602564834653784e-01, 1.7492979089206884e-02, 0.0000000000000000e+00, 0.0000000000000000e+00,
+	2.8296450340615342e-02, 1.2675417933944497e-01, 2.7443022882552975e-01, 4.4528623876733686e-01,
+	6.1275760640664301e-01, 7.5581676482890525e-01, 8.6362997350495319e-01, 9.3611138882252320e-01,
+	9.8016299108460037e-01, 1.0043918511082626e+00, 1.0164902741717816e+00, 1.0220023170963635e+00,
+	1.0241938069974819e+00, 1.0247793599150044e+00, 1.0247906671559344e+00, 1.0247906671559346e+00,
+}
+
+var rgbRefl2SpectYellow = []float64{
+	0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00,
+	0.0000000000000000e+00, 0.0000000000000000e+00, 6.0157063014957268e-03, 7.1217417488375470e-02,
+	1.9086963655397329e-01, 3.5080456579433072e-01, 5.3035229412738683e-01, 7.1122859812141825e-01,
+	8.7810968238660736e-01, 1.0160832838175480e+00, 1.1135635223940499e+00, 1.1659678360614198e+00,
+	1.1746472692040584e+00, 1.1457731465923533e+00, 1.0894615422805207e+00, 1.0185173184698011e+00,
+	9.4616199482344476e-01, 8.8303568196031978e-01, 8.3489149303411558e-01, 8.0229493037509658e-01,
+	7.8239810446488578e-01, 7.7142682769311210e-01, 7.6594069580159929e-01, 7.6343921221395716e-01,
+	7.6244416547609373e-01, 7.6217822973411486e-01, 7.6217309412865297e-01, 7.6217309412865286e-01,
+}
+
+var rgbRefl2SpectRed = []float64{
+	5.1937965367821533e-02, 5.1937965367821533e-02, 5.1929184928178572e-02, 5.1409100591110458e-02,
+	4.8820047790960885e-02, 4.1159128606089605e-02, 2.7249339473417518e-02, 1.0840856977401069e-02,
+	0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00,
+	0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00,
+	0.0000000000000000e+00, 4.7796480155776602e-02, 1.8848269102829585e-01, 3.8193488309529910e-01,
+	5.8647056403227782e-01, 7.6817943738570249e-01, 9.0814143500535072e-01, 1.0034520883554838e+00,
+	1.0618312403180761e+00, 1.0940870229480475e+00, 1.1102342095245705e+00, 1.1176013458554823e+00,
+	1.1205330130678492e+00, 1.1213166784282953e+00, 1.1213318128060632e+00, 1.1213318128060630e+00,
+}
+
+var rgbRefl2SpectGreen = []float64{
+	0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00,
+	0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00,
+	1.1270932317426482e-01, 3.1362280436771878e-01, 5.5691818611268473e-01, 8.0248081208277267e-01,
+	1.0171863867231992e+00, 1.1696423172272419e+00, 1.2369929575746361e+00, 1.2126482188915766e+00,
+	1.1034719829514217e+00, 9.2727779477995764e-01, 7.1072927667759955e-01, 4.8605669131510326e-01,
+	2.8523260130404182e-01, 1.3245985742666758e-01, 3.8461483126911923e-02, 0.0000000000000000e+00,
+	0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00,
+	0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00,
+}
+
+var rgbRefl2SpectBlue = []float64{
+	1.2920058401977146e+00, 1.2920058401977146e+00, 1.2919708880529721e+00, 1.2898816345961233e+00,
+	1.2791536388545315e+00, 1.2448544481481461e+00, 1.1724555459093622e+00, 1.0584654051765636e+00,
+	9.0704202536355627e-01, 7.2946843038347564e-01, 5.4222857268665958e-01, 3.6093904690000822e-01,
+	2.0077695780941429e-01, 7.8624669760732013e-02, 9.1605404614465424e-03, 0.0000000000000000e+00,
+	0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00,
+	0.0000000000000000e+00, 9.0555017256827020e-03, 2.0398913690213922e-02, 2.9844346715381605e-02,
+	3.6262954230823485e-02, 4.0012869810000240e-02, 4.1946280637605929e-02, 4.2842873410504786e-02,
+	4.3203284660113458e-02, 4.3300097181545302e-02, 4.3301968938757034e-02, 4.3301968938756985e-02,
+}
+
+// The illuminant basis spectra
+var rgbIllum2SpectWhite = []float64{
+	1.2581215445643033e+00, 1.2581215445643033e+00, 1.2581230022973808e+00, 1.2582097331984843e+00,
+	1.2586486967951700e+00, 1.2600093575650195e+00, 1.2627331337925398e+00, 1.2666674191167406e+00,
+	1.2711951919850311e+00, 1.2753487034990283e+00, 1.2779737701336353e+00, 1.2778677696811191e+00,
+	1.2734993748418455e+00, 1.2630018893696795e+00, 1.2450554210692979e+00, 1.2195902561110306e+00,
+	1.1876344918457880e+00, 1.1511317738768101e+00, 1.1127724770206247e+00, 1.0756862806301695e+00,
+	1.0428806418949441e+00, 1.0165177204057949e+00, 9.9736817117620669e-01, 9.8478336910107589e-01,
+	9.7724255544786109e-01, 9.7312993637768497e-01, 9.7108603120410952e-01, 9.7015732892957685e-01,
+	9.6978872024887564e-01, 9.6969031176252252e-01, 9.6968841181992149e-01, 9.6968841181992160e-01,
+}
+
+var rgbIllum2SpectCyan = []float64{
+	1.1581044850409021e+00, 1.1581044850409021e+00, 1.1581174825614740e+00, 1.1588924770475659e+00,
+	1.1628418105314042e+00, 1.1752697956828955e+00, 1.2008195963119079e+00, 1.2394194233074585e+00,
+	1.2874912892058390e+00, 1.3385987202022740e+00, 1.3845888870687049e+00, 1.4173346244847564e+00,
+	1.4274237172419926e+00, 1.4038009581352164e+00, 1.3384400900767142e+00, 1.2302664910595744e+00,
+	1.0843020272579298e+00, 9.1063684977632053e-01, 7.2350208450980213e-01, 5.3963246118514085e-01,
+	3.7527181521240982e-01, 2.4230083496937843e-01, 1.4530427043203167e-01, 8.1388753965630597e-02,
+	4.3025446185761915e-02, 2.2081415280331876e-02, 1.1666580261413279e-02, 6.9327708640706670e-03,
+	5.0534970100027075e-03, 4.5517311469117673e-03, 4.5420434809398495e-03, 4.5420434809401210e-03,
+}
+
+var rgbIllum2SpectMagenta = []float64{
+	1.4871619853313418e+00, 1.4871619853313418e+00, 1.4871144392140190e+00, 1.4842743378130621e+00,
+	1.4697207831357666e+00, 1.4233811511449370e+00, 1.3262103952070661e+00, 1.1747407608141787e+00,
+	9.7651560883873689e-01, 7.4888603925031472e-01, 5.1590722138693512e-01, 3.0053439871643395e-01,
+	1.2616589675024059e-01, 1.9021806170228972e-02, 0.0000000000000000e+00, 0.0000000000000000e+00,
+	3.0769464191310547e-02, 1.3783206498823355e-01, 2.9841450066053304e-01, 4.8420274676533859e-01,
+	6.6631054430244019e-01, 8.2187258828060705e-01, 9.3910830596871520e-01, 1.0179243513137506e+00,
+	1.0658259143033422e+00, 1.0921722945708447e+00, 1.1053280788032558e+00, 1.1113218555967164e+00,
+	1.1137048742872726e+00, 1.1143416025450914e+00, 1.1143538980005534e+00, 1.1143538980005536e+00,
+}
+
+var rgbIllum2SpectYellow = []float64{
+	0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00,
+	0.0000000000000000e+00, 0.0000000000000000e+00, 6.5414586423807042e-03, 7.7441578389811958e-02,
+	2.0755099584792056e-01, 3.8146369581429918e-01, 5.7670328703770446e-01, 7.7338756693172883e-01,
+	9.5485349232854511e-01, 1.1048855188715310e+00, 1.2108851998963581e+00, 1.2678694729570648e+00,
+	1.2773074591379658e+00, 1.2459098360770566e+00, 1.1846767884130829e+00, 1.1075322799023808e+00,
+	1.0288533462132432e+00, 9.6021000756862829e-01, 9.0785817971203497e-01, 8.7241278796065769e-01,
+	8.5077704690495981e-01, 8.3884691772979958e-01, 8.3288131650587471e-01, 8.3016121173129698e-01,
+	8.2907920128119394e-01, 8.2879002365673893e-01, 8.2878443921676659e-01, 8.2878443921676637e-01,
+}
+
+var rgbIllum2SpectRed = []float64{
+	5.6477167500436389e-02, 5.6477167500436389e-02, 5.6467619680129394e-02, 5.5902081734795335e-02,
+	5.3086754495347603e-02, 4.4756296939932015e-02, 2.9630839383437406e-02, 1.1788311132809310e-02,
+	0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00,
+	0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00,
+	0.0000000000000000e+00, 5.1973730518167588e-02, 2.0495543937370364e-01, 4.1531469733308018e-01,
+	6.3772610352286574e-01, 8.3531571651635161e-01, 9.8750991833539215e-01, 1.0911503997387406e+00,
+	1.1546316917103261e+00, 1.1897065204131700e+00, 1.2072649163665869e+00, 1.2152759154423660e+00,
+	1.2184638004323880e+00, 1.2193159554891522e+00, 1.2193324125602396e+00, 1.2193324125602394e+00,
+}
+
+var rgbIllum2SpectGreen = []float64{
+	0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00,
+	0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00,
+	1.2255973599839082e-01, 3.4103237446429019e-01, 6.0559094793906365e-01, 8.7261491509955014e-01,
+	1.1060850292322368e+00, 1.2718650913223517e+00, 1.3451019493552638e+00, 1.3186295630262963e+00,
+	1.1999116941110950e+00, 1.0083187310927875e+00, 7.7284460648613973e-01, 5.2853639867684299e-01,
+	3.1016096387969144e-01, 1.4403640000123488e-01, 4.1822886389377305e-02, 0.0000000000000000e+00,
+	0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00,
+	0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00,
+}
+
+var rgbIllum2SpectBlue = []float64{
+	1.4049227714568240e+00, 1.4049227714568240e+00, 1.4048847646130993e+00, 1.4026129174855178e+00,
+	1.3909473312779910e+00, 1.3536505075588607e+00, 1.2749241866560244e+00, 1.1509716939857364e+00,
+	9.8631442401728608e-01, 7.9322149871070280e-01, 5.8961751208914337e-01, 3.9248389621841384e-01,
+	2.1832418340083248e-01, 8.5496199404347495e-02, 9.9611406486896925e-03, 0.0000000000000000e+00,
+	0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00, 0.0000000000000000e+00,
+	0.0000000000000000e+00, 9.8469218834423833e-03, 2.2181709605877842e-02, 3.2452641462732044e-02,
+	3.9432213519548932e-02, 4.3509859010238038e-02, 4.5612243390999632e-02, 4.6587195333303869e-02,
+	4.6979105304536674e-02, 4.7084378912201519e-02, 4.7086414254649288e-02, 4.7086414254649295e-02,
+}
@@ -0,0 +1,268 @@
+package spectrum
+
+import "math"
+
+const (
+	// SampledLambdaStart is the start of the wavelength range of SampledSpectrum in nm
+	SampledLambdaStart = 400
+	// SampledLambdaEnd is the end of the wavelength range of SampledSpectrum in nm
+	SampledLambdaEnd = 700
+	// NSpectralSamples is number of the equal sized wavelength bins of SampledSpectrum
+	NSpectralSamples = 60
+)
+
+// SampledSpectrum represents the spectrum by its average values over the equal sized wavelength bins
+// of the range [SampledLambdaStart, SampledLambdaEnd]
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/spectrum.h#L259
+type SampledSpectrum [NSpectralSamples]float64
+
+// The color matching functions averaged over the SampledSpectrum bins
+var sampledX, sampledY, sampledZ = sampledCIE()
+
+// NewSampledSpectrum creates constant spectrum
+func NewSampledSpectrum(v float64) SampledSpectrum {
+	var s SampledSpectrum
+	for i := range s {
+		s[i] = v
+	}
+	return s
+}
+
+// SampledSpectrumFromSampled creates spectrum of the piecewise-linear function given by the samples (lambda, v),
+// the wavelengths are in nm and need not to be sorted
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/spectrum.h#L265
+func SampledSpectrumFromSampled(lambda, v []float64) SampledSpectrum {
+	lambda, v = sortSpectrumSamples(lambda, v)
+
+	var s SampledSpectrum
+	for i := range s {
+		// Compute average value of given SPD over i-th sample's range
+		lambda0, lambda1 := sampledBin(i)
+		s[i] = AverageSpectrumSamples(lambda, v, lambda0, lambda1)
+	}
+	return s
+}
+
+// SampledSpectrumFromRGB creates smooth spectrum of the linear sRGB color using Smits' method, it combines
+// the basis spectra of white and the primary and secondary colors
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/spectrum.cpp#L1016
+func SampledSpectrumFromRGB(rgb [3]float64, spectrumType SpectrumType) SampledSpectrum {
+	basis, scale := &rgbIllumBasis, rgbIllumScale
+	if spectrumType == Reflectance {
+		basis, scale = &rgbReflBasis, rgbReflScale
+	}
+
+	var r SampledSpectrum
+	add := func(w float64, s SampledSpectrum) {
+		r = r.Add(s.Multiply(w))
+	}
+
+	if rgb[0] <= rgb[1] && rgb[0] <= rgb[2] {
+		// Compute spectrum with rgb[0] as minimum
+		add(rgb[0], basis.white)
+		if rgb[1] <= rgb[2] {
+			add(rgb[1]-rgb[0], basis.cyan)
+			add(rgb[2]-rgb[1], basis.blue)
+		} else {
+			add(rgb[2]-rgb[0], basis.cyan)
+			add(rgb[1]-rgb[2], basis.green)
+		}
+	} else if rgb[1] <= rgb[0] && rgb[1] <= rgb[2] {
+		// Compute spectrum with rgb[1] as minimum
+		add(rgb[1], basis.white)
+		if rgb[0] <= rgb[2] {
+			add(rgb[0]-rgb[1], basis.magenta)
+			add(rgb[2]-rgb[0], basis.blue)
+		} else {
+			add(rgb[2]-rgb[1], basis.magenta)
+			add(rgb[0]-rgb[2], basis.red)
+		}
+	} else {
+		// Compute spectrum with rgb[2] as minimum
+		add(rgb[2], basis.white)
+		if rgb[0] <= rgb[1] {
+			add(rgb[0]-rgb[2], basis.yellow)
+			add(rgb[1]-rgb[0], basis.green)
+		} else {
+			add(rgb[1]-rgb[2], basis.yellow)
+			add(rgb[0]-rgb[1], basis.red)
+		}
+	}
+
+	return r.Multiply(scale).Clamp(0, math.Inf(1))
+}
+
+// FromRGB is SampledSpectrumFromRGB usable through the value, e.g. by the generic textures
+func (SampledSpectrum) FromRGB(rgb [3]float64, spectrumType SpectrumType) SampledSpectrum {
+	return SampledSpectrumFromRGB(rgb, spectrumType)
+}
+
+// SampledSpectrumFromXYZ creates spectrum of the CIE XYZ color
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/spectrum.h#L359
+func SampledSpectrumFromXYZ(xyz [3]float64, spectrumType SpectrumType) SampledSpectrum {
+	return SampledSpectrumFromRGB(XYZToRGB(xyz), spectrumType)
+}
+
+func (s SampledSpectrum) Add(s2 SampledSpectrum) SampledSpectrum {
+	for i := range s {
+		s[i] += s2[i]
+	}
+	return s
+}
+
+func (s SampledSpectrum) Subtract(s2 SampledSpectrum) SampledSpectrum {
+	for i := range s {
+		s[i] -= s2[i]
+	}
+	return s
+}
+
+// MultiplyS multiplies the spectra component-wise
+func (s SampledSpectrum) MultiplyS(s2 SampledSpectrum) SampledSpectrum {
+	for i := range s {
+		s[i] *= s2[i]
+	}
+	return s
+}
+
+// DivideS divides the spectra component-wise
+func (s SampledSpectrum) DivideS(s2 SampledSpectrum) SampledSpectrum {
+	for i := range s {
+		s[i] /= s2[i]
+	}
+	return s
+}
+
+func (s SampledSpectrum) Multiply(d float64) SampledSpectrum {
+	for i := range s {
+		s[i] *= d
+	}
+	return s
+}
+
+func (s SampledSpectrum) Divide(d float64) SampledSpectrum {
+	inv := 1 / d
+	return s.Multiply(inv)
+}
+
+func (s SampledSpectrum) Negate() SampledSpectrum {
+	return s.Multiply(-1)
+}
+
+func (s SampledSpectrum) Sqrt() SampledSpectrum {
+	for i := range s {
+		s[i] = math.Sqrt(s[i])
+	}
+	return s
+}
+
+func (s SampledSpectrum) Exp() SampledSpectrum {
+	for i := range s {
+		s[i] = math.Exp(s[i])
+	}
+	return s
+}
+
+func (s SampledSpectrum) Pow(e float64) SampledSpectrum {
+	for i := range s {
+		s[i] = math.Pow(s[i], e)
+	}
+	return s
+}
+
+func (s1 SampledSpectrum) Lerp(t float64, s2 SampledSpectrum) SampledSpectrum {
+	return s1.Multiply(1 - t).Add(s2.Multiply(t))
+}
+
+func (s SampledSpectrum) Clamp(low, high float64) SampledSpectrum {
+	for i := range s {
+		s[i] = math.Min(math.Max(s[i], low), high)
+	}
+	return s
+}
+
+func (s SampledSpectrum) IsBlack() bool {
+	for _, c := range s {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (s SampledSpectrum) HasNaNs() bool {
+	for _, c := range s {
+		if math.IsNaN(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s SampledSpectrum) MaxComponentValue() float64 {
+	m := s[0]
+	for _, c := range s[1:] {
+		m = math.Max(m, c)
+	}
+	return m
+}
+
+// ToXYZ integrates the spectrum against the color matching functions
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/spectrum.h#L327
+func (s SampledSpectrum) ToXYZ() [3]float64 {
+	var xyz [3]float64
+	for i, c := range s {
+		xyz[0] += sampledX[i] * c
+		xyz[1] += sampledY[i] * c
+		xyz[2] += sampledZ[i] * c
+	}
+
+	scale := float64(SampledLambdaEnd-SampledLambdaStart) / (CIEYIntegral * NSpectralSamples)
+	for i := range xyz {
+		xyz[i] *= scale
+	}
+	return xyz
+}
+
+func (s SampledSpectrum) ToRGB() [3]float64 {
+	return XYZToRGB(s.ToXYZ())
+}
+
+func (s SampledSpectrum) ToRGBSpectrum() RGBSpectrum {
+	return RGBSpectrum(s.ToRGB())
+}
+
+// Y returns the luminance
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/spectrum.h#L340
+func (s SampledSpectrum) Y() float64 {
+	yy := 0.0
+	for i, c := range s {
+		yy += sampledY[i] * c
+	}
+	return yy * float64(SampledLambdaEnd-SampledLambdaStart) / (CIEYIntegral * NSpectralSamples)
+}
+
+// sampledBin returns the wavelength range of the i-th sample
+func sampledBin(i int) (float64, float64) {
+	lambda0 := lerp(float64(i)/NSpectralSamples, SampledLambdaStart, SampledLambdaEnd)
+	lambda1 := lerp(float64(i+1)/NSpectralSamples, SampledLambdaStart, SampledLambdaEnd)
+	return lambda0, lambda1
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/spectrum.h#L279
+func sampledCIE() (SampledSpectrum, SampledSpectrum, SampledSpectrum) {
+	var x, y, z SampledSpectrum
+	for i := range x {
+		lambda0, lambda1 := sampledBin(i)
+		x[i] = AverageSpectrumSamples(CIELambda, CIEX, lambda0, lambda1)
+		y[i] = AverageSpectrumSamples(CIELambda, CIEY, lambda0, lambda1)
+		z[i] = AverageSpectrumSamples(CIELambda, CIEZ, lambda0, lambda1)
+	}
+	return x, y, z
+}
@@ -0,0 +1,101 @@
+package spectrum_test
+
+import (
+	"math"
+	"pbrt-go/spectrum"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var rgbColors = [][3]float64{
+	{1, 1, 1},
+	{0, 0, 0},
+	{1, 0, 0},
+	{0, 1, 0},
+	{0, 0, 1},
+	{0.5, 0.5, 0.5},
+	{0.2, 0.5, 0.9},
+	{0.9, 0.3, 0.1},
+	{0.1, 0.8, 0.4},
+	{2, 4, 3},
+}
+
+func TestSampledSpectrumFromRGB_illuminant(t *testing.T) {
+	for _, rgb := range rgbColors {
+		s := spectrum.SampledSpectrumFromRGB(rgb, spectrum.Illuminant)
+		assert.InDeltaSlice(t, rgb[:], toSlice(s.ToRGB()), 1e-6, "rgb %v", rgb)
+		assert.GreaterOrEqual(t, minComponent(s), 0.0)
+	}
+}
+
+func TestSampledSpectrumFromRGB_reflectance(t *testing.T) {
+	for _, rgb := range rgbColors[:9] {
+		s := spectrum.SampledSpectrumFromRGB(rgb, spectrum.Reflectance)
+		assert.InDeltaSlice(t, rgb[:], toSlice(s.ToRGB()), 1e-6, "rgb %v", rgb)
+		assert.GreaterOrEqual(t, minComponent(s), 0.0)
+
+		// the colors of sRGB need up to 29 % more than the full reflectance at the peaks of the spectra
+		assert.LessOrEqual(t, s.MaxComponentValue(), 1.29*math.Max(rgb[0], math.Max(rgb[1], rgb[2]))+1e-6)
+	}
+}
+
+func TestSampledSpectrumFromXYZ(t *testing.T) {
+	xyz := [3]float64{0.3, 0.4, 0.2}
+	s := spectrum.SampledSpectrumFromXYZ(xyz, spectrum.Illuminant)
+
+	assert.InDeltaSlice(t, xyz[:], toSlice(s.ToXYZ()), 1e-5)
+	assert.InDelta(t, 0.4, s.Y(), 1e-5)
+}
+
+func TestSampledSpectrumFromSampled(t *testing.T) {
+	// unsorted samples of linear function
+	s := spectrum.SampledSpectrumFromSampled([]float64{700, 400, 550}, []float64{1, 0, 0.5})
+
+	for i, v := range s {
+		// average over the bin equals the value at its center
+		lambda := 400 + (float64(i)+0.5)*300/spectrum.NSpectralSamples
+		assert.InDelta(t, (lambda-400)/300, v, 1e-12)
+	}
+
+	// constant spectrum has luminance of the equal energy illuminant
+	c := spectrum.SampledSpectrumFromSampled([]float64{300, 800}, []float64{1, 1})
+	assert.InDelta(t, 1, c.Y()*spectrum.CIEYIntegral/sumVisibleY(), 1e-2)
+}
+
+func TestSampledSpectrum_arithmetic(t *testing.T) {
+	s1 := spectrum.NewSampledSpectrum(2)
+	s2 := spectrum.NewSampledSpectrum(4)
+
+	assert.Equal(t, spectrum.NewSampledSpectrum(6), s1.Add(s2))
+	assert.Equal(t, spectrum.NewSampledSpectrum(2), s2.Subtract(s1))
+	assert.Equal(t, spectrum.NewSampledSpectrum(8), s1.MultiplyS(s2))
+	assert.Equal(t, spectrum.NewSampledSpectrum(2), s2.DivideS(s1))
+	assert.Equal(t, spectrum.NewSampledSpectrum(6), s1.Multiply(3))
+	assert.Equal(t, spectrum.NewSampledSpectrum(1), s1.Divide(2))
+	assert.Equal(t, spectrum.NewSampledSpectrum(-2), s1.Negate())
+	assert.Equal(t, spectrum.NewSampledSpectrum(2), s2.Sqrt())
+	assert.Equal(t, spectrum.NewSampledSpectrum(16), s2.Pow(2))
+	assert.Equal(t, spectrum.NewSampledSpectrum(3), s1.Lerp(0.5, s2))
+	assert.Equal(t, spectrum.NewSampledSpectrum(3), s2.Clamp(0, 3))
+
+	assert.True(t, spectrum.NewSampledSpectrum(0).IsBlack())
+	assert.False(t, s1.IsBlack())
+	assert.False(t, s1.HasNaNs())
+	assert.True(t, s1.Sqrt().Negate().Sqrt().HasNaNs())
+}
+
+func minComponent(s spectrum.SampledSpectrum) float64 {
+	return -s.Negate().MaxComponentValue()
+}
+
+// sumVisibleY returns integral of the CIE Y over the SampledSpectrum range
+func sumVisibleY() float64 {
+	sum := 0.0
+	for i, lambda := range spectrum.CIELambda {
+		if lambda >= 400 && lambda < 700 {
+			sum += spectrum.CIEY[i] * spectrum.CIELambdaStep
+		}
+	}
+	return sum
+}
@@ -0,0 +1,126 @@
+package spectrum
+
+import (
+	"math"
+	"sort"
+)
+
+// SpectrumType tells how to convert RGB color to the spectrum, the reflectances are limited to [0, 1]
+// while the illuminants are not
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/spectrum.h#L52
+type SpectrumType int
+
+const (
+	Reflectance SpectrumType = iota
+	Illuminant
+)
+
+// XYZToRGB converts CIE XYZ color to linear sRGB
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/spectrum.h#L62
+func XYZToRGB(xyz [3]float64) [3]float64 {
+	return [3]float64{
+		3.240479*xyz[0] - 1.537150*xyz[1] - 0.498535*xyz[2],
+		-0.969256*xyz[0] + 1.875991*xyz[1] + 0.041556*xyz[2],
+		0.055648*xyz[0] - 0.204043*xyz[1] + 1.057311*xyz[2],
+	}
+}
+
+// RGBToXYZ converts linear sRGB color to CIE XYZ
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/spectrum.h#L68
+func RGBToXYZ(rgb [3]float64) [3]float64 {
+	return [3]float64{
+		0.412453*rgb[0] + 0.357580*rgb[1] + 0.180423*rgb[2],
+		0.212671*rgb[0] + 0.715160*rgb[1] + 0.072169*rgb[2],
+		0.019334*rgb[0] + 0.119193*rgb[1] + 0.950227*rgb[2],
+	}
+}
+
+// AverageSpectrumSamples returns average of the piecewise-linear function given by the sorted samples (lambda, vals)
+// over the wavelength range [lambdaStart, lambdaEnd], the function is constant beyond the samples
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/spectrum.cpp#L70
+func AverageSpectrumSamples(lambda, vals []float64, lambdaStart, lambdaEnd float64) float64 {
+	n := len(lambda)
+
+	// Handle cases with out-of-bounds range or single sample only
+	if lambdaEnd <= lambda[0] {
+		return vals[0]
+	}
+	if lambdaStart >= lambda[n-1] {
+		return vals[n-1]
+	}
+	if n == 1 {
+		return vals[0]
+	}
+
+	// Add contributions of constant segments before/after samples
+	sum := 0.0
+	if lambdaStart < lambda[0] {
+		sum += vals[0] * (lambda[0] - lambdaStart)
+	}
+	if lambdaEnd > lambda[n-1] {
+		sum += vals[n-1] * (lambdaEnd - lambda[n-1])
+	}
+
+	// Advance to first relevant wavelength segment
+	i := 0
+	for lambdaStart > lambda[i+1] {
+		i++
+	}
+
+	// Loop over wavelength sample segments and add contributions
+	interp := func(w float64, i int) float64 {
+		return lerp((w-lambda[i])/(lambda[i+1]-lambda[i]), vals[i], vals[i+1])
+	}
+	for ; i+1 < n && lambdaEnd >= lambda[i]; i++ {
+		segLambdaStart := math.Max(lambdaStart, lambda[i])
+		segLambdaEnd := math.Min(lambdaEnd, lambda[i+1])
+		sum += 0.5 * (interp(segLambdaStart, i) + interp(segLambdaEnd, i)) * (segLambdaEnd - segLambdaStart)
+	}
+
+	return sum / (lambdaEnd - lambdaStart)
+}
+
+// InterpolateSpectrumSamples returns value of the piecewise-linear function given by the sorted samples at l
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/spectrum.cpp#L1062
+func InterpolateSpectrumSamples(lambda, vals []float64, l float64) float64 {
+	n := len(lambda)
+	if l <= lambda[0] {
+		return vals[0]
+	}
+	if l >= lambda[n-1] {
+		return vals[n-1]
+	}
+
+	// lambda[offset] <= l < lambda[offset+1]
+	offset := sort.Search(n, func(i int) bool { return lambda[i] > l }) - 1
+	t := (l - lambda[offset]) / (lambda[offset+1] - lambda[offset])
+	return lerp(t, vals[offset], vals[offset+1])
+}
+
+// sortSpectrumSamples returns copies of the samples sorted by the wavelength
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/spectrum.cpp#L56
+func sortSpectrumSamples(lambda, vals []float64) ([]float64, []float64) {
+	indices := make([]int, len(lambda))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(i, j int) bool { return lambda[indices[i]] < lambda[indices[j]] })
+
+	sortedLambda := make([]float64, len(lambda))
+	sortedVals := make([]float64, len(vals))
+	for i, index := range indices {
+		sortedLambda[i], sortedVals[i] = lambda[index], vals[index]
+	}
+
+	return sortedLambda, sortedVals
+}
+
+func lerp(t, v1, v2 float64) float64 {
+	return (1-t)*v1 + t*v2
+}
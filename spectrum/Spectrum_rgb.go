@@ -0,0 +1,28 @@
+//go:build !sampled
+// +build !sampled
+
+package spectrum
+
+// Spectrum is the spectrum representation used by the renderer, RGBSpectrum by default,
+// SampledSpectrum when built with the "sampled" tag
+type Spectrum = RGBSpectrum
+
+// NewSpectrum creates constant spectrum
+func NewSpectrum(v float64) Spectrum {
+	return NewRGBSpectrum(v)
+}
+
+// SpectrumFromRGB creates spectrum of the linear sRGB color
+func SpectrumFromRGB(rgb [3]float64, spectrumType SpectrumType) Spectrum {
+	return RGBSpectrumFromRGB(rgb, spectrumType)
+}
+
+// SpectrumFromXYZ creates spectrum of the CIE XYZ color
+func SpectrumFromXYZ(xyz [3]float64, spectrumType SpectrumType) Spectrum {
+	return RGBSpectrumFromXYZ(xyz, spectrumType)
+}
+
+// SpectrumFromSampled creates spectrum of the piecewise-linear function given by the samples (lambda, v)
+func SpectrumFromSampled(lambda, v []float64) Spectrum {
+	return RGBSpectrumFromSampled(lambda, v)
+}
@@ -0,0 +1,28 @@
+//go:build sampled
+// +build sampled
+
+package spectrum
+
+// Spectrum is the spectrum representation used by the renderer, SampledSpectrum when built with the "sampled" tag,
+// RGBSpectrum otherwise
+type Spectrum = SampledSpectrum
+
+// NewSpectrum creates constant spectrum
+func NewSpectrum(v float64) Spectrum {
+	return NewSampledSpectrum(v)
+}
+
+// SpectrumFromRGB creates spectrum of the linear sRGB color
+func SpectrumFromRGB(rgb [3]float64, spectrumType SpectrumType) Spectrum {
+	return SampledSpectrumFromRGB(rgb, spectrumType)
+}
+
+// SpectrumFromXYZ creates spectrum of the CIE XYZ color
+func SpectrumFromXYZ(xyz [3]float64, spectrumType SpectrumType) Spectrum {
+	return SampledSpectrumFromXYZ(xyz, spectrumType)
+}
+
+// SpectrumFromSampled creates spectrum of the piecewise-linear function given by the samples (lambda, v)
+func SpectrumFromSampled(lambda, v []float64) Spectrum {
+	return SampledSpectrumFromSampled(lambda, v)
+}
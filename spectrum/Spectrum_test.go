@@ -0,0 +1,81 @@
+package spectrum_test
+
+import (
+	"pbrt-go/spectrum"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXYZToRGB(t *testing.T) {
+	for _, rgb := range [][3]float64{{1, 1, 1}, {1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {0.2, 0.5, 0.9}} {
+		xyz := spectrum.RGBToXYZ(rgb)
+		assert.InDeltaSlice(t, rgb[:], toSlice(spectrum.XYZToRGB(xyz)), 1e-5)
+	}
+
+	// D65 white point
+	xyz := spectrum.RGBToXYZ([3]float64{1, 1, 1})
+	assert.InDelta(t, 0.9505, xyz[0], 1e-3)
+	assert.InDelta(t, 1.0, xyz[1], 1e-5)
+	assert.InDelta(t, 1.089, xyz[2], 1e-3)
+}
+
+func TestSpectrumFromRGB_roundTrip(t *testing.T) {
+	for _, spectrumType := range []spectrum.SpectrumType{spectrum.Reflectance, spectrum.Illuminant} {
+		for _, rgb := range [][3]float64{{1, 1, 1}, {1, 0, 0}, {0, 1, 0}, {0, 0, 1}} {
+			s := spectrum.SpectrumFromRGB(rgb, spectrumType)
+			assert.InDeltaSlice(t, rgb[:], toSlice(s.ToRGB()), 1e-6, "rgb %v, type %v", rgb, spectrumType)
+		}
+	}
+}
+
+func TestAverageSpectrumSamples(t *testing.T) {
+	lambda := []float64{400, 500, 600}
+	vals := []float64{1, 3, 2}
+
+	// inside single segment
+	assert.InDelta(t, 2.0, spectrum.AverageSpectrumSamples(lambda, vals, 400, 500), 1e-12)
+	// across segments
+	assert.InDelta(t, (200+250)/200.0, spectrum.AverageSpectrumSamples(lambda, vals, 400, 600), 1e-12)
+	// constant beyond the samples
+	assert.InDelta(t, 1.0, spectrum.AverageSpectrumSamples(lambda, vals, 300, 350), 1e-12)
+	assert.InDelta(t, 2.0, spectrum.AverageSpectrumSamples(lambda, vals, 650, 700), 1e-12)
+	assert.InDelta(t, (100+200+250+200)/400.0, spectrum.AverageSpectrumSamples(lambda, vals, 300, 700), 1e-12)
+}
+
+func TestInterpolateSpectrumSamples(t *testing.T) {
+	lambda := []float64{400, 500, 600}
+	vals := []float64{1, 3, 2}
+
+	assert.Equal(t, 1.0, spectrum.InterpolateSpectrumSamples(lambda, vals, 350))
+	assert.Equal(t, 1.0, spectrum.InterpolateSpectrumSamples(lambda, vals, 400))
+	assert.Equal(t, 2.0, spectrum.InterpolateSpectrumSamples(lambda, vals, 450))
+	assert.Equal(t, 3.0, spectrum.InterpolateSpectrumSamples(lambda, vals, 500))
+	assert.Equal(t, 2.5, spectrum.InterpolateSpectrumSamples(lambda, vals, 550))
+	assert.Equal(t, 2.0, spectrum.InterpolateSpectrumSamples(lambda, vals, 650))
+}
+
+func TestCIE(t *testing.T) {
+	assert.Len(t, spectrum.CIELambda, spectrum.NCIESamples)
+	assert.Equal(t, 360.0, spectrum.CIELambda[0])
+	assert.Equal(t, 830.0, spectrum.CIELambda[spectrum.NCIESamples-1])
+
+	// Y peaks at 555 nm
+	assert.Equal(t, 1.0, spectrum.CIEY[(555-spectrum.CIELambdaStart)/spectrum.CIELambdaStep])
+
+	sum := 0.0
+	for _, y := range spectrum.CIEY {
+		sum += y * spectrum.CIELambdaStep
+	}
+	assert.InDelta(t, spectrum.CIEYIntegral, sum, 1e-3)
+}
+
+func TestSpectrum(t *testing.T) {
+	s := spectrum.SpectrumFromRGB([3]float64{0.2, 0.5, 0.9}, spectrum.Illuminant)
+	assert.InDeltaSlice(t, []float64{0.2, 0.5, 0.9}, toSlice(s.ToRGB()), 1e-6)
+	assert.InDelta(t, 1.0, spectrum.NewSpectrum(1).MaxComponentValue(), 1e-12)
+}
+
+func toSlice(v [3]float64) []float64 {
+	return v[:]
+}
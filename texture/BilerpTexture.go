@@ -0,0 +1,22 @@
+package texture
+
+import "pbrt-go/mymath"
+
+// BilerpTexture bilinearly interpolates the four corner values over the unit square of the (s, t) coordinates
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/textures/bilerp.h#L48
+type BilerpTexture[T Value[T]] struct {
+	Mapping            TextureMapping2D
+	V00, V01, V10, V11 T
+}
+
+func NewBilerpTexture[T Value[T]](mapping TextureMapping2D, v00, v01, v10, v11 T) *BilerpTexture[T] {
+	return &BilerpTexture[T]{mapping, v00, v01, v10, v11}
+}
+
+func (t *BilerpTexture[T]) Evaluate(si *mymath.SurfaceInteraction) T {
+	st, _, _ := t.Mapping.Map(si)
+	s, tt := st.X, st.Y
+	return t.V00.Multiply((1 - s) * (1 - tt)).Add(t.V01.Multiply((1 - s) * tt)).
+		Add(t.V10.Multiply(s * (1 - tt))).Add(t.V11.Multiply(s * tt))
+}
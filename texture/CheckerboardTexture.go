@@ -0,0 +1,126 @@
+package texture
+
+import (
+	"math"
+	"pbrt-go/mymath"
+)
+
+// AAMethod is antialiasing method of the checkerboard textures
+type AAMethod int
+
+const (
+	// AANone does point sampling
+	AANone AAMethod = iota
+	// AAClosedForm averages the checks over the box filter given by the texture coordinate differentials
+	AAClosedForm
+)
+
+// CheckerboardTexture alternates the two textures in the checks of the unit size in (s, t) coordinates
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/textures/checkerboard.h#L51
+type CheckerboardTexture[T Value[T]] struct {
+	Mapping    TextureMapping2D
+	Tex1, Tex2 Texture[T]
+	AAMethod   AAMethod
+}
+
+func NewCheckerboardTexture[T Value[T]](mapping TextureMapping2D, tex1, tex2 Texture[T], aaMethod AAMethod) *CheckerboardTexture[T] {
+	return &CheckerboardTexture[T]{mapping, tex1, tex2, aaMethod}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/textures/checkerboard.h#L61
+func (t *CheckerboardTexture[T]) Evaluate(si *mymath.SurfaceInteraction) T {
+	st, dstdx, dstdy := t.Mapping.Map(si)
+
+	if t.AAMethod == AANone {
+		// Point sample Checkerboard2DTexture
+		return t.pointSample(si, int(math.Floor(st.X))+int(math.Floor(st.Y)))
+	}
+
+	// Compute closed-form box-filtered Checkerboard2DTexture value
+
+	// Evaluate single check if filter is entirely inside one of them
+	ds := math.Max(math.Abs(dstdx.X), math.Abs(dstdy.X))
+	dt := math.Max(math.Abs(dstdx.Y), math.Abs(dstdy.Y))
+	s0, s1 := st.X-ds, st.X+ds
+	t0, t1 := st.Y-dt, st.Y+dt
+	if math.Floor(s0) == math.Floor(s1) && math.Floor(t0) == math.Floor(t1) {
+		return t.pointSample(si, int(math.Floor(st.X))+int(math.Floor(st.Y)))
+	}
+
+	// Apply box filter to checkerboard region
+	area2 := oddParity(oddFraction(s0, s1), oddFraction(t0, t1))
+	if ds > 1 || dt > 1 {
+		area2 = 0.5
+	}
+	return t.Tex1.Evaluate(si).Lerp(area2, t.Tex2.Evaluate(si))
+}
+
+func (t *CheckerboardTexture[T]) pointSample(si *mymath.SurfaceInteraction, sum int) T {
+	if sum%2 == 0 {
+		return t.Tex1.Evaluate(si)
+	}
+	return t.Tex2.Evaluate(si)
+}
+
+// Checkerboard3DTexture alternates the two textures in the cubes of the unit size in 3D texture space
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/textures/checkerboard.h#L113
+type Checkerboard3DTexture[T Value[T]] struct {
+	Mapping    TextureMapping3D
+	Tex1, Tex2 Texture[T]
+	AAMethod   AAMethod
+}
+
+func NewCheckerboard3DTexture[T Value[T]](mapping TextureMapping3D, tex1, tex2 Texture[T], aaMethod AAMethod) *Checkerboard3DTexture[T] {
+	return &Checkerboard3DTexture[T]{mapping, tex1, tex2, aaMethod}
+}
+
+// Evaluate returns the check value, unlike pbrt the closed-form antialiasing is supported too. The checks are
+// separable so the box-filtered parity is combined from the per-axis fractions.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/textures/checkerboard.h#L121
+func (t *Checkerboard3DTexture[T]) Evaluate(si *mymath.SurfaceInteraction) T {
+	p, dpdx, dpdy := t.Mapping.Map(si)
+
+	d := mymath.NewPoint3(
+		math.Max(math.Abs(dpdx.X), math.Abs(dpdy.X)),
+		math.Max(math.Abs(dpdx.Y), math.Abs(dpdy.Y)),
+		math.Max(math.Abs(dpdx.Z), math.Abs(dpdy.Z)))
+	p0, p1 := p.AddP(d.Multiply(-1)), p.AddP(d)
+
+	if t.AAMethod == AANone || p0.Floor() == p1.Floor() {
+		if (int(math.Floor(p.X))+int(math.Floor(p.Y))+int(math.Floor(p.Z)))%2 == 0 {
+			return t.Tex1.Evaluate(si)
+		}
+		return t.Tex2.Evaluate(si)
+	}
+
+	odd := oddParity(oddParity(oddFraction(p0.X, p1.X), oddFraction(p0.Y, p1.Y)), oddFraction(p0.Z, p1.Z))
+	if d.X > 1 || d.Y > 1 || d.Z > 1 {
+		odd = 0.5
+	}
+	return t.Tex1.Evaluate(si).Lerp(odd, t.Tex2.Evaluate(si))
+}
+
+// oddFraction returns fraction of the interval [x0, x1] covered by the odd cells
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/textures/checkerboard.h#L91
+func oddFraction(x0, x1 float64) float64 {
+	if x0 == x1 {
+		return math.Abs(math.Mod(math.Floor(x0), 2))
+	}
+	return (bumpInt(x1) - bumpInt(x0)) / (x1 - x0)
+}
+
+// bumpInt is integral of the function which is 1 in the odd cells and 0 in the even ones
+func bumpInt(x float64) float64 {
+	h := math.Floor(x / 2)
+	return h + 2*math.Max(x/2-h-0.5, 0)
+}
+
+// oddParity returns probability that the sum of two independent cell indices is odd, given probabilities
+// p and q of each of them being odd
+func oddParity(p, q float64) float64 {
+	return p + q - 2*p*q
+}
@@ -0,0 +1,73 @@
+package texture_test
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/texture"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var aaMethods = []texture.AAMethod{texture.AANone, texture.AAClosedForm}
+
+func TestCheckerboardTexture_pointSample(t *testing.T) {
+	for _, aaMethod := range aaMethods {
+		tex := texture.NewCheckerboardTexture[texture.Float](texture.NewUVMapping2D(1, 1, 0, 0), texture.NewConstantTexture[texture.Float](0.0), texture.NewConstantTexture[texture.Float](1.0), aaMethod)
+
+		assert.Equal(t, 0.0, float64(tex.Evaluate(newSurfaceInteractionDifferentials(mymath.NewPoint3(0.5, 0.5, 0), 0.1))))
+		assert.Equal(t, 1.0, float64(tex.Evaluate(newSurfaceInteractionDifferentials(mymath.NewPoint3(1.5, 0.5, 0), 0.1))))
+		assert.Equal(t, 1.0, float64(tex.Evaluate(newSurfaceInteractionDifferentials(mymath.NewPoint3(0.5, -0.5, 0), 0.1))))
+		assert.Equal(t, 0.0, float64(tex.Evaluate(newSurfaceInteractionDifferentials(mymath.NewPoint3(-0.5, -0.5, 0), 0.1))))
+	}
+}
+
+func TestCheckerboardTexture_closedForm(t *testing.T) {
+	tex := texture.NewCheckerboardTexture[texture.Float](texture.NewUVMapping2D(1, 1, 0, 0), texture.NewConstantTexture[texture.Float](0.0), texture.NewConstantTexture[texture.Float](1.0), texture.AAClosedForm)
+
+	// footprint over the edge between two checks
+	assert.InDelta(t, 0.5, float64(tex.Evaluate(newSurfaceInteractionDifferentials(mymath.NewPoint3(1, 0.5, 0), 0.2))), 1e-12)
+	assert.InDelta(t, 0.25, float64(tex.Evaluate(newSurfaceInteractionDifferentials(mymath.NewPoint3(0.9, 0.5, 0), 0.2))), 1e-12)
+
+	// footprint over the corner of four checks
+	assert.InDelta(t, 0.5, float64(tex.Evaluate(newSurfaceInteractionDifferentials(mymath.NewPoint3(1, 1, 0), 0.2))), 1e-12)
+	assert.InDelta(t, 0.5*0.75+0.5*0.25, float64(tex.Evaluate(newSurfaceInteractionDifferentials(mymath.NewPoint3(0.9, 1, 0), 0.2))), 1e-12)
+
+	// footprint over many checks
+	assert.InDelta(t, 0.5, float64(tex.Evaluate(newSurfaceInteractionDifferentials(mymath.NewPoint3(0.3, 0.7, 0), 3))), 1e-12)
+}
+
+// Box-filtered value equals the average of the point samples over the footprint
+func TestCheckerboardTexture_closedForm_average(t *testing.T) {
+	aa := texture.NewCheckerboardTexture[texture.Float](texture.NewUVMapping2D(1, 1, 0, 0), texture.NewConstantTexture[texture.Float](0.0), texture.NewConstantTexture[texture.Float](1.0), texture.AAClosedForm)
+	point := texture.NewCheckerboardTexture[texture.Float](texture.NewUVMapping2D(1, 1, 0, 0), texture.NewConstantTexture[texture.Float](0.0), texture.NewConstantTexture[texture.Float](1.0), texture.AANone)
+
+	for _, p := range []mymath.Point3{mymath.NewPoint3(0.1, 0.2, 0), mymath.NewPoint3(-1.3, 2.9, 0), mymath.NewPoint3(5.05, -0.77, 0)} {
+		const width, n = 0.6, 200
+		sum := 0.0
+		for y := 0; y < n; y++ {
+			for x := 0; x < n; x++ {
+				q := mymath.NewPoint3(p.X-width+2*width*(float64(x)+0.5)/n, p.Y-width+2*width*(float64(y)+0.5)/n, 0)
+				sum += float64(point.Evaluate(newSurfaceInteraction(q, mymath.NewPoint2(q.X, q.Y))))
+			}
+		}
+
+		assert.InDelta(t, sum/(n*n), float64(aa.Evaluate(newSurfaceInteractionDifferentials(p, width))), 1e-2, "point %v", p)
+	}
+}
+
+func TestCheckerboard3DTexture(t *testing.T) {
+	mapping := texture.NewIdentityMapping3D(mymath.NewTransformEmpty())
+
+	for _, aaMethod := range aaMethods {
+		tex := texture.NewCheckerboard3DTexture[texture.Float](mapping, texture.NewConstantTexture[texture.Float](0.0), texture.NewConstantTexture[texture.Float](1.0), aaMethod)
+
+		assert.Equal(t, 0.0, float64(tex.Evaluate(newSurfaceInteractionDifferentials(mymath.NewPoint3(0.5, 0.5, 0.5), 0.1))))
+		assert.Equal(t, 1.0, float64(tex.Evaluate(newSurfaceInteractionDifferentials(mymath.NewPoint3(0.5, 0.5, 1.5), 0.1))))
+		assert.Equal(t, 0.0, float64(tex.Evaluate(newSurfaceInteractionDifferentials(mymath.NewPoint3(1.5, 0.5, 1.5), 0.1))))
+		assert.Equal(t, 1.0, float64(tex.Evaluate(newSurfaceInteractionDifferentials(mymath.NewPoint3(1.5, 1.5, 1.5), 0.1))))
+	}
+
+	tex := texture.NewCheckerboard3DTexture[texture.Float](mapping, texture.NewConstantTexture[texture.Float](0.0), texture.NewConstantTexture[texture.Float](1.0), texture.AAClosedForm)
+	assert.InDelta(t, 0.5, float64(tex.Evaluate(newSurfaceInteractionDifferentials(mymath.NewPoint3(1, 0.5, 0.5), 0.2))), 1e-12)
+	assert.InDelta(t, 0.25, float64(tex.Evaluate(newSurfaceInteractionDifferentials(mymath.NewPoint3(0.5, 0.9, 0.5), 0.2))), 1e-12)
+}
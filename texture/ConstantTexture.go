@@ -0,0 +1,18 @@
+package texture
+
+import "pbrt-go/mymath"
+
+// ConstantTexture returns the same value everywhere
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/textures/constant.h#L48
+type ConstantTexture[T any] struct {
+	Value T
+}
+
+func NewConstantTexture[T any](value T) *ConstantTexture[T] {
+	return &ConstantTexture[T]{value}
+}
+
+func (t *ConstantTexture[T]) Evaluate(_ *mymath.SurfaceInteraction) T {
+	return t.Value
+}
@@ -0,0 +1,39 @@
+package texture
+
+import (
+	"math"
+	"pbrt-go/mymath"
+)
+
+// DotsTexture places randomly positioned dots of the inside texture into the cells of the (s, t) coordinates,
+// some of the cells are left empty
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/textures/dots.h#L49
+type DotsTexture[T Value[T]] struct {
+	Mapping               TextureMapping2D
+	OutsideDot, InsideDot Texture[T]
+}
+
+func NewDotsTexture[T Value[T]](mapping TextureMapping2D, outsideDot, insideDot Texture[T]) *DotsTexture[T] {
+	return &DotsTexture[T]{mapping, outsideDot, insideDot}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/textures/dots.h#L57
+func (t *DotsTexture[T]) Evaluate(si *mymath.SurfaceInteraction) T {
+	// Compute cell indices for dots
+	st, _, _ := t.Mapping.Map(si)
+	sCell, tCell := math.Floor(st.X+0.5), math.Floor(st.Y+0.5)
+
+	// Return insideDot result if point is inside dot
+	if Noise(sCell+0.5, tCell+0.5, 0.5) > 0 {
+		const radius = 0.35
+		const maxShift = 0.5 - radius
+		sCenter := sCell + maxShift*Noise(sCell+1.5, tCell+2.8, 0.5)
+		tCenter := tCell + maxShift*Noise(sCell+4.5, tCell+9.8, 0.5)
+		ds, dt := st.X-sCenter, st.Y-tCenter
+		if ds*ds+dt*dt < radius*radius {
+			return t.InsideDot.Evaluate(si)
+		}
+	}
+	return t.OutsideDot.Evaluate(si)
+}
@@ -0,0 +1,21 @@
+package texture
+
+import "pbrt-go/mymath"
+
+// FBmTexture returns fractional Brownian motion noise
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/textures/fbm.h#L48
+type FBmTexture struct {
+	Mapping TextureMapping3D
+	Omega   float64
+	Octaves int
+}
+
+func NewFBmTexture(mapping TextureMapping3D, octaves int, omega float64) *FBmTexture {
+	return &FBmTexture{mapping, omega, octaves}
+}
+
+func (t *FBmTexture) Evaluate(si *mymath.SurfaceInteraction) Float {
+	p, dpdx, dpdy := t.Mapping.Map(si)
+	return Float(FBm(p, dpdx, dpdy, t.Omega, t.Octaves))
+}
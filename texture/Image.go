@@ -0,0 +1,60 @@
+package texture
+
+import (
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"os"
+	"pbrt-go/mymath"
+	"pbrt-go/spectrum"
+)
+
+// ImageWrap tells how to look up the texels outside of the image
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/mipmap.h#L51
+type ImageWrap int
+
+const (
+	WrapRepeat ImageWrap = iota
+	WrapBlack
+	WrapClamp
+)
+
+// ReadImage reads the PNG, JPEG or GIF image, the colors are in [0, 1] and keep the image encoding,
+// the first row is the top one
+func ReadImage(filename string) (mymath.Point2i, []spectrum.RGBSpectrum, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return mymath.Point2i{}, nil, err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return mymath.Point2i{}, nil, err
+	}
+
+	bounds := img.Bounds()
+	resolution := mymath.NewPoint2i(bounds.Dx(), bounds.Dy())
+	texels := make([]spectrum.RGBSpectrum, 0, resolution.X*resolution.Y)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			texels = append(texels, spectrum.RGBSpectrum{float64(r) / 0xffff, float64(g) / 0xffff, float64(b) / 0xffff})
+		}
+	}
+
+	return resolution, texels, nil
+}
+
+// InverseGammaCorrect converts the sRGB encoded value to linear
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/pbrt.h#L299
+func InverseGammaCorrect(value float64) float64 {
+	if value <= 0.04045 {
+		return value / 12.92
+	}
+	return math.Pow((value+0.055)/1.055, 2.4)
+}
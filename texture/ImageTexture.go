@@ -0,0 +1,57 @@
+package texture
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/spectrum"
+)
+
+// ImageTexture looks up the image with the MIPMap filtering, the image covers the unit square of the (s, t)
+// coordinates with (0, 0) at its lower left corner
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/textures/imagemap.h#L68
+type ImageTexture[T Value[T]] struct {
+	Mapping TextureMapping2D
+	MIPMap  *MIPMap[T]
+}
+
+// NewImageTexture creates texture from the image texels given row by row starting with the top row. The colors
+// are converted to linear by the sRGB curve when gamma is true and multiplied by scale.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/textures/imagemap.cpp#L46
+func NewImageTexture[T Value[T]](mapping TextureMapping2D, resolution mymath.Point2i, image []spectrum.RGBSpectrum, doTrilinear bool, maxAnisotropy float64, wrapMode ImageWrap, scale float64, gamma bool) *ImageTexture[T] {
+	var zero T
+	texels := make([]T, len(image))
+	for i := range image {
+		// Flip image in y; texture coordinate space has (0,0) at the lower left corner
+		x, y := i%resolution.X, i/resolution.X
+		rgb := image[(resolution.Y-1-y)*resolution.X+x]
+
+		for c := range rgb {
+			if gamma {
+				rgb[c] = InverseGammaCorrect(rgb[c])
+			}
+			rgb[c] *= scale
+		}
+		texels[i] = zero.FromRGB(rgb, spectrum.Reflectance)
+	}
+
+	return &ImageTexture[T]{
+		Mapping: mapping,
+		MIPMap:  NewMIPMap(resolution, texels, doTrilinear, maxAnisotropy, wrapMode),
+	}
+}
+
+// NewImageTextureFromFile creates texture from the image file, see ReadImage and NewImageTexture
+func NewImageTextureFromFile[T Value[T]](mapping TextureMapping2D, filename string, doTrilinear bool, maxAnisotropy float64, wrapMode ImageWrap, scale float64, gamma bool) (*ImageTexture[T], error) {
+	resolution, image, err := ReadImage(filename)
+	if err != nil {
+		return nil, err
+	}
+	return NewImageTexture[T](mapping, resolution, image, doTrilinear, maxAnisotropy, wrapMode, scale, gamma), nil
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/textures/imagemap.h#L82
+func (t *ImageTexture[T]) Evaluate(si *mymath.SurfaceInteraction) T {
+	st, dstdx, dstdy := t.Mapping.Map(si)
+	return t.MIPMap.LookupEWA(st, dstdx, dstdy)
+}
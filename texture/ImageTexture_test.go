@@ -0,0 +1,84 @@
+package texture_test
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"pbrt-go/mymath"
+	"pbrt-go/spectrum"
+	"pbrt-go/texture"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 2x2 image, top row is red and green, bottom row is blue and white
+var testImage = []spectrum.RGBSpectrum{
+	{1, 0, 0}, {0, 1, 0},
+	{0, 0, 1}, {1, 1, 1},
+}
+
+func TestNewImageTexture(t *testing.T) {
+	tex := texture.NewImageTexture[spectrum.Spectrum](texture.NewUVMapping2D(1, 1, 0, 0), mymath.NewPoint2i(2, 2), testImage, false, 8, texture.WrapRepeat, 1, false)
+
+	// t axis goes up
+	assertReflectance(t, [3]float64{0, 0, 1}, tex.MIPMap.Texel(0, 0, 0), 1e-12)
+	assertReflectance(t, [3]float64{1, 1, 1}, tex.MIPMap.Texel(0, 1, 0), 1e-12)
+	assertReflectance(t, [3]float64{1, 0, 0}, tex.MIPMap.Texel(0, 0, 1), 1e-12)
+	assertReflectance(t, [3]float64{0, 1, 0}, tex.MIPMap.Texel(0, 1, 1), 1e-12)
+}
+
+func TestImageTexture_Evaluate(t *testing.T) {
+	for _, doTrilinear := range []bool{false, true} {
+		tex := texture.NewImageTexture[texture.Float](texture.NewUVMapping2D(1, 1, 0, 0), mymath.NewPoint2i(2, 2), testImage, doTrilinear, 8, texture.WrapClamp, 2, false)
+		blue := spectrum.RGBSpectrum(testImage[2]).Y()
+
+		// without differentials the texels are bilinearly interpolated
+		assert.InDelta(t, 2, float64(tex.Evaluate(newSurfaceInteraction(mymath.Point3{}, mymath.NewPoint2(0.75, 0.25)))), 1e-12)
+		assert.InDelta(t, (2+2*blue)/2, float64(tex.Evaluate(newSurfaceInteraction(mymath.Point3{}, mymath.NewPoint2(0.5, 0.25)))), 1e-12)
+
+		// footprint covering the whole image averages it
+		average := 0.0
+		for _, rgb := range testImage {
+			average += 2 * rgb.Y() / 4
+		}
+		si := newSurfaceInteractionDifferentials(mymath.NewPoint3(0.5, 0.5, 0), 2)
+		assert.InDelta(t, average, float64(tex.Evaluate(si)), 1e-12)
+	}
+}
+
+func TestNewImageTextureFromFile(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 1))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	img.Set(1, 0, color.RGBA{G: 188, A: 255})
+	img.Set(2, 0, color.RGBA{B: 10, A: 255})
+	img.Set(3, 0, color.RGBA{A: 255})
+
+	filename := filepath.Join(t.TempDir(), "texture.png")
+	file, err := os.Create(filename)
+	assert.NoError(t, err)
+	assert.NoError(t, png.Encode(file, img))
+	assert.NoError(t, file.Close())
+
+	tex, err := texture.NewImageTextureFromFile[spectrum.Spectrum](texture.NewUVMapping2D(1, 1, 0, 0), filename, false, 8, texture.WrapRepeat, 1, true)
+	assert.NoError(t, err)
+	assert.Equal(t, mymath.NewPoint2i(4, 1), tex.MIPMap.Resolution)
+
+	assertReflectance(t, [3]float64{1, 0, 0}, tex.MIPMap.Texel(0, 0, 0), 1e-6)
+	assertReflectance(t, [3]float64{0, 0.5, 0}, tex.MIPMap.Texel(0, 1, 0), 1e-2)
+	assertReflectance(t, [3]float64{0, 0, 10.0 / 255 / 12.92}, tex.MIPMap.Texel(0, 2, 0), 1e-6)
+	assertReflectance(t, [3]float64{0, 0, 0}, tex.MIPMap.Texel(0, 3, 0), 1e-6)
+
+	_, err = texture.NewImageTextureFromFile[texture.Float](texture.NewUVMapping2D(1, 1, 0, 0), filepath.Join(t.TempDir(), "missing.png"), false, 8, texture.WrapRepeat, 1, true)
+	assert.Error(t, err)
+}
+
+// assertReflectance checks the spectrum against the reflectance spectrum of the color
+func assertReflectance(t *testing.T, expected [3]float64, actual spectrum.Spectrum, delta float64) {
+	expectedSpectrum := spectrum.SpectrumFromRGB(expected, spectrum.Reflectance)
+	for i := range expectedSpectrum {
+		assert.InDelta(t, expectedSpectrum[i], actual[i], delta, "color %v", expected)
+	}
+}
@@ -0,0 +1,366 @@
+package texture
+
+import (
+	"math"
+	"pbrt-go/mymath"
+)
+
+const weightLUTSize = 128
+
+// weightLut holds the Gaussian filter weights of the EWA filtering indexed by the squared radius
+var weightLut = func() [weightLUTSize]float64 {
+	const alpha = 2
+	var lut [weightLUTSize]float64
+	for i := range lut {
+		r2 := float64(i) / (weightLUTSize - 1)
+		lut[i] = math.Exp(-alpha*r2) - math.Exp(-alpha)
+	}
+	return lut
+}()
+
+// MIPMap is pyramid of the successively downsampled images, the texture lookups choose level according to
+// the filter width so that the texture is antialiased
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/mipmap.h#L76
+type MIPMap[T Value[T]] struct {
+	DoTrilinear   bool
+	MaxAnisotropy float64
+	WrapMode      ImageWrap
+	Resolution    mymath.Point2i
+	pyramid       []mipMapLevel[T]
+}
+
+type mipMapLevel[T Value[T]] struct {
+	resolution mymath.Point2i
+	texels     []T
+}
+
+// resampleWeight holds the filter weights of the four texels contributing to the resampled texel
+type resampleWeight struct {
+	firstTexel int
+	weight     [4]float64
+}
+
+// NewMIPMap creates the pyramid from the image given row by row, the resolution is rounded up to the power
+// of two with Lanczos filter
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/mipmap.h#L153
+func NewMIPMap[T Value[T]](resolution mymath.Point2i, img []T, doTrilinear bool, maxAnisotropy float64, wrapMode ImageWrap) *MIPMap[T] {
+	m := &MIPMap[T]{
+		DoTrilinear:   doTrilinear,
+		MaxAnisotropy: maxAnisotropy,
+		WrapMode:      wrapMode,
+		Resolution:    resolution,
+	}
+
+	if !mymath.IsPowerOf2(int64(resolution.X)) || !mymath.IsPowerOf2(int64(resolution.Y)) {
+		// Resample image to power-of-two resolution
+		resPow2 := mymath.NewPoint2i(int(mymath.RoundUpPow2(int64(resolution.X))), int(mymath.RoundUpPow2(int64(resolution.Y))))
+		img = m.resample(resolution, resPow2, img)
+		m.Resolution = resPow2
+	}
+
+	// Initialize levels of MIPMap from image
+	nLevels := 1 + mymath.Log2Int(int64(maxInt(m.Resolution.X, m.Resolution.Y)))
+	m.pyramid = make([]mipMapLevel[T], nLevels)
+
+	// Initialize most detailed level of MIPMap
+	m.pyramid[0] = mipMapLevel[T]{m.Resolution, img}
+	for i := 1; i < nLevels; i++ {
+		// Initialize i-th MIPMap level from (i-1)-st level
+		sRes := maxInt(1, m.pyramid[i-1].resolution.X/2)
+		tRes := maxInt(1, m.pyramid[i-1].resolution.Y/2)
+		level := mipMapLevel[T]{mymath.NewPoint2i(sRes, tRes), make([]T, sRes*tRes)}
+
+		// Filter four texels from finer level of pyramid
+		for t := 0; t < tRes; t++ {
+			for s := 0; s < sRes; s++ {
+				sum := m.Texel(i-1, 2*s, 2*t).Add(m.Texel(i-1, 2*s+1, 2*t)).
+					Add(m.Texel(i-1, 2*s, 2*t+1)).Add(m.Texel(i-1, 2*s+1, 2*t+1))
+				level.texels[t*sRes+s] = sum.Multiply(0.25)
+			}
+		}
+		m.pyramid[i] = level
+	}
+
+	return m
+}
+
+// resample scales the image to the new resolution in s and then in t direction
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/mipmap.h#L162
+func (m *MIPMap[T]) resample(res, resPow2 mymath.Point2i, img []T) []T {
+	// Resample image in s direction
+	sWeights := resampleWeights(res.X, resPow2.X)
+	resampled := make([]T, resPow2.X*resPow2.Y)
+	for t := 0; t < res.Y; t++ {
+		for s := 0; s < resPow2.X; s++ {
+			// Compute texel (s,t) in s-zoomed image
+			var sum T
+			for j, w := range sWeights[s].weight {
+				origS, ok := m.wrap(sWeights[s].firstTexel+j, res.X)
+				if ok {
+					sum = sum.Add(img[t*res.X+origS].Multiply(w))
+				}
+			}
+			resampled[t*resPow2.X+s] = sum
+		}
+	}
+
+	// Resample image in t direction
+	tWeights := resampleWeights(res.Y, resPow2.Y)
+	column := make([]T, resPow2.Y)
+	for s := 0; s < resPow2.X; s++ {
+		for t := 0; t < resPow2.Y; t++ {
+			var sum T
+			for j, w := range tWeights[t].weight {
+				origT, ok := m.wrap(tWeights[t].firstTexel+j, res.Y)
+				if ok {
+					sum = sum.Add(resampled[origT*resPow2.X+s].Multiply(w))
+				}
+			}
+			column[t] = sum
+		}
+		for t := 0; t < resPow2.Y; t++ {
+			resampled[t*resPow2.X+s] = column[t].Clamp(0, math.Inf(1))
+		}
+	}
+
+	return resampled
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/mipmap.h#L123
+func resampleWeights(oldRes, newRes int) []resampleWeight {
+	const filterWidth = 2
+	wt := make([]resampleWeight, newRes)
+	for i := range wt {
+		// Compute image resampling weights for i-th texel
+		center := (float64(i) + 0.5) * float64(oldRes) / float64(newRes)
+		wt[i].firstTexel = int(math.Floor(center - filterWidth + 0.5))
+		sum := 0.0
+		for j := range wt[i].weight {
+			pos := float64(wt[i].firstTexel+j) + 0.5
+			wt[i].weight[j] = Lanczos((pos-center)/filterWidth, 2)
+			sum += wt[i].weight[j]
+		}
+
+		// Normalize filter weights for texel resampling
+		for j := range wt[i].weight {
+			wt[i].weight[j] /= sum
+		}
+	}
+	return wt
+}
+
+// Levels returns number of the pyramid levels
+func (m *MIPMap[T]) Levels() int {
+	return len(m.pyramid)
+}
+
+// LevelResolution returns resolution of the pyramid level
+func (m *MIPMap[T]) LevelResolution(level int) mymath.Point2i {
+	return m.pyramid[level].resolution
+}
+
+// Texel returns the texel at (s, t) of the pyramid level, the coordinates outside of the level are handled
+// according to WrapMode
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/mipmap.h#L305
+func (m *MIPMap[T]) Texel(level, s, t int) T {
+	l := &m.pyramid[level]
+
+	// Compute texel (s,t) accounting for boundary conditions
+	s, sOk := m.wrap(s, l.resolution.X)
+	t, tOk := m.wrap(t, l.resolution.Y)
+	if !sOk || !tOk {
+		var black T
+		return black
+	}
+	return l.texels[t*l.resolution.X+s]
+}
+
+// wrap maps the texel coordinate to [0, res), returns false when the texel is black
+func (m *MIPMap[T]) wrap(s, res int) (int, bool) {
+	switch m.WrapMode {
+	case WrapRepeat:
+		return mod(s, res), true
+	case WrapClamp:
+		return clampInt(s, 0, res-1), true
+	default:
+		return s, s >= 0 && s < res
+	}
+}
+
+// Lookup returns texture value filtered by the triangle filter of the given width, the two nearest pyramid levels
+// are interpolated
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/mipmap.h#L336
+func (m *MIPMap[T]) Lookup(st mymath.Point2, width float64) T {
+	// Compute MIPMap level for trilinear filtering
+	level := float64(m.Levels()-1) + math.Log2(math.Max(width, 1e-8))
+
+	// Perform trilinear interpolation at appropriate MIPMap level
+	if level < 0 {
+		return m.triangle(0, st)
+	} else if level >= float64(m.Levels()-1) {
+		return m.Texel(m.Levels()-1, 0, 0)
+	}
+
+	iLevel := int(math.Floor(level))
+	delta := level - float64(iLevel)
+	return m.triangle(iLevel, st).Lerp(delta, m.triangle(iLevel+1, st))
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/mipmap.h#L356
+func (m *MIPMap[T]) triangle(level int, st mymath.Point2) T {
+	level = clampInt(level, 0, m.Levels()-1)
+	res := m.pyramid[level].resolution
+	s := st.X*float64(res.X) - 0.5
+	t := st.Y*float64(res.Y) - 0.5
+	s0, t0 := int(math.Floor(s)), int(math.Floor(t))
+	ds, dt := s-float64(s0), t-float64(t0)
+	return m.Texel(level, s0, t0).Multiply((1 - ds) * (1 - dt)).Add(m.Texel(level, s0, t0+1).Multiply((1 - ds) * dt)).
+		Add(m.Texel(level, s0+1, t0).Multiply(ds * (1 - dt))).Add(m.Texel(level, s0+1, t0+1).Multiply(ds * dt))
+}
+
+// LookupEWA returns texture value filtered over the ellipse given by the texture coordinate differentials
+// dst0 and dst1. Trilinear filtering is used instead when DoTrilinear is set.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/mipmap.h#L370
+func (m *MIPMap[T]) LookupEWA(st mymath.Point2, dst0, dst1 mymath.Vector2) T {
+	if m.DoTrilinear {
+		width := 2 * math.Max(
+			math.Max(math.Abs(dst0.X), math.Abs(dst0.Y)),
+			math.Max(math.Abs(dst1.X), math.Abs(dst1.Y)))
+		return m.Lookup(st, width)
+	}
+
+	// Compute ellipse minor and major axes
+	if lengthSq(dst0) < lengthSq(dst1) {
+		dst0, dst1 = dst1, dst0
+	}
+	majorLength := math.Sqrt(lengthSq(dst0))
+	minorLength := math.Sqrt(lengthSq(dst1))
+
+	// Clamp ellipse eccentricity if too large
+	if minorLength*m.MaxAnisotropy < majorLength && minorLength > 0 {
+		scale := majorLength / (minorLength * m.MaxAnisotropy)
+		dst1 = dst1.Multiply(scale)
+		minorLength *= scale
+	}
+	if minorLength == 0 {
+		return m.triangle(0, st)
+	}
+
+	// Choose level of detail for EWA lookup and perform EWA filtering
+	lod := math.Max(0, float64(m.Levels()-1)+math.Log2(minorLength))
+	iLod := int(math.Floor(lod))
+	return m.ewa(iLod, st, dst0, dst1).Lerp(lod-float64(iLod), m.ewa(iLod+1, st, dst0, dst1))
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/mipmap.h#L401
+func (m *MIPMap[T]) ewa(level int, st mymath.Point2, dst0, dst1 mymath.Vector2) T {
+	if level >= m.Levels() {
+		return m.Texel(m.Levels()-1, 0, 0)
+	}
+
+	// Convert EWA coordinates to appropriate scale for level
+	res := m.pyramid[level].resolution
+	s := st.X*float64(res.X) - 0.5
+	t := st.Y*float64(res.Y) - 0.5
+	dst0 = mymath.NewVector2(dst0.X*float64(res.X), dst0.Y*float64(res.Y))
+	dst1 = mymath.NewVector2(dst1.X*float64(res.X), dst1.Y*float64(res.Y))
+
+	// Compute ellipse coefficients to bound EWA filter region
+	a := dst0.Y*dst0.Y + dst1.Y*dst1.Y + 1
+	b := -2 * (dst0.X*dst0.Y + dst1.X*dst1.Y)
+	c := dst0.X*dst0.X + dst1.X*dst1.X + 1
+	invF := 1 / (a*c - b*b*0.25)
+	a *= invF
+	b *= invF
+	c *= invF
+
+	// Compute the ellipse's (s,t) bounding box in texture space
+	det := -b*b + 4*a*c
+	invDet := 1 / det
+	uSqrt, vSqrt := math.Sqrt(det*c), math.Sqrt(a*det)
+	s0 := int(math.Ceil(s - 2*invDet*uSqrt))
+	s1 := int(math.Floor(s + 2*invDet*uSqrt))
+	t0 := int(math.Ceil(t - 2*invDet*vSqrt))
+	t1 := int(math.Floor(t + 2*invDet*vSqrt))
+
+	// Scan over ellipse bound and compute quadratic equation
+	var sum T
+	sumWts := 0.0
+	for it := t0; it <= t1; it++ {
+		tt := float64(it) - t
+		for is := s0; is <= s1; is++ {
+			ss := float64(is) - s
+
+			// Compute squared radius and filter texel if inside ellipse
+			r2 := a*ss*ss + b*ss*tt + c*tt*tt
+			if r2 < 1 {
+				index := minInt(int(r2*weightLUTSize), weightLUTSize-1)
+				weight := weightLut[index]
+				sum = sum.Add(m.Texel(level, is, it).Multiply(weight))
+				sumWts += weight
+			}
+		}
+	}
+
+	return sum.Multiply(1 / sumWts)
+}
+
+// Lanczos returns the windowed sinc filter value at x, the sinc is windowed by the wider sinc scaled by tau
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/texture.cpp#L88
+func Lanczos(x, tau float64) float64 {
+	x = math.Abs(x)
+	if x < 1e-5 {
+		return 1
+	}
+	if x > 1 {
+		return 0
+	}
+
+	x *= math.Pi
+	s := math.Sin(x*tau) / (x * tau)
+	lanczos := math.Sin(x) / x
+	return s * lanczos
+}
+
+func lengthSq(v mymath.Vector2) float64 {
+	return v.X*v.X + v.Y*v.Y
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func mod(a, b int) int {
+	result := a % b
+	if result < 0 {
+		result += b
+	}
+	return result
+}
+
+func clampInt(v, low, high int) int {
+	if v < low {
+		return low
+	}
+	if v > high {
+		return high
+	}
+	return v
+}
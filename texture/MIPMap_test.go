@@ -0,0 +1,137 @@
+package texture_test
+
+import (
+	"math/rand"
+	"pbrt-go/mymath"
+	"pbrt-go/texture"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMIPMap_pyramid(t *testing.T) {
+	img := make([]texture.Float, 8*4)
+	for i := range img {
+		img[i] = texture.Float(i)
+	}
+
+	m := texture.NewMIPMap(mymath.NewPoint2i(8, 4), img, false, 8, texture.WrapClamp)
+	assert.Equal(t, 4, m.Levels())
+	assert.Equal(t, mymath.NewPoint2i(8, 4), m.LevelResolution(0))
+	assert.Equal(t, mymath.NewPoint2i(4, 2), m.LevelResolution(1))
+	assert.Equal(t, mymath.NewPoint2i(2, 1), m.LevelResolution(2))
+	assert.Equal(t, mymath.NewPoint2i(1, 1), m.LevelResolution(3))
+
+	assert.Equal(t, 11.0, float64(m.Texel(0, 3, 1)))
+	assert.Equal(t, (0+1+8+9)/4.0, float64(m.Texel(1, 0, 0)))
+	assert.Equal(t, (22+23+30+31)/4.0, float64(m.Texel(1, 3, 1)))
+
+	// coarsest level is the average
+	assert.InDelta(t, 15.5, float64(m.Texel(3, 0, 0)), 1e-12)
+}
+
+func TestNewMIPMap_resample(t *testing.T) {
+	for _, wrapMode := range []texture.ImageWrap{texture.WrapRepeat, texture.WrapClamp} {
+		img := make([]texture.Float, 5*3)
+		for i := range img {
+			img[i] = 0.7
+		}
+
+		// constant image stays constant
+		m := texture.NewMIPMap(mymath.NewPoint2i(5, 3), img, false, 8, wrapMode)
+		assert.Equal(t, mymath.NewPoint2i(8, 4), m.Resolution)
+		for t0 := 0; t0 < 4; t0++ {
+			for s := 0; s < 8; s++ {
+				assert.InDelta(t, 0.7, float64(m.Texel(0, s, t0)), 1e-12)
+			}
+		}
+	}
+
+	// resampled values are non-negative
+	m := texture.NewMIPMap(mymath.NewPoint2i(3, 1), []texture.Float{0, 1, 0}, false, 8, texture.WrapBlack)
+	for s := 0; s < 4; s++ {
+		assert.GreaterOrEqual(t, float64(m.Texel(0, s, 0)), 0.0)
+	}
+}
+
+func TestMIPMap_Texel_wrap(t *testing.T) {
+	img := []texture.Float{1, 2, 3, 4}
+	repeat := texture.NewMIPMap(mymath.NewPoint2i(2, 2), img, false, 8, texture.WrapRepeat)
+	black := texture.NewMIPMap(mymath.NewPoint2i(2, 2), img, false, 8, texture.WrapBlack)
+	clamp := texture.NewMIPMap(mymath.NewPoint2i(2, 2), img, false, 8, texture.WrapClamp)
+
+	assert.Equal(t, 4.0, float64(repeat.Texel(0, -1, 3)))
+	assert.Equal(t, 0.0, float64(black.Texel(0, -1, 1)))
+	assert.Equal(t, 3.0, float64(clamp.Texel(0, -1, 1)))
+	assert.Equal(t, 2.0, float64(clamp.Texel(0, 5, -1)))
+}
+
+func TestMIPMap_Lookup(t *testing.T) {
+	img := randomImage(16, 16, 1)
+	m := texture.NewMIPMap(mymath.NewPoint2i(16, 16), img, true, 8, texture.WrapRepeat)
+
+	// narrow filter interpolates the finest level
+	assert.InDelta(t, float64(img[3*16+5]), float64(m.Lookup(mymath.NewPoint2((5+0.5)/16, (3+0.5)/16), 0)), 1e-12)
+
+	// wide filter returns the average
+	assert.InDelta(t, float64(m.Texel(m.Levels()-1, 0, 0)), float64(m.Lookup(mymath.NewPoint2(0.3, 0.6), 1)), 1e-12)
+	assert.InDelta(t, average(img), float64(m.Lookup(mymath.NewPoint2(0.3, 0.6), 1)), 1e-12)
+
+	// width of two finest texels selects the next level
+	assert.InDelta(t, float64(m.Texel(1, 1, 2)), float64(m.Lookup(mymath.NewPoint2(1.5/8, 2.5/8), 2.0/16)), 1e-12)
+}
+
+func TestMIPMap_LookupEWA(t *testing.T) {
+	for _, wrapMode := range []texture.ImageWrap{texture.WrapRepeat, texture.WrapClamp} {
+		constant := make([]texture.Float, 16*8)
+		for i := range constant {
+			constant[i] = 0.25
+		}
+		m := texture.NewMIPMap(mymath.NewPoint2i(16, 8), constant, false, 8, wrapMode)
+
+		rng := rand.New(rand.NewSource(1))
+		for i := 0; i < 100; i++ {
+			st := mymath.NewPoint2(rng.Float64(), rng.Float64())
+			dst0 := mymath.NewVector2(0.2*rng.Float64()-0.1, 0.2*rng.Float64()-0.1)
+			dst1 := mymath.NewVector2(0.02*rng.Float64()-0.01, 0.02*rng.Float64()-0.01)
+			assert.InDelta(t, 0.25, float64(m.LookupEWA(st, dst0, dst1)), 1e-12)
+		}
+	}
+
+	// stripes along t are averaged across them but not along them
+	stripes := make([]texture.Float, 16*16)
+	for i := range stripes {
+		stripes[i] = texture.Float((i % 16) % 2)
+	}
+	m := texture.NewMIPMap(mymath.NewPoint2i(16, 16), stripes, false, 16, texture.WrapRepeat)
+	assert.InDelta(t, 0.5, float64(m.LookupEWA(mymath.NewPoint2(0.5, 0.5), mymath.NewVector2(0.5, 0), mymath.NewVector2(0, 0.01))), 0.1)
+
+	// without filter width the finest level is interpolated
+	assert.InDelta(t, 1, float64(m.LookupEWA(mymath.NewPoint2(1.5/16, 0.5), mymath.Vector2{}, mymath.Vector2{})), 1e-12)
+}
+
+func TestLanczos(t *testing.T) {
+	assert.Equal(t, 1.0, texture.Lanczos(0, 2))
+	assert.InDelta(t, 0.0, texture.Lanczos(1, 2), 1e-12)
+	assert.InDelta(t, 0.0, texture.Lanczos(0.5, 2), 1e-12)
+	assert.Equal(t, 0.0, texture.Lanczos(1.5, 2))
+	assert.Equal(t, texture.Lanczos(0.3, 2), texture.Lanczos(-0.3, 2))
+	assert.Less(t, texture.Lanczos(0.75, 2), 0.0)
+}
+
+func randomImage(width, height int, seed int64) []texture.Float {
+	rng := rand.New(rand.NewSource(seed))
+	img := make([]texture.Float, width*height)
+	for i := range img {
+		img[i] = texture.Float(rng.Float64())
+	}
+	return img
+}
+
+func average(values []texture.Float) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += float64(v)
+	}
+	return sum / float64(len(values))
+}
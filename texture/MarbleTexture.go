@@ -0,0 +1,59 @@
+package texture
+
+import (
+	"math"
+	"pbrt-go/mymath"
+	"pbrt-go/spectrum"
+)
+
+// marbleColors are control points of the marble color spline
+var marbleColors = [][3]float64{
+	{0.58, 0.58, 0.6}, {0.58, 0.58, 0.6}, {0.58, 0.58, 0.6},
+	{0.5, 0.5, 0.5}, {0.6, 0.59, 0.58}, {0.58, 0.58, 0.6},
+	{0.58, 0.58, 0.6}, {0.2, 0.2, 0.33}, {0.58, 0.58, 0.6},
+}
+
+// MarbleTexture perturbs layers of the marble colors along y axis by FBm noise
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/textures/marble.h#L48
+type MarbleTexture struct {
+	Mapping          TextureMapping3D
+	Octaves          int
+	Omega            float64
+	Scale, Variation float64
+}
+
+func NewMarbleTexture(mapping TextureMapping3D, octaves int, omega, scale, variation float64) *MarbleTexture {
+	return &MarbleTexture{mapping, octaves, omega, scale, variation}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/textures/marble.h#L58
+func (t *MarbleTexture) Evaluate(si *mymath.SurfaceInteraction) spectrum.Spectrum {
+	p, dpdx, dpdy := t.Mapping.Map(si)
+	p = p.Multiply(t.Scale)
+	marble := p.Y + t.Variation*FBm(p, dpdx.Multiply(t.Scale), dpdy.Multiply(t.Scale), t.Omega, t.Octaves)
+	tt := 0.5 + 0.5*math.Sin(marble)
+
+	// Evaluate marble spline at tt
+	nSeg := len(marbleColors) - 3
+	first := int(math.Floor(tt * float64(nSeg)))
+	if first > nSeg-1 {
+		first = nSeg - 1
+	}
+	tt = tt*float64(nSeg) - float64(first)
+
+	c0 := spectrum.SpectrumFromRGB(marbleColors[first], spectrum.Reflectance)
+	c1 := spectrum.SpectrumFromRGB(marbleColors[first+1], spectrum.Reflectance)
+	c2 := spectrum.SpectrumFromRGB(marbleColors[first+2], spectrum.Reflectance)
+	c3 := spectrum.SpectrumFromRGB(marbleColors[first+3], spectrum.Reflectance)
+
+	// Bezier spline evaluated with de Castilejau's algorithm
+	s0 := c0.Lerp(tt, c1)
+	s1 := c1.Lerp(tt, c2)
+	s2 := c2.Lerp(tt, c3)
+	s0 = s0.Lerp(tt, s1)
+	s1 = s1.Lerp(tt, s2)
+
+	// Extra scale of 1.5 to increase variation among colors
+	return s0.Lerp(tt, s1).Multiply(1.5)
+}
@@ -0,0 +1,21 @@
+package texture
+
+import "pbrt-go/mymath"
+
+// MixTexture linearly interpolates the two textures by the amount texture
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/textures/mix.h#L48
+type MixTexture[T Value[T]] struct {
+	Tex1, Tex2 Texture[T]
+	Amount     FloatTexture
+}
+
+func NewMixTexture[T Value[T]](tex1, tex2 Texture[T], amount FloatTexture) *MixTexture[T] {
+	return &MixTexture[T]{tex1, tex2, amount}
+}
+
+func (t *MixTexture[T]) Evaluate(si *mymath.SurfaceInteraction) T {
+	t1, t2 := t.Tex1.Evaluate(si), t.Tex2.Evaluate(si)
+	amt := t.Amount.Evaluate(si)
+	return t1.Lerp(float64(amt), t2)
+}
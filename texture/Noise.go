@@ -0,0 +1,168 @@
+package texture
+
+import (
+	"math"
+	"pbrt-go/mymath"
+)
+
+const noisePermSize = 256
+
+// noisePerm is Perlin's permutation table repeated twice so that the nested lookups do not need
+// the index wrapping
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/texture.cpp#L46
+var noisePerm = func() [2 * noisePermSize]int {
+	perm := [noisePermSize]int{
+		151, 160, 137, 91, 90, 15, 131, 13, 201, 95, 96, 53, 194, 233, 7, 225, 140, 36, 103, 30, 69, 142,
+		// Remainder of the noise permutation table
+		8, 99, 37, 240, 21, 10, 23, 190, 6, 148, 247, 120, 234, 75, 0, 26, 197, 62, 94, 252, 219, 203, 117, 35,
+		11, 32, 57, 177, 33, 88, 237, 149, 56, 87, 174, 20, 125, 136, 171, 168, 68, 175, 74, 165, 71, 134, 139,
+		48, 27, 166, 77, 146, 158, 231, 83, 111, 229, 122, 60, 211, 133, 230, 220, 105, 92, 41, 55, 46, 245, 40,
+		244, 102, 143, 54, 65, 25, 63, 161, 1, 216, 80, 73, 209, 76, 132, 187, 208, 89, 18, 169, 200, 196, 135,
+		130, 116, 188, 159, 86, 164, 100, 109, 198, 173, 186, 3, 64, 52, 217, 226, 250, 124, 123, 5, 202, 38,
+		147, 118, 126, 255, 82, 85, 212, 207, 206, 59, 227, 47, 16, 58, 17, 182, 189, 28, 42, 223, 183, 170,
+		213, 119, 248, 152, 2, 44, 154, 163, 70, 221, 153, 101, 155, 167, 43, 172, 9, 129, 22, 39, 253, 19, 98,
+		108, 110, 79, 113, 224, 232, 178, 185, 112, 104, 218, 246, 97, 228, 251, 34, 242, 193, 238, 210, 144,
+		12, 191, 179, 162, 241, 81, 51, 145, 235, 249, 14, 239, 107, 49, 192, 214, 31, 181, 199, 106, 157, 184,
+		84, 204, 176, 115, 121, 50, 45, 127, 4, 150, 254, 138, 236, 205, 93, 222, 114, 67, 29, 24, 72, 243, 141,
+		128, 195, 78, 66, 215, 61, 156, 180,
+	}
+
+	var table [2 * noisePermSize]int
+	copy(table[:], perm[:])
+	copy(table[noisePermSize:], perm[:])
+	return table
+}()
+
+// Noise returns Perlin noise value in the range [-1, 1] at the point (x, y, z), the value is zero
+// at the integer lattice points
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/texture.cpp#L186
+func Noise(x, y, z float64) float64 {
+	// Compute noise cell coordinates and offsets
+	ix, iy, iz := int(math.Floor(x)), int(math.Floor(y)), int(math.Floor(z))
+	dx, dy, dz := x-float64(ix), y-float64(iy), z-float64(iz)
+
+	// Compute gradient weights
+	ix &= noisePermSize - 1
+	iy &= noisePermSize - 1
+	iz &= noisePermSize - 1
+	w000 := grad(ix, iy, iz, dx, dy, dz)
+	w100 := grad(ix+1, iy, iz, dx-1, dy, dz)
+	w010 := grad(ix, iy+1, iz, dx, dy-1, dz)
+	w110 := grad(ix+1, iy+1, iz, dx-1, dy-1, dz)
+	w001 := grad(ix, iy, iz+1, dx, dy, dz-1)
+	w101 := grad(ix+1, iy, iz+1, dx-1, dy, dz-1)
+	w011 := grad(ix, iy+1, iz+1, dx, dy-1, dz-1)
+	w111 := grad(ix+1, iy+1, iz+1, dx-1, dy-1, dz-1)
+
+	// Compute trilinear interpolation of weights
+	wx, wy, wz := noiseWeight(dx), noiseWeight(dy), noiseWeight(dz)
+	x00 := mymath.Lerp(wx, w000, w100)
+	x10 := mymath.Lerp(wx, w010, w110)
+	x01 := mymath.Lerp(wx, w001, w101)
+	x11 := mymath.Lerp(wx, w011, w111)
+	y0 := mymath.Lerp(wy, x00, x10)
+	y1 := mymath.Lerp(wy, x01, x11)
+	return mymath.Lerp(wz, y0, y1)
+}
+
+// NoiseP returns Perlin noise value at the point p
+func NoiseP(p mymath.Point3) float64 {
+	return Noise(p.X, p.Y, p.Z)
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/texture.cpp#L223
+func grad(x, y, z int, dx, dy, dz float64) float64 {
+	h := noisePerm[noisePerm[noisePerm[x]+y]+z]
+	h &= 15
+
+	u := dy
+	if h < 8 || h == 12 || h == 13 {
+		u = dx
+	}
+	v := dz
+	if h < 4 || h == 12 || h == 13 {
+		v = dy
+	}
+
+	if h&1 != 0 {
+		u = -u
+	}
+	if h&2 != 0 {
+		v = -v
+	}
+	return u + v
+}
+
+// noiseWeight is the smooth interpolation weight 6t^5 - 15t^4 + 10t^3
+func noiseWeight(t float64) float64 {
+	t3 := t * t * t
+	t4 := t3 * t
+	return 6*t4*t - 15*t4 + 10*t3
+}
+
+// FBm returns fractional Brownian motion, sum of the noise octaves with the frequency doubled and the amplitude
+// scaled by omega in each octave. The octaves above the sampling rate given by dpdx and dpdy are left out.
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/texture.cpp#L235
+func FBm(p mymath.Point3, dpdx, dpdy mymath.Vector3, omega float64, maxOctaves int) float64 {
+	// Compute number of octaves for antialiased FBm
+	nInt, nPartial := octaves(dpdx, dpdy, maxOctaves)
+
+	// Compute sum of octaves of noise for FBm
+	sum, lambda, o := 0.0, 1.0, 1.0
+	for i := 0; i < nInt; i++ {
+		sum += o * NoiseP(p.Multiply(lambda))
+		lambda *= 1.99
+		o *= omega
+	}
+
+	sum += o * smoothStep(0.3, 0.7, nPartial) * NoiseP(p.Multiply(lambda))
+	return sum
+}
+
+// Turbulence returns sum of the absolute values of the noise octaves, the octaves above the sampling rate
+// are replaced by their average value
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/texture.cpp#L253
+func Turbulence(p mymath.Point3, dpdx, dpdy mymath.Vector3, omega float64, maxOctaves int) float64 {
+	// Compute number of octaves for antialiased FBm
+	nInt, nPartial := octaves(dpdx, dpdy, maxOctaves)
+
+	// Compute sum of octaves of noise for turbulence
+	sum, lambda, o := 0.0, 1.0, 1.0
+	for i := 0; i < nInt; i++ {
+		sum += o * math.Abs(NoiseP(p.Multiply(lambda)))
+		lambda *= 1.99
+		o *= omega
+	}
+
+	// Account for contributions of clamped octaves in turbulence
+	sum += o * mymath.Lerp(smoothStep(0.3, 0.7, nPartial), 0.2, math.Abs(NoiseP(p.Multiply(lambda))))
+	for i := nInt; i < maxOctaves; i++ {
+		sum += o * 0.2
+		o *= omega
+	}
+
+	return sum
+}
+
+// octaves returns number of the whole octaves and fraction of the last octave that are below the sampling rate
+func octaves(dpdx, dpdy mymath.Vector3, maxOctaves int) (int, float64) {
+	len2 := math.Max(dpdx.LengthSq(), dpdy.LengthSq())
+	n := mymath.Clamp(-1-0.5*math.Log2(len2), 0, float64(maxOctaves))
+	nInt := math.Floor(n)
+	return int(nInt), n - nInt
+}
+
+func smoothStep(a, b, x float64) float64 {
+	if a == b {
+		if x < a {
+			return 0
+		}
+		return 1
+	}
+	t := mymath.Clamp((x-a)/(b-a), 0, 1)
+	return t * t * (3 - 2*t)
+}
@@ -0,0 +1,85 @@
+package texture_test
+
+import (
+	"math"
+	"math/rand"
+	"pbrt-go/mymath"
+	"pbrt-go/texture"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoise(t *testing.T) {
+	// zero at the lattice points
+	for _, p := range [][3]float64{{0, 0, 0}, {1, 2, 3}, {-5, 7, 300}} {
+		assert.Equal(t, 0.0, texture.Noise(p[0], p[1], p[2]))
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	sum := 0.0
+	for i := 0; i < 10000; i++ {
+		x, y, z := 100*rng.Float64()-50, 100*rng.Float64()-50, 100*rng.Float64()-50
+		n := texture.Noise(x, y, z)
+		assert.LessOrEqual(t, math.Abs(n), 1.0)
+
+		// continuous
+		assert.InDelta(t, n, texture.Noise(x+1e-7, y, z), 1e-5)
+
+		// periodic with the permutation table size
+		assert.InDelta(t, n, texture.Noise(x+256, y, z-256), 1e-9)
+
+		sum += n
+	}
+
+	assert.InDelta(t, 0, sum/10000, 0.02)
+}
+
+func TestFBm(t *testing.T) {
+	p := mymath.NewPoint3(0.3, 1.7, -2.2)
+	zero := mymath.Vector3{}
+
+	// single octave is the noise itself
+	assert.Equal(t, texture.NoiseP(p), texture.FBm(p, zero, zero, 0.5, 1))
+
+	// octaves above the sampling rate are left out
+	wide := mymath.NewVector3(1, 0, 0)
+	assert.Equal(t, 0.0, texture.FBm(p, wide, wide, 0.5, 8))
+	assert.Equal(t, texture.FBm(p, zero, zero, 0.5, 3), texture.FBm(p, mymath.NewVector3(1.0/16, 0, 0), zero, 0.5, 8))
+}
+
+func TestTurbulence(t *testing.T) {
+	p := mymath.NewPoint3(0.3, 1.7, -2.2)
+	zero := mymath.Vector3{}
+
+	// the octave following the last one is replaced by its average as in pbrt
+	assert.InDelta(t, math.Abs(texture.NoiseP(p))+0.5*0.2, texture.Turbulence(p, zero, zero, 0.5, 1), 1e-12)
+
+	// octaves above the sampling rate are replaced by their average
+	wide := mymath.NewVector3(1, 0, 0)
+	assert.InDelta(t, 0.2+0.2*(1+0.5+0.25), texture.Turbulence(p, wide, wide, 0.5, 3), 1e-12)
+}
+
+func TestNoiseTextures(t *testing.T) {
+	mapping := texture.NewIdentityMapping3D(mymath.NewTransformEmpty())
+	fbm := texture.NewFBmTexture(mapping, 8, 0.5)
+	wrinkled := texture.NewWrinkledTexture(mapping, 8, 0.5)
+	windy := texture.NewWindyTexture(mapping)
+	marble := texture.NewMarbleTexture(mapping, 8, 0.5, 1, 1)
+
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 1000; i++ {
+		p := mymath.NewPoint3(10*rng.Float64(), 10*rng.Float64(), 10*rng.Float64())
+		si := newSurfaceInteraction(p, mymath.Point2{})
+
+		assert.Equal(t, texture.FBm(p, mymath.Vector3{}, mymath.Vector3{}, 0.5, 8), float64(fbm.Evaluate(si)))
+		assert.GreaterOrEqual(t, float64(wrinkled.Evaluate(si)), 0.0)
+		assert.LessOrEqual(t, math.Abs(float64(windy.Evaluate(si))), 4.0)
+
+		color := marble.Evaluate(si)
+		assert.False(t, color.HasNaNs())
+		for _, c := range color.ToRGB() {
+			assert.LessOrEqual(t, c, 1.5*0.6+1e-6)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package texture
+
+import "pbrt-go/mymath"
+
+// ScaleTexture returns product of the two textures
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/textures/scale.h#L48
+type ScaleTexture[T Value[T]] struct {
+	Tex1, Tex2 Texture[T]
+}
+
+func NewScaleTexture[T Value[T]](tex1, tex2 Texture[T]) *ScaleTexture[T] {
+	return &ScaleTexture[T]{tex1, tex2}
+}
+
+func (t *ScaleTexture[T]) Evaluate(si *mymath.SurfaceInteraction) T {
+	return t.Tex1.Evaluate(si).MultiplyS(t.Tex2.Evaluate(si))
+}
@@ -0,0 +1,60 @@
+package texture
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/spectrum"
+)
+
+// Texture returns value of type T at the surface point
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/texture.h#L140
+type Texture[T any] interface {
+	Evaluate(si *mymath.SurfaceInteraction) T
+}
+
+// FloatTexture is texture of the scalar values, e.g. roughness or bump map
+type FloatTexture = Texture[Float]
+
+// SpectrumTexture is texture of the colors
+type SpectrumTexture = Texture[spectrum.Spectrum]
+
+// Value is type of the texture values that can be combined by the textures, i.e. Float or spectrum.Spectrum
+type Value[T any] interface {
+	Add(v T) T
+	MultiplyS(v T) T
+	Multiply(s float64) T
+	Lerp(t float64, v T) T
+	Clamp(low, high float64) T
+	// FromRGB converts the color to the value, the receiver is not used
+	FromRGB(rgb [3]float64, spectrumType spectrum.SpectrumType) T
+}
+
+// Float is the scalar texture value
+type Float float64
+
+func (f Float) Add(f2 Float) Float {
+	return f + f2
+}
+
+func (f Float) MultiplyS(f2 Float) Float {
+	return f * f2
+}
+
+func (f Float) Multiply(s float64) Float {
+	return f * Float(s)
+}
+
+func (f1 Float) Lerp(t float64, f2 Float) Float {
+	return Float(mymath.Lerp(t, float64(f1), float64(f2)))
+}
+
+func (f Float) Clamp(low, high float64) Float {
+	return Float(mymath.Clamp(float64(f), low, high))
+}
+
+// FromRGB returns luminance of the color
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/textures/imagemap.h#L90
+func (Float) FromRGB(rgb [3]float64, _ spectrum.SpectrumType) Float {
+	return Float(spectrum.RGBSpectrum(rgb).Y())
+}
@@ -0,0 +1,156 @@
+package texture
+
+import (
+	"math"
+	"pbrt-go/mymath"
+)
+
+// TextureMapping2D computes the 2D texture coordinates (s, t) of the surface point together with their screen
+// space partial derivatives
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/texture.h#L53
+type TextureMapping2D interface {
+	Map(si *mymath.SurfaceInteraction) (st mymath.Point2, dstdx, dstdy mymath.Vector2)
+}
+
+// TextureMapping3D computes the 3D texture coordinates of the surface point together with their screen
+// space partial derivatives
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/texture.h#L117
+type TextureMapping3D interface {
+	Map(si *mymath.SurfaceInteraction) (p mymath.Point3, dpdx, dpdy mymath.Vector3)
+}
+
+// UVMapping2D scales and offsets the (u, v) parametrization of the shape
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/texture.h#L62
+type UVMapping2D struct {
+	Su, Sv, Du, Dv float64
+}
+
+func NewUVMapping2D(su, sv, du, dv float64) *UVMapping2D {
+	return &UVMapping2D{su, sv, du, dv}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/texture.cpp#L100
+func (m *UVMapping2D) Map(si *mymath.SurfaceInteraction) (mymath.Point2, mymath.Vector2, mymath.Vector2) {
+	// Compute texture differentials for 2D (u, v) mapping
+	dstdx := mymath.NewVector2(m.Su*si.Dudx, m.Sv*si.Dvdx)
+	dstdy := mymath.NewVector2(m.Su*si.Dudy, m.Sv*si.Dvdy)
+	return mymath.NewPoint2(m.Su*si.Uv.X+m.Du, m.Sv*si.Uv.Y+m.Dv), dstdx, dstdy
+}
+
+// SphericalMapping2D projects the point to the unit sphere around the texture space origin,
+// s is polar angle and t azimuth both scaled to [0, 1]
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/texture.h#L75
+type SphericalMapping2D struct {
+	WorldToTexture mymath.Transform
+}
+
+func NewSphericalMapping2D(worldToTexture mymath.Transform) *SphericalMapping2D {
+	return &SphericalMapping2D{worldToTexture}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/texture.cpp#L114
+func (m *SphericalMapping2D) Map(si *mymath.SurfaceInteraction) (mymath.Point2, mymath.Vector2, mymath.Vector2) {
+	st := m.sphere(si.P)
+
+	// Compute texture coordinate differentials for sphere (u, v) mapping
+	const delta = 0.1
+	stDeltaX := m.sphere(si.P.AddV(si.Dpdx.Multiply(delta)))
+	stDeltaY := m.sphere(si.P.AddV(si.Dpdy.Multiply(delta)))
+
+	// Handle sphere mapping discontinuity for coordinate differentials, phi wraps around in the t coordinate
+	dstdx := mymath.NewVector2(stDeltaX.X-st.X, wrapDifference(stDeltaX.Y-st.Y))
+	dstdy := mymath.NewVector2(stDeltaY.X-st.X, wrapDifference(stDeltaY.Y-st.Y))
+
+	return st, dstdx.Multiply(1 / delta), dstdy.Multiply(1 / delta)
+}
+
+func (m *SphericalMapping2D) sphere(p mymath.Point3) mymath.Point2 {
+	vec := m.WorldToTexture.ApplyP(p).SubtractP(mymath.NewPoint3(0, 0, 0)).Normalize()
+	theta, phi := mymath.SphericalTheta(vec), mymath.SphericalPhi(vec)
+	return mymath.NewPoint2(theta/math.Pi, phi/(2*math.Pi))
+}
+
+// CylindricalMapping2D projects the point to the cylinder around the texture space z axis,
+// s is the azimuth scaled to [0, 1] and t is the height
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/texture.h#L90
+type CylindricalMapping2D struct {
+	WorldToTexture mymath.Transform
+}
+
+func NewCylindricalMapping2D(worldToTexture mymath.Transform) *CylindricalMapping2D {
+	return &CylindricalMapping2D{worldToTexture}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/texture.cpp#L141
+func (m *CylindricalMapping2D) Map(si *mymath.SurfaceInteraction) (mymath.Point2, mymath.Vector2, mymath.Vector2) {
+	st := m.cylinder(si.P)
+
+	// Compute texture coordinate differentials for cylinder (u, v) mapping
+	const delta = 0.01
+	stDeltaX := m.cylinder(si.P.AddV(si.Dpdx.Multiply(delta)))
+	stDeltaY := m.cylinder(si.P.AddV(si.Dpdy.Multiply(delta)))
+
+	// Handle cylinder mapping discontinuity for coordinate differentials, the azimuth wraps around
+	// in the s coordinate
+	dstdx := mymath.NewVector2(wrapDifference(stDeltaX.X-st.X), stDeltaX.Y-st.Y)
+	dstdy := mymath.NewVector2(wrapDifference(stDeltaY.X-st.X), stDeltaY.Y-st.Y)
+
+	return st, dstdx.Multiply(1 / delta), dstdy.Multiply(1 / delta)
+}
+
+func (m *CylindricalMapping2D) cylinder(p mymath.Point3) mymath.Point2 {
+	vec := m.WorldToTexture.ApplyP(p).SubtractP(mymath.NewPoint3(0, 0, 0)).Normalize()
+	return mymath.NewPoint2((math.Pi+math.Atan2(vec.Y, vec.X))/(2*math.Pi), vec.Z)
+}
+
+// PlanarMapping2D projects the point to the plane spanned by the vectors Vs and Vt
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/texture.h#L105
+type PlanarMapping2D struct {
+	Vs, Vt mymath.Vector3
+	Ds, Dt float64
+}
+
+func NewPlanarMapping2D(vs, vt mymath.Vector3, ds, dt float64) *PlanarMapping2D {
+	return &PlanarMapping2D{vs, vt, ds, dt}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/texture.cpp#L165
+func (m *PlanarMapping2D) Map(si *mymath.SurfaceInteraction) (mymath.Point2, mymath.Vector2, mymath.Vector2) {
+	vec := si.P.SubtractP(mymath.NewPoint3(0, 0, 0))
+	dstdx := mymath.NewVector2(si.Dpdx.Dot(m.Vs), si.Dpdx.Dot(m.Vt))
+	dstdy := mymath.NewVector2(si.Dpdy.Dot(m.Vs), si.Dpdy.Dot(m.Vt))
+	return mymath.NewPoint2(m.Ds+vec.Dot(m.Vs), m.Dt+vec.Dot(m.Vt)), dstdx, dstdy
+}
+
+// IdentityMapping3D uses the texture space position of the point
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/texture.h#L124
+type IdentityMapping3D struct {
+	WorldToTexture mymath.Transform
+}
+
+func NewIdentityMapping3D(worldToTexture mymath.Transform) *IdentityMapping3D {
+	return &IdentityMapping3D{worldToTexture}
+}
+
+// see https://github.com/mmp/pbrt-v3/blob/master/src/core/texture.cpp#L174
+func (m *IdentityMapping3D) Map(si *mymath.SurfaceInteraction) (mymath.Point3, mymath.Vector3, mymath.Vector3) {
+	return m.WorldToTexture.ApplyP(si.P), m.WorldToTexture.ApplyV(si.Dpdx), m.WorldToTexture.ApplyV(si.Dpdy)
+}
+
+// wrapDifference returns the shorter way around for the difference of the periodic coordinate with period 1,
+// pbrt applies it to the already divided differentials which does not detect the jump
+func wrapDifference(d float64) float64 {
+	if d > 0.5 {
+		return d - 1
+	} else if d < -0.5 {
+		return d + 1
+	}
+	return d
+}
@@ -0,0 +1,75 @@
+package texture_test
+
+import (
+	"math"
+	"pbrt-go/mymath"
+	"pbrt-go/texture"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUVMapping2D_Map(t *testing.T) {
+	si := newSurfaceInteractionDifferentials(mymath.NewPoint3(0.25, 0.5, 0), 0.01)
+
+	st, dstdx, dstdy := texture.NewUVMapping2D(2, 4, 0.1, 0.2).Map(si)
+	assert.InDelta(t, 0.6, st.X, 1e-12)
+	assert.InDelta(t, 2.2, st.Y, 1e-12)
+	assert.Equal(t, mymath.NewVector2(0.02, 0), dstdx)
+	assert.Equal(t, mymath.NewVector2(0, 0.04), dstdy)
+}
+
+func TestSphericalMapping2D_Map(t *testing.T) {
+	mapping := texture.NewSphericalMapping2D(mymath.NewTransformTranslate(mymath.NewVector3(0, 0, -1)))
+
+	// north pole of the sphere centered at (0, 0, 1)
+	st, _, _ := mapping.Map(newSurfaceInteraction(mymath.NewPoint3(0, 0, 2), mymath.Point2{}))
+	assert.InDelta(t, 0, st.X, 1e-12)
+
+	// equator
+	st, _, _ = mapping.Map(newSurfaceInteraction(mymath.NewPoint3(0, 5, 1), mymath.Point2{}))
+	assert.InDelta(t, 0.5, st.X, 1e-12)
+	assert.InDelta(t, 0.25, st.Y, 1e-12)
+
+	// differentials across the discontinuity at phi = 0 are small
+	si := newSurfaceInteraction(mymath.NewPoint3(1, -1e-4, 1), mymath.Point2{})
+	si.Dpdx = mymath.NewVector3(0, 1e-3, 0)
+	_, dstdx, _ := mapping.Map(si)
+	assert.Less(t, math.Abs(dstdx.Y), 0.5)
+}
+
+func TestCylindricalMapping2D_Map(t *testing.T) {
+	mapping := texture.NewCylindricalMapping2D(mymath.NewTransformEmpty())
+
+	st, _, _ := mapping.Map(newSurfaceInteraction(mymath.NewPoint3(-1, 0, 0), mymath.Point2{}))
+	assert.InDelta(t, 1, st.X, 1e-12)
+	assert.InDelta(t, 0, st.Y, 1e-12)
+
+	st, _, _ = mapping.Map(newSurfaceInteraction(mymath.NewPoint3(1, 0, 0), mymath.Point2{}))
+	assert.InDelta(t, 0.5, st.X, 1e-12)
+
+	// differentials across the discontinuity at s = 0 are small
+	si := newSurfaceInteraction(mymath.NewPoint3(-1, 1e-4, 0), mymath.Point2{})
+	si.Dpdx = mymath.NewVector3(0, -1e-3, 0)
+	_, dstdx, _ := mapping.Map(si)
+	assert.Less(t, math.Abs(dstdx.X), 0.5)
+}
+
+func TestPlanarMapping2D_Map(t *testing.T) {
+	mapping := texture.NewPlanarMapping2D(mymath.NewVector3(1, 0, 0), mymath.NewVector3(0, 0, 2), 0.5, 0)
+
+	si := newSurfaceInteractionDifferentials(mymath.NewPoint3(1, 2, 3), 0.1)
+	st, dstdx, dstdy := mapping.Map(si)
+	assert.Equal(t, mymath.NewPoint2(1.5, 6), st)
+	assert.Equal(t, mymath.NewVector2(0.1, 0), dstdx)
+	assert.Equal(t, mymath.NewVector2(0, 0), dstdy)
+}
+
+func TestIdentityMapping3D_Map(t *testing.T) {
+	mapping := texture.NewIdentityMapping3D(mymath.NewTransformScale(2, 2, 2))
+
+	p, dpdx, dpdy := mapping.Map(newSurfaceInteractionDifferentials(mymath.NewPoint3(1, 2, 3), 0.1))
+	assert.Equal(t, mymath.NewPoint3(2, 4, 6), p)
+	assert.Equal(t, mymath.NewVector3(0.2, 0, 0), dpdx)
+	assert.Equal(t, mymath.NewVector3(0, 0.2, 0), dpdy)
+}
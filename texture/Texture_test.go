@@ -0,0 +1,99 @@
+package texture_test
+
+import (
+	"pbrt-go/mymath"
+	"pbrt-go/spectrum"
+	"pbrt-go/texture"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstantTexture(t *testing.T) {
+	si := newSurfaceInteraction(mymath.NewPoint3(1, 2, 3), mymath.NewPoint2(0.3, 0.4))
+
+	var f texture.FloatTexture = texture.NewConstantTexture[texture.Float](0.5)
+	assert.Equal(t, 0.5, float64(f.Evaluate(si)))
+
+	var s texture.SpectrumTexture = texture.NewConstantTexture(spectrum.NewSpectrum(2))
+	assert.Equal(t, spectrum.NewSpectrum(2), s.Evaluate(si))
+}
+
+func TestScaleTexture(t *testing.T) {
+	si := newSurfaceInteraction(mymath.NewPoint3(1, 2, 3), mymath.NewPoint2(0.3, 0.4))
+
+	f := texture.NewScaleTexture[texture.Float](texture.NewConstantTexture[texture.Float](0.5), texture.NewConstantTexture[texture.Float](3.0))
+	assert.Equal(t, 1.5, float64(f.Evaluate(si)))
+
+	s := texture.NewScaleTexture[spectrum.Spectrum](
+		texture.NewConstantTexture(spectrum.NewSpectrum(2)),
+		texture.NewConstantTexture(spectrum.NewSpectrum(3)))
+	assert.Equal(t, spectrum.NewSpectrum(6), s.Evaluate(si))
+}
+
+func TestMixTexture(t *testing.T) {
+	si := newSurfaceInteraction(mymath.NewPoint3(1, 2, 3), mymath.NewPoint2(0.3, 0.4))
+
+	// amount taken from the u coordinate
+	amount := texture.NewBilerpTexture[texture.Float](texture.NewUVMapping2D(1, 1, 0, 0), 0, 0, 1, 1)
+	f := texture.NewMixTexture[texture.Float](texture.NewConstantTexture[texture.Float](1.0), texture.NewConstantTexture[texture.Float](2.0), amount)
+	assert.InDelta(t, 1.3, float64(f.Evaluate(si)), 1e-12)
+
+	s := texture.NewMixTexture[spectrum.Spectrum](
+		texture.NewConstantTexture(spectrum.NewSpectrum(1)),
+		texture.NewConstantTexture(spectrum.NewSpectrum(2)),
+		amount)
+	assert.InDelta(t, 1.3, s.Evaluate(si).MaxComponentValue(), 1e-12)
+}
+
+func TestBilerpTexture(t *testing.T) {
+	tex := texture.NewBilerpTexture[texture.Float](texture.NewUVMapping2D(1, 1, 0, 0), 1, 2, 3, 4)
+
+	assert.InDelta(t, 1.0, float64(tex.Evaluate(newSurfaceInteraction(mymath.Point3{}, mymath.NewPoint2(0, 0)))), 1e-12)
+	assert.InDelta(t, 2.0, float64(tex.Evaluate(newSurfaceInteraction(mymath.Point3{}, mymath.NewPoint2(0, 1)))), 1e-12)
+	assert.InDelta(t, 3.0, float64(tex.Evaluate(newSurfaceInteraction(mymath.Point3{}, mymath.NewPoint2(1, 0)))), 1e-12)
+	assert.InDelta(t, 4.0, float64(tex.Evaluate(newSurfaceInteraction(mymath.Point3{}, mymath.NewPoint2(1, 1)))), 1e-12)
+	assert.InDelta(t, 2.5, float64(tex.Evaluate(newSurfaceInteraction(mymath.Point3{}, mymath.NewPoint2(0.5, 0.5)))), 1e-12)
+	assert.InDelta(t, 1.0*0.9*0.8+2.0*0.9*0.2+3.0*0.1*0.8+4.0*0.1*0.2,
+		float64(tex.Evaluate(newSurfaceInteraction(mymath.Point3{}, mymath.NewPoint2(0.1, 0.2)))), 1e-12)
+}
+
+func TestDotsTexture(t *testing.T) {
+	tex := texture.NewDotsTexture[texture.Float](texture.NewUVMapping2D(1, 1, 0, 0), texture.NewConstantTexture[texture.Float](0.0), texture.NewConstantTexture[texture.Float](1.0))
+
+	// sample the dots on the fine grid
+	inside, cells := 0, map[[2]int]bool{}
+	for y := 0; y < 400; y++ {
+		for x := 0; x < 400; x++ {
+			u, v := float64(x)/20, float64(y)/20
+			value := float64(tex.Evaluate(newSurfaceInteraction(mymath.Point3{}, mymath.NewPoint2(u, v))))
+			assert.Contains(t, []float64{0, 1}, value)
+
+			if value == 1 {
+				inside++
+				cells[[2]int{int(u + 0.5), int(v + 0.5)}] = true
+			}
+		}
+	}
+
+	// some cells have dots, each dot covers pi*0.35^2 of its cell
+	assert.Greater(t, len(cells), 50)
+	assert.Less(t, len(cells), 350)
+	assert.InDelta(t, 0.385, float64(inside)/float64(len(cells)*400), 0.05)
+}
+
+func newSurfaceInteraction(p mymath.Point3, uv mymath.Point2) *mymath.SurfaceInteraction {
+	si := mymath.NewSurfaceInteraction(p, mymath.Vector3{}, uv, mymath.NewVector3(0, 0, 1),
+		mymath.NewVector3(1, 0, 0), mymath.NewVector3(0, 1, 0), mymath.Normal3{}, mymath.Normal3{}, 0, nil)
+	return &si
+}
+
+// newSurfaceInteractionDifferentials creates interaction on the plane z=0 with uv equal to (x, y), the screen
+// space differentials are given by the pixel footprint width
+func newSurfaceInteractionDifferentials(p mymath.Point3, width float64) *mymath.SurfaceInteraction {
+	si := newSurfaceInteraction(p, mymath.NewPoint2(p.X, p.Y))
+	si.Dpdx = mymath.NewVector3(width, 0, 0)
+	si.Dpdy = mymath.NewVector3(0, width, 0)
+	si.Dudx, si.Dvdy = width, width
+	return si
+}
@@ -0,0 +1,25 @@
+package texture
+
+import (
+	"math"
+	"pbrt-go/mymath"
+)
+
+// WindyTexture imitates waves on the water surface with the wave height modulated by the low frequency
+// wind strength, it is mostly used as bump map
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/textures/windy.h#L48
+type WindyTexture struct {
+	Mapping TextureMapping3D
+}
+
+func NewWindyTexture(mapping TextureMapping3D) *WindyTexture {
+	return &WindyTexture{mapping}
+}
+
+func (t *WindyTexture) Evaluate(si *mymath.SurfaceInteraction) Float {
+	p, dpdx, dpdy := t.Mapping.Map(si)
+	windStrength := FBm(p.Multiply(0.1), dpdx.Multiply(0.1), dpdy.Multiply(0.1), 0.5, 3)
+	waveHeight := FBm(p, dpdx, dpdy, 0.5, 6)
+	return Float(math.Abs(windStrength) * waveHeight)
+}
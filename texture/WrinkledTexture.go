@@ -0,0 +1,21 @@
+package texture
+
+import "pbrt-go/mymath"
+
+// WrinkledTexture returns turbulence noise, it is mostly used as bump map
+//
+// see https://github.com/mmp/pbrt-v3/blob/master/src/textures/wrinkled.h#L48
+type WrinkledTexture struct {
+	Mapping TextureMapping3D
+	Omega   float64
+	Octaves int
+}
+
+func NewWrinkledTexture(mapping TextureMapping3D, octaves int, omega float64) *WrinkledTexture {
+	return &WrinkledTexture{mapping, omega, octaves}
+}
+
+func (t *WrinkledTexture) Evaluate(si *mymath.SurfaceInteraction) Float {
+	p, dpdx, dpdy := t.Mapping.Map(si)
+	return Float(Turbulence(p, dpdx, dpdy, t.Omega, t.Octaves))
+}